@@ -9,7 +9,7 @@ import (
 )
 
 func main() {
-	log := logger.NewConsoleLogger(os.Stdout)
+	log := logger.NewConsoleLogger(os.Stderr)
 
 	app := cli.New(log)
 	if err := app.Execute(); err != nil {
@@ -0,0 +1,282 @@
+// Package captures reads recorded HTTP traffic, such as HAR files, and enriches specifications with it.
+package captures
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"mime"
+	"net/url"
+	"os"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/GabrielNunesIT/openapi-converter/internal/domain"
+)
+
+// ExampleName is the name of the examples added from captured exchanges.
+const ExampleName = "captured"
+
+// HAR is an HTTP Archive, the log of the exchanges a browser or proxy recorded.
+type HAR struct {
+	Log struct {
+		Entries []Entry `json:"entries"`
+	} `json:"log"`
+}
+
+// Entry is an exchange recorded in a HAR file.
+type Entry struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Request is the request of a recorded exchange.
+type Request struct {
+	Method   string    `json:"method"`
+	URL      string    `json:"url"`
+	PostData *PostData `json:"postData"`
+}
+
+// PostData is the body of a recorded request.
+type PostData struct {
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+}
+
+// Response is the response of a recorded exchange.
+type Response struct {
+	Status  int     `json:"status"`
+	Content Content `json:"content"`
+}
+
+// Content is the body of a recorded response, base64-encoded when Encoding is "base64".
+type Content struct {
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+	Encoding string `json:"encoding"`
+}
+
+// paramPattern matches the parameters of a path template segment, e.g. "{petId}".
+var paramPattern = regexp.MustCompile(`\{[^}]*\}`)
+
+// ReadHAR parses a HAR file from r.
+func ReadHAR(r io.Reader) (*HAR, error) {
+	var har HAR
+	if err := json.NewDecoder(r).Decode(&har); err != nil {
+		return nil, fmt.Errorf("failed to parse HAR: %w", err)
+	}
+
+	return &har, nil
+}
+
+// ReadHARFile parses the HAR file at path.
+func ReadHARFile(path string) (*HAR, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open HAR file: %w", err)
+	}
+	defer file.Close()
+
+	return ReadHAR(file)
+}
+
+// Enrich adds the payloads of the recorded exchanges to doc as examples named ExampleName: the request body
+// of an exchange to the request body of the operation it calls, and the response body to the response of its
+// status, each under the media type of the payload. Operations are matched by method and path template,
+// possibly after the base path of a server, the template with the most literal segments winning. Only the first
+// exchange recorded for a media type is kept, and examples the specification already names ExampleName are
+// left alone. Enrich returns the number of entries matching an operation.
+func (h *HAR) Enrich(doc *domain.OpenAPIDocument) int {
+	matched := 0
+
+	for _, entry := range h.Log.Entries {
+		reqURL, err := url.Parse(entry.Request.URL)
+		if err != nil {
+			continue
+		}
+
+		op := findOperation(doc, entry.Request.Method, reqURL.Path)
+		if op == nil {
+			continue
+		}
+
+		matched++
+
+		summary := strings.ToUpper(entry.Request.Method) + " " + reqURL.RequestURI()
+
+		if data := entry.Request.PostData; data != nil && op.RequestBody != nil {
+			if value, ok := payload(data.MimeType, data.Text, ""); ok {
+				body := *op.RequestBody
+				body.Content = addExample(body.Content, data.MimeType, summary, value)
+				op.RequestBody = &body
+			}
+		}
+
+		content := entry.Response.Content
+		if value, ok := payload(content.MimeType, content.Text, content.Encoding); ok {
+			if i := responseIndex(op.Responses, entry.Response.Status); i >= 0 {
+				op.Responses = slices.Clone(op.Responses)
+				op.Responses[i].Content = addExample(op.Responses[i].Content, content.MimeType,
+					summary+" returning "+strconv.Itoa(entry.Response.Status), value)
+			}
+		}
+	}
+
+	return matched
+}
+
+// findOperation returns the operation of doc called by a request, nil when there is none.
+func findOperation(doc *domain.OpenAPIDocument, method, path string) *domain.Operation {
+	var found *domain.Operation
+
+	bestLiterals, bestPrefix := -1, 0
+
+	for i := range doc.Paths {
+		literals, prefix, ok := matchPath(doc.Paths[i].Path, path)
+		if !ok || literals < bestLiterals || literals == bestLiterals && prefix >= bestPrefix {
+			continue
+		}
+
+		for j, op := range doc.Paths[i].Operations {
+			if strings.EqualFold(op.Method, method) {
+				found = &doc.Paths[i].Operations[j]
+				bestLiterals, bestPrefix = literals, prefix
+			}
+		}
+	}
+
+	return found
+}
+
+// matchPath reports whether a path template, e.g. "/pets/{petId}", matches the end of a request path,
+// with the number of literal segments of the template and of request path segments before it.
+func matchPath(template, path string) (int, int, bool) {
+	templateSegments := strings.Split(strings.Trim(template, "/"), "/")
+	pathSegments := strings.Split(strings.Trim(path, "/"), "/")
+
+	prefix := len(pathSegments) - len(templateSegments)
+	if prefix < 0 {
+		return 0, 0, false
+	}
+
+	literals := 0
+
+	for i, segment := range templateSegments {
+		actual := pathSegments[prefix+i]
+
+		if !strings.Contains(segment, "{") {
+			if segment != actual {
+				return 0, 0, false
+			}
+
+			literals++
+
+			continue
+		}
+
+		literalParts := paramPattern.Split(segment, -1)
+		for j, part := range literalParts {
+			literalParts[j] = regexp.QuoteMeta(part)
+		}
+
+		if !regexp.MustCompile("^" + strings.Join(literalParts, "[^/]+") + "$").MatchString(actual) {
+			return 0, 0, false
+		}
+	}
+
+	return literals, prefix, true
+}
+
+// responseIndex returns the index of the response documenting a status: the exact status code,
+// else its range such as "2XX", else the default response; -1 when there is none.
+func responseIndex(responses []domain.Response, status int) int {
+	code := strconv.Itoa(status)
+
+	for _, candidate := range []string{code, code[:1] + "XX", "default"} {
+		if i := slices.IndexFunc(responses, func(resp domain.Response) bool {
+			return strings.EqualFold(resp.StatusCode, candidate)
+		}); i >= 0 {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// payload decodes a recorded body, JSON bodies as their value and others as text.
+// It reports false for empty or undecodable bodies.
+func payload(mimeType, text, encoding string) (any, bool) {
+	if encoding == "base64" {
+		data, err := base64.StdEncoding.DecodeString(text)
+		if err != nil {
+			return nil, false
+		}
+
+		text = string(data)
+	}
+
+	if strings.TrimSpace(text) == "" {
+		return nil, false
+	}
+
+	if !strings.Contains(mediaType(mimeType), "json") {
+		return text, true
+	}
+
+	var value any
+	if err := json.Unmarshal([]byte(text), &value); err != nil {
+		return nil, false
+	}
+
+	return value, true
+}
+
+// addExample returns a copy of content with an example added to the media type documenting mimeType:
+// the exact media type, else its range such as "image/*", else "*/*". Content is returned as is when no media
+// type documents mimeType or the media type already has an example named ExampleName.
+func addExample(content map[string]domain.MediaType, mimeType, summary string, value any) map[string]domain.MediaType {
+	mediaRange := mediaType(mimeType)
+	if i := strings.Index(mediaRange, "/"); i >= 0 {
+		mediaRange = mediaRange[:i] + "/*"
+	}
+
+	for _, candidate := range []string{mediaType(mimeType), mediaRange, "*/*"} {
+		for contentType, media := range content {
+			if mediaType(contentType) != candidate {
+				continue
+			}
+
+			if _, exists := media.Examples[ExampleName]; exists {
+				return content
+			}
+
+			media.Examples = maps.Clone(media.Examples)
+			if media.Examples == nil {
+				media.Examples = make(map[string]domain.Example)
+			}
+
+			media.Examples[ExampleName] = domain.Example{Summary: summary, Value: value}
+
+			content = maps.Clone(content)
+			content[contentType] = media
+
+			return content
+		}
+	}
+
+	return content
+}
+
+// mediaType returns a content type without its parameters, in lower case, e.g. "application/json"
+// for "application/json; charset=utf-8".
+func mediaType(contentType string) string {
+	if parsed, _, err := mime.ParseMediaType(contentType); err == nil {
+		return parsed
+	}
+
+	return strings.ToLower(strings.TrimSpace(contentType))
+}
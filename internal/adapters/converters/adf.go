@@ -1,22 +1,182 @@
 package converters
 
 import (
+	"bufio"
 	"encoding/json"
 	"fmt"
 	"io"
-	"sort"
+	"net/url"
+	"slices"
+	"strings"
 
 	"github.com/GabrielNunesIT/openapi-converter/internal/domain"
 )
 
-const adfFormat = "confluence"
+const (
+	adfFormat = "confluence"
+
+	// methodColorsOff is the method colours value rendering methods as plain text in the endpoint titles.
+	methodColorsOff = "none"
+)
+
+// defaultMethodColors are the colours of the method lozenges, by method. Other methods are neutral.
+var defaultMethodColors = map[string]string{ //nolint:gochecknoglobals // read-only lookup table
+	"GET":    "green",
+	"POST":   "blue",
+	"PUT":    "yellow",
+	"PATCH":  "yellow",
+	"DELETE": "red",
+}
+
+// adfStatusColors are the colours of the ADF status node.
+var adfStatusColors = []string{"neutral", "purple", "blue", "red", "yellow", "green"} //nolint:gochecknoglobals // read-only
+
+// Sections of the ADF output that can be set in a panel.
+const (
+	panelDescription = "description" // Description of the API, in an info panel
+	panelDeprecated  = "deprecated"  // Deprecation notice of an endpoint, in a warning panel
+	panelSecurity    = "security"    // Authentication required by an endpoint, in a note panel
+)
+
+// PanelSections returns the sections of the Confluence output that can be set in a panel, all of them by default.
+func PanelSections() []string {
+	return []string{panelDescription, panelDeprecated, panelSecurity}
+}
 
 // ADFConverter converts OpenAPI documents to Atlassian Document Format (ADF) for Confluence.
-type ADFConverter struct{}
+type ADFConverter struct {
+	tables      bool     // Render parameters and responses as tables instead of bullet lists
+	snippets    []string // Languages of the sample requests, nil for curl only
+	extensions  []string // Vendor extensions to render, as "x-name" or "x-name=Label"
+	depth       int      // Property levels of inline objects listed under a schema
+	appendix    bool     // Render component schemas once in an appendix rather than under every tag
+	colors      []string // Method lozenge colours replacing the defaults, as "method=colour", or "none"
+	panels      []string // Sections set in panels, nil for all of them
+	stable      bool     // Anchor operations at their operationId, see documentTOC
+	plainLinks  bool     // Link server and external documentation URLs with link marks rather than smart-link cards
+	collapse    int      // Examples longer than this many bytes are collapsed into expands, 0 for none
+	directional bool     // Leave read-only properties out of request fields and write-only ones out of response fields
+	sequences   bool     // Render a Mermaid sequence diagram of the links and callbacks of each operation
+	graph       bool     // Render a Mermaid graph of the schema references after the endpoints and schemas
+	locale      Locale   // Translations of the fixed strings, nil for English
+	headings    headingLevels
+}
+
+// ADFOptions configures an ADFConverter. The fields tagged option can also be set by name, e.g. with
+// --option confluence.tables=true, see RegisterFormat.
+type ADFOptions struct {
+	// Tables renders parameters and responses as ADF tables instead of bullet lists.
+	Tables bool `option:"tables"`
+
+	// Snippets are the languages of the sample request of each endpoint, such as "curl" or "python", nil for curl
+	// only. An empty list disables sample requests.
+	Snippets []string `option:"snippets"`
+
+	// Extensions are the vendor extensions of the API, its operations and schemas to render, each given as
+	// "x-name" or "x-name=Label".
+	Extensions []string `option:"extensions"`
+
+	// SchemaDepth lists the properties of inline objects nested below their property, down to that many levels.
+	// A depth below 1 keeps DefaultSchemaDepth.
+	SchemaDepth int `option:"schema-depth"`
+
+	// SchemaAppendix renders every component schema once in a "Schemas" appendix, the "Schemas Used" section of
+	// each tag linking to it.
+	SchemaAppendix bool `option:"schema-appendix"`
+
+	// MethodColors are the colours of the method lozenges heading the endpoints, each given as "method=colour" with
+	// one of the colours of the ADF status node: neutral, purple, blue, red, yellow or green. Methods not given keep
+	// their default colour; "none" renders the methods as plain text in the endpoint titles.
+	MethodColors []string `option:"method-colors"`
+
+	// Panels are the sections rendered in panels among PanelSections, nil for all of them: the API description in
+	// an info panel, the deprecation notices of endpoints in warning panels and their required authentication in
+	// note panels. An empty list renders every section as plain paragraphs.
+	Panels []string `option:"panels"`
+
+	// StableAnchors anchors the first occurrence of each operation with an operationId at "op-" followed by the
+	// operationId, e.g. "op-listPets", whatever else the document holds, so that other documents can link to it.
+	StableAnchors bool `option:"stable-anchors"`
+
+	// PlainLinks renders server and external documentation URLs as links rather than inlineCard smart links, for
+	// sites or readers without smart-link previews.
+	PlainLinks bool `option:"plain-links"`
+
+	// CollapseSize collapses the request and response examples longer than this many bytes into expands titled by
+	// their content type, leaving shorter ones open under the request body and their status code; 0 collapses none.
+	CollapseSize int `option:"collapse-examples"`
+
+	// Directional leaves the read-only properties out of the request body field tables and the write-only
+	// properties out of the response field tables, as they are not sent that way.
+	Directional bool `option:"directional-fields"`
+
+	// Sequences renders a "Sequence" section under each operation with links or callbacks, holding a Mermaid
+	// sequence diagram of the requests the client sends and the callbacks it receives as a code block, which
+	// Confluence apps drawing Mermaid show as a diagram, or which publishing replaces with its rendered image.
+	Sequences bool `option:"sequence-diagrams"`
+
+	// SchemaGraph renders a "Schema Graph" section after the endpoints and schemas, holding a Mermaid flowchart of
+	// the references between component schemas and from the operations as a code block, which publishing can
+	// replace with its rendered image.
+	SchemaGraph bool `option:"schema-graph"`
+
+	// HeadingOffset adds levels to every heading of the page, e.g. 1 to start at h2 when the page is embedded under
+	// a heading of its own.
+	HeadingOffset int `option:"heading-offset"`
+
+	// MaxHeadingLevel raises the headings deeper than this level to it, 0 for h6.
+	MaxHeadingLevel int `option:"max-heading-level"`
+
+	// Locale translates the section headings and other fixed strings of the page, nil for English.
+	Locale Locale
+}
 
 // NewADFConverter creates a new ADF converter.
-func NewADFConverter() *ADFConverter {
-	return &ADFConverter{}
+func NewADFConverter(opts ADFOptions) *ADFConverter {
+	c := &ADFConverter{
+		tables:      opts.Tables,
+		snippets:    opts.Snippets,
+		extensions:  opts.Extensions,
+		depth:       DefaultSchemaDepth,
+		appendix:    opts.SchemaAppendix,
+		colors:      opts.MethodColors,
+		panels:      opts.Panels,
+		stable:      opts.StableAnchors,
+		plainLinks:  opts.PlainLinks,
+		collapse:    opts.CollapseSize,
+		directional: opts.Directional,
+		sequences:   opts.Sequences,
+		graph:       opts.SchemaGraph,
+		locale:      opts.Locale,
+		headings:    headingLevels{offset: opts.HeadingOffset, max: opts.MaxHeadingLevel},
+	}
+
+	if opts.SchemaDepth > 0 {
+		c.depth = opts.SchemaDepth
+	}
+
+	return c
+}
+
+func init() { //nolint:gochecknoinits // converters self-register with the default registry
+	RegisterFormat(adfFormat, func(opts Options) ADFOptions {
+		return ADFOptions{
+			Tables:         opts.Tables,
+			Snippets:       opts.Snippets,
+			Extensions:     opts.Extensions,
+			SchemaDepth:    opts.SchemaDepth,
+			SchemaAppendix: opts.SchemaAppendix,
+			MethodColors:   opts.MethodColors,
+			Panels:         opts.Panels,
+			StableAnchors:  opts.StableAnchors,
+			PlainLinks:     opts.PlainLinks,
+			CollapseSize:   opts.CollapseSize,
+			Directional:    opts.Directional,
+			Sequences:      opts.Sequences,
+			SchemaGraph:    opts.SchemaGraph,
+			Locale:         opts.Locale,
+		}
+	}, func(opts ADFOptions) domain.Converter { return NewADFConverter(opts) }, "adf")
 }
 
 // Format returns the output format name.
@@ -40,9 +200,19 @@ type adfNode struct {
 }
 
 type adfAttrs struct {
-	Level int    `json:"level,omitempty"`
-	Order int    `json:"order,omitempty"`
-	URL   string `json:"url,omitempty"`
+	Level         int            `json:"level,omitempty"`
+	Order         int            `json:"order,omitempty"`
+	URL           string         `json:"url,omitempty"`
+	Layout        string         `json:"layout,omitempty"`
+	Title         string         `json:"title,omitempty"`
+	Language      string         `json:"language,omitempty"`
+	Text          string         `json:"text,omitempty"`
+	Color         string         `json:"color,omitempty"`
+	ExtensionType string         `json:"extensionType,omitempty"`
+	ExtensionKey  string         `json:"extensionKey,omitempty"`
+	Parameters    map[string]any `json:"parameters,omitempty"`
+	PanelType     string         `json:"panelType,omitempty"`
+	Rowspan       int            `json:"rowspan,omitempty"`
 }
 
 type adfMark struct {
@@ -50,162 +220,228 @@ type adfMark struct {
 	Attrs map[string]any `json:"attrs,omitempty"`
 }
 
-type adfEndpointRef struct {
-	path      string
-	method    string
-	operation domain.Operation
-}
-
 // Convert transforms an OpenAPI document to ADF JSON format.
 func (c *ADFConverter) Convert(doc *domain.OpenAPIDocument, output io.Writer) error {
-	adf := &adfDocument{
-		Version: 1,
-		Type:    "doc",
-		Content: []adfNode{},
+	generators, err := snippetGeneratorsFor(c.snippets)
+	if err != nil {
+		return err
+	}
+
+	extensions, err := extensionFieldsFor(c.extensions)
+	if err != nil {
+		return err
+	}
+
+	methodColors, err := methodColorsFor(c.colors)
+	if err != nil {
+		return err
+	}
+
+	for _, section := range c.panels {
+		if !slices.Contains(PanelSections(), section) {
+			return fmt.Errorf("unsupported panel section: %s (supported: %s)", section, strings.Join(PanelSections(), ", "))
+		}
 	}
 
+	samples := newSampler(doc.Components)
+
+	// Sections are written as soon as they are rendered, so that large specifications are not held as one node tree
+	adf := newADFStream(output)
+
 	// Title
-	adf.Content = append(adf.Content, c.heading(doc.Title, 1))
-	adf.Content = append(adf.Content, c.paragraph(fmt.Sprintf("Version: %s", doc.Version)))
+	adf.add(c.heading(doc.Title, 1))
+	adf.add(c.paragraph(fmt.Sprintf("Version: %s", doc.Version)))
+	adf.add(c.extensionNodes(selectExtensions(extensions, doc.Extensions))...)
+
+	if preamble := strings.TrimSpace(doc.Preamble); preamble != "" {
+		adf.add(c.richText(preamble)...)
+	}
+
+	// Table of contents
+	toc := newDocumentTOC(doc, c.stable)
+	if c.appendix {
+		toc.addSchemaAppendix(doc)
+	}
+
+	if c.graph {
+		toc.addSchemaGraph(doc)
+	}
+
+	toc.localize(c.locale)
+
+	if len(toc.Entries) > 0 {
+		adf.add(c.heading(c.locale.T("Contents"), 2))
+		adf.add(c.tocList(toc.Entries))
+	}
+
+	// About
+	if about := aboutMarkdown(doc); about != "" {
+		adf.add(c.heading(c.locale.T("About"), 2))
+		adf.add(c.richText(about)...)
+	}
 
 	// Description
-	if doc.Description != "" {
-		adf.Content = append(adf.Content, c.heading("Description", 2))
-		adf.Content = append(adf.Content, c.paragraph(doc.Description))
+	if description := c.describedNodes(doc.Description, doc.ExternalDocs); len(description) > 0 {
+		adf.add(c.heading(c.locale.T("Description"), 2))
+		adf.add(c.panelled(panelDescription, "info", description...)...)
 	}
 
 	// Servers
 	if len(doc.Servers) > 0 {
-		adf.Content = append(adf.Content, c.heading("Servers", 2))
-		adf.Content = append(adf.Content, c.serverList(doc.Servers))
+		adf.add(c.heading(c.locale.T("Servers"), 2))
+		adf.add(c.serverList(doc.Servers))
+	}
+
+	// Authentication
+	if len(doc.SecuritySchemes) > 0 {
+		adf.add(c.heading(c.locale.T("Authentication"), 2))
+		adf.add(c.securitySchemeNodes(doc)...)
 	}
 
 	// Endpoints grouped by tags
 	if len(doc.Paths) > 0 {
-		adf.Content = append(adf.Content, c.heading("API Endpoints", 2))
+		adf.add(c.heading(c.locale.T("API Endpoints"), 2))
 
-		tagPaths := c.groupPathsByTag(doc)
-		tags := make([]string, 0, len(tagPaths))
-		for tag := range tagPaths {
-			tags = append(tags, tag)
-		}
-		sort.Strings(tags)
+		tagPaths := groupPathsByTag(doc)
+		tags := orderedTags(doc, tagPaths)
 
 		for _, tag := range tags {
 			// Tag header
-			adf.Content = append(adf.Content, c.heading(tag, 3))
+			adf.add(c.anchoredHeading(tag, 3, toc.tagAnchor(tag)))
+			info := tagInfo(doc, tag)
+			adf.add(c.describedNodes(info.Description, info.ExternalDocs)...)
 
 			// Add components used by this tag's endpoints
-			tagComponents := c.collectTagComponents(tagPaths[tag])
+			tagComponents := collectTagComponents(tagPaths[tag])
 			if len(tagComponents) > 0 {
-				adf.Content = append(adf.Content, c.tagComponentNodes(tagComponents, doc.Components)...)
+				if c.appendix {
+					adf.add(c.heading(c.locale.T("Schemas Used"), 4), c.tocList(toc.schemaLinks(tagComponents)))
+				} else {
+					adf.add(c.tagComponentNodes(tagComponents, doc.Components, extensions)...)
+				}
 			}
 
 			// Add endpoints
 			for _, ep := range tagPaths[tag] {
-				adf.Content = append(adf.Content, c.operationNodes(ep.path, ep.operation)...)
+				snippets := requestSnippets(generators, doc, ep.path, ep.operation)
+				adf.add(c.endpointNodes(toc.endpointAnchor(tag, ep), ep.path, ep.operation, methodColors,
+					snippets, samples.operationExamples(ep.operation),
+					bodyFieldsByContentType(ep.operation, doc.Components, c.depth, c.directional),
+					responseFieldsByStatus(ep.operation, doc.Components, c.depth, c.directional),
+					selectExtensions(extensions, ep.operation.Extensions), relatedOperations(ep.operation, toc),
+					operationSequence(c.sequences, doc, ep))...)
 			}
 		}
 	}
 
-	encoder := json.NewEncoder(output)
-	encoder.SetIndent("", "  ")
-
-	if err := encoder.Encode(adf); err != nil {
-		return fmt.Errorf("failed to encode ADF: %w", err)
+	// Webhooks
+	if len(doc.Webhooks) > 0 {
+		adf.add(c.anchoredHeading(c.locale.T("Webhooks"), 2, webhooksAnchor))
+
+		for _, ep := range webhookRefs(doc) {
+			adf.add(c.endpointNodes(toc.endpointAnchor("", ep), ep.path, ep.operation, methodColors,
+				nil, samples.operationExamples(ep.operation),
+				bodyFieldsByContentType(ep.operation, doc.Components, c.depth, c.directional),
+				responseFieldsByStatus(ep.operation, doc.Components, c.depth, c.directional),
+				selectExtensions(extensions, ep.operation.Extensions), relatedOperations(ep.operation, toc),
+				operationSequence(c.sequences, doc, ep))...)
+		}
 	}
 
-	return nil
-}
-
-// groupPathsByTag groups paths by their operation tags.
-func (c *ADFConverter) groupPathsByTag(doc *domain.OpenAPIDocument) map[string][]adfEndpointRef {
-	result := make(map[string][]adfEndpointRef)
-
-	for _, path := range doc.Paths {
-		for _, op := range path.Operations {
-			tags := op.Tags
-			if len(tags) == 0 {
-				tags = []string{"Default"}
-			}
+	// Schemas appendix
+	if c.appendix && len(doc.Components) > 0 {
+		adf.add(c.anchoredHeading(c.locale.T("Schemas"), 2, schemasAnchor))
 
-			for _, tag := range tags {
-				result[tag] = append(result[tag], adfEndpointRef{
-					path:      path.Path,
-					method:    op.Method,
-					operation: op,
-				})
-			}
+		for _, name := range sortedComponentNames(doc.Components) {
+			schema := doc.Components[name]
+			adf.add(c.componentSchemaNodes(name, toc.schemaAnchor(name),
+				flattenAllOf(schema, doc.Components), selectExtensions(extensions, schema.Extensions))...)
 		}
 	}
 
-	// Sort endpoints within each tag by path then method
-	for tag := range result {
-		sort.Slice(result[tag], func(i, j int) bool {
-			if result[tag][i].path == result[tag][j].path {
-				return result[tag][i].method < result[tag][j].method
-			}
+	// Schema graph
+	if c.graph && len(doc.Components) > 0 {
+		adf.add(c.anchoredHeading(c.locale.T("Schema Graph"), 2, schemaGraphAnchor))
+		adf.add(c.codeBlock(newSchemaGraph(doc).mermaid(), mermaidLanguage))
+	}
 
-			return result[tag][i].path < result[tag][j].path
-		})
+	if epilogue := strings.TrimSpace(doc.Epilogue); epilogue != "" {
+		adf.add(c.richText(epilogue)...)
 	}
 
-	return result
+	if err := adf.close(); err != nil {
+		return fmt.Errorf("failed to encode ADF: %w", err)
+	}
+
+	return nil
 }
 
-// collectTagComponents gathers all unique component names used by endpoints in a tag.
-func (c *ADFConverter) collectTagComponents(endpoints []adfEndpointRef) []string {
-	componentSet := make(map[string]struct{})
+// adfStream writes the top-level nodes of an ADF document as they are rendered, keeping in memory one section
+// at a time rather than the whole document. The output is that of an indented adfDocument holding the nodes.
+type adfStream struct {
+	out     *bufio.Writer
+	written int   // Nodes written so far
+	err     error // First error met, after which nothing more is written
+}
 
-	for _, ep := range endpoints {
-		// Check request body
-		if ep.operation.RequestBody != nil {
-			for _, media := range ep.operation.RequestBody.Content {
-				c.collectSchemaRefs(media.Schema, componentSet)
-			}
+// newADFStream starts a document on output.
+func newADFStream(output io.Writer) *adfStream {
+	s := &adfStream{out: bufio.NewWriter(output)}
+	_, s.err = fmt.Fprintf(s.out, "{\n  \"version\": %d,\n  \"type\": %q,\n  \"content\": [", 1, "doc")
+
+	return s
+}
+
+// add writes nodes to the content of the document.
+func (s *adfStream) add(nodes ...adfNode) {
+	for _, node := range nodes {
+		if s.err != nil {
+			return
 		}
 
-		// Check responses
-		for _, resp := range ep.operation.Responses {
-			for _, media := range resp.Content {
-				c.collectSchemaRefs(media.Schema, componentSet)
-			}
+		data, err := json.MarshalIndent(node, "    ", "  ")
+		if err != nil {
+			s.err = err
+
+			return
 		}
 
-		// Check parameters
-		for _, param := range ep.operation.Parameters {
-			c.collectSchemaRefs(param.Schema, componentSet)
+		separator := ",\n    "
+		if s.written == 0 {
+			separator = "\n    "
 		}
-	}
 
-	// Convert set to sorted slice
-	components := make([]string, 0, len(componentSet))
-	for name := range componentSet {
-		components = append(components, name)
-	}
-	sort.Strings(components)
+		if _, s.err = s.out.WriteString(separator); s.err == nil {
+			_, s.err = s.out.Write(data)
+		}
 
-	return components
+		s.written++
+	}
 }
 
-// collectSchemaRefs recursively collects component references from a schema.
-func (c *ADFConverter) collectSchemaRefs(schema domain.Schema, refs map[string]struct{}) {
-	if schema.Ref != "" {
-		refs[extractRefName(schema.Ref)] = struct{}{}
+// close ends the document, returning the first error met while writing it.
+func (s *adfStream) close() error {
+	if s.err != nil {
+		return s.err
 	}
 
-	for _, prop := range schema.Properties {
-		c.collectSchemaRefs(prop, refs)
+	end := "\n  ]\n}\n"
+	if s.written == 0 {
+		end = "]\n}\n"
 	}
 
-	if schema.Items != nil {
-		c.collectSchemaRefs(*schema.Items, refs)
+	if _, err := s.out.WriteString(end); err != nil {
+		return err
 	}
+
+	return s.out.Flush()
 }
 
 // tagComponentNodes generates ADF nodes for component schemas used in a tag.
-func (c *ADFConverter) tagComponentNodes(componentNames []string, components map[string]domain.Schema) []adfNode {
-	nodes := []adfNode{c.heading("Schemas Used", 4)}
+func (c *ADFConverter) tagComponentNodes(componentNames []string, components map[string]domain.Schema,
+	extensions []extensionField,
+) []adfNode {
+	nodes := []adfNode{c.heading(c.locale.T("Schemas Used"), 4)}
 
 	for _, name := range componentNames {
 		schema, exists := components[name]
@@ -213,98 +449,398 @@ func (c *ADFConverter) tagComponentNodes(componentNames []string, components map
 			continue
 		}
 
-		nodes = append(nodes, c.componentSchemaNodes(name, schema)...)
+		nodes = append(nodes, c.componentSchemaNodes(name, "", flattenAllOf(schema, components),
+			selectExtensions(extensions, schema.Extensions))...)
 	}
 
 	return nodes
 }
 
-// componentSchemaNodes generates ADF nodes for a single component schema.
-func (c *ADFConverter) componentSchemaNodes(name string, schema domain.Schema) []adfNode {
+// componentSchemaNodes generates ADF nodes for a single component schema, anchored when anchor is not empty.
+func (c *ADFConverter) componentSchemaNodes(name, anchor string, schema domain.Schema, extensions []extensionValue) []adfNode {
 	nodes := []adfNode{}
 
 	// Schema name as bold paragraph
+	title := []adfNode{c.boldText(name)}
+	if anchor != "" {
+		title = append([]adfNode{c.anchor(anchor)}, title...)
+	}
+
+	if schema.Deprecated {
+		title = append(title, adfNode{Type: "text", Text: " "}, c.deprecatedStatus())
+	}
+
 	nodes = append(nodes, adfNode{
-		Type: "paragraph",
-		Content: []adfNode{
-			c.boldText(name),
-		},
+		Type:    "paragraph",
+		Content: title,
 	})
 
 	// Type info
-	if schema.Type != "" {
-		typeStr := schema.Type
-		if schema.Format != "" {
-			typeStr = fmt.Sprintf("%s (%s)", schema.Type, schema.Format)
-		}
+	if typeStr := formatSchemaType(schema); typeStr != "" {
 		nodes = append(nodes, c.paragraph(fmt.Sprintf("Type: %s", typeStr)))
 	}
 
 	// Description
-	if schema.Description != "" {
-		nodes = append(nodes, c.paragraph(schema.Description))
-	}
-
-	// Properties as bullet list
-	if len(schema.Properties) > 0 {
-		propNames := make([]string, 0, len(schema.Properties))
-		for propName := range schema.Properties {
-			propNames = append(propNames, propName)
-		}
-		sort.Strings(propNames)
-
-		items := make([]adfNode, 0, len(propNames))
-		for _, propName := range propNames {
-			prop := schema.Properties[propName]
-			propType := prop.Type
-			if prop.Ref != "" {
-				propType = extractRefName(prop.Ref)
-			} else if prop.Format != "" {
-				propType = fmt.Sprintf("%s (%s)", prop.Type, prop.Format)
-			}
+	nodes = append(nodes, c.describedNodes(schema.Description, schema.ExternalDocs)...)
 
-			items = append(items, adfNode{
-				Type: "listItem",
+	nodes = append(nodes, c.extensionNodes(extensions)...)
+
+	// Properties as bullet list, with those of inline objects nested below them
+	if properties := nestedProperties(schema, c.depth); len(properties) > 0 {
+		nodes = append(nodes, c.propertyList(properties))
+	}
+
+	// Alternatives of oneOf/anyOf schemas
+	if label, variants := schemaVariants(schema); len(variants) > 0 {
+		nodes = append(nodes, c.paragraph(label))
+		nodes = append(nodes, c.variantList(variants))
+	}
+
+	if schema.Discriminator != nil {
+		nodes = append(nodes, c.discriminatorNodes(schema.Discriminator)...)
+	}
+
+	return nodes
+}
+
+func (c *ADFConverter) propertyList(properties []schemaProperty) adfNode {
+	items := make([]adfNode, 0, len(properties))
+
+	for _, property := range properties {
+		content := []adfNode{
+			{
+				Type: "paragraph",
 				Content: []adfNode{
-					{
-						Type: "paragraph",
-						Content: []adfNode{
-							c.codeText(propName),
-							{Type: "text", Text: fmt.Sprintf(" (%s)", propType)},
-						},
-					},
+					c.codeText(property.Name),
+					{Type: "text", Text: fmt.Sprintf(" (%s)", formatPropertyDetails(property.Schema, property.Required, c.locale))},
 				},
-			})
+			},
 		}
 
-		nodes = append(nodes, adfNode{
-			Type:    "bulletList",
-			Content: items,
+		if len(property.Children) > 0 {
+			content = append(content, c.propertyList(property.Children))
+		}
+
+		items = append(items, adfNode{Type: "listItem", Content: content})
+	}
+
+	return adfNode{
+		Type:    "bulletList",
+		Content: items,
+	}
+}
+
+func (c *ADFConverter) variantList(variants []domain.Schema) adfNode {
+	items := make([]adfNode, 0, len(variants))
+
+	for _, variant := range variants {
+		content := []adfNode{c.codeText(formatSchemaType(variant))}
+		if variant.Ref == "" && variant.Description != "" {
+			content = append(content, adfNode{Type: "text", Text: ": "})
+			content = append(content, c.inlineRichText(variant.Description)...)
+		}
+
+		items = append(items, adfNode{
+			Type:    "listItem",
+			Content: []adfNode{{Type: "paragraph", Content: content}},
 		})
 	}
 
-	return nodes
+	return adfNode{
+		Type:    "bulletList",
+		Content: items,
+	}
+}
+
+// discriminatorNodes describes the discriminator property and its value to schema mapping.
+func (c *ADFConverter) discriminatorNodes(discriminator *domain.Discriminator) []adfNode {
+	nodes := []adfNode{{
+		Type: "paragraph",
+		Content: []adfNode{
+			{Type: "text", Text: "Discriminator: "},
+			c.codeText(discriminator.PropertyName),
+		},
+	}}
+
+	if len(discriminator.Mapping) == 0 {
+		return nodes
+	}
+
+	rows := []adfNode{
+		c.tableRow("tableHeader", c.textCell("Value"), c.textCell("Schema")),
+	}
+
+	for _, value := range sortedDiscriminatorValues(discriminator) {
+		rows = append(rows, c.tableRow("tableCell",
+			[]adfNode{c.codeText(value)},
+			c.textCell(extractRefName(discriminator.Mapping[value])),
+		))
+	}
+
+	return append(nodes, c.table(rows))
 }
 
 func (c *ADFConverter) heading(text string, level int) adfNode {
 	return adfNode{
 		Type:  "heading",
-		Attrs: &adfAttrs{Level: level},
+		Attrs: &adfAttrs{Level: c.headings.level(level)},
 		Content: []adfNode{
 			{Type: "text", Text: text},
 		},
 	}
 }
 
-func (c *ADFConverter) paragraph(text string) adfNode {
+// anchoredHeading is a heading the table of contents links to, through a Confluence anchor macro before its text.
+func (c *ADFConverter) anchoredHeading(text string, level int, anchor string) adfNode {
+	node := c.heading(text, level)
+	node.Content = append([]adfNode{c.anchor(anchor)}, node.Content...)
+
+	return node
+}
+
+// anchorParagraph holds the anchor of an endpoint, placed before its expand so that links land on its title.
+func (c *ADFConverter) anchorParagraph(anchor string) adfNode {
 	return adfNode{
-		Type: "paragraph",
-		Content: []adfNode{
-			{Type: "text", Text: text},
+		Type:    "paragraph",
+		Content: []adfNode{c.anchor(anchor)},
+	}
+}
+
+// anchor is the Confluence anchor macro, which links to "#name" jump to.
+func (c *ADFConverter) anchor(name string) adfNode {
+	return adfNode{
+		Type: "inlineExtension",
+		Attrs: &adfAttrs{
+			ExtensionType: "com.atlassian.confluence.macro.core",
+			ExtensionKey:  "anchor",
+			Parameters: map[string]any{
+				"macroParams":   map[string]any{"": map[string]string{"value": name}},
+				"macroMetadata": map[string]any{"schemaVersion": map[string]string{"value": "1"}, "title": "Anchor"},
+			},
 		},
 	}
 }
 
+// tocList renders table of contents entries as nested bullet lists of links to their anchors.
+func (c *ADFConverter) tocList(entries []tocEntry) adfNode {
+	items := make([]adfNode, 0, len(entries))
+
+	for _, entry := range entries {
+		item := adfNode{
+			Type: "listItem",
+			Content: []adfNode{{
+				Type: "paragraph",
+				Content: []adfNode{{
+					Type:  "text",
+					Text:  entry.Title,
+					Marks: []adfMark{linkMark("#" + entry.Anchor)},
+				}},
+			}},
+		}
+
+		if len(entry.Children) > 0 {
+			item.Content = append(item.Content, c.tocList(entry.Children))
+		}
+
+		items = append(items, item)
+	}
+
+	return adfNode{Type: "bulletList", Content: items}
+}
+
+// hasPanel reports whether a section of the output is set in a panel.
+func (c *ADFConverter) hasPanel(section string) bool {
+	return c.panels == nil || slices.Contains(c.panels, section)
+}
+
+// panelled wraps the nodes of a section in a panel of the given type (info, note, warning, error or success),
+// or returns them as they are when the section is not set in panels. Panels cannot hold block quotes,
+// whose content takes their place.
+func (c *ADFConverter) panelled(section, panelType string, nodes ...adfNode) []adfNode {
+	if !c.hasPanel(section) {
+		return nodes
+	}
+
+	content := make([]adfNode, 0, len(nodes))
+
+	for _, node := range nodes {
+		if node.Type == "blockquote" {
+			content = append(content, node.Content...)
+		} else {
+			content = append(content, node)
+		}
+	}
+
+	return []adfNode{{
+		Type:    "panel",
+		Attrs:   &adfAttrs{PanelType: panelType},
+		Content: content,
+	}}
+}
+
+// richText renders a CommonMark description as ADF blocks.
+func (c *ADFConverter) richText(text string) []adfNode {
+	return c.markdownNodes(parseMarkdown(text))
+}
+
+// inlineRichText renders a CommonMark description as ADF inline content, for table cells and list items.
+func (c *ADFConverter) inlineRichText(text string) []adfNode {
+	return c.inlineNodes(parseInlines(strings.TrimSpace(text)), nil)
+}
+
+func (c *ADFConverter) markdownNodes(blocks []mdBlock) []adfNode {
+	nodes := make([]adfNode, 0, len(blocks))
+
+	for _, block := range blocks {
+		switch block.Kind {
+		case mdParagraph:
+			nodes = append(nodes, adfNode{Type: "paragraph", Content: c.inlineNodes(block.Inlines, nil)})
+		case mdHeading:
+			level := c.headings.level(block.Level)
+			nodes = append(nodes, adfNode{Type: "heading", Attrs: &adfAttrs{Level: level}, Content: c.inlineNodes(block.Inlines, nil)})
+		case mdBulletList, mdOrderedList:
+			list := adfNode{Type: "bulletList"}
+			if block.Kind == mdOrderedList {
+				list = adfNode{Type: "orderedList", Attrs: &adfAttrs{Order: block.Start}}
+			}
+
+			for _, item := range block.Items {
+				list.Content = append(list.Content, adfNode{Type: "listItem", Content: listItemContent(c.markdownNodes(item))})
+			}
+
+			nodes = append(nodes, list)
+		case mdCodeBlock:
+			nodes = append(nodes, c.codeBlock(block.Code, block.Language))
+		case mdQuote:
+			nodes = append(nodes, adfNode{Type: "blockquote", Content: listItemContent(c.markdownNodes(block.Children))})
+		case mdRule:
+			nodes = append(nodes, adfNode{Type: "rule"})
+		}
+	}
+
+	return nodes
+}
+
+// listItemContent adapts blocks to the content of list items and block quotes, which start with a paragraph
+// or code block and hold no headings, rules or block quotes: headings become paragraphs, and quotes their content.
+func listItemContent(nodes []adfNode) []adfNode {
+	content := make([]adfNode, 0, len(nodes))
+
+	for _, node := range nodes {
+		switch node.Type {
+		case "heading":
+			content = append(content, adfNode{Type: "paragraph", Content: node.Content})
+		case "blockquote":
+			content = append(content, node.Content...)
+		case "rule":
+			// Dropped, rules only separate top-level blocks
+		default:
+			content = append(content, node)
+		}
+	}
+
+	if len(content) == 0 || (content[0].Type != "paragraph" && content[0].Type != "codeBlock") {
+		content = append([]adfNode{{Type: "paragraph"}}, content...)
+	}
+
+	return content
+}
+
+// inlineNodes renders inlines as text nodes with the marks of their formatting, added to marks.
+// Code cannot be combined with marks other than links, which it keeps.
+func (c *ADFConverter) inlineNodes(inlines []mdInline, marks []adfMark) []adfNode {
+	nodes := []adfNode{}
+
+	for _, inline := range inlines {
+		switch inline.Kind {
+		case mdText:
+			if inline.Text != "" {
+				nodes = append(nodes, adfNode{Type: "text", Text: inline.Text, Marks: marks})
+			}
+		case mdCode:
+			codeMarks := []adfMark{}
+			for _, mark := range marks {
+				if mark.Type == "link" {
+					codeMarks = append(codeMarks, mark)
+				}
+			}
+
+			if inline.Text != "" {
+				nodes = append(nodes, adfNode{Type: "text", Text: inline.Text, Marks: append(codeMarks, adfMark{Type: "code"})})
+			}
+		case mdStrong:
+			nodes = append(nodes, c.inlineNodes(inline.Children, withMark(marks, adfMark{Type: "strong"}))...)
+		case mdEmphasis:
+			nodes = append(nodes, c.inlineNodes(inline.Children, withMark(marks, adfMark{Type: "em"}))...)
+		case mdLink:
+			if href := safeHref(inline.Href); href != "" {
+				nodes = append(nodes, c.inlineNodes(inline.Children,
+					withMark(marks, linkMark(href)))...)
+			} else {
+				nodes = append(nodes, c.inlineNodes(inline.Children, marks)...)
+			}
+		case mdLineBreak:
+			nodes = append(nodes, adfNode{Type: "hardBreak"})
+		}
+	}
+
+	return nodes
+}
+
+// withMark returns a copy of marks with mark added, unless a mark of its type is already there.
+func withMark(marks []adfMark, mark adfMark) []adfMark {
+	if slices.ContainsFunc(marks, func(m adfMark) bool { return m.Type == mark.Type }) {
+		return marks
+	}
+
+	return append(slices.Clone(marks), mark)
+}
+
+// paragraph renders plain text as a paragraph, the URLs it contains linked to themselves.
+func (c *ADFConverter) paragraph(text string) adfNode {
+	return adfNode{
+		Type:    "paragraph",
+		Content: c.linkedText(text),
+	}
+}
+
+// linkedText splits plain text into text nodes, the http(s) and mailto URLs it contains, taken as in CommonMark
+// descriptions, linked to themselves.
+func (c *ADFConverter) linkedText(text string) []adfNode {
+	nodes := []adfNode{}
+	start := 0
+
+	for i := 0; i < len(text); i++ {
+		if (text[i] != 'h' && text[i] != 'm') || (i > 0 && isWordByte(text[i-1])) {
+			continue
+		}
+
+		link := mdURLPattern.FindString(text[i:])
+		if link == "" {
+			continue
+		}
+
+		if i > start {
+			nodes = append(nodes, adfNode{Type: "text", Text: text[start:i]})
+		}
+
+		nodes = append(nodes, adfNode{Type: "text", Text: link, Marks: []adfMark{linkMark(link)}})
+		i += len(link) - 1
+		start = i + 1
+	}
+
+	if start < len(text) || len(nodes) == 0 {
+		nodes = append(nodes, adfNode{Type: "text", Text: text[start:]})
+	}
+
+	return nodes
+}
+
+// linkMark returns the mark linking text to href, a URL or an anchor of the page such as "#op-listPets".
+func linkMark(href string) adfMark {
+	return adfMark{Type: "link", Attrs: map[string]any{"href": href}}
+}
+
 func (c *ADFConverter) boldText(text string) adfNode {
 	return adfNode{
 		Type: "text",
@@ -329,15 +865,15 @@ func (c *ADFConverter) serverList(servers []domain.Server) adfNode {
 	items := make([]adfNode, 0, len(servers))
 
 	for _, server := range servers {
-		text := server.URL
+		content := []adfNode{c.urlNode(server.URL, server.URL)}
 		if server.Description != "" {
-			text = fmt.Sprintf("%s - %s", server.URL, server.Description)
+			content = append(content, adfNode{Type: "text", Text: " - " + server.Description})
 		}
 
 		items = append(items, adfNode{
 			Type: "listItem",
 			Content: []adfNode{
-				c.paragraph(text),
+				{Type: "paragraph", Content: content},
 			},
 		})
 	}
@@ -348,63 +884,315 @@ func (c *ADFConverter) serverList(servers []domain.Server) adfNode {
 	}
 }
 
-func (c *ADFConverter) operationNodes(pathStr string, operation domain.Operation) []adfNode {
-	nodes := []adfNode{}
-
-	// Endpoint heading with method and path
-	endpointTitle := fmt.Sprintf("%s %s", formatMethod(operation.Method), pathStr)
-	nodes = append(nodes, c.heading(endpointTitle, 5))
+// describedNodes renders a CommonMark description followed by a "See also" paragraph linking to external documentation.
+func (c *ADFConverter) describedNodes(description string, docs *domain.ExternalDocs) []adfNode {
+	nodes := c.richText(strings.TrimSpace(description))
+	if docs == nil || docs.URL == "" {
+		return nodes
+	}
 
-	// Summary (bold)
-	if operation.Summary != "" {
-		nodes = append(nodes, adfNode{
-			Type: "paragraph",
-			Content: []adfNode{
-				c.boldText(operation.Summary),
-			},
-		})
+	label := docs.Description
+	if label == "" {
+		label = docs.URL
 	}
 
-	// Description
-	if operation.Description != "" {
-		nodes = append(nodes, c.paragraph(operation.Description))
+	seeAlso := []adfNode{{Type: "text", Text: "See also: "}}
+
+	// Cards show the title of the page they link to, after the description of the documentation
+	card := c.urlNode(docs.URL, label)
+	if card.Type == "inlineCard" && docs.Description != "" {
+		seeAlso = append(seeAlso, adfNode{Type: "text", Text: docs.Description + " "})
 	}
 
-	// Parameters
-	if len(operation.Parameters) > 0 {
-		nodes = append(nodes, c.heading("Parameters", 6))
-		nodes = append(nodes, c.parameterList(operation.Parameters))
+	return append(nodes, adfNode{Type: "paragraph", Content: append(seeAlso, card)})
+}
+
+// urlNode renders a URL as an inlineCard smart link, or text linking to it with WithPlainLinks. URLs that are not
+// absolute http(s) URLs, such as relative or templated server URLs, are rendered as text without a link.
+func (c *ADFConverter) urlNode(rawURL, text string) adfNode {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" || strings.ContainsAny(rawURL, "{}") {
+		return adfNode{Type: "text", Text: text}
 	}
 
-	// Responses
-	if len(operation.Responses) > 0 {
-		nodes = append(nodes, c.heading("Responses", 6))
-		nodes = append(nodes, c.responseList(operation.Responses))
+	if c.plainLinks {
+		return adfNode{Type: "text", Text: text, Marks: []adfMark{linkMark(rawURL)}}
 	}
 
-	// Divider between endpoints
-	nodes = append(nodes, adfNode{Type: "rule"})
+	return adfNode{Type: "inlineCard", Attrs: &adfAttrs{URL: rawURL}}
+}
+
+// extensionNodes renders vendor extensions as "Label: value" paragraphs with a bold label.
+func (c *ADFConverter) extensionNodes(extensions []extensionValue) []adfNode {
+	nodes := make([]adfNode, 0, len(extensions))
+
+	for _, extension := range extensions {
+		nodes = append(nodes, adfNode{
+			Type:    "paragraph",
+			Content: []adfNode{c.boldText(extension.Label + ":"), {Type: "text", Text: " " + extension.Value}},
+		})
+	}
 
 	return nodes
 }
 
-func (c *ADFConverter) parameterList(params []domain.Parameter) adfNode {
-	items := make([]adfNode, 0, len(params))
+// endpointNodes renders an endpoint after a paragraph holding its anchor, so that links land on its title.
+// With method colours, the paragraph shows the method as a status lozenge followed by the path,
+// and the expand is titled with the summary alone.
+func (c *ADFConverter) endpointNodes(anchor, pathStr string, operation domain.Operation, methodColors map[string]string,
+	snippets []codeSnippet, examples []operationExample, fields map[string][]bodyField, responses []responseFields,
+	extensions []extensionValue, related []relatedOperation, sequence string,
+) []adfNode {
+	heading := c.anchorParagraph(anchor)
+	title := endpointTitle(pathStr, operation)
+
+	if methodColors != nil {
+		method := formatMethod(operation.Method)
+		color, ok := methodColors[method]
+		if !ok {
+			color = "neutral"
+		}
 
-	for _, param := range params {
-		required := ""
-		if param.Required {
-			required = " (required)"
+		heading.Content = append(heading.Content, c.status(method, color), adfNode{Type: "text", Text: " "}, c.codeText(pathStr))
+
+		title = operation.Summary
+		if title == "" {
+			title = "Details"
+		}
+
+		title += deprecatedSuffix(operation.Deprecated)
+	}
+
+	details := c.operationNodes(title, operation, snippets, examples, fields, responses, extensions, related, sequence)
+
+	return append([]adfNode{heading}, details...)
+}
+
+// methodColorsFor returns the colours of the method lozenges by upper-case method, the defaults overridden by
+// the colours given as "method=colour". It returns nil for "none", which renders methods as plain text.
+func methodColorsFor(specs []string) (map[string]string, error) {
+	if len(specs) == 1 && strings.EqualFold(strings.TrimSpace(specs[0]), methodColorsOff) {
+		return nil, nil //nolint:nilnil // a nil map stands for plain-text methods
+	}
+
+	colors := make(map[string]string, len(defaultMethodColors)+len(specs))
+	for method, color := range defaultMethodColors {
+		colors[method] = color
+	}
+
+	for _, spec := range specs {
+		method, color, ok := strings.Cut(spec, "=")
+		method = strings.ToUpper(strings.TrimSpace(method))
+		color = strings.ToLower(strings.TrimSpace(color))
+
+		if !ok || method == "" || !slices.Contains(adfStatusColors, color) {
+			return nil, fmt.Errorf("invalid method colour %q: expected method=colour with a colour among %s",
+				spec, strings.Join(adfStatusColors, ", "))
+		}
+
+		colors[method] = color
+	}
+
+	return colors, nil
+}
+
+// operationNodes renders an endpoint as a collapsible expand with the given title,
+// so that large APIs stay readable on Confluence. Snippets are shown one after the other as example requests.
+func (c *ADFConverter) operationNodes(title string, operation domain.Operation, snippets []codeSnippet,
+	examples []operationExample, fields map[string][]bodyField, responses []responseFields, extensions []extensionValue,
+	related []relatedOperation, sequence string,
+) []adfNode {
+	details := []adfNode{}
+
+	// Deprecation lozenge, with a notice in a panel
+	if operation.Deprecated {
+		notice := adfNode{
+			Type:    "paragraph",
+			Content: []adfNode{c.deprecatedStatus()},
+		}
+
+		if c.hasPanel(panelDeprecated) {
+			notice.Content = append(notice.Content, adfNode{Type: "text", Text: " This endpoint is deprecated and may be removed."})
+		}
+
+		details = append(details, c.panelled(panelDeprecated, "warning", notice)...)
+	}
+
+	// Description
+	details = append(details, c.describedNodes(operation.Description, operation.ExternalDocs)...)
+
+	// Required authentication as status lozenges
+	if len(operation.Security) > 0 {
+		details = append(details, c.panelled(panelSecurity, "note", c.securityLozenges(operation))...)
+	}
+
+	details = append(details, c.extensionNodes(extensions)...)
+
+	// Parameters
+	if len(operation.Parameters) > 0 {
+		details = append(details, c.heading(c.locale.T("Parameters"), 6))
+
+		if c.tables {
+			details = append(details, c.parameterTable(operation.Parameters))
+		} else {
+			details = append(details, c.parameterList(operation.Parameters))
+		}
+	}
+
+	// Examples by the status code of their response, request examples under ""
+	statusExamples := map[string][]operationExample{}
+	for _, example := range examples {
+		statusExamples[example.Status] = append(statusExamples[example.Status], example)
+	}
+
+	// Request body, followed by its examples
+	if operation.RequestBody != nil {
+		details = append(details, c.heading(c.locale.T("Request Body"), 6))
+		details = append(details, c.requestBodyNodes(*operation.RequestBody, fields)...)
+
+		for _, example := range statusExamples[""] {
+			nodes, _ := c.exampleNodes(example)
+			details = append(details, nodes...)
+		}
+	}
+
+	// Responses, each with its examples
+	if len(operation.Responses) > 0 {
+		details = append(details, c.heading(c.locale.T("Responses"), 6))
+
+		if c.tables {
+			details = append(details, c.responseTable(operation.Responses, statusExamples))
+		} else {
+			details = append(details, c.responseList(operation.Responses, statusExamples)...)
+		}
+	}
+
+	// Flattened response fields, by status code and content type
+	if len(responses) > 0 {
+		details = append(details, c.heading(c.locale.T("Response Fields"), 6))
+		details = append(details, c.responseFieldNodes(responses)...)
+	}
+
+	// Response headers
+	if headers := responseHeaders(operation.Responses); len(headers) > 0 {
+		details = append(details, c.heading(c.locale.T("Response Headers"), 6))
+
+		if c.tables {
+			details = append(details, c.responseHeaderTable(headers))
+		} else {
+			details = append(details, c.responseHeaderList(headers))
+		}
+	}
+
+	// Limits stamped by the gateway
+	if limits := operationLimits(operation); len(limits) > 0 {
+		details = append(details, c.heading(c.locale.T("Limits"), 6), c.limitTable(limits))
+	}
+
+	// Links to the operations that can follow
+	if len(related) > 0 {
+		details = append(details, c.heading(c.locale.T("Related Operations"), 6))
+		details = append(details, c.relatedOperationList(related))
+	}
+
+	// Callbacks
+	if callbacks := callbackRequests(operation); len(callbacks) > 0 {
+		details = append(details, c.heading(c.locale.T("Callbacks"), 6))
+		details = append(details, c.callbackNodes(callbacks)...)
+	}
+
+	// Sequence diagram
+	if sequence != "" {
+		details = append(details, c.heading(c.locale.T("Sequence"), 6))
+		details = append(details, c.codeBlock(sequence, mermaidLanguage))
+	}
+
+	// Sample requests
+	if len(snippets) > 0 {
+		details = append(details, c.heading(c.locale.T("Example Request"), 6))
+
+		for _, snippet := range snippets {
+			details = append(details,
+				adfNode{Type: "paragraph", Content: []adfNode{c.boldText(snippet.Label)}},
+				c.codeBlock(snippet.Code, snippet.Language),
+			)
+		}
+	}
+
+	// An expand needs at least one child node
+	if len(details) == 0 {
+		details = append(details, c.paragraph("No further details."))
+	}
+
+	return []adfNode{c.expand(title, details)}
+}
+
+// endpointTitle returns "METHOD /path — summary", flagging deprecated operations.
+func endpointTitle(pathStr string, operation domain.Operation) string {
+	title := fmt.Sprintf("%s %s", formatMethod(operation.Method), pathStr)
+	if operation.Summary != "" {
+		title += " \u2014 " + operation.Summary
+	}
+
+	return title + deprecatedSuffix(operation.Deprecated)
+}
+
+// securitySchemeNodes generates ADF nodes describing every security scheme of the document.
+func (c *ADFConverter) securitySchemeNodes(doc *domain.OpenAPIDocument) []adfNode {
+	nodes := []adfNode{}
+
+	for _, name := range sortedSecuritySchemes(doc) {
+		scheme := doc.SecuritySchemes[name]
+
+		nodes = append(nodes, adfNode{
+			Type:    "paragraph",
+			Content: []adfNode{c.boldText(name)},
+		})
+		nodes = append(nodes, c.paragraph(fmt.Sprintf("Type: %s", formatSecurityScheme(scheme))))
+
+		if scheme.Description != "" {
+			nodes = append(nodes, c.richText(scheme.Description)...)
+		}
+
+		for _, flow := range scheme.Flows {
+			nodes = append(nodes, c.paragraph(fmt.Sprintf("%s flow", formatOAuthFlow(flow.Type))))
+			nodes = append(nodes, c.oauthFlowList(flow))
+		}
+	}
+
+	return nodes
+}
+
+// oauthFlowList lists the endpoints and scopes of an OAuth 2.0 flow.
+func (c *ADFConverter) oauthFlowList(flow domain.OAuthFlow) adfNode {
+	items := []adfNode{}
+
+	urls := []struct{ label, url string }{
+		{"Authorization URL", flow.AuthorizationURL},
+		{"Token URL", flow.TokenURL},
+		{"Refresh URL", flow.RefreshURL},
+	}
+
+	for _, u := range urls {
+		if u.url == "" {
+			continue
 		}
 
+		items = append(items, adfNode{
+			Type:    "listItem",
+			Content: []adfNode{c.paragraph(fmt.Sprintf("%s: %s", u.label, u.url))},
+		})
+	}
+
+	for _, scope := range sortedScopes(flow) {
 		items = append(items, adfNode{
 			Type: "listItem",
 			Content: []adfNode{
 				{
 					Type: "paragraph",
 					Content: []adfNode{
-						c.codeText(param.Name),
-						{Type: "text", Text: fmt.Sprintf(" (%s): %s%s", param.In, param.Description, required)},
+						c.codeText(scope),
+						{Type: "text", Text: fmt.Sprintf(": %s", flow.Scopes[scope])},
 					},
 				},
 			},
@@ -417,21 +1205,185 @@ func (c *ADFConverter) parameterList(params []domain.Parameter) adfNode {
 	}
 }
 
-func (c *ADFConverter) responseList(responses []domain.Response) adfNode {
+// securityLozenges renders one status lozenge per alternative security requirement of an operation.
+func (c *ADFConverter) securityLozenges(operation domain.Operation) adfNode {
+	content := []adfNode{c.boldText("Auth: ")}
+
+	for i, requirement := range operation.Security {
+		if i > 0 {
+			content = append(content, adfNode{Type: "text", Text: " or "})
+		}
+
+		color := "purple"
+		if len(requirement) == 0 {
+			color = "neutral"
+		}
+
+		content = append(content, c.status(formatSecurityRequirement(requirement), color))
+	}
+
+	return adfNode{
+		Type:    "paragraph",
+		Content: content,
+	}
+}
+
+func (c *ADFConverter) status(text, color string) adfNode {
+	return adfNode{
+		Type:  "status",
+		Attrs: &adfAttrs{Text: text, Color: color},
+	}
+}
+
+func (c *ADFConverter) deprecatedStatus() adfNode {
+	return c.status(deprecatedLabel, "red")
+}
+
+// exampleNodes renders the request and response examples of an operation as labelled code blocks.
+// exampleNodes renders an example as a code block labelled by its content type, or as a nested expand holding
+// the code block when it is longer than the collapse size, reporting whether it was collapsed. Nested expands
+// cannot be list items, so callers listing examples in list items end the list before collapsed ones.
+func (c *ADFConverter) exampleNodes(example operationExample) ([]adfNode, bool) {
+	value := formatExampleValue(example.Value)
+	code := c.codeBlock(value, "json")
+
+	if c.collapse > 0 && len(value) > c.collapse {
+		return []adfNode{c.nestedExpand(example.Label, []adfNode{code})}, true
+	}
+
+	return []adfNode{{Type: "paragraph", Content: []adfNode{c.boldText(example.Label)}}, code}, false
+}
+
+func (c *ADFConverter) expand(title string, content []adfNode) adfNode {
+	return adfNode{
+		Type:    "expand",
+		Attrs:   &adfAttrs{Title: title},
+		Content: content,
+	}
+}
+
+// nestedExpand is the collapsible section allowed inside an expand.
+func (c *ADFConverter) nestedExpand(title string, content []adfNode) adfNode {
+	return adfNode{
+		Type:    "nestedExpand",
+		Attrs:   &adfAttrs{Title: title},
+		Content: content,
+	}
+}
+
+func (c *ADFConverter) codeBlock(code, language string) adfNode {
+	return adfNode{
+		Type:  "codeBlock",
+		Attrs: &adfAttrs{Language: language},
+		Content: []adfNode{
+			{Type: "text", Text: code},
+		},
+	}
+}
+
+func (c *ADFConverter) parameterList(params []domain.Parameter) adfNode {
+	items := make([]adfNode, 0, len(params))
+
+	for _, param := range params {
+		required := ""
+		if param.Required {
+			required = " (" + c.locale.T("required") + ")"
+		}
+
+		location := param.In
+		if details := formatSchemaDetails(param.Schema); details != "" {
+			location += ", " + details
+		}
+
+		content := append([]adfNode{
+			c.codeText(param.Name),
+			{Type: "text", Text: fmt.Sprintf(" (%s): ", location)},
+		}, c.inlineRichText(param.Description)...)
+		if required != "" {
+			content = append(content, adfNode{Type: "text", Text: required})
+		}
+		if param.Deprecated {
+			content = append(content, adfNode{Type: "text", Text: " "}, c.deprecatedStatus())
+		}
+
+		items = append(items, adfNode{
+			Type:    "listItem",
+			Content: []adfNode{{Type: "paragraph", Content: content}},
+		})
+	}
+
+	return adfNode{
+		Type:    "bulletList",
+		Content: items,
+	}
+}
+
+// responseList lists the responses with the examples of each under it. A response with collapsed examples
+// ends the list, followed by their expands, and the responses after it start another list.
+func (c *ADFConverter) responseList(responses []domain.Response, examples map[string][]operationExample) []adfNode {
+	nodes := []adfNode{}
 	items := make([]adfNode, 0, len(responses))
 
 	for _, resp := range responses {
-		items = append(items, adfNode{
+		item := adfNode{
 			Type: "listItem",
 			Content: []adfNode{
 				{
 					Type: "paragraph",
-					Content: []adfNode{
+					Content: slices.Concat([]adfNode{
 						c.codeText(resp.StatusCode),
-						{Type: "text", Text: fmt.Sprintf(": %s", resp.Description)},
-					},
+						{Type: "text", Text: ": "},
+					}, c.inlineRichText(resp.Description), c.responseContentSuffix(resp)),
 				},
 			},
+		}
+
+		collapsed := []adfNode{}
+
+		for _, example := range examples[resp.StatusCode] {
+			if nodes, isCollapsed := c.exampleNodes(example); isCollapsed {
+				collapsed = append(collapsed, nodes...)
+			} else {
+				item.Content = append(item.Content, nodes...)
+			}
+		}
+
+		items = append(items, item)
+
+		if len(collapsed) > 0 {
+			nodes = append(nodes, adfNode{Type: "bulletList", Content: items})
+			nodes = append(nodes, collapsed...)
+			items = []adfNode{}
+		}
+	}
+
+	if len(items) > 0 {
+		nodes = append(nodes, adfNode{Type: "bulletList", Content: items})
+	}
+
+	return nodes
+}
+
+func (c *ADFConverter) responseHeaderList(headers []responseHeader) adfNode {
+	items := make([]adfNode, 0, len(headers))
+
+	for _, header := range headers {
+		text := fmt.Sprintf(" (%s", header.StatusCode)
+		if details := formatSchemaDetails(header.Schema); details != "" {
+			text += ", " + details
+		}
+
+		content := append([]adfNode{
+			c.codeText(header.Name),
+			{Type: "text", Text: text + "): "},
+		}, c.inlineRichText(header.Description)...)
+		if header.Deprecated {
+			content = append(content, adfNode{Type: "text", Text: " "}, c.deprecatedStatus())
+		}
+
+		items = append(items, adfNode{
+			Type:    "listItem",
+			Content: []adfNode{{Type: "paragraph", Content: content}},
 		})
 	}
 
@@ -440,3 +1392,431 @@ func (c *ADFConverter) responseList(responses []domain.Response) adfNode {
 		Content: items,
 	}
 }
+
+func (c *ADFConverter) responseHeaderTable(headers []responseHeader) adfNode {
+	rows := []adfNode{
+		c.tableRow("tableHeader", c.textCell("Status"), c.textCell("Header"), c.textCell("Type"), c.textCell("Description")),
+	}
+
+	for _, header := range headers {
+		name := []adfNode{c.codeText(header.Name)}
+		if header.Deprecated {
+			name = append(name, adfNode{Type: "text", Text: " "}, c.deprecatedStatus())
+		}
+
+		rows = append(rows, c.tableRow("tableCell",
+			[]adfNode{c.codeText(header.StatusCode)},
+			name,
+			c.textCell(formatSchemaDetails(header.Schema)),
+			c.inlineRichText(header.Description),
+		))
+	}
+
+	return c.table(rows)
+}
+
+func (c *ADFConverter) limitTable(limits []operationLimit) adfNode {
+	rows := []adfNode{c.tableRow("tableHeader", c.textCell("Limit"), c.textCell("Value"))}
+
+	for _, limit := range limits {
+		rows = append(rows, c.tableRow("tableCell", c.textCell(c.locale.T(limit.Label)), c.textCell(limit.Value)))
+	}
+
+	return c.table(rows)
+}
+
+func (c *ADFConverter) parameterTable(params []domain.Parameter) adfNode {
+	rows := []adfNode{
+		c.tableRow("tableHeader", c.textCell("Name"), c.textCell("In"), c.textCell("Type"), c.textCell(c.locale.T("Required")),
+			c.textCell("Description")),
+	}
+
+	for _, param := range params {
+		required := "No"
+		if param.Required {
+			required = "Yes"
+		}
+
+		name := []adfNode{c.codeText(param.Name)}
+		if param.Deprecated {
+			name = append(name, adfNode{Type: "text", Text: " "}, c.deprecatedStatus())
+		}
+
+		rows = append(rows, c.tableRow("tableCell",
+			name,
+			c.textCell(param.In),
+			c.textCell(formatSchemaDetails(param.Schema)),
+			c.textCell(required),
+			c.inlineRichText(param.Description),
+		))
+	}
+
+	return c.table(rows)
+}
+
+// responseTable lists the responses in a table, with the examples of each in its description cell.
+func (c *ADFConverter) responseTable(responses []domain.Response, examples map[string][]operationExample) adfNode {
+	rows := []adfNode{
+		c.tableRow("tableHeader", c.textCell("Status"), c.textCell("Description"), c.textCell("Content")),
+	}
+
+	for _, resp := range sortedResponses(responses) {
+		row := c.tableRow("tableCell",
+			[]adfNode{c.codeText(resp.StatusCode)},
+			c.inlineRichText(resp.Description),
+			c.mediaText(contentSummaries(resp.Content)),
+		)
+
+		for _, example := range examples[resp.StatusCode] {
+			nodes, _ := c.exampleNodes(example)
+			row.Content[1].Content = append(row.Content[1].Content, nodes...)
+		}
+
+		rows = append(rows, row)
+	}
+
+	return c.table(rows)
+}
+
+// requestBodyNodes describes a request body: whether it is required, then each content type with its schema,
+// followed by a table of the flattened fields of each content type that has any.
+func (c *ADFConverter) requestBodyNodes(body domain.RequestBody, fields map[string][]bodyField) []adfNode {
+	nodes := []adfNode{{Type: "paragraph", Content: []adfNode{c.boldText(c.locale.T(requiredLabel(body.Required)))}}}
+
+	if body.Description != "" {
+		nodes = append(nodes, c.richText(body.Description)...)
+	}
+
+	summaries := contentSummaries(body.Content)
+	if len(summaries) == 0 {
+		return nodes
+	}
+
+	if c.tables {
+		rows := []adfNode{c.tableRow("tableHeader", c.textCell("Content Type"), c.textCell("Schema"))}
+		for _, summary := range summaries {
+			schema := []adfNode{}
+			if summary.Schema != "" {
+				schema = append(schema, c.codeText(summary.Schema))
+			}
+
+			rows = append(rows, c.tableRow("tableCell", []adfNode{c.codeText(summary.ContentType)}, schema))
+		}
+
+		nodes = append(nodes, c.table(rows))
+	} else {
+		items := make([]adfNode, 0, len(summaries))
+		for _, summary := range summaries {
+			items = append(items, adfNode{
+				Type:    "listItem",
+				Content: []adfNode{{Type: "paragraph", Content: c.mediaText([]mediaSummary{summary})}},
+			})
+		}
+
+		nodes = append(nodes, adfNode{Type: "bulletList", Content: items})
+	}
+
+	for _, summary := range summaries {
+		if flat, ok := fields[summary.ContentType]; ok {
+			nodes = append(nodes,
+				adfNode{Type: "paragraph", Content: []adfNode{c.boldText("Fields: "), c.codeText(summary.ContentType)}},
+				c.bodyFieldTable(flat),
+			)
+		}
+	}
+
+	return nodes
+}
+
+// bodyFieldTable lists request body fields with their type, whether they are required, description and constraints.
+func (c *ADFConverter) bodyFieldTable(fields []bodyField) adfNode {
+	rows := []adfNode{
+		c.tableRow("tableHeader", c.textCell("Field"), c.textCell("Type"), c.textCell(c.locale.T("Required")), c.textCell("Description"),
+			c.textCell("Constraints")),
+	}
+
+	for _, field := range fields {
+		required := "No"
+		if field.Required {
+			required = "Yes"
+		}
+
+		rows = append(rows, c.tableRow("tableCell",
+			[]adfNode{c.codeText(field.Path)},
+			c.textCell(formatSchemaType(field.Schema)),
+			c.textCell(required),
+			c.inlineRichText(field.Schema.Description),
+			c.textCell(joinConstraints("", schemaConstraints(field.Schema))),
+		))
+	}
+
+	return c.table(rows)
+}
+
+// responseFieldNodes labels the flattened fields of each response with its status code, content type and schema,
+// each followed by a table of the fields with their type, format, nullability, whether they are required and description.
+func (c *ADFConverter) responseFieldNodes(responses []responseFields) []adfNode {
+	nodes := make([]adfNode, 0, 2*len(responses))
+
+	for _, resp := range responses {
+		label := []adfNode{c.boldText(resp.StatusCode + " "), c.codeText(resp.ContentType)}
+		if resp.Schema != "" {
+			label = append(label, adfNode{Type: "text", Text: ": "}, c.codeText(resp.Schema))
+		}
+
+		rows := []adfNode{
+			c.tableRow("tableHeader", c.textCell("Field"), c.textCell("Type"), c.textCell("Format"), c.textCell("Nullable"),
+				c.textCell(c.locale.T("Required")), c.textCell("Description")),
+		}
+
+		for _, field := range resp.Fields {
+			format := []adfNode{}
+			if f := fieldFormat(field.Schema); f != "" {
+				format = append(format, c.codeText(f))
+			}
+
+			rows = append(rows, c.tableRow("tableCell",
+				[]adfNode{c.codeText(field.Path)},
+				c.textCell(fieldType(field.Schema)),
+				format,
+				c.textCell(yesNo(field.Schema.Nullable)),
+				c.textCell(yesNo(field.Required)),
+				c.inlineRichText(field.Schema.Description),
+			))
+		}
+
+		nodes = append(nodes, adfNode{Type: "paragraph", Content: label}, c.table(rows))
+	}
+
+	return nodes
+}
+
+// relatedOperationList lists the operations following the responses, linked to their endpoint when it has an anchor.
+func (c *ADFConverter) relatedOperationList(related []relatedOperation) adfNode {
+	items := make([]adfNode, 0, len(related))
+
+	for _, operation := range related {
+		target := c.codeText(operation.Target)
+		if operation.Anchor != "" {
+			target.Marks = append(target.Marks, linkMark("#"+operation.Anchor))
+		}
+
+		content := []adfNode{{Type: "text", Text: operation.StatusCode + " \u2192 "}, target}
+
+		if values := formatLinkValues(operation); values != "" {
+			content = append(content, adfNode{Type: "text", Text: " (" + values + ")"})
+		}
+
+		if operation.Description != "" {
+			content = append(content, adfNode{Type: "text", Text: " - "})
+			content = append(content, c.inlineRichText(operation.Description)...)
+		}
+
+		items = append(items, adfNode{
+			Type:    "listItem",
+			Content: []adfNode{{Type: "paragraph", Content: content}},
+		})
+	}
+
+	return adfNode{Type: "bulletList", Content: items}
+}
+
+// callbackNodes describes each callback request by its URL expression, payload and expected responses.
+func (c *ADFConverter) callbackNodes(callbacks []callbackRequest) []adfNode {
+	nodes := []adfNode{}
+
+	for _, callback := range callbacks {
+		op := callback.Operation
+
+		nodes = append(nodes, adfNode{
+			Type: "paragraph",
+			Content: []adfNode{
+				c.boldText(callback.Name),
+				{Type: "text", Text: ": "},
+				c.codeText(formatMethod(op.Method) + " " + callback.Expression),
+			},
+		})
+
+		if op.Summary != "" {
+			nodes = append(nodes, c.paragraph(op.Summary))
+		}
+
+		if op.Description != "" {
+			nodes = append(nodes, c.richText(op.Description)...)
+		}
+
+		if op.RequestBody != nil {
+			if summaries := contentSummaries(op.RequestBody.Content); len(summaries) > 0 {
+				nodes = append(nodes, adfNode{
+					Type:    "paragraph",
+					Content: append([]adfNode{{Type: "text", Text: "Payload: "}}, c.mediaText(summaries)...),
+				})
+			}
+		}
+
+		if len(op.Responses) > 0 {
+			nodes = append(nodes, c.paragraph("Expected responses: "+callbackResponses(op)))
+		}
+	}
+
+	return nodes
+}
+
+// responseContentSuffix lists the content types of a response after its description, if it has any.
+func (c *ADFConverter) responseContentSuffix(resp domain.Response) []adfNode {
+	summaries := contentSummaries(resp.Content)
+	if len(summaries) == 0 {
+		return nil
+	}
+
+	return append([]adfNode{{Type: "text", Text: " \u2014 "}}, c.mediaText(summaries)...)
+}
+
+// mediaText renders media types as inline code, each followed by its schema: "application/json: Pet".
+func (c *ADFConverter) mediaText(summaries []mediaSummary) []adfNode {
+	nodes := []adfNode{}
+
+	for i, summary := range summaries {
+		if i > 0 {
+			nodes = append(nodes, adfNode{Type: "text", Text: ", "})
+		}
+
+		nodes = append(nodes, c.codeText(summary.ContentType))
+		if summary.Schema != "" {
+			nodes = append(nodes, adfNode{Type: "text", Text: ": "}, c.codeText(summary.Schema))
+		}
+	}
+
+	return nodes
+}
+
+func (c *ADFConverter) table(rows []adfNode) adfNode {
+	return adfNode{
+		Type:    "table",
+		Attrs:   &adfAttrs{Layout: "default"},
+		Content: rows,
+	}
+}
+
+// tableRow builds a row whose cells are of the given type ("tableHeader" or "tableCell").
+func (c *ADFConverter) tableRow(cellType string, cells ...[]adfNode) adfNode {
+	row := adfNode{
+		Type:    "tableRow",
+		Content: make([]adfNode, 0, len(cells)),
+	}
+
+	for _, inline := range cells {
+		row.Content = append(row.Content, adfNode{
+			Type: cellType,
+			Content: []adfNode{
+				{Type: "paragraph", Content: inline},
+			},
+		})
+	}
+
+	return row
+}
+
+// textCell returns the inline content of a plain-text table cell.
+func (c *ADFConverter) textCell(text string) []adfNode {
+	if text == "" {
+		return nil
+	}
+
+	return []adfNode{{Type: "text", Text: text}}
+}
+
+// ConvertChangelog transforms a changelog between two specifications to ADF JSON format.
+func (c *ADFConverter) ConvertChangelog(changelog *domain.Changelog, output io.Writer) error {
+	adf := &adfDocument{
+		Version: 1,
+		Type:    "doc",
+		Content: []adfNode{},
+	}
+
+	adf.Content = append(adf.Content, c.heading(ChangelogTitle(changelog), 1))
+	adf.Content = append(adf.Content, c.paragraph(ChangelogSummary(changelog)))
+
+	for _, section := range changelogSections(changelog) {
+		adf.Content = append(adf.Content, c.heading(section.title, 2))
+
+		if section.title == breakingSectionTitle {
+			adf.Content = append(adf.Content, c.breakingChangesPanel(section))
+		} else {
+			adf.Content = append(adf.Content, c.changelogTable(section))
+		}
+	}
+
+	encoder := json.NewEncoder(output)
+	encoder.SetIndent("", "  ")
+
+	if err := encoder.Encode(adf); err != nil {
+		return fmt.Errorf("failed to encode ADF: %w", err)
+	}
+
+	return nil
+}
+
+// breakingChangesPanel lists the breaking changes of a changelog in an error panel, for release pages to open with.
+func (c *ADFConverter) breakingChangesPanel(section changelogSection) adfNode {
+	items := []adfNode{}
+
+	for _, group := range section.groups {
+		for _, item := range group.items {
+			items = append(items, adfNode{
+				Type:    "listItem",
+				Content: []adfNode{{Type: "paragraph", Content: []adfNode{{Type: "text", Text: item.text}}}},
+			})
+		}
+	}
+
+	return adfNode{
+		Type:    "panel",
+		Attrs:   &adfAttrs{PanelType: "error"},
+		Content: []adfNode{{Type: "bulletList", Content: items}},
+	}
+}
+
+// changelogTable renders a section of a changelog as a table with a row per change and a status lozenge telling
+// breaking changes apart. Changes of the same endpoint or schema share a cell naming it.
+func (c *ADFConverter) changelogTable(section changelogSection) adfNode {
+	subject := "Endpoint"
+	if section.subject == domain.SubjectSchema {
+		subject = "Schema"
+	}
+
+	grouped := len(section.groups) > 0 && section.groups[0].title != ""
+
+	headers := [][]adfNode{{{Type: "text", Text: subject}}}
+	if grouped {
+		headers = append(headers, []adfNode{{Type: "text", Text: "Change"}})
+	}
+
+	rows := []adfNode{c.tableRow("tableHeader", append(headers, []adfNode{{Type: "text", Text: "Impact"}})...)}
+
+	for _, group := range section.groups {
+		for i, item := range group.items {
+			impact := c.status("compatible", "green")
+			if item.breaking {
+				impact = c.status("breaking", "red")
+			}
+
+			if !grouped {
+				rows = append(rows, c.tableRow("tableCell", []adfNode{{Type: "text", Text: item.text}}, []adfNode{impact}))
+
+				continue
+			}
+
+			row := c.tableRow("tableCell", []adfNode{{Type: "text", Text: item.text}}, []adfNode{impact})
+			if i == 0 {
+				target := c.tableRow("tableCell", []adfNode{{Type: "text", Text: group.title}})
+				target.Content[0].Attrs = &adfAttrs{Rowspan: len(group.items)}
+				row.Content = append(target.Content, row.Content...)
+			}
+
+			rows = append(rows, row)
+		}
+	}
+
+	return c.table(rows)
+}
@@ -0,0 +1,213 @@
+package converters
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+)
+
+// adfContinuationReserve is the room left in every part of a split ADF document for its continuation link.
+const adfContinuationReserve = 1024
+
+// SplitADF splits an ADF document, as written by the ADF converter, into compact documents whose encoding stays
+// within maxSize bytes once LinkADFContinuation links them to the next one. Documents are split between top-level
+// nodes, headings and endpoint headers moving to the next document along with the content they introduce, so
+// anchors only resolve within the document holding them, and lists too large to fit, such as the table of
+// contents, are split between their items. A document within maxSize, or any document when maxSize is not
+// positive, is returned as is; a single node too large to fit is an error.
+func SplitADF(content []byte, maxSize int) ([][]byte, error) {
+	if maxSize <= 0 || len(content) <= maxSize {
+		return [][]byte{content}, nil
+	}
+
+	var doc adfDocument
+	if err := json.Unmarshal(content, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse ADF document: %w", err)
+	}
+
+	envelope, err := encodeCompactADF(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	split := adfSplit{budget: maxSize - adfContinuationReserve - len(envelope)}
+
+	for i, node := range doc.Content {
+		nodes, err := fitADFNode(node, split.budget)
+		if err != nil {
+			return nil, fmt.Errorf("top-level node %d (%s) does not fit in the %d bytes of content of documents of %d bytes: %w",
+				i+1, node.Type, split.budget, maxSize, err)
+		}
+
+		for _, node := range nodes {
+			split.add(node)
+		}
+	}
+
+	parts := append(split.parts, split.current)
+	documents := make([][]byte, 0, len(parts))
+
+	for _, nodes := range parts {
+		data, err := encodeCompactADF(nodes)
+		if err != nil {
+			return nil, err
+		}
+
+		documents = append(documents, data)
+	}
+
+	return documents, nil
+}
+
+// adfSplit gathers the top-level nodes of a document into parts whose nodes take at most budget bytes.
+type adfSplit struct {
+	budget  int
+	parts   [][]adfNode
+	current []adfNode // Nodes of the part being filled
+	sizes   []int     // Sizes of the nodes of current
+	size    int       // Sum of sizes
+}
+
+// add adds a node fitting in the budget to the current part, first closing the part when the node does not fit
+// in it, in which case the headings and endpoint headers ending the part move to the next one.
+func (s *adfSplit) add(node adfNode) {
+	nodeSize := adfNodeSize(node)
+
+	if len(s.current) > 0 && s.size+nodeSize > s.budget {
+		keep := len(s.current)
+		for keep > 1 && leadsADFContent(s.current[keep-1]) {
+			keep--
+		}
+
+		s.parts = append(s.parts, s.current[:keep])
+		s.current, s.sizes = slices.Clone(s.current[keep:]), slices.Clone(s.sizes[keep:])
+
+		s.size = 0
+		for _, carried := range s.sizes {
+			s.size += carried
+		}
+	}
+
+	s.current, s.sizes = append(s.current, node), append(s.sizes, nodeSize)
+	s.size += nodeSize
+}
+
+// fitADFNode returns a node as is when it fits in budget, or the lists it splits into between their items when it
+// is a list too large, an ordered list carrying on its numbering.
+func fitADFNode(node adfNode, budget int) ([]adfNode, error) {
+	if size := adfNodeSize(node); size <= budget {
+		return []adfNode{node}, nil
+	} else if node.Type != "bulletList" && node.Type != "orderedList" {
+		return nil, fmt.Errorf("%s takes %d bytes", node.Type, size)
+	}
+
+	order := 1
+	if node.Attrs != nil && node.Attrs.Order > 0 {
+		order = node.Attrs.Order
+	}
+
+	lists := []adfNode{}
+	list := adfNode{Type: node.Type}
+
+	for i, item := range node.Content {
+		pieces, err := fitADFListItem(item, budget-adfNodeSize(adfNode{Type: node.Type, Attrs: &adfAttrs{Order: order + i}}))
+		if err != nil {
+			return nil, fmt.Errorf("list item %d: %w", i+1, err)
+		}
+
+		for _, piece := range pieces {
+			grown := list
+			grown.Content = append(slices.Clone(list.Content), piece)
+
+			if len(list.Content) == 0 || adfNodeSize(grown) <= budget {
+				list = grown
+
+				continue
+			}
+
+			lists = append(lists, list)
+			list = adfNode{Type: node.Type, Content: []adfNode{piece}}
+
+			// A list starting with the rest of a split item carries on with the number of that item
+			if node.Type == "orderedList" && order+i > 1 {
+				list.Attrs = &adfAttrs{Order: order + i}
+			}
+		}
+	}
+
+	return append(lists, list), nil
+}
+
+// fitADFListItem returns a list item as is when it fits in budget, or, when it is an item too large holding a
+// paragraph and nested lists, such as a tag of the table of contents and its endpoints, the items its nested
+// lists split into, each led by the paragraph.
+func fitADFListItem(item adfNode, budget int) ([]adfNode, error) {
+	if size := adfNodeSize(item); size <= budget {
+		return []adfNode{item}, nil
+	} else if len(item.Content) < 2 || item.Content[0].Type != "paragraph" {
+		return nil, fmt.Errorf("%s takes %d bytes", item.Type, size)
+	}
+
+	lead := item.Content[0]
+	pieces := []adfNode{}
+
+	for _, nested := range item.Content[1:] {
+		lists, err := fitADFNode(nested, budget-adfNodeSize(adfNode{Type: item.Type, Content: []adfNode{lead}}))
+		if err != nil {
+			return nil, err
+		}
+
+		for _, list := range lists {
+			pieces = append(pieces, adfNode{Type: item.Type, Content: []adfNode{lead, list}})
+		}
+	}
+
+	return pieces, nil
+}
+
+// adfNodeSize returns the bytes a node takes in the content of a compact document, its separator included.
+func adfNodeSize(node adfNode) int {
+	data, err := json.Marshal(node)
+	if err != nil {
+		return 0
+	}
+
+	return len(data) + len(",")
+}
+
+// LinkADFContinuation appends a "Continued on" paragraph to a part of a split ADF document, linking label to href.
+func LinkADFContinuation(content []byte, label, href string) ([]byte, error) {
+	var doc adfDocument
+	if err := json.Unmarshal(content, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse ADF document: %w", err)
+	}
+
+	return encodeCompactADF(append(doc.Content, adfNode{
+		Type: "paragraph",
+		Content: []adfNode{
+			{Type: "text", Text: "Continued on "},
+			{Type: "text", Text: label, Marks: []adfMark{linkMark(href)}},
+		},
+	}))
+}
+
+// leadsADFContent reports whether a top-level node introduces the content following it: a heading,
+// or a paragraph opening with the anchor of an endpoint, such as its header before the expand of its details.
+func leadsADFContent(node adfNode) bool {
+	if node.Type == "heading" {
+		return true
+	}
+
+	return node.Type == "paragraph" && len(node.Content) > 0 && node.Content[0].Type == "inlineExtension" &&
+		node.Content[0].Attrs != nil && node.Content[0].Attrs.ExtensionKey == "anchor"
+}
+
+// encodeCompactADF encodes the nodes of a document without indentation, as the parts of a split document.
+func encodeCompactADF(nodes []adfNode) ([]byte, error) {
+	data, err := json.Marshal(adfDocument{Version: 1, Type: "doc", Content: nodes})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode ADF document: %w", err)
+	}
+
+	return append(data, '\n'), nil
+}
@@ -0,0 +1,99 @@
+package converters_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/GabrielNunesIT/openapi-converter/internal/adapters/converters"
+	"github.com/GabrielNunesIT/openapi-converter/internal/adapters/parsers"
+)
+
+// manyEndpointsSpec returns a specification of count operations with descriptions long enough to need splitting.
+func manyEndpointsSpec(count int) []byte {
+	var spec strings.Builder
+
+	spec.WriteString("openapi: 3.0.3\ninfo:\n  title: Many\n  version: 1.0.0\npaths:\n")
+
+	for i := range count {
+		fmt.Fprintf(&spec, "  /items/%d:\n    get:\n      tags: [items]\n      operationId: getItem%d\n", i, i)
+		fmt.Fprintf(&spec, "      summary: Get item %d\n      description: %s\n", i, strings.Repeat("Lorem ipsum dolor. ", 20))
+		spec.WriteString("      responses:\n        '200':\n          description: The item\n")
+	}
+
+	return []byte(spec.String())
+}
+
+func TestSplitADFManyEndpoints(t *testing.T) {
+	const operations = 40
+
+	doc, err := parsers.NewOpenAPIParser().Parse(manyEndpointsSpec(operations), "many.yaml")
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+
+	var content bytes.Buffer
+	if err := converters.NewADFConverter(converters.ADFOptions{}).Convert(doc, &content); err != nil {
+		t.Fatalf("convert: %v", err)
+	}
+
+	for _, maxSize := range []int{40000, 20000, 8000} {
+		t.Run(fmt.Sprint(maxSize), func(t *testing.T) {
+			parts, err := converters.SplitADF(content.Bytes(), maxSize)
+			if err != nil {
+				t.Fatalf("split: %v", err)
+			}
+
+			if len(parts) < 2 {
+				t.Fatalf("got %d part, want the %d-byte document split", len(parts), content.Len())
+			}
+
+			expands := 0
+
+			for i, part := range parts {
+				if i+1 < len(parts) {
+					if part, err = converters.LinkADFContinuation(part, "next", "next"); err != nil {
+						t.Fatalf("link part %d: %v", i+1, err)
+					}
+				}
+
+				if len(part) > maxSize {
+					t.Errorf("part %d takes %d bytes, more than %d", i+1, len(part), maxSize)
+				}
+
+				var doc struct {
+					Content []struct {
+						Type    string `json:"type"`
+						Content []struct {
+							Type string `json:"type"`
+						} `json:"content"`
+					} `json:"content"`
+				}
+				if err := json.Unmarshal(part, &doc); err != nil {
+					t.Fatalf("part %d is not JSON: %v", i+1, err)
+				}
+
+				for j, node := range doc.Content {
+					if node.Type != "expand" {
+						continue
+					}
+
+					expands++
+
+					// An endpoint header, opening with its anchor, comes right before its expand
+					previous := doc.Content[max(j-1, 0)]
+					if j == 0 || previous.Type != "paragraph" || len(previous.Content) == 0 ||
+						previous.Content[0].Type != "inlineExtension" {
+						t.Errorf("part %d: expand %d is separated from its endpoint header", i+1, j+1)
+					}
+				}
+			}
+
+			if expands != operations {
+				t.Errorf("got %d endpoint expands, want %d", expands, operations)
+			}
+		})
+	}
+}
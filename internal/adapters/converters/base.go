@@ -2,12 +2,36 @@
 package converters
 
 import (
+	"cmp"
+	"encoding/json"
 	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/GabrielNunesIT/openapi-converter/internal/domain"
 )
 
+// deprecatedLabel marks operations, parameters and schemas that are deprecated.
+const deprecatedLabel = "deprecated"
+
+// Markers of the schemas that accept null, and of the properties sent in one direction only.
+const (
+	nullableLabel  = "nullable"
+	readOnlyLabel  = "read-only"
+	writeOnlyLabel = "write-only"
+)
+
+var (
+	// wordSeparators split names into words, for the type names of the formats generating code.
+	wordSeparators = regexp.MustCompile(`[^0-9A-Za-z]+`)
+
+	// invalidIdentifierChars are the characters the identifiers of the formats generating code cannot hold.
+	invalidIdentifierChars = regexp.MustCompile(`[^_0-9A-Za-z]+`)
+)
+
 // formatMethod returns a styled method string.
 func formatMethod(method string) string {
 	return strings.ToUpper(method)
@@ -27,12 +51,21 @@ func formatParameters(params []domain.Parameter) string {
 			required = " (required)"
 		}
 
-		result.WriteString(fmt.Sprintf("- %s (%s): %s%s\n", p.Name, p.In, p.Description, required))
+		result.WriteString(fmt.Sprintf("- %s (%s): %s%s%s\n", p.Name, p.In, p.Description, required, deprecatedSuffix(p.Deprecated)))
 	}
 
 	return result.String()
 }
 
+// deprecatedSuffix returns " (deprecated)" for deprecated items and an empty string otherwise.
+func deprecatedSuffix(deprecated bool) string {
+	if !deprecated {
+		return ""
+	}
+
+	return " (" + deprecatedLabel + ")"
+}
+
 // formatResponses returns a formatted response list.
 func formatResponses(responses []domain.Response) string {
 	if len(responses) == 0 {
@@ -47,3 +80,1329 @@ func formatResponses(responses []domain.Response) string {
 
 	return result.String()
 }
+
+// endpointRef ties an operation to the path it belongs to.
+type endpointRef struct {
+	path      string
+	method    string
+	operation domain.Operation
+}
+
+// groupPathsByTag groups paths by their operation tags, keeping the order of the document.
+func groupPathsByTag(doc *domain.OpenAPIDocument) map[string][]endpointRef {
+	result := make(map[string][]endpointRef)
+
+	for _, path := range doc.Paths {
+		for _, op := range path.Operations {
+			tags := op.Tags
+			if len(tags) == 0 {
+				tags = []string{"Default"}
+			}
+
+			for _, tag := range tags {
+				result[tag] = append(result[tag], endpointRef{
+					path:      path.Path,
+					method:    op.Method,
+					operation: op,
+				})
+			}
+		}
+	}
+
+	return result
+}
+
+// webhookRefs flattens webhook operations into endpoint references ordered by name then method.
+func webhookRefs(doc *domain.OpenAPIDocument) []endpointRef {
+	refs := []endpointRef{}
+
+	for _, webhook := range doc.Webhooks {
+		for _, op := range webhook.Operations {
+			refs = append(refs, endpointRef{
+				path:      webhook.Name,
+				method:    op.Method,
+				operation: op,
+			})
+		}
+	}
+
+	sort.Slice(refs, func(i, j int) bool {
+		if refs[i].path == refs[j].path {
+			return refs[i].method < refs[j].method
+		}
+
+		return refs[i].path < refs[j].path
+	})
+
+	return refs
+}
+
+// orderedTags returns the tag names of a grouping in the order the document declares them,
+// followed by the undeclared ones in alphabetical order.
+func orderedTags(doc *domain.OpenAPIDocument, tagPaths map[string][]endpointRef) []string {
+	tags := make([]string, 0, len(tagPaths))
+	listed := make(map[string]struct{}, len(tagPaths))
+
+	for _, tag := range doc.Tags {
+		_, used := tagPaths[tag.Name]
+		if _, seen := listed[tag.Name]; used && !seen {
+			tags = append(tags, tag.Name)
+			listed[tag.Name] = struct{}{}
+		}
+	}
+
+	undeclared := []string{}
+	for tag := range tagPaths {
+		if _, seen := listed[tag]; !seen {
+			undeclared = append(undeclared, tag)
+		}
+	}
+	sort.Strings(undeclared)
+
+	return append(tags, undeclared...)
+}
+
+// tagInfo returns the declaration of a tag, with only its name when the document does not declare it.
+func tagInfo(doc *domain.OpenAPIDocument, name string) domain.Tag {
+	for _, tag := range doc.Tags {
+		if tag.Name == name {
+			return tag
+		}
+	}
+
+	return domain.Tag{Name: name}
+}
+
+// withExternalDocs returns a description followed by a Markdown link to the external documentation, if any.
+func withExternalDocs(description string, docs *domain.ExternalDocs) string {
+	parts := []string{}
+	if description = strings.TrimSpace(description); description != "" {
+		parts = append(parts, description)
+	}
+
+	if docs != nil && docs.URL != "" {
+		label := docs.Description
+		if label == "" {
+			label = docs.URL
+		}
+
+		parts = append(parts, fmt.Sprintf("See also: [%s](%s)", label, docs.URL))
+	}
+
+	return strings.Join(parts, "\n\n")
+}
+
+// withPlainExternalDocs is the plain-text counterpart of withExternalDocs, for formats without links.
+func withPlainExternalDocs(description string, docs *domain.ExternalDocs) string {
+	parts := []string{}
+	if description = strings.TrimSpace(description); description != "" {
+		parts = append(parts, description)
+	}
+
+	if docs != nil && docs.URL != "" {
+		if docs.Description != "" {
+			parts = append(parts, fmt.Sprintf("See also: %s (%s)", docs.Description, docs.URL))
+		} else {
+			parts = append(parts, "See also: "+docs.URL)
+		}
+	}
+
+	return strings.Join(parts, "\n\n")
+}
+
+// aboutEntry is a line of the About block: the contact, license or terms of service of the API.
+type aboutEntry struct {
+	Label string
+	Parts []aboutPart
+}
+
+// aboutPart is a piece of an About entry, linked when it has a URL.
+type aboutPart struct {
+	Text string
+	URL  string
+}
+
+// aboutEntries returns the About block of a document, empty when its info gives no contact, license or terms.
+func aboutEntries(doc *domain.OpenAPIDocument) []aboutEntry {
+	entries := []aboutEntry{}
+
+	if contact := doc.Contact; contact != nil {
+		parts := []aboutPart{}
+		if contact.Name != "" {
+			parts = append(parts, aboutPart{Text: contact.Name})
+		}
+
+		if contact.Email != "" {
+			parts = append(parts, aboutPart{Text: contact.Email, URL: "mailto:" + contact.Email})
+		}
+
+		if contact.URL != "" {
+			parts = append(parts, aboutPart{Text: contact.URL, URL: contact.URL})
+		}
+
+		if len(parts) > 0 {
+			entries = append(entries, aboutEntry{Label: "Contact", Parts: parts})
+		}
+	}
+
+	if license := doc.License; license != nil && (license.Name != "" || license.URL != "") {
+		text := license.Name
+		if text == "" {
+			text = license.URL
+		}
+
+		entries = append(entries, aboutEntry{Label: "License", Parts: []aboutPart{{Text: text, URL: license.URL}}})
+	}
+
+	if doc.TermsOfService != "" {
+		terms := aboutPart{Text: doc.TermsOfService, URL: doc.TermsOfService}
+		entries = append(entries, aboutEntry{Label: "Terms of service", Parts: []aboutPart{terms}})
+	}
+
+	return entries
+}
+
+// markdown returns the entry as Markdown, its parts linked.
+func (e aboutEntry) markdown() string {
+	parts := make([]string, 0, len(e.Parts))
+
+	for _, part := range e.Parts {
+		if part.URL == "" {
+			parts = append(parts, part.Text)
+		} else {
+			parts = append(parts, fmt.Sprintf("[%s](%s)", part.Text, part.URL))
+		}
+	}
+
+	return fmt.Sprintf("**%s:** %s", e.Label, strings.Join(parts, ", "))
+}
+
+// plain returns the entry as plain text, for formats without links, with the URLs not already shown in parentheses.
+func (e aboutEntry) plain() string {
+	parts := make([]string, 0, len(e.Parts))
+
+	for _, part := range e.Parts {
+		if part.URL == "" || part.URL == part.Text || part.URL == "mailto:"+part.Text {
+			parts = append(parts, part.Text)
+		} else {
+			parts = append(parts, fmt.Sprintf("%s (%s)", part.Text, part.URL))
+		}
+	}
+
+	return fmt.Sprintf("%s: %s", e.Label, strings.Join(parts, ", "))
+}
+
+// aboutMarkdown returns the About block of a document as a Markdown list, empty when it has no entry.
+func aboutMarkdown(doc *domain.OpenAPIDocument) string {
+	lines := []string{}
+	for _, entry := range aboutEntries(doc) {
+		lines = append(lines, "- "+entry.markdown())
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// Groupings of the operations into sections, chosen with GroupOperations.
+const (
+	GroupByTag        = "tag"         // The tags of the operations
+	GroupByPathPrefix = "path-prefix" // The first segment of the path of the operations
+	GroupByNone       = "none"        // A single section
+	GroupByExtension  = "x-group"     // The x-group extension of the operations, falling back to their tags
+)
+
+// groupExtension is the operation extension naming the sections of the x-group grouping.
+const groupExtension = "x-group"
+
+// GroupBys returns the supported groupings of the operations.
+func GroupBys() []string {
+	return []string{GroupByTag, GroupByPathPrefix, GroupByNone, GroupByExtension}
+}
+
+// GroupOperations returns the document with its operations grouped into sections the given way. Converters render
+// a section per tag, so the tags of the operations are replaced by the names of their groups, declared in the order
+// the document first uses them; groups named like a declared tag keep its description.
+func GroupOperations(doc *domain.OpenAPIDocument, groupBy string) (*domain.OpenAPIDocument, error) {
+	var group func(path string, op domain.Operation) []string
+
+	switch groupBy {
+	case GroupByTag, "":
+		return doc, nil
+	case GroupByPathPrefix:
+		group = func(path string, _ domain.Operation) []string {
+			if segment, _, _ := strings.Cut(strings.TrimPrefix(path, "/"), "/"); segment != "" {
+				return []string{segment}
+			}
+
+			return nil
+		}
+	case GroupByNone:
+		group = func(string, domain.Operation) []string { return nil }
+	case GroupByExtension:
+		group = func(_ string, op domain.Operation) []string {
+			if groups := extensionGroups(op.Extensions[groupExtension]); len(groups) > 0 {
+				return groups
+			}
+
+			return op.Tags
+		}
+	default:
+		return nil, fmt.Errorf("unsupported grouping: %s (supported: %s)", groupBy, strings.Join(GroupBys(), ", "))
+	}
+
+	grouped := *doc
+	grouped.Paths = make([]domain.Path, 0, len(doc.Paths))
+	grouped.Tags = nil
+
+	declared := map[string]struct{}{}
+
+	for _, path := range doc.Paths {
+		regrouped := path
+		regrouped.Operations = make([]domain.Operation, 0, len(path.Operations))
+
+		for _, op := range path.Operations {
+			op.Tags = group(path.Path, op)
+
+			for _, name := range op.Tags {
+				if _, seen := declared[name]; !seen {
+					grouped.Tags = append(grouped.Tags, tagInfo(doc, name))
+					declared[name] = struct{}{}
+				}
+			}
+
+			regrouped.Operations = append(regrouped.Operations, op)
+		}
+
+		grouped.Paths = append(grouped.Paths, regrouped)
+	}
+
+	return &grouped, nil
+}
+
+// extensionGroups returns the group names of an x-group extension, a string or a list of strings.
+func extensionGroups(value any) []string {
+	switch value := value.(type) {
+	case string:
+		if value = strings.TrimSpace(value); value != "" {
+			return []string{value}
+		}
+	case []any:
+		groups := []string{}
+
+		for _, item := range value {
+			if name, ok := item.(string); ok && strings.TrimSpace(name) != "" {
+				groups = append(groups, strings.TrimSpace(name))
+			}
+		}
+
+		return groups
+	}
+
+	return nil
+}
+
+// Orders of the tag sections, chosen with OrderTags.
+const (
+	TagOrderSpec  = "spec"  // The order the document declares its tags in, undeclared tags last
+	TagOrderAlpha = "alpha" // Alphabetical order
+)
+
+// TagOrders returns the supported orders of the tag sections.
+func TagOrders() []string {
+	return []string{TagOrderSpec, TagOrderAlpha}
+}
+
+// OrderTags returns the document with its tag sections in the given order. Converters render tags in the order
+// they are declared, so the alphabetical order declares every tag, including those only used by operations,
+// sorted by name.
+func OrderTags(doc *domain.OpenAPIDocument, order string) (*domain.OpenAPIDocument, error) {
+	switch order {
+	case TagOrderSpec, "":
+		return doc, nil
+	case TagOrderAlpha:
+	default:
+		return nil, fmt.Errorf("unsupported tag order: %s (supported: %s)", order, strings.Join(TagOrders(), ", "))
+	}
+
+	names := []string{}
+	for _, tag := range doc.Tags {
+		names = append(names, tag.Name)
+	}
+
+	for name := range groupPathsByTag(doc) {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+	names = slices.Compact(names)
+
+	ordered := *doc
+	ordered.Tags = make([]domain.Tag, 0, len(names))
+
+	for _, name := range names {
+		ordered.Tags = append(ordered.Tags, tagInfo(doc, name))
+	}
+
+	return &ordered, nil
+}
+
+// Orders of the operations of each tag section, chosen with OrderOperations.
+const (
+	OperationOrderSpec    = "spec-order" // The order of the document: paths as written, the operations of a path by method
+	OperationOrderPath    = "path"       // By path, then method name, the default order of the command line
+	OperationOrderMethod  = "method"     // By method, then path
+	OperationOrderSummary = "summary"    // By summary, operations without one last
+)
+
+// operationMethods lists the HTTP methods in the order the operations of a path are parsed and sorted in.
+//
+//nolint:gochecknoglobals // read-only lookup table
+var operationMethods = []string{"GET", "POST", "PUT", "PATCH", "DELETE", "HEAD", "OPTIONS", "TRACE"}
+
+// OperationOrders returns the supported orders of the operations.
+func OperationOrders() []string {
+	return []string{OperationOrderSpec, OperationOrderPath, OperationOrderMethod, OperationOrderSummary}
+}
+
+// OrderOperations returns the document with its operations in the given order, ties keeping the order of the document.
+// Converters render operations in the order of the paths holding them, so the paths are regrouped: ordered by method
+// or summary, a path may be listed several times, each time with some of its operations.
+func OrderOperations(doc *domain.OpenAPIDocument, order string) (*domain.OpenAPIDocument, error) {
+	byPath := func(a, b endpointRef) int { return strings.Compare(a.path, b.path) }
+	byMethod := func(a, b endpointRef) int { return compareMethods(a.method, b.method) }
+
+	var compare func(a, b endpointRef) int
+
+	switch order {
+	case OperationOrderSpec, "":
+		return doc, nil
+	case OperationOrderPath:
+		compare = func(a, b endpointRef) int { return cmp.Or(byPath(a, b), strings.Compare(a.method, b.method)) }
+	case OperationOrderMethod:
+		compare = func(a, b endpointRef) int { return cmp.Or(byMethod(a, b), byPath(a, b)) }
+	case OperationOrderSummary:
+		compare = func(a, b endpointRef) int {
+			switch summaryA, summaryB := a.operation.Summary, b.operation.Summary; {
+			case summaryA == "" && summaryB != "":
+				return 1
+			case summaryA != "" && summaryB == "":
+				return -1
+			default:
+				return strings.Compare(strings.ToLower(summaryA), strings.ToLower(summaryB))
+			}
+		}
+	default:
+		return nil, fmt.Errorf("unsupported operation order: %s (supported: %s)", order, strings.Join(OperationOrders(), ", "))
+	}
+
+	refs := []endpointRef{}
+	for _, path := range doc.Paths {
+		for _, op := range path.Operations {
+			refs = append(refs, endpointRef{path: path.Path, method: op.Method, operation: op})
+		}
+	}
+
+	slices.SortStableFunc(refs, compare)
+
+	ordered := *doc
+	ordered.Paths = nil
+
+	for _, ref := range refs {
+		if last := len(ordered.Paths) - 1; last >= 0 && ordered.Paths[last].Path == ref.path {
+			ordered.Paths[last].Operations = append(ordered.Paths[last].Operations, ref.operation)
+
+			continue
+		}
+
+		ordered.Paths = append(ordered.Paths, domain.Path{Path: ref.path, Operations: []domain.Operation{ref.operation}})
+	}
+
+	return &ordered, nil
+}
+
+// compareMethods orders HTTP methods as operationMethods does, unknown methods last in alphabetical order.
+func compareMethods(a, b string) int {
+	rank := func(method string) int {
+		if i := slices.Index(operationMethods, strings.ToUpper(method)); i >= 0 {
+			return i
+		}
+
+		return len(operationMethods)
+	}
+
+	return cmp.Or(cmp.Compare(rank(a), rank(b)), strings.Compare(a, b))
+}
+
+// responseHeader is a header of one of the responses of an operation.
+type responseHeader struct {
+	StatusCode string
+	domain.Header
+}
+
+// responseHeaders lists the headers of the responses of an operation, in status code order.
+func responseHeaders(responses []domain.Response) []responseHeader {
+	var headers []responseHeader
+
+	for _, resp := range sortedResponses(responses) {
+		for _, header := range resp.Headers {
+			headers = append(headers, responseHeader{StatusCode: resp.StatusCode, Header: header})
+		}
+	}
+
+	return headers
+}
+
+// callbackRequest is a request sent by a callback of an operation.
+type callbackRequest struct {
+	Name       string // Callback name
+	Expression string // Runtime expression of the URL the request is sent to
+	Operation  domain.Operation
+}
+
+// callbackRequests lists the requests sent by the callbacks of an operation, in callback order.
+func callbackRequests(op domain.Operation) []callbackRequest {
+	var requests []callbackRequest
+
+	for _, callback := range op.Callbacks {
+		for _, callbackOp := range callback.Operations {
+			requests = append(requests, callbackRequest{Name: callback.Name, Expression: callback.Expression, Operation: callbackOp})
+		}
+	}
+
+	return requests
+}
+
+// callbackResponses lists the responses a callback expects, such as "204 Notification received, 410 Gone".
+func callbackResponses(op domain.Operation) string {
+	responses := sortedResponses(op.Responses)
+	labels := make([]string, 0, len(responses))
+
+	for _, resp := range responses {
+		labels = append(labels, strings.TrimSpace(resp.StatusCode+" "+resp.Description))
+	}
+
+	return strings.Join(labels, ", ")
+}
+
+// collectTagComponents gathers all unique component names used by endpoints in a tag.
+func collectTagComponents(endpoints []endpointRef) []string {
+	componentSet := make(map[string]struct{})
+
+	for _, ep := range endpoints {
+		// Check request body
+		if ep.operation.RequestBody != nil {
+			for _, media := range ep.operation.RequestBody.Content {
+				collectSchemaRefs(media.Schema, componentSet)
+			}
+		}
+
+		// Check responses
+		for _, resp := range ep.operation.Responses {
+			for _, media := range resp.Content {
+				collectSchemaRefs(media.Schema, componentSet)
+			}
+
+			for _, header := range resp.Headers {
+				collectSchemaRefs(header.Schema, componentSet)
+			}
+		}
+
+		// Check parameters
+		for _, param := range ep.operation.Parameters {
+			collectSchemaRefs(param.Schema, componentSet)
+		}
+
+		// Check callback payloads and the responses expected to them
+		for _, callback := range callbackRequests(ep.operation) {
+			if callback.Operation.RequestBody != nil {
+				for _, media := range callback.Operation.RequestBody.Content {
+					collectSchemaRefs(media.Schema, componentSet)
+				}
+			}
+
+			for _, resp := range callback.Operation.Responses {
+				for _, media := range resp.Content {
+					collectSchemaRefs(media.Schema, componentSet)
+				}
+			}
+		}
+	}
+
+	// Convert set to sorted slice
+	components := make([]string, 0, len(componentSet))
+	for name := range componentSet {
+		components = append(components, name)
+	}
+	sort.Strings(components)
+
+	return components
+}
+
+// sortedComponentNames returns the names of the component schemas in alphabetical order.
+func sortedComponentNames(components map[string]domain.Schema) []string {
+	names := make([]string, 0, len(components))
+	for name := range components {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// collectSchemaRefs recursively collects component references from a schema.
+// Each referenced schema is walked once, so a circular reference such as a tree node listing its children
+// only records the name of the schema it leads back to.
+func collectSchemaRefs(schema domain.Schema, refs map[string]struct{}) {
+	walkSchemaRefs(schema, refs, make(map[string]struct{}))
+}
+
+func walkSchemaRefs(schema domain.Schema, refs, walked map[string]struct{}) {
+	if schema.Ref != "" {
+		name := extractRefName(schema.Ref)
+		refs[name] = struct{}{}
+
+		if _, circular := walked[name]; circular {
+			return
+		}
+
+		walked[name] = struct{}{}
+	}
+
+	for _, prop := range schema.Properties {
+		walkSchemaRefs(prop, refs, walked)
+	}
+
+	if schema.Items != nil {
+		walkSchemaRefs(*schema.Items, refs, walked)
+	}
+
+	for _, composed := range [][]domain.Schema{schema.AllOf, schema.AnyOf, schema.OneOf} {
+		for _, member := range composed {
+			walkSchemaRefs(member, refs, walked)
+		}
+	}
+
+	if schema.Discriminator != nil {
+		for _, ref := range schema.Discriminator.Mapping {
+			refs[extractRefName(ref)] = struct{}{}
+		}
+	}
+}
+
+// DefaultSchemaDepth is the number of property levels of inline objects listed when no depth is configured.
+const DefaultSchemaDepth = 3
+
+// schemaProperty is a property of an object schema, with the properties of the inline object it holds nested below it.
+type schemaProperty struct {
+	Name     string
+	Schema   domain.Schema
+	Required bool // Required by the object holding it
+	Children []schemaProperty
+}
+
+// nestedProperties lists the properties of an object schema by name, down to depth levels. A property holding an
+// inline object, directly or as array items, lists its properties as children; referenced schemas are documented
+// on their own and are not expanded.
+func nestedProperties(schema domain.Schema, depth int) []schemaProperty {
+	if depth < 1 || len(schema.Properties) == 0 {
+		return nil
+	}
+
+	names := make([]string, 0, len(schema.Properties))
+	for name := range schema.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	properties := make([]schemaProperty, 0, len(names))
+	for _, name := range names {
+		property := schema.Properties[name]
+
+		properties = append(properties, schemaProperty{
+			Name:     name,
+			Schema:   property,
+			Required: slices.Contains(schema.Required, name),
+			Children: nestedProperties(inlineObject(property), depth-1),
+		})
+	}
+
+	return properties
+}
+
+// bodyField is a field of a request body, named by its dot-notation path such as "owner.address.city".
+// Fields of array items are named after the array with "[]", as in "tags[].name".
+type bodyField struct {
+	Path     string
+	Schema   domain.Schema
+	Required bool // Required by the object holding it
+}
+
+// bodyFields flattens the properties of a request body schema depth first, down to depth levels. Unlike
+// nestedProperties, referenced objects are expanded too, since they make up the payload to send.
+// Properties that skip, when set, reports are left out with their own properties.
+func bodyFields(schema domain.Schema, components map[string]domain.Schema, depth int, skip func(domain.Schema) bool) []bodyField {
+	return appendBodyFields(nil, "", bodyObject(schema, components), components, depth, skip)
+}
+
+func appendBodyFields(fields []bodyField, prefix string, schema domain.Schema, components map[string]domain.Schema,
+	depth int, skip func(domain.Schema) bool,
+) []bodyField {
+	if depth < 1 {
+		return fields
+	}
+
+	names := make([]string, 0, len(schema.Properties))
+	for name := range schema.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		property := schema.Properties[name]
+		if skip != nil && skip(property) {
+			continue
+		}
+
+		path := prefix + name
+
+		fields = append(fields, bodyField{Path: path, Schema: property, Required: slices.Contains(schema.Required, name)})
+
+		if property.Type == "array" {
+			path += "[]"
+		}
+
+		fields = appendBodyFields(fields, path+".", bodyObject(property, components), components, depth-1, skip)
+	}
+
+	return fields
+}
+
+// bodyObject returns the object a schema holds, directly or as array items, with its allOf members merged.
+func bodyObject(schema domain.Schema, components map[string]domain.Schema) domain.Schema {
+	for schema.Type == "array" && schema.Items != nil {
+		schema = *schema.Items
+	}
+
+	return flattenAllOf(schema, components)
+}
+
+// bodyFieldsByContentType flattens the request body schema of each content type, leaving out those without fields.
+// Directional flattening leaves out the read-only properties, which clients do not send.
+func bodyFieldsByContentType(op domain.Operation, components map[string]domain.Schema, depth int,
+	directional bool,
+) map[string][]bodyField {
+	if op.RequestBody == nil {
+		return nil
+	}
+
+	var skip func(domain.Schema) bool
+	if directional {
+		skip = func(schema domain.Schema) bool { return schema.ReadOnly }
+	}
+
+	fields := make(map[string][]bodyField, len(op.RequestBody.Content))
+	for contentType, media := range op.RequestBody.Content {
+		if flat := bodyFields(media.Schema, components, depth, skip); len(flat) > 0 {
+			fields[contentType] = flat
+		}
+	}
+
+	return fields
+}
+
+// responseFields is the flattened schema of a response in one of its content types.
+type responseFields struct {
+	StatusCode  string
+	ContentType string
+	Schema      string // Name of the component schema, or "array of" it, empty for inline objects
+	Fields      []bodyField
+}
+
+// responseFieldsByStatus flattens the response schema of each status code and content type, in that order,
+// leaving out those without fields. Directional flattening leaves out the write-only properties, which servers
+// do not return.
+func responseFieldsByStatus(op domain.Operation, components map[string]domain.Schema, depth int,
+	directional bool,
+) []responseFields {
+	var tables []responseFields
+
+	var skip func(domain.Schema) bool
+	if directional {
+		skip = func(schema domain.Schema) bool { return schema.WriteOnly }
+	}
+
+	for _, resp := range sortedResponses(op.Responses) {
+		for _, contentType := range sortedContentTypes(resp.Content) {
+			schema := resp.Content[contentType].Schema
+			flat := bodyFields(schema, components, depth, skip)
+			if len(flat) == 0 {
+				continue
+			}
+
+			table := responseFields{StatusCode: resp.StatusCode, ContentType: contentType, Fields: flat}
+
+			// The fields of arrays are those of their items, which the schema name tells apart
+			if schema.Ref != "" || schema.Type == "array" {
+				table.Schema = formatSchemaType(schema)
+			}
+
+			tables = append(tables, table)
+		}
+	}
+
+	return tables
+}
+
+// fieldType returns the type of a field without its format, which response field tables show apart.
+func fieldType(schema domain.Schema) string {
+	schema.Format = ""
+
+	if schema.Ref == "" && schema.Type == "array" && schema.Items != nil {
+		return "array of " + fieldType(*schema.Items)
+	}
+
+	return formatSchemaType(schema)
+}
+
+// fieldFormat returns the format of a field, or of the items of an array field; empty for none.
+func fieldFormat(schema domain.Schema) string {
+	for schema.Ref == "" && schema.Type == "array" && schema.Items != nil {
+		schema = *schema.Items
+	}
+
+	if schema.Ref != "" {
+		return ""
+	}
+
+	return schema.Format
+}
+
+// yesNo returns "Yes" or "No", for the boolean columns of tables.
+func yesNo(value bool) string {
+	if value {
+		return "Yes"
+	}
+
+	return "No"
+}
+
+// inlineObject returns the schema defined inline by a property or its array items, empty when it is a reference.
+func inlineObject(schema domain.Schema) domain.Schema {
+	for schema.Ref == "" && schema.Type == "array" && schema.Items != nil {
+		schema = *schema.Items
+	}
+
+	if schema.Ref != "" {
+		return domain.Schema{}
+	}
+
+	return schema
+}
+
+// formatSchemaType returns a short type label for a schema, preferring the referenced component name.
+func formatSchemaType(schema domain.Schema) string {
+	if schema.Ref != "" {
+		return extractRefName(schema.Ref)
+	}
+
+	if schema.Type == "array" && schema.Items != nil {
+		return fmt.Sprintf("array of %s", formatSchemaType(*schema.Items))
+	}
+
+	// Composed schemas without an explicit type are described by their members
+	if schema.Type == "" {
+		switch {
+		case len(schema.OneOf) > 0:
+			return joinSchemaTypes(schema.OneOf, " | ")
+		case len(schema.AnyOf) > 0:
+			return joinSchemaTypes(schema.AnyOf, " | ")
+		case len(schema.AllOf) > 0:
+			return joinSchemaTypes(schema.AllOf, " & ")
+		}
+	}
+
+	if schema.Format != "" {
+		return fmt.Sprintf("%s (%s)", schema.Type, schema.Format)
+	}
+
+	return schema.Type
+}
+
+// formatSchemaDetails returns the type label of a schema followed by its constraints,
+// e.g. "string, one of: active, suspended, deleted; default: active".
+func formatSchemaDetails(schema domain.Schema) string {
+	return joinConstraints(formatSchemaType(schema), schemaConstraints(schema))
+}
+
+// formatPropertyDetails is formatSchemaDetails for a property, flagged first as required, in the language of locale,
+// when the object holding it requires it.
+func formatPropertyDetails(schema domain.Schema, required bool, locale Locale) string {
+	return joinConstraints(formatSchemaType(schema), propertyConstraints(schema, required, locale))
+}
+
+// propertyConstraints is schemaConstraints for a property, flagged first as required when the object holding
+// it requires it.
+func propertyConstraints(schema domain.Schema, required bool, locale Locale) []string {
+	if !required {
+		return schemaConstraints(schema)
+	}
+
+	return append([]string{locale.T("required")}, schemaConstraints(schema)...)
+}
+
+// joinConstraints appends constraints to a type label; constraints are separated by semicolons
+// because enumerations already use commas.
+func joinConstraints(schemaType string, constraints []string) string {
+	if len(constraints) == 0 {
+		return schemaType
+	}
+
+	if schemaType == "" {
+		return strings.Join(constraints, "; ")
+	}
+
+	return schemaType + ", " + strings.Join(constraints, "; ")
+}
+
+// schemaConstraints describes the values a schema accepts: enumerations, constant, bounds, lengths, pattern, item
+// counts and default.
+// Deprecated, nullable, read-only and write-only schemas are flagged first.
+func schemaConstraints(schema domain.Schema) []string {
+	constraints := []string{}
+
+	if schema.Deprecated {
+		constraints = append(constraints, deprecatedLabel)
+	}
+
+	if schema.Nullable {
+		constraints = append(constraints, nullableLabel)
+	}
+
+	if schema.ReadOnly {
+		constraints = append(constraints, readOnlyLabel)
+	}
+
+	if schema.WriteOnly {
+		constraints = append(constraints, writeOnlyLabel)
+	}
+
+	if len(schema.Enum) > 0 {
+		values := make([]string, 0, len(schema.Enum))
+		for _, value := range schema.Enum {
+			values = append(values, formatConstraintValue(value))
+		}
+
+		constraints = append(constraints, "one of: "+strings.Join(values, ", "))
+	}
+
+	if schema.Const != nil {
+		constraints = append(constraints, "const: "+formatConstraintValue(schema.Const))
+	}
+
+	if schema.Minimum != nil {
+		operator := ">="
+		if schema.ExclusiveMinimum {
+			operator = ">"
+		}
+
+		constraints = append(constraints, fmt.Sprintf("%s %s", operator, strconv.FormatFloat(*schema.Minimum, 'f', -1, 64)))
+	}
+
+	if schema.Maximum != nil {
+		operator := "<="
+		if schema.ExclusiveMaximum {
+			operator = "<"
+		}
+
+		constraints = append(constraints, fmt.Sprintf("%s %s", operator, strconv.FormatFloat(*schema.Maximum, 'f', -1, 64)))
+	}
+
+	switch {
+	case schema.MinLength > 0 && schema.MaxLength != nil:
+		constraints = append(constraints, fmt.Sprintf("length %d..%d", schema.MinLength, *schema.MaxLength))
+	case schema.MinLength > 0:
+		constraints = append(constraints, fmt.Sprintf("min length %d", schema.MinLength))
+	case schema.MaxLength != nil:
+		constraints = append(constraints, fmt.Sprintf("max length %d", *schema.MaxLength))
+	}
+
+	if schema.Pattern != "" {
+		constraints = append(constraints, "pattern: "+schema.Pattern)
+	}
+
+	switch {
+	case schema.MinItems > 0 && schema.MaxItems != nil:
+		constraints = append(constraints, fmt.Sprintf("%d..%d items", schema.MinItems, *schema.MaxItems))
+	case schema.MinItems > 0:
+		constraints = append(constraints, fmt.Sprintf("min %d items", schema.MinItems))
+	case schema.MaxItems != nil:
+		constraints = append(constraints, fmt.Sprintf("max %d items", *schema.MaxItems))
+	}
+
+	if schema.UniqueItems {
+		constraints = append(constraints, "unique items")
+	}
+
+	// The constraints of inline items apply to each of them, e.g. "each one of: read, write"
+	if schema.Items != nil && schema.Items.Ref == "" {
+		for _, constraint := range schemaConstraints(*schema.Items) {
+			constraints = append(constraints, "each "+constraint)
+		}
+	}
+
+	if schema.Default != nil {
+		constraints = append(constraints, "default: "+formatConstraintValue(schema.Default))
+	}
+
+	return constraints
+}
+
+// formatConstraintValue renders an enum, const or default value, using JSON for anything but plain strings.
+func formatConstraintValue(value any) string {
+	if text, ok := value.(string); ok {
+		return text
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+
+	return string(data)
+}
+
+func joinSchemaTypes(schemas []domain.Schema, sep string) string {
+	types := make([]string, 0, len(schemas))
+	for _, schema := range schemas {
+		if schemaType := formatSchemaType(schema); schemaType != "" {
+			types = append(types, schemaType)
+		}
+	}
+
+	return strings.Join(types, sep)
+}
+
+// flattenAllOf merges the properties of allOf members into the schema, resolving references against components.
+// Properties declared on the schema itself take precedence over inherited ones.
+func flattenAllOf(schema domain.Schema, components map[string]domain.Schema) domain.Schema {
+	return mergeAllOf(schema, components, make(map[string]struct{}))
+}
+
+func mergeAllOf(schema domain.Schema, components map[string]domain.Schema, visiting map[string]struct{}) domain.Schema {
+	if len(schema.AllOf) == 0 {
+		return schema
+	}
+
+	merged := schema
+	merged.AllOf = nil
+	merged.Properties = make(map[string]domain.Schema)
+	merged.Required = slices.Clone(schema.Required) // Appended to below, without touching the schema
+
+	for _, member := range schema.AllOf {
+		if member.Ref != "" {
+			name := extractRefName(member.Ref)
+			if _, cyclic := visiting[name]; cyclic {
+				continue
+			}
+
+			resolved, exists := components[name]
+			if !exists {
+				continue
+			}
+
+			visiting[name] = struct{}{}
+			member = mergeAllOf(resolved, components, visiting)
+			delete(visiting, name)
+		} else {
+			member = mergeAllOf(member, components, visiting)
+		}
+
+		for propName, prop := range member.Properties {
+			merged.Properties[propName] = prop
+		}
+
+		merged.Required = append(merged.Required, member.Required...)
+
+		if merged.Type == "" {
+			merged.Type = member.Type
+		}
+	}
+
+	for propName, prop := range schema.Properties {
+		merged.Properties[propName] = prop
+	}
+
+	return merged
+}
+
+// schemaVariants returns the alternatives of a oneOf or anyOf schema with a label introducing them.
+func schemaVariants(schema domain.Schema) (string, []domain.Schema) {
+	switch {
+	case len(schema.OneOf) > 0:
+		return "One of the following:", schema.OneOf
+	case len(schema.AnyOf) > 0:
+		return "Any of the following:", schema.AnyOf
+	default:
+		return "", nil
+	}
+}
+
+// sortedDiscriminatorValues returns the mapped discriminator values in alphabetical order.
+func sortedDiscriminatorValues(discriminator *domain.Discriminator) []string {
+	values := make([]string, 0, len(discriminator.Mapping))
+	for value := range discriminator.Mapping {
+		values = append(values, value)
+	}
+	sort.Strings(values)
+
+	return values
+}
+
+// sortedResponses returns a copy of the responses ordered by status code.
+func sortedResponses(responses []domain.Response) []domain.Response {
+	sorted := make([]domain.Response, len(responses))
+	copy(sorted, responses)
+
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].StatusCode < sorted[j].StatusCode
+	})
+
+	return sorted
+}
+
+// sortedContentTypes returns the media type keys of a content map in alphabetical order.
+func sortedContentTypes(content map[string]domain.MediaType) []string {
+	types := make([]string, 0, len(content))
+	for contentType := range content {
+		types = append(types, contentType)
+	}
+	sort.Strings(types)
+
+	return types
+}
+
+// mediaSummary pairs a media type with the schema it carries.
+type mediaSummary struct {
+	ContentType string
+	Schema      string // Schema name or type, empty for untyped content
+}
+
+// contentSummaries lists the media types of a request body or response in alphabetical order.
+func contentSummaries(content map[string]domain.MediaType) []mediaSummary {
+	summaries := make([]mediaSummary, 0, len(content))
+
+	for _, contentType := range sortedContentTypes(content) {
+		summaries = append(summaries, mediaSummary{
+			ContentType: contentType,
+			Schema:      formatSchemaType(content[contentType].Schema),
+		})
+	}
+
+	return summaries
+}
+
+// formatMediaSummary returns "application/json: Pet", or only the media type for untyped content.
+func formatMediaSummary(summary mediaSummary) string {
+	if summary.Schema == "" {
+		return summary.ContentType
+	}
+
+	return summary.ContentType + ": " + summary.Schema
+}
+
+// requiredLabel returns "Required" or "Optional".
+func requiredLabel(required bool) string {
+	if required {
+		return "Required"
+	}
+
+	return "Optional"
+}
+
+// exampleEntry is a single example payload with a display label.
+type exampleEntry struct {
+	label string
+	value any
+}
+
+// mediaExamples returns the inline and named examples of a media type in a stable order.
+func mediaExamples(media domain.MediaType) []exampleEntry {
+	entries := []exampleEntry{}
+
+	if media.Example != nil {
+		entries = append(entries, exampleEntry{value: media.Example})
+	}
+
+	names := make([]string, 0, len(media.Examples))
+	for name := range media.Examples {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		example := media.Examples[name]
+		if example.Value == nil {
+			continue
+		}
+
+		label := name
+		if example.Summary != "" {
+			label = fmt.Sprintf("%s - %s", name, example.Summary)
+		}
+
+		entries = append(entries, exampleEntry{label: label, value: example.Value})
+	}
+
+	return entries
+}
+
+// formatExampleValue renders an example payload as indented JSON, leaving plain strings untouched.
+func formatExampleValue(value any) string {
+	if text, ok := value.(string); ok {
+		return text
+	}
+
+	data, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+
+	return string(data)
+}
+
+// sortedSecuritySchemes returns the security scheme names of a document in alphabetical order.
+func sortedSecuritySchemes(doc *domain.OpenAPIDocument) []string {
+	names := make([]string, 0, len(doc.SecuritySchemes))
+	for name := range doc.SecuritySchemes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// formatSecurityScheme returns a one-line description of how a security scheme is applied.
+func formatSecurityScheme(scheme domain.SecurityScheme) string {
+	switch scheme.Type {
+	case "apiKey":
+		return fmt.Sprintf("API key in %s parameter %q", scheme.In, scheme.Name)
+	case "http":
+		label := fmt.Sprintf("HTTP %s authentication", strings.ToLower(scheme.Scheme))
+		if scheme.BearerFormat != "" {
+			label = fmt.Sprintf("%s (%s)", label, scheme.BearerFormat)
+		}
+
+		return label
+	case "oauth2":
+		return "OAuth 2.0"
+	case "openIdConnect":
+		return fmt.Sprintf("OpenID Connect (%s)", scheme.OpenIDConnectURL)
+	case "mutualTLS":
+		return "Mutual TLS"
+	default:
+		return scheme.Type
+	}
+}
+
+// formatOAuthFlow returns a readable name for an OAuth 2.0 flow type.
+func formatOAuthFlow(flowType string) string {
+	switch flowType {
+	case "implicit":
+		return "Implicit"
+	case "password":
+		return "Password"
+	case "clientCredentials":
+		return "Client credentials"
+	case "authorizationCode":
+		return "Authorization code"
+	default:
+		return flowType
+	}
+}
+
+// sortedScopes returns the scope names of an OAuth 2.0 flow in alphabetical order.
+func sortedScopes(flow domain.OAuthFlow) []string {
+	scopes := make([]string, 0, len(flow.Scopes))
+	for scope := range flow.Scopes {
+		scopes = append(scopes, scope)
+	}
+	sort.Strings(scopes)
+
+	return scopes
+}
+
+// formatSecurityRequirement renders the schemes of a requirement joined with " + ", listing scopes in parentheses.
+func formatSecurityRequirement(requirement domain.SecurityRequirement) string {
+	if len(requirement) == 0 {
+		return "anonymous"
+	}
+
+	names := make([]string, 0, len(requirement))
+	for name := range requirement {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	parts := make([]string, 0, len(names))
+	for _, name := range names {
+		if scopes := requirement[name]; len(scopes) > 0 {
+			parts = append(parts, fmt.Sprintf("%s (%s)", name, strings.Join(scopes, ", ")))
+		} else {
+			parts = append(parts, name)
+		}
+	}
+
+	return strings.Join(parts, " + ")
+}
+
+// securityLabels returns one label per alternative security requirement of an operation.
+func securityLabels(op domain.Operation) []string {
+	labels := make([]string, 0, len(op.Security))
+	for _, requirement := range op.Security {
+		labels = append(labels, formatSecurityRequirement(requirement))
+	}
+
+	return labels
+}
+
+// pascalCase joins the words of a name capitalised, e.g. "billing.invoice-line" into "BillingInvoiceLine",
+// prefixed with "T" when that would be empty or start with a digit, which type names cannot.
+func pascalCase(name string) string {
+	var typeName strings.Builder
+
+	for _, word := range wordSeparators.Split(name, -1) {
+		if word != "" {
+			typeName.WriteString(strings.ToUpper(word[:1]) + word[1:])
+		}
+	}
+
+	if typeName.Len() == 0 || isDigit(typeName.String()[0]) {
+		return "T" + typeName.String()
+	}
+
+	return typeName.String()
+}
+
+// isDigit reports whether b is an ASCII digit.
+func isDigit(b byte) bool {
+	return b >= '0' && b <= '9'
+}
+
+// writeLineComment writes a description as the line comments of the formats generating code, each line indented by indent.
+func writeLineComment(b *strings.Builder, description, indent string) {
+	description = strings.TrimSpace(description)
+	if description == "" {
+		return
+	}
+
+	for line := range strings.SplitSeq(description, "\n") {
+		b.WriteString(strings.TrimRight(indent+"// "+line, " ") + "\n")
+	}
+}
+
+// jsonString quotes a string as a JSON string, which YAML front matter and JavaScript also read,
+// whatever characters it holds.
+func jsonString(text string) string {
+	quoted, _ := json.Marshal(text) //nolint:errchkjson // strings always encode
+
+	return string(quoted)
+}
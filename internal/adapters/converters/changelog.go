@@ -0,0 +1,158 @@
+package converters
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/GabrielNunesIT/openapi-converter/internal/domain"
+)
+
+// breakingSectionTitle is the section repeating every breaking change, whose items need no extra marker.
+const breakingSectionTitle = "Breaking Changes"
+
+// changelogSection is a titled part of a rendered changelog.
+type changelogSection struct {
+	title   string
+	subject domain.ChangeSubject // Endpoint or schema, the subject of every change of the section
+	groups  []changelogGroup
+}
+
+// changelogGroup lists the changes of a single endpoint or schema; the title is empty for flat lists.
+type changelogGroup struct {
+	title string
+	items []changelogItem
+}
+
+type changelogItem struct {
+	text     string
+	breaking bool
+}
+
+// ChangelogTitle returns the heading of a changelog, e.g. "Pet Store: changes from 1.0.0 to 1.1.0".
+func ChangelogTitle(changelog *domain.Changelog) string {
+	return fmt.Sprintf("%s: changes from %s to %s", changelog.Title, changelog.FromVersion, changelog.ToVersion)
+}
+
+// ChangelogSummary returns a one-line count of the changes by kind, with the version bump they call for.
+func ChangelogSummary(changelog *domain.Changelog) string {
+	if len(changelog.Changes) == 0 {
+		return "No changes."
+	}
+
+	var added, removed, changed, breaking int
+
+	for _, change := range changelog.Changes {
+		switch change.Kind {
+		case domain.ChangeAdded:
+			added++
+		case domain.ChangeRemoved:
+			removed++
+		case domain.ChangeModified:
+			changed++
+		}
+
+		if change.Breaking {
+			breaking++
+		}
+	}
+
+	summary := fmt.Sprintf("%d added, %d removed, %d changed, %d breaking.", added, removed, changed, breaking)
+
+	// Changelogs built by other programs may leave the bump out
+	if changelog.Bump != "" {
+		summary += fmt.Sprintf(" Recommended version bump: %s", changelog.Bump)
+		if changelog.RecommendedVersion != "" {
+			summary += fmt.Sprintf(" (%s)", changelog.RecommendedVersion)
+		}
+
+		summary += "."
+	}
+
+	return summary
+}
+
+// changelogSections arranges the changes of a changelog into the sections shared by every output format.
+func changelogSections(changelog *domain.Changelog) []changelogSection {
+	var (
+		breaking        []changelogItem
+		addedEndpoints  []changelogItem
+		removedEndpoint []changelogItem
+		addedSchemas    []changelogItem
+		removedSchemas  []changelogItem
+		changedTargets  = map[domain.ChangeSubject][]changelogGroup{}
+	)
+
+	for _, change := range changelog.Changes {
+		if change.Breaking {
+			breaking = append(breaking, changelogItem{text: fmt.Sprintf("%s: %s", change.Target, changeText(change)), breaking: true})
+		}
+
+		item := changelogItem{text: change.Target, breaking: change.Breaking}
+		if change.Detail != "" && change.Subject == domain.SubjectEndpoint {
+			item.text = fmt.Sprintf("%s - %s", change.Target, change.Detail)
+		}
+
+		switch {
+		case change.Subject == domain.SubjectEndpoint && change.Kind == domain.ChangeAdded:
+			addedEndpoints = append(addedEndpoints, item)
+		case change.Subject == domain.SubjectEndpoint:
+			removedEndpoint = append(removedEndpoint, item)
+		case change.Subject == domain.SubjectSchema && change.Kind == domain.ChangeAdded:
+			addedSchemas = append(addedSchemas, item)
+		case change.Subject == domain.SubjectSchema && change.Kind == domain.ChangeRemoved:
+			removedSchemas = append(removedSchemas, item)
+		default:
+			kind := domain.SubjectEndpoint
+			if change.Subject == domain.SubjectSchema || change.Subject == domain.SubjectProperty {
+				kind = domain.SubjectSchema
+			}
+
+			changedTargets[kind] = appendToGroup(changedTargets[kind], change.Target,
+				changelogItem{text: changeText(change), breaking: change.Breaking})
+		}
+	}
+
+	sections := []changelogSection{}
+
+	add := func(title string, subject domain.ChangeSubject, groups ...changelogGroup) {
+		for _, group := range groups {
+			if len(group.items) > 0 {
+				sections = append(sections, changelogSection{title: title, subject: subject, groups: groups})
+
+				return
+			}
+		}
+	}
+
+	add(breakingSectionTitle, "", changelogGroup{items: breaking})
+	add("New Endpoints", domain.SubjectEndpoint, changelogGroup{items: addedEndpoints})
+	add("Removed Endpoints", domain.SubjectEndpoint, changelogGroup{items: removedEndpoint})
+	add("Changed Endpoints", domain.SubjectEndpoint, changedTargets[domain.SubjectEndpoint]...)
+	add("New Schemas", domain.SubjectSchema, changelogGroup{items: addedSchemas})
+	add("Removed Schemas", domain.SubjectSchema, changelogGroup{items: removedSchemas})
+	add("Changed Schemas", domain.SubjectSchema, changedTargets[domain.SubjectSchema]...)
+
+	return sections
+}
+
+// appendToGroup adds an item to the group with the given title, starting a new group when the title changes.
+// Changes are sorted by target, so items of the same target are always adjacent.
+func appendToGroup(groups []changelogGroup, title string, item changelogItem) []changelogGroup {
+	if len(groups) == 0 || groups[len(groups)-1].title != title {
+		groups = append(groups, changelogGroup{title: title})
+	}
+
+	groups[len(groups)-1].items = append(groups[len(groups)-1].items, item)
+
+	return groups
+}
+
+// changeText capitalises the detail of a change, falling back to its kind.
+func changeText(change domain.Change) string {
+	text := change.Detail
+	if text == "" || change.Subject == domain.SubjectEndpoint {
+		text = fmt.Sprintf("%s %s", change.Subject, change.Kind)
+	}
+
+	return strings.ToUpper(text[:1]) + text[1:]
+}
@@ -0,0 +1,702 @@
+package converters
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// The CommonMark of descriptions is parsed into blocks of inlines that the rich text formats render natively.
+// The parser covers what descriptions use in practice: paragraphs, ATX and setext headings, bullet and ordered
+// lists, fenced and indented code, block quotes and thematic breaks; emphasis, code spans, links, autolinks,
+// bare URLs and hard line breaks. HTML and link reference definitions are kept as text.
+
+type mdBlockKind int
+
+const (
+	mdParagraph mdBlockKind = iota
+	mdHeading
+	mdBulletList
+	mdOrderedList
+	mdCodeBlock
+	mdQuote
+	mdRule
+)
+
+// mdBlock is a block of a Markdown description.
+type mdBlock struct {
+	Kind     mdBlockKind
+	Level    int         // Heading level
+	Start    int         // First number of an ordered list
+	Tight    bool        // Whether the items of a list are not separated by blank lines
+	Inlines  []mdInline  // Content of a paragraph or heading
+	Items    [][]mdBlock // Items of a list
+	Children []mdBlock   // Content of a block quote
+	Code     string      // Content of a code block
+	Language string      // Info string of a fenced code block
+}
+
+type mdInlineKind int
+
+const (
+	mdText mdInlineKind = iota
+	mdCode
+	mdStrong
+	mdEmphasis
+	mdLink
+	mdLineBreak
+)
+
+// mdInline is a run of inline content of a Markdown description.
+type mdInline struct {
+	Kind     mdInlineKind
+	Text     string     // Text of a text run or code span
+	Href     string     // Destination of a link
+	Children []mdInline // Content of emphasis and links
+}
+
+var (
+	mdHeadingPattern = regexp.MustCompile(`^ {0,3}(#{1,6})(?:[ \t]+(.*?))?(?:[ \t]+#+)?[ \t]*$`)
+	mdRulePattern    = regexp.MustCompile(`^ {0,3}(?:(?:\*[ \t]*){3,}|(?:-[ \t]*){3,}|(?:_[ \t]*){3,})$`)
+	mdSetextPattern  = regexp.MustCompile(`^ {0,3}(=+|-+)[ \t]*$`)
+	mdFencePattern   = regexp.MustCompile("^( {0,3})(`{3,}|~{3,})[ \t]*([^`]*?)[ \t]*$")
+	mdBulletPattern  = regexp.MustCompile(`^( {0,3})([-+*])([ \t]+|$)`)
+	mdOrderedPattern = regexp.MustCompile(`^( {0,3})(\d{1,9})([.)])([ \t]+|$)`)
+	mdURLPattern     = regexp.MustCompile(`^(?:https?://|mailto:)[^\s<]*[^\s<?!.,:*_~)'"]`)
+)
+
+// parseMarkdown parses a description into blocks.
+func parseMarkdown(text string) []mdBlock {
+	text = strings.ReplaceAll(strings.ReplaceAll(text, "\r\n", "\n"), "\t", "    ")
+
+	return parseMarkdownLines(strings.Split(strings.TrimRight(text, "\n "), "\n"))
+}
+
+//nolint:gocognit,gocyclo,cyclop,funlen // one branch per kind of block, in order of precedence
+func parseMarkdownLines(lines []string) []mdBlock {
+	blocks := []mdBlock{}
+	paragraph := []string{}
+
+	flush := func() {
+		if len(paragraph) > 0 {
+			blocks = append(blocks, mdBlock{Kind: mdParagraph, Inlines: parseInlines(strings.Join(paragraph, "\n"))})
+			paragraph = paragraph[:0]
+		}
+	}
+
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+
+		switch {
+		case strings.TrimSpace(line) == "":
+			flush()
+		case len(paragraph) > 0 && mdSetextPattern.MatchString(line):
+			level := 2
+			if strings.Contains(line, "=") {
+				level = 1
+			}
+
+			heading := strings.TrimSpace(strings.Join(paragraph, "\n"))
+			paragraph = paragraph[:0]
+			blocks = append(blocks, mdBlock{Kind: mdHeading, Level: level, Inlines: parseInlines(heading)})
+		case mdRulePattern.MatchString(line):
+			flush()
+			blocks = append(blocks, mdBlock{Kind: mdRule})
+		case mdHeadingPattern.MatchString(line):
+			flush()
+
+			match := mdHeadingPattern.FindStringSubmatch(line)
+			blocks = append(blocks, mdBlock{Kind: mdHeading, Level: len(match[1]), Inlines: parseInlines(match[2])})
+		case mdFencePattern.MatchString(line):
+			flush()
+
+			match := mdFencePattern.FindStringSubmatch(line)
+			indent, fence := len(match[1]), match[2]
+			code := []string{}
+
+			for i++; i < len(lines); i++ {
+				if closing := strings.TrimSpace(lines[i]); strings.HasPrefix(closing, fence) &&
+					strings.Trim(closing, fence[:1]) == "" {
+					break
+				}
+
+				code = append(code, trimIndent(lines[i], indent))
+			}
+
+			language, _, _ := strings.Cut(match[3], " ")
+			blocks = append(blocks, mdBlock{Kind: mdCodeBlock, Code: strings.Join(code, "\n"), Language: language})
+		case len(paragraph) == 0 && strings.HasPrefix(line, "    "):
+			code := []string{}
+
+			for ; i < len(lines) && (strings.HasPrefix(lines[i], "    ") || strings.TrimSpace(lines[i]) == ""); i++ {
+				code = append(code, trimIndent(lines[i], 4))
+			}
+
+			i--
+
+			blocks = append(blocks, mdBlock{Kind: mdCodeBlock, Code: strings.TrimRight(strings.Join(code, "\n"), "\n")})
+		case strings.HasPrefix(strings.TrimLeft(line, " "), ">") && len(line)-len(strings.TrimLeft(line, " ")) < 4:
+			flush()
+
+			quoted := []string{}
+
+			for ; i < len(lines) && strings.TrimSpace(lines[i]) != ""; i++ {
+				content := strings.TrimLeft(lines[i], " ")
+				if rest, ok := strings.CutPrefix(content, ">"); ok {
+					content = strings.TrimPrefix(rest, " ")
+				}
+
+				quoted = append(quoted, content)
+			}
+
+			i--
+
+			blocks = append(blocks, mdBlock{Kind: mdQuote, Children: parseMarkdownLines(quoted)})
+		case mdBulletPattern.MatchString(line) || mdOrderedPattern.MatchString(line):
+			flush()
+
+			var list mdBlock
+
+			list, i = parseMarkdownList(lines, i)
+			i--
+
+			blocks = append(blocks, list)
+		default:
+			paragraph = append(paragraph, strings.TrimLeft(line, " "))
+		}
+	}
+
+	flush()
+
+	return blocks
+}
+
+// parseMarkdownList parses the list starting at lines[start] and returns it with the index of the line following it.
+func parseMarkdownList(lines []string, start int) (mdBlock, int) {
+	list := mdBlock{Kind: mdBulletList, Tight: true}
+
+	_, marker, ordered := listMarker(lines[start])
+	if ordered {
+		list.Kind = mdOrderedList
+		list.Start, _ = strconv.Atoi(strings.TrimLeft(mdOrderedPattern.FindStringSubmatch(lines[start])[2], " "))
+	}
+
+	i := start
+	for i < len(lines) {
+		width, itemMarker, _ := listMarker(lines[i])
+		if width == 0 || itemMarker != marker {
+			break
+		}
+
+		item := []string{strings.TrimLeft(lines[i][min(width, len(lines[i])):], " ")}
+		blank := false
+
+		for i++; i < len(lines); i++ {
+			line := lines[i]
+
+			switch {
+			case strings.TrimSpace(line) == "":
+				blank = true
+
+				item = append(item, "")
+
+				continue
+			case indentOf(line) >= width:
+				if blank {
+					list.Tight = false
+				}
+
+				item = append(item, trimIndent(line, width))
+				blank = false
+
+				continue
+			case !blank && !startsBlock(line):
+				// Lazy continuation of the paragraph of the item
+				item = append(item, strings.TrimLeft(line, " "))
+
+				continue
+			}
+
+			break
+		}
+
+		list.Items = append(list.Items, parseMarkdownLines(item))
+
+		if blank && i < len(lines) {
+			if width, itemMarker, _ := listMarker(lines[i]); width > 0 && itemMarker == marker {
+				list.Tight = false
+			}
+		}
+	}
+
+	return list, i
+}
+
+// listMarker returns the width of the list marker starting a line, including its indentation and the spaces
+// after it, and the marker character, such as "-" or ".". The width is 0 for lines not starting a list item.
+func listMarker(line string) (int, string, bool) {
+	if match := mdBulletPattern.FindStringSubmatch(line); match != nil && !mdRulePattern.MatchString(line) {
+		return markerWidth(match[0], len(match[1])+1), match[2], false
+	}
+
+	if match := mdOrderedPattern.FindStringSubmatch(line); match != nil {
+		return markerWidth(match[0], len(match[1])+len(match[2])+1), match[3], true
+	}
+
+	return 0, "", false
+}
+
+// markerWidth is the content offset of a list item: past the marker and one to four spaces after it.
+func markerWidth(matched string, marker int) int {
+	spaces := len(matched) - marker
+	if spaces == 0 || spaces > 4 {
+		return marker + 1
+	}
+
+	return len(matched)
+}
+
+// startsBlock reports whether a line starts a block that ends a lazy paragraph continuation.
+func startsBlock(line string) bool {
+	trimmed := strings.TrimLeft(line, " ")
+
+	return mdHeadingPattern.MatchString(line) || mdRulePattern.MatchString(line) || mdFencePattern.MatchString(line) ||
+		strings.HasPrefix(trimmed, ">") || mdBulletPattern.MatchString(line) || mdOrderedPattern.MatchString(line)
+}
+
+func indentOf(line string) int {
+	return len(line) - len(strings.TrimLeft(line, " "))
+}
+
+// trimIndent removes up to n leading spaces.
+func trimIndent(line string, n int) string {
+	return line[min(n, indentOf(line)):]
+}
+
+// parseInlines parses the inline content of a paragraph or heading.
+//
+//nolint:gocognit,gocyclo,cyclop,funlen // one branch per kind of inline, in order of precedence
+func parseInlines(text string) []mdInline {
+	inlines := []mdInline{}
+
+	var plain strings.Builder
+
+	flush := func() {
+		if plain.Len() > 0 {
+			inlines = append(inlines, mdInline{Kind: mdText, Text: plain.String()})
+			plain.Reset()
+		}
+	}
+
+	for i := 0; i < len(text); {
+		ch := text[i]
+
+		switch {
+		case ch == '\\' && i+1 < len(text) && text[i+1] == '\n':
+			flush()
+
+			inlines = append(inlines, mdInline{Kind: mdLineBreak})
+			i += 2
+		case ch == '\\' && i+1 < len(text) && strings.IndexByte("!\"#$%&'()*+,-./:;<=>?@[\\]^_`{|}~", text[i+1]) >= 0:
+			plain.WriteByte(text[i+1])
+
+			i += 2
+		case ch == '\n':
+			trailing := len(plain.String()) - len(strings.TrimRight(plain.String(), " "))
+			content := strings.TrimRight(plain.String(), " ")
+			plain.Reset()
+			plain.WriteString(content)
+
+			if trailing >= 2 {
+				flush()
+
+				inlines = append(inlines, mdInline{Kind: mdLineBreak})
+			} else {
+				plain.WriteByte(' ')
+			}
+
+			i++
+			for i < len(text) && text[i] == ' ' {
+				i++
+			}
+		case ch == '`':
+			run := runLength(text, i, '`')
+			if end := findBacktickRun(text, i+run, run); end >= 0 {
+				flush()
+
+				code := strings.ReplaceAll(text[i+run:end], "\n", " ")
+				if len(code) > 2 && code[0] == ' ' && code[len(code)-1] == ' ' && strings.TrimSpace(code) != "" {
+					code = code[1 : len(code)-1]
+				}
+
+				inlines = append(inlines, mdInline{Kind: mdCode, Text: code})
+				i = end + run
+
+				continue
+			}
+
+			plain.WriteString(text[i : i+run])
+			i += run
+		case ch == '<':
+			if end := strings.IndexByte(text[i:], '>'); end > 0 && mdURLPattern.MatchString(text[i+1:i+end]) &&
+				!strings.ContainsAny(text[i+1:i+end], " <") {
+				flush()
+
+				url := text[i+1 : i+end]
+				inlines = append(inlines, mdInline{Kind: mdLink, Href: url, Children: []mdInline{{Kind: mdText, Text: url}}})
+				i += end + 1
+
+				continue
+			}
+
+			plain.WriteByte(ch)
+			i++
+		case ch == '[' || (ch == '!' && i+1 < len(text) && text[i+1] == '['):
+			open := i
+			if ch == '!' {
+				open++
+			}
+
+			if label, href, end, ok := parseLink(text, open); ok {
+				flush()
+
+				inlines = append(inlines, mdInline{Kind: mdLink, Href: href, Children: unlinked(parseInlines(label))})
+				i = end
+
+				continue
+			}
+
+			plain.WriteByte(ch)
+			i++
+		case ch == '*' || ch == '_':
+			run := runLength(text, i, ch)
+			size := min(run, 2)
+
+			if end := findCloser(text, i, size, ch); end >= 0 {
+				flush()
+
+				kind := mdEmphasis
+				if size == 2 {
+					kind = mdStrong
+				}
+
+				inlines = append(inlines, mdInline{Kind: kind, Children: parseInlines(text[i+size : end])})
+				i = end + size
+
+				continue
+			}
+
+			plain.WriteString(text[i : i+run])
+			i += run
+		case (ch == 'h' || ch == 'm') && (i == 0 || !isWordByte(text[i-1])) && mdURLPattern.MatchString(text[i:]):
+			flush()
+
+			url := mdURLPattern.FindString(text[i:])
+			inlines = append(inlines, mdInline{Kind: mdLink, Href: url, Children: []mdInline{{Kind: mdText, Text: url}}})
+			i += len(url)
+		default:
+			plain.WriteByte(ch)
+			i++
+		}
+	}
+
+	flush()
+
+	return inlines
+}
+
+// unlinked replaces the links among inlines by their content, as links cannot nest, such as a URL
+// autolinked in the label of a link to itself.
+func unlinked(inlines []mdInline) []mdInline {
+	result := make([]mdInline, 0, len(inlines))
+
+	for _, inline := range inlines {
+		if inline.Kind == mdLink {
+			result = append(result, unlinked(inline.Children)...)
+
+			continue
+		}
+
+		inline.Children = unlinked(inline.Children)
+		result = append(result, inline)
+	}
+
+	return result
+}
+
+// parseLink parses "[label](destination "title")" at text[open], returning the label, the destination
+// and the index following the link.
+func parseLink(text string, open int) (string, string, int, bool) {
+	depth := 0
+
+	for i := open; i < len(text); i++ {
+		switch text[i] {
+		case '\\':
+			i++
+		case '[':
+			depth++
+		case ']':
+			depth--
+			if depth > 0 {
+				continue
+			}
+
+			if i+1 >= len(text) || text[i+1] != '(' {
+				return "", "", 0, false
+			}
+
+			closing := closingParen(text[i+2:])
+			if closing < 0 {
+				return "", "", 0, false
+			}
+
+			destination := strings.TrimSpace(text[i+2 : i+2+closing])
+			if href, _, ok := strings.Cut(destination, " "); ok {
+				destination = href
+			}
+
+			return text[open+1 : i], strings.Trim(destination, "<>"), i + 3 + closing, true
+		}
+	}
+
+	return "", "", 0, false
+}
+
+// closingParen returns the index of the parenthesis closing a link destination, which may hold balanced ones.
+func closingParen(text string) int {
+	depth := 0
+
+	for i := 0; i < len(text); i++ {
+		switch text[i] {
+		case '\\':
+			i++
+		case '(':
+			depth++
+		case ')':
+			if depth == 0 {
+				return i
+			}
+
+			depth--
+		}
+	}
+
+	return -1
+}
+
+// findCloser finds the delimiter run of size delimiters closing the emphasis opened at text[open].
+// Openers must be followed, and closers preceded, by other than whitespace; "_" does not emphasize within words.
+func findCloser(text string, open, size int, delimiter byte) int {
+	after := open + runLength(text, open, delimiter)
+	if after >= len(text) || isSpaceByte(text[after]) {
+		return -1
+	}
+
+	if delimiter == '_' && open > 0 && isWordByte(text[open-1]) {
+		return -1
+	}
+
+	for i := open + size; i < len(text); i++ {
+		switch {
+		case text[i] == '\\':
+			i++
+		case text[i] == '`':
+			run := runLength(text, i, '`')
+			if end := findBacktickRun(text, i+run, run); end >= 0 {
+				i = end + run - 1
+			}
+		case text[i] == delimiter:
+			run := runLength(text, i, delimiter)
+			closes := run >= size && !isSpaceByte(text[i-1]) && i > open+size &&
+				(delimiter != '_' || i+run >= len(text) || !isWordByte(text[i+run]))
+
+			if closes && (size == 2 || run != 2) {
+				return i + run - size
+			}
+
+			i += run - 1
+		}
+	}
+
+	return -1
+}
+
+// findBacktickRun finds the run of exactly n backticks closing a code span, from text[from].
+func findBacktickRun(text string, from, n int) int {
+	for i := from; i < len(text); {
+		if text[i] != '`' {
+			i++
+
+			continue
+		}
+
+		run := runLength(text, i, '`')
+		if run == n {
+			return i
+		}
+
+		i += run
+	}
+
+	return -1
+}
+
+func runLength(text string, from int, ch byte) int {
+	n := 0
+	for from+n < len(text) && text[from+n] == ch {
+		n++
+	}
+
+	return n
+}
+
+func isSpaceByte(ch byte) bool {
+	return unicode.IsSpace(rune(ch))
+}
+
+func isWordByte(ch byte) bool {
+	return ch >= 0x80 || unicode.IsLetter(rune(ch)) || unicode.IsDigit(rune(ch))
+}
+
+// safeHref returns a link destination, or an empty one for schemes that would run code, such as "javascript:".
+func safeHref(href string) string {
+	scheme, _, ok := strings.Cut(href, ":")
+	if !ok || strings.ContainsAny(scheme, "/?#") {
+		return href
+	}
+
+	switch strings.ToLower(scheme) {
+	case "http", "https", "mailto":
+		return href
+	default:
+		return ""
+	}
+}
+
+// inlineText returns the text of inlines without their formatting.
+func inlineText(inlines []mdInline) string {
+	var b strings.Builder
+
+	for _, inline := range inlines {
+		switch inline.Kind {
+		case mdText, mdCode:
+			b.WriteString(inline.Text)
+		case mdLineBreak:
+			b.WriteString(" ")
+		case mdStrong, mdEmphasis, mdLink:
+			b.WriteString(inlineText(inline.Children))
+		}
+	}
+
+	return b.String()
+}
+
+// markdownHTML renders a description as (X)HTML blocks, one per line.
+func markdownHTML(text string) string {
+	var b strings.Builder
+
+	writeMarkdownHTML(&b, parseMarkdown(text), false)
+
+	return b.String()
+}
+
+// inlineMarkdownHTML renders a description as (X)HTML inline content, for table cells and list items.
+func inlineMarkdownHTML(text string) string {
+	var b strings.Builder
+
+	writeInlineHTML(&b, parseInlines(strings.TrimSpace(text)))
+
+	return b.String()
+}
+
+// writeMarkdownHTML writes blocks as HTML. The paragraphs of tight list items are written without <p>.
+func writeMarkdownHTML(b *strings.Builder, blocks []mdBlock, tight bool) {
+	for _, block := range blocks {
+		switch block.Kind {
+		case mdParagraph:
+			if tight {
+				writeInlineHTML(b, block.Inlines)
+
+				continue
+			}
+
+			b.WriteString("<p>")
+			writeInlineHTML(b, block.Inlines)
+			b.WriteString("</p>\n")
+		case mdHeading:
+			fmt.Fprintf(b, "<h%d>", block.Level)
+			writeInlineHTML(b, block.Inlines)
+			fmt.Fprintf(b, "</h%d>\n", block.Level)
+		case mdBulletList, mdOrderedList:
+			tag := "ul"
+			if block.Kind == mdOrderedList {
+				tag = "ol"
+			}
+
+			b.WriteString("<" + tag)
+
+			if block.Kind == mdOrderedList && block.Start != 1 {
+				fmt.Fprintf(b, ` start="%d"`, block.Start)
+			}
+
+			b.WriteString(">\n")
+
+			for _, item := range block.Items {
+				b.WriteString("<li>")
+				writeMarkdownHTML(b, item, block.Tight)
+				b.WriteString("</li>\n")
+			}
+
+			b.WriteString("</" + tag + ">\n")
+		case mdCodeBlock:
+			b.WriteString("<pre><code")
+
+			if block.Language != "" {
+				fmt.Fprintf(b, ` class="language-%s"`, html.EscapeString(block.Language))
+			}
+
+			b.WriteString(">" + html.EscapeString(block.Code) + "</code></pre>\n")
+		case mdQuote:
+			b.WriteString("<blockquote>\n")
+			writeMarkdownHTML(b, block.Children, false)
+			b.WriteString("</blockquote>\n")
+		case mdRule:
+			b.WriteString("<hr />\n")
+		}
+	}
+}
+
+func writeInlineHTML(b *strings.Builder, inlines []mdInline) {
+	for _, inline := range inlines {
+		switch inline.Kind {
+		case mdText:
+			b.WriteString(html.EscapeString(inline.Text))
+		case mdCode:
+			b.WriteString("<code>" + html.EscapeString(inline.Text) + "</code>")
+		case mdStrong:
+			b.WriteString("<strong>")
+			writeInlineHTML(b, inline.Children)
+			b.WriteString("</strong>")
+		case mdEmphasis:
+			b.WriteString("<em>")
+			writeInlineHTML(b, inline.Children)
+			b.WriteString("</em>")
+		case mdLink:
+			href := safeHref(inline.Href)
+			if href == "" {
+				writeInlineHTML(b, inline.Children)
+
+				continue
+			}
+
+			fmt.Fprintf(b, `<a href="%s">`, html.EscapeString(href))
+			writeInlineHTML(b, inline.Children)
+			b.WriteString("</a>")
+		case mdLineBreak:
+			b.WriteString("<br />")
+		}
+	}
+}
@@ -0,0 +1,206 @@
+package converters
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/GabrielNunesIT/openapi-converter/internal/domain"
+)
+
+const (
+	docusaurusFormat   = "docusaurus"
+	docusaurusSidebars = "sidebars.js"
+)
+
+// mdxTag matches the HTML of the Markdown output that MDX reads as JSX, anchors and line breaks,
+// and autolinks, which MDX does not support.
+var mdxTag = regexp.MustCompile(`^(?:<a [^>]*>|</a>|<br>|<(https?://[^>\s]+)>)`)
+
+// DocusaurusConverter converts OpenAPI documents to MDX pages for Docusaurus: the Markdown output, escaped for MDX,
+// under front matter giving the id, title and sidebar position of the page. Split by tag, it writes an index page,
+// a page per tag and a sidebars.js fragment declaring a category of the pages.
+type DocusaurusConverter struct {
+	markdown *MarkdownConverter
+}
+
+// NewDocusaurusConverter creates a new Docusaurus MDX converter, rendering the pages as the Markdown converter
+// configured by opts.
+func NewDocusaurusConverter(opts MarkdownOptions) *DocusaurusConverter {
+	return &DocusaurusConverter{markdown: NewMarkdownConverter(opts)}
+}
+
+func init() { //nolint:gochecknoinits // converters self-register with the default registry
+	RegisterFormat(docusaurusFormat, markdownOptions,
+		func(opts MarkdownOptions) domain.Converter { return NewDocusaurusConverter(opts) }, "mdx")
+}
+
+// Format returns the output format name.
+func (c *DocusaurusConverter) Format() string {
+	return docusaurusFormat
+}
+
+// Convert transforms an OpenAPI document to a single MDX page.
+func (c *DocusaurusConverter) Convert(doc *domain.OpenAPIDocument, output io.Writer) error {
+	return c.writePage(doc, uniqueSlug(doc.Title, map[string]struct{}{}), doc.Title, 1, output)
+}
+
+// MultiConvert writes an MDX page for the index and each tag into outputDir, creating it if needed,
+// and a sidebars.js fragment listing them.
+func (c *DocusaurusConverter) MultiConvert(doc *domain.OpenAPIDocument, outputDir string) ([]string, error) {
+	if err := os.MkdirAll(outputDir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	parts := SplitByTag(doc)
+	files := make([]string, 0, len(parts)+1)
+
+	for i, part := range parts {
+		title := part.Name
+		if title == "" {
+			title = doc.Title
+		}
+
+		path := filepath.Join(outputDir, part.Slug+".mdx")
+
+		if err := c.writePageFile(part.Document, part.Slug, title, i+1, path); err != nil {
+			return files, err
+		}
+
+		files = append(files, path)
+	}
+
+	path := filepath.Join(outputDir, docusaurusSidebars)
+
+	//nolint:gosec // the sidebar fragment is meant to be read by the docs site
+	if err := os.WriteFile(path, []byte(docusaurusSidebar(doc.Title, filepath.Base(outputDir), parts)), 0o644); err != nil {
+		return files, fmt.Errorf("failed to write sidebar: %w", err)
+	}
+
+	return append(files, path), nil
+}
+
+func (c *DocusaurusConverter) writePageFile(doc *domain.OpenAPIDocument, id, title string, position int, path string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create output file: %w", err)
+	}
+	defer file.Close()
+
+	if err := c.writePage(doc, id, title, position, file); err != nil {
+		return fmt.Errorf("failed to convert %s: %w", id, err)
+	}
+
+	return nil
+}
+
+// writePage writes the front matter of a page followed by the Markdown of doc escaped for MDX.
+func (c *DocusaurusConverter) writePage(doc *domain.OpenAPIDocument, id, title string, position int, output io.Writer) error {
+	var md strings.Builder
+	if err := c.markdown.Convert(doc, &md); err != nil {
+		return err
+	}
+
+	page := fmt.Sprintf("---\nid: %s\ntitle: %s\nsidebar_position: %d\n---\n\n", id, jsonString(title), position) +
+		escapeMDX(md.String())
+
+	if _, err := io.WriteString(output, page); err != nil {
+		return fmt.Errorf("failed to write MDX: %w", err)
+	}
+
+	return nil
+}
+
+// docusaurusSidebar returns a sidebars.js fragment exporting a category of the pages, linked to the index page.
+// Docusaurus identifies pages by their path under the docs directory, taken to be the directory of the pages.
+func docusaurusSidebar(title, dir string, parts []DocumentPart) string {
+	var js strings.Builder
+
+	js.WriteString("// Sidebar category of the " + title + " API documentation, generated by openapi-converter.\n")
+	js.WriteString("// Add it to a sidebar of sidebars.js, e.g. api: [require('./docs/" + dir + "/sidebars.js')].\n")
+	js.WriteString("module.exports = {\n")
+	js.WriteString("  type: \"category\",\n")
+	js.WriteString("  label: " + jsonString(title) + ",\n")
+	js.WriteString("  link: {type: \"doc\", id: " + jsonString(dir+"/"+parts[0].Slug) + "},\n")
+	js.WriteString("  items: [\n")
+
+	for _, part := range parts[1:] {
+		js.WriteString("    " + jsonString(dir+"/"+part.Slug) + ",\n")
+	}
+
+	js.WriteString("  ],\n};\n")
+
+	return js.String()
+}
+
+// escapeMDX escapes Markdown for MDX, which reads braces as JavaScript expressions and angle brackets as JSX,
+// outside of code blocks and spans. Anchors are kept, line breaks closed and autolinks made Markdown links.
+func escapeMDX(markdown string) string {
+	lines := strings.Split(markdown, "\n")
+	fence := "" // Marker of the fenced code block the line is in, if any
+
+	for i, line := range lines {
+		trimmed := strings.TrimLeft(line, " ")
+
+		switch {
+		case fence != "":
+			if strings.HasPrefix(trimmed, fence) {
+				fence = ""
+			}
+		case strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~"):
+			fence = trimmed[:3]
+		default:
+			lines[i] = escapeMDXLine(line)
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// escapeMDXLine escapes a line of Markdown text for MDX, leaving its code spans as they are.
+func escapeMDXLine(line string) string {
+	var out strings.Builder
+
+	for i := 0; i < len(line); i++ {
+		switch line[i] {
+		case '`':
+			ticks := len(line[i:]) - len(strings.TrimLeft(line[i:], "`"))
+			marker := line[i : i+ticks]
+
+			// A code span runs to the next run of as many backticks
+			if end := strings.Index(line[i+ticks:], marker); end >= 0 {
+				out.WriteString(line[i : i+ticks+end+ticks])
+				i += ticks + end + ticks - 1
+			} else {
+				out.WriteString(marker)
+				i += ticks - 1
+			}
+		case '{', '}':
+			out.WriteString(`\` + line[i:i+1])
+		case '<':
+			match := mdxTag.FindStringSubmatch(line[i:])
+
+			switch {
+			case match == nil:
+				out.WriteString("&lt;")
+			case match[0] == "<br>":
+				out.WriteString("<br />")
+			case match[1] != "":
+				out.WriteString("[" + match[1] + "](" + match[1] + ")")
+			default:
+				out.WriteString(match[0])
+			}
+
+			if match != nil {
+				i += len(match[0]) - 1
+			}
+		default:
+			out.WriteByte(line[i])
+		}
+	}
+
+	return out.String()
+}
@@ -3,21 +3,50 @@ package converters
 import (
 	"fmt"
 	"io"
+	"slices"
 	"sort"
+	"strings"
 
 	"github.com/GabrielNunesIT/openapi-converter/internal/domain"
 	"github.com/gomutex/godocx"
 	"github.com/gomutex/godocx/docx"
 )
 
-const docxFormat = "docx"
+const (
+	docxFormat = "docx"
+
+	// Styles of the default godocx template
+	docxTableStyle    = "TableGrid"
+	docxCodeStyle     = "MacroText"
+	docxCodeCharStyle = "MacroTextChar"
+)
 
 // DocxConverter converts OpenAPI documents to Word (DOCX) format.
-type DocxConverter struct{}
+type DocxConverter struct {
+	appendix bool   // Render component schemas once in an appendix rather than under every tag
+	locale   Locale // Translations of the fixed strings, nil for English
+}
+
+// DocxOptions configures a DocxConverter. The fields tagged option can also be set by name, e.g. with
+// --option docx.schema-appendix=true, see RegisterFormat.
+type DocxOptions struct {
+	// SchemaAppendix renders every component schema once in a "Schemas" appendix, the "Schemas Used" section of
+	// each tag only naming them.
+	SchemaAppendix bool `option:"schema-appendix"`
+
+	// Locale translates the section headings and other fixed strings of the document, nil for English.
+	Locale Locale
+}
 
 // NewDocxConverter creates a new DOCX converter.
-func NewDocxConverter() *DocxConverter {
-	return &DocxConverter{}
+func NewDocxConverter(opts DocxOptions) *DocxConverter {
+	return &DocxConverter{appendix: opts.SchemaAppendix, locale: opts.Locale}
+}
+
+func init() { //nolint:gochecknoinits // converters self-register with the default registry
+	RegisterFormat(docxFormat, func(opts Options) DocxOptions {
+		return DocxOptions{SchemaAppendix: opts.SchemaAppendix, Locale: opts.Locale}
+	}, func(opts DocxOptions) domain.Converter { return NewDocxConverter(opts) }, "word")
 }
 
 // Format returns the output format name.
@@ -33,9 +62,15 @@ func (c *DocxConverter) Convert(doc *domain.OpenAPIDocument, output io.Writer) e
 	}
 
 	c.addTitle(document, doc)
+	c.addFreeText(document, doc.Preamble)
+	c.addAbout(document, doc)
 	c.addDescription(document, doc)
 	c.addServers(document, doc)
+	c.addSecuritySchemes(document, doc)
 	c.addPaths(document, doc)
+	c.addWebhooks(document, doc)
+	c.addSchemaAppendix(document, doc)
+	c.addFreeText(document, doc.Epilogue)
 
 	if err := document.Write(output); err != nil {
 		return fmt.Errorf("failed to write document: %w", err)
@@ -50,13 +85,39 @@ func (c *DocxConverter) addTitle(document *docx.RootDoc, doc *domain.OpenAPIDocu
 	document.AddEmptyParagraph()
 }
 
+// addFreeText adds the preamble or epilogue of the document as a paragraph.
+func (c *DocxConverter) addFreeText(document *docx.RootDoc, text string) {
+	if text = strings.TrimSpace(text); text == "" {
+		return
+	}
+
+	document.AddParagraph(text)
+	document.AddEmptyParagraph()
+}
+
+// addAbout lists the contact, license and terms of service of the API.
+func (c *DocxConverter) addAbout(document *docx.RootDoc, doc *domain.OpenAPIDocument) {
+	entries := aboutEntries(doc)
+	if len(entries) == 0 {
+		return
+	}
+
+	_, _ = document.AddHeading(c.locale.T("About"), 1)
+	for _, entry := range entries {
+		document.AddParagraph(entry.plain())
+	}
+
+	document.AddEmptyParagraph()
+}
+
 func (c *DocxConverter) addDescription(document *docx.RootDoc, doc *domain.OpenAPIDocument) {
-	if doc.Description == "" {
+	description := withPlainExternalDocs(doc.Description, doc.ExternalDocs)
+	if description == "" {
 		return
 	}
 
-	_, _ = document.AddHeading("Description", 1)
-	document.AddParagraph(doc.Description)
+	_, _ = document.AddHeading(c.locale.T("Description"), 1)
+	document.AddParagraph(description)
 	document.AddEmptyParagraph()
 }
 
@@ -65,7 +126,7 @@ func (c *DocxConverter) addServers(document *docx.RootDoc, doc *domain.OpenAPIDo
 		return
 	}
 
-	_, _ = document.AddHeading("Servers", 1)
+	_, _ = document.AddHeading(c.locale.T("Servers"), 1)
 
 	for _, server := range doc.Servers {
 		text := server.URL
@@ -79,95 +140,45 @@ func (c *DocxConverter) addServers(document *docx.RootDoc, doc *domain.OpenAPIDo
 	document.AddEmptyParagraph()
 }
 
-type docxEndpointRef struct {
-	path      string
-	method    string
-	operation domain.Operation
-}
-
-// groupPathsByTag groups paths by their operation tags.
-func (c *DocxConverter) groupPathsByTag(doc *domain.OpenAPIDocument) map[string][]docxEndpointRef {
-	result := make(map[string][]docxEndpointRef)
+func (c *DocxConverter) addSecuritySchemes(document *docx.RootDoc, doc *domain.OpenAPIDocument) {
+	if len(doc.SecuritySchemes) == 0 {
+		return
+	}
 
-	for _, path := range doc.Paths {
-		for _, op := range path.Operations {
-			tags := op.Tags
-			if len(tags) == 0 {
-				tags = []string{"Default"}
-			}
+	_, _ = document.AddHeading(c.locale.T("Authentication"), 1)
 
-			for _, tag := range tags {
-				result[tag] = append(result[tag], docxEndpointRef{
-					path:      path.Path,
-					method:    op.Method,
-					operation: op,
-				})
-			}
-		}
-	}
+	for _, name := range sortedSecuritySchemes(doc) {
+		scheme := doc.SecuritySchemes[name]
 
-	// Sort endpoints within each tag by path then method
-	for tag := range result {
-		sort.Slice(result[tag], func(i, j int) bool {
-			if result[tag][i].path == result[tag][j].path {
-				return result[tag][i].method < result[tag][j].method
-			}
+		_, _ = document.AddHeading(name, 2)
+		document.AddParagraph(fmt.Sprintf("Type: %s", formatSecurityScheme(scheme)))
 
-			return result[tag][i].path < result[tag][j].path
-		})
-	}
+		if scheme.Description != "" {
+			document.AddParagraph(scheme.Description)
+		}
 
-	return result
-}
+		for _, flow := range scheme.Flows {
+			_, _ = document.AddHeading(fmt.Sprintf("%s flow", formatOAuthFlow(flow.Type)), 3)
 
-// collectTagComponents gathers all unique component names used by endpoints in a tag.
-func (c *DocxConverter) collectTagComponents(endpoints []docxEndpointRef) []string {
-	componentSet := make(map[string]struct{})
+			if flow.AuthorizationURL != "" {
+				document.AddParagraph(fmt.Sprintf("Authorization URL: %s", flow.AuthorizationURL))
+			}
 
-	for _, ep := range endpoints {
-		// Check request body
-		if ep.operation.RequestBody != nil {
-			for _, media := range ep.operation.RequestBody.Content {
-				c.collectSchemaRefs(media.Schema, componentSet)
+			if flow.TokenURL != "" {
+				document.AddParagraph(fmt.Sprintf("Token URL: %s", flow.TokenURL))
 			}
-		}
 
-		// Check responses
-		for _, resp := range ep.operation.Responses {
-			for _, media := range resp.Content {
-				c.collectSchemaRefs(media.Schema, componentSet)
+			if flow.RefreshURL != "" {
+				document.AddParagraph(fmt.Sprintf("Refresh URL: %s", flow.RefreshURL))
 			}
-		}
 
-		// Check parameters
-		for _, param := range ep.operation.Parameters {
-			c.collectSchemaRefs(param.Schema, componentSet)
+			for _, scope := range sortedScopes(flow) {
+				document.AddParagraph(fmt.Sprintf("• %s: %s", scope, flow.Scopes[scope]))
+			}
 		}
 	}
 
-	// Convert set to sorted slice
-	components := make([]string, 0, len(componentSet))
-	for name := range componentSet {
-		components = append(components, name)
-	}
-	sort.Strings(components)
-
-	return components
-}
-
-// collectSchemaRefs recursively collects component references from a schema.
-func (c *DocxConverter) collectSchemaRefs(schema domain.Schema, refs map[string]struct{}) {
-	if schema.Ref != "" {
-		refs[extractRefName(schema.Ref)] = struct{}{}
-	}
-
-	for _, prop := range schema.Properties {
-		c.collectSchemaRefs(prop, refs)
-	}
-
-	if schema.Items != nil {
-		c.collectSchemaRefs(*schema.Items, refs)
-	}
+	document.AddEmptyParagraph()
 }
 
 func (c *DocxConverter) addPaths(document *docx.RootDoc, doc *domain.OpenAPIDocument) {
@@ -175,24 +186,29 @@ func (c *DocxConverter) addPaths(document *docx.RootDoc, doc *domain.OpenAPIDocu
 		return
 	}
 
-	_, _ = document.AddHeading("API Endpoints", 1)
+	_, _ = document.AddHeading(c.locale.T("API Endpoints"), 1)
 
 	// Group by tags
-	tagPaths := c.groupPathsByTag(doc)
-	tags := make([]string, 0, len(tagPaths))
-	for tag := range tagPaths {
-		tags = append(tags, tag)
-	}
-	sort.Strings(tags)
+	tagPaths := groupPathsByTag(doc)
+	tags := orderedTags(doc, tagPaths)
 
 	for _, tag := range tags {
 		// Tag header
 		_, _ = document.AddHeading(tag, 2)
 
+		info := tagInfo(doc, tag)
+		if description := withPlainExternalDocs(info.Description, info.ExternalDocs); description != "" {
+			document.AddParagraph(description)
+		}
+
 		// Add components used by this tag's endpoints
-		tagComponents := c.collectTagComponents(tagPaths[tag])
+		tagComponents := collectTagComponents(tagPaths[tag])
 		if len(tagComponents) > 0 {
-			c.addTagComponents(document, tagComponents, doc.Components)
+			if c.appendix {
+				c.addTagComponentNames(document, tagComponents)
+			} else {
+				c.addTagComponents(document, tagComponents, doc.Components)
+			}
 		}
 
 		// Add endpoints
@@ -202,9 +218,46 @@ func (c *DocxConverter) addPaths(document *docx.RootDoc, doc *domain.OpenAPIDocu
 	}
 }
 
+func (c *DocxConverter) addWebhooks(document *docx.RootDoc, doc *domain.OpenAPIDocument) {
+	if len(doc.Webhooks) == 0 {
+		return
+	}
+
+	_, _ = document.AddHeading(c.locale.T("Webhooks"), 1)
+
+	for _, ep := range webhookRefs(doc) {
+		c.addOperation(document, ep.path, ep.operation)
+	}
+}
+
+// addSchemaAppendix renders every component schema once, when the appendix is enabled.
+func (c *DocxConverter) addSchemaAppendix(document *docx.RootDoc, doc *domain.OpenAPIDocument) {
+	if !c.appendix || len(doc.Components) == 0 {
+		return
+	}
+
+	_, _ = document.AddHeading(c.locale.T("Schemas"), 1)
+
+	for _, name := range sortedComponentNames(doc.Components) {
+		c.addComponentSchema(document, name, flattenAllOf(doc.Components[name], doc.Components))
+	}
+}
+
+// addTagComponentNames lists the component schemas used by endpoints in a tag, which are documented in the appendix.
+func (c *DocxConverter) addTagComponentNames(document *docx.RootDoc, componentNames []string) {
+	_, _ = document.AddHeading(c.locale.T("Schemas Used"), 3)
+	document.AddParagraph("Documented in the Schemas section:")
+
+	for _, name := range componentNames {
+		document.AddParagraph(fmt.Sprintf("• %s", name))
+	}
+
+	document.AddEmptyParagraph()
+}
+
 // addTagComponents renders the component schemas used by endpoints in a tag.
 func (c *DocxConverter) addTagComponents(document *docx.RootDoc, componentNames []string, components map[string]domain.Schema) {
-	_, _ = document.AddHeading("Schemas Used", 3)
+	_, _ = document.AddHeading(c.locale.T("Schemas Used"), 3)
 
 	for _, name := range componentNames {
 		schema, exists := components[name]
@@ -212,7 +265,7 @@ func (c *DocxConverter) addTagComponents(document *docx.RootDoc, componentNames
 			continue
 		}
 
-		c.addComponentSchema(document, name, schema)
+		c.addComponentSchema(document, name, flattenAllOf(schema, components))
 	}
 
 	document.AddEmptyParagraph()
@@ -221,47 +274,41 @@ func (c *DocxConverter) addTagComponents(document *docx.RootDoc, componentNames
 // addComponentSchema renders a single component schema.
 func (c *DocxConverter) addComponentSchema(document *docx.RootDoc, name string, schema domain.Schema) {
 	// Schema name as bold heading
-	_, _ = document.AddHeading(name, 4)
+	_, _ = document.AddHeading(name+deprecatedSuffix(schema.Deprecated), 4)
 
 	// Type info
-	if schema.Type != "" {
-		typeStr := schema.Type
-		if schema.Format != "" {
-			typeStr = fmt.Sprintf("%s (%s)", schema.Type, schema.Format)
-		}
+	if typeStr := formatSchemaType(schema); typeStr != "" {
 		document.AddParagraph(fmt.Sprintf("Type: %s", typeStr))
 	}
 
 	// Description
-	if schema.Description != "" {
-		document.AddParagraph(schema.Description)
+	if description := withPlainExternalDocs(schema.Description, schema.ExternalDocs); description != "" {
+		document.AddParagraph(description)
+	}
+
+	// Structure as a code block, followed by the property descriptions
+	if len(schema.Properties) > 0 || schema.Items != nil {
+		c.addCodeBlock(document, schemaOutline(schema, 0))
+	}
+
+	if rows := propertyRows(schema, c.locale); len(rows) > 0 {
+		c.addTable(document, []string{"Property", "Type", "Description"}, rows)
 	}
 
-	// Properties
-	if len(schema.Properties) > 0 {
-		document.AddParagraph("Properties:")
+	// Alternatives of oneOf/anyOf schemas
+	if label, variants := schemaVariants(schema); len(variants) > 0 {
+		document.AddParagraph(label)
 
-		propNames := make([]string, 0, len(schema.Properties))
-		for propName := range schema.Properties {
-			propNames = append(propNames, propName)
+		for _, variant := range variants {
+			document.AddParagraph(fmt.Sprintf("  • %s", formatSchemaType(variant)))
 		}
-		sort.Strings(propNames)
-
-		for _, propName := range propNames {
-			prop := schema.Properties[propName]
-			propType := prop.Type
-			if prop.Ref != "" {
-				propType = extractRefName(prop.Ref)
-			} else if prop.Format != "" {
-				propType = fmt.Sprintf("%s (%s)", prop.Type, prop.Format)
-			}
+	}
 
-			propDesc := ""
-			if prop.Description != "" {
-				propDesc = fmt.Sprintf(" - %s", prop.Description)
-			}
+	if schema.Discriminator != nil {
+		document.AddParagraph(fmt.Sprintf("Discriminator: %s", schema.Discriminator.PropertyName))
 
-			document.AddParagraph(fmt.Sprintf("  • %s (%s)%s", propName, propType, propDesc))
+		for _, value := range sortedDiscriminatorValues(schema.Discriminator) {
+			document.AddParagraph(fmt.Sprintf("  • %s → %s", value, extractRefName(schema.Discriminator.Mapping[value])))
 		}
 	}
 
@@ -270,7 +317,7 @@ func (c *DocxConverter) addComponentSchema(document *docx.RootDoc, name string,
 
 func (c *DocxConverter) addOperation(document *docx.RootDoc, pathStr string, op domain.Operation) {
 	// Method and path header
-	_, _ = document.AddHeading(fmt.Sprintf("%s %s", formatMethod(op.Method), pathStr), 3)
+	_, _ = document.AddHeading(fmt.Sprintf("%s %s%s", formatMethod(op.Method), pathStr, deprecatedSuffix(op.Deprecated)), 3)
 
 	// Summary
 	if op.Summary != "" {
@@ -278,32 +325,252 @@ func (c *DocxConverter) addOperation(document *docx.RootDoc, pathStr string, op
 	}
 
 	// Description
-	if op.Description != "" {
-		document.AddParagraph(op.Description)
+	if description := withPlainExternalDocs(op.Description, op.ExternalDocs); description != "" {
+		document.AddParagraph(description)
+	}
+
+	// Authentication
+	if labels := securityLabels(op); len(labels) > 0 {
+		document.AddParagraph(fmt.Sprintf("Authentication: %s", strings.Join(labels, " or ")))
 	}
 
 	// Parameters
 	if len(op.Parameters) > 0 {
-		_, _ = document.AddHeading("Parameters", 4)
+		_, _ = document.AddHeading(c.locale.T("Parameters"), 4)
 
+		rows := make([][]string, 0, len(op.Parameters))
 		for _, param := range op.Parameters {
-			required := ""
-			if param.Required {
-				required = " (required)"
+			rows = append(rows, []string{
+				param.Name + deprecatedSuffix(param.Deprecated),
+				param.In,
+				formatSchemaDetails(param.Schema),
+				c.locale.T(requiredLabel(param.Required)),
+				param.Description,
+			})
+		}
+
+		c.addTable(document, []string{"Name", "In", "Type", c.locale.T("Required"), "Description"}, rows)
+	}
+
+	// Request body
+	if op.RequestBody != nil {
+		_, _ = document.AddHeading(c.locale.T("Request Body"), 4)
+		document.AddParagraph(c.locale.T(requiredLabel(op.RequestBody.Required)))
+
+		if op.RequestBody.Description != "" {
+			document.AddParagraph(op.RequestBody.Description)
+		}
+
+		for _, contentType := range sortedContentTypes(op.RequestBody.Content) {
+			schema := op.RequestBody.Content[contentType].Schema
+
+			if schemaType := formatSchemaType(schema); schemaType != "" {
+				document.AddParagraph(fmt.Sprintf("%s: %s", contentType, schemaType))
+			} else {
+				document.AddParagraph(contentType)
 			}
 
-			document.AddParagraph(fmt.Sprintf("• %s (%s): %s%s", param.Name, param.In, param.Description, required))
+			// Inline object schemas are shown in full, named ones are described under Schemas Used
+			if schema.Ref == "" && (len(schema.Properties) > 0 || schema.Items != nil) {
+				c.addCodeBlock(document, schemaOutline(schema, 0))
+			}
 		}
 	}
 
 	// Responses
 	if len(op.Responses) > 0 {
-		_, _ = document.AddHeading("Responses", 4)
+		_, _ = document.AddHeading(c.locale.T("Responses"), 4)
+
+		rows := make([][]string, 0, len(op.Responses))
+		for _, resp := range sortedResponses(op.Responses) {
+			summaries := contentSummaries(resp.Content)
+
+			content := make([]string, 0, len(summaries))
+			for _, summary := range summaries {
+				content = append(content, formatMediaSummary(summary))
+			}
+
+			rows = append(rows, []string{resp.StatusCode, resp.Description, strings.Join(content, "\n")})
+		}
+
+		c.addTable(document, []string{"Status", "Description", "Content"}, rows)
+	}
+
+	// Response headers
+	if headers := responseHeaders(op.Responses); len(headers) > 0 {
+		_, _ = document.AddHeading(c.locale.T("Response Headers"), 4)
+
+		rows := make([][]string, 0, len(headers))
+		for _, header := range headers {
+			rows = append(rows, []string{
+				header.StatusCode, header.Name + deprecatedSuffix(header.Deprecated), formatSchemaDetails(header.Schema), header.Description,
+			})
+		}
+
+		c.addTable(document, []string{"Status", "Header", "Type", "Description"}, rows)
+	}
+
+	// Limits stamped by the gateway
+	if limits := operationLimits(op); len(limits) > 0 {
+		_, _ = document.AddHeading(c.locale.T("Limits"), 4)
+
+		rows := make([][]string, 0, len(limits))
+		for _, limit := range limits {
+			rows = append(rows, []string{c.locale.T(limit.Label), limit.Value})
+		}
+
+		c.addTable(document, []string{"Limit", "Value"}, rows)
+	}
+
+	// Links to the operations that can follow
+	if related := relatedOperations(op, nil); len(related) > 0 {
+		_, _ = document.AddHeading(c.locale.T("Related Operations"), 4)
+
+		rows := make([][]string, 0, len(related))
+		for _, operation := range related {
+			rows = append(rows, []string{operation.StatusCode, operation.Target, formatLinkValues(operation), operation.Description})
+		}
+
+		c.addTable(document, []string{"Response", "Operation", "Values", "Description"}, rows)
+	}
+
+	// Callbacks
+	if callbacks := callbackRequests(op); len(callbacks) > 0 {
+		_, _ = document.AddHeading(c.locale.T("Callbacks"), 4)
+
+		for _, callback := range callbacks {
+			c.addCallback(document, callback)
+		}
+	}
+
+	document.AddEmptyParagraph()
+}
+
+// addCallback renders a callback request with its URL expression, payload and expected responses.
+func (c *DocxConverter) addCallback(document *docx.RootDoc, callback callbackRequest) {
+	op := callback.Operation
+
+	title := document.AddEmptyParagraph()
+	title.AddText(callback.Name + ": ").Bold(true)
+	title.AddText(formatMethod(op.Method) + " " + callback.Expression).Style(docxCodeCharStyle)
+
+	if op.Summary != "" {
+		document.AddParagraph(op.Summary)
+	}
+
+	if op.Description != "" {
+		document.AddParagraph(op.Description)
+	}
+
+	if op.RequestBody != nil {
+		for _, summary := range contentSummaries(op.RequestBody.Content) {
+			document.AddParagraph("Payload: " + formatMediaSummary(summary))
+		}
+	}
+
+	if len(op.Responses) > 0 {
+		document.AddParagraph("Expected responses: " + callbackResponses(op))
+	}
+}
+
+// addTable renders rows as a grid table below a bold header row. Lines of a cell become separate paragraphs.
+func (c *DocxConverter) addTable(document *docx.RootDoc, header []string, rows [][]string) {
+	table := document.AddTable()
+	table.Style(docxTableStyle)
+
+	headerRow := table.AddRow()
+	for _, title := range header {
+		headerRow.AddCell().AddEmptyPara().AddText(title).Bold(true)
+	}
+
+	for _, row := range rows {
+		tableRow := table.AddRow()
 
-		for _, resp := range op.Responses {
-			document.AddParagraph(fmt.Sprintf("• %s: %s", resp.StatusCode, resp.Description))
+		for _, value := range row {
+			cell := tableRow.AddCell()
+
+			for _, line := range strings.Split(value, "\n") {
+				cell.AddParagraph(line)
+			}
 		}
 	}
 
 	document.AddEmptyParagraph()
 }
+
+// addCodeBlock renders text in the monospaced macro text style, one paragraph per line so indentation is kept.
+func (c *DocxConverter) addCodeBlock(document *docx.RootDoc, code string) {
+	for _, line := range strings.Split(code, "\n") {
+		paragraph := document.AddEmptyParagraph()
+		paragraph.Style(docxCodeStyle)
+		paragraph.AddText(line)
+	}
+
+	document.AddEmptyParagraph()
+}
+
+// propertyRows lists the properties of a schema as property, type and description cells, in name order.
+// Schemas whose properties have no description need no table next to their outline.
+func propertyRows(schema domain.Schema, locale Locale) [][]string {
+	propNames := make([]string, 0, len(schema.Properties))
+	described := false
+
+	for propName, prop := range schema.Properties {
+		propNames = append(propNames, propName)
+		described = described || prop.Description != ""
+	}
+
+	if !described {
+		return nil
+	}
+
+	sort.Strings(propNames)
+
+	rows := make([][]string, 0, len(propNames))
+	for _, propName := range propNames {
+		prop := schema.Properties[propName]
+		details := formatPropertyDetails(prop, slices.Contains(schema.Required, propName), locale)
+		rows = append(rows, []string{propName + deprecatedSuffix(prop.Deprecated), details, prop.Description})
+	}
+
+	return rows
+}
+
+// ConvertChangelog transforms a changelog between two specifications to DOCX format.
+func (c *DocxConverter) ConvertChangelog(changelog *domain.Changelog, output io.Writer) error {
+	document, err := godocx.NewDocument()
+	if err != nil {
+		return fmt.Errorf("failed to create document: %w", err)
+	}
+
+	_, _ = document.AddHeading(ChangelogTitle(changelog), 0)
+	document.AddParagraph(ChangelogSummary(changelog))
+	document.AddEmptyParagraph()
+
+	for _, section := range changelogSections(changelog) {
+		_, _ = document.AddHeading(section.title, 1)
+
+		for _, group := range section.groups {
+			if group.title != "" {
+				_, _ = document.AddHeading(group.title, 2)
+			}
+
+			for _, item := range group.items {
+				text := item.text
+				if item.breaking && section.title != breakingSectionTitle {
+					text += " (breaking)"
+				}
+
+				document.AddParagraph(fmt.Sprintf("• %s", text))
+			}
+		}
+
+		document.AddEmptyParagraph()
+	}
+
+	if err := document.Write(output); err != nil {
+		return fmt.Errorf("failed to write document: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,215 @@
+package converters
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/GabrielNunesIT/openapi-converter/internal/domain"
+)
+
+// sampleServerURL prefixes generated requests when the document declares no server.
+const sampleServerURL = "http://localhost"
+
+// sampleField is a named value of a sample request, such as a header, a cookie or a form field.
+type sampleField struct {
+	Name  string
+	Value string
+}
+
+// sampleRequest is a concrete request to an operation, from which code snippets are generated.
+type sampleRequest struct {
+	Method    string
+	URL       string        // Server URL, path with sample path parameters and query string
+	Headers   []sampleField // Required header parameters, credentials and the body content type
+	Cookies   []sampleField
+	BasicAuth bool          // Credentials are sent with HTTP basic authentication
+	Body      string        // Raw payload, JSON or URL-encoded
+	Form      []sampleField // Fields of a multipart/form-data payload
+}
+
+// jsonBody reports whether the body is a JSON document, which snippets can embed as code.
+func (r sampleRequest) jsonBody() bool {
+	for _, header := range r.Headers {
+		if header.Name == "Content-Type" && strings.Contains(header.Value, "json") {
+			return r.Body != ""
+		}
+	}
+
+	return false
+}
+
+// buildSampleRequest synthesizes a request calling an operation: the first server URL, the path with sample
+// path parameters, the required query, header and cookie parameters, the credentials of the first security
+// requirement and a sample body of the preferred request content type.
+func buildSampleRequest(doc *domain.OpenAPIDocument, path string, op domain.Operation) sampleRequest {
+	samples := newSampler(doc.Components)
+	req := sampleRequest{Method: formatMethod(op.Method)}
+	query := []string{}
+
+	for _, param := range op.Parameters {
+		value := formatSampleParam(samples.value(param.Schema, 0))
+
+		switch param.In {
+		case "path":
+			path = strings.ReplaceAll(path, "{"+param.Name+"}", url.PathEscape(value))
+		case "query":
+			if param.Required {
+				query = append(query, url.QueryEscape(param.Name)+"="+url.QueryEscape(value))
+			}
+		case "header":
+			if param.Required {
+				req.Headers = append(req.Headers, sampleField{Name: param.Name, Value: value})
+			}
+		case "cookie":
+			if param.Required {
+				req.Cookies = append(req.Cookies, sampleField{Name: param.Name, Value: value})
+			}
+		}
+	}
+
+	query = append(query, req.addAuth(doc, op)...)
+
+	if op.RequestBody != nil {
+		if contentTypes := sortedContentTypes(op.RequestBody.Content); len(contentTypes) > 0 {
+			contentType := preferredContentType(contentTypes)
+			req.addBody(contentType, op.RequestBody.Content[contentType], samples)
+		}
+	}
+
+	req.URL = sampleServerURL
+	if len(doc.Servers) > 0 {
+		req.URL = strings.TrimSuffix(doc.Servers[0].URL, "/")
+	}
+
+	req.URL += path
+	if len(query) > 0 {
+		req.URL += "?" + strings.Join(query, "&")
+	}
+
+	return req
+}
+
+// addAuth adds the credentials of the first security requirement of an operation, with placeholders
+// in place of secrets. It returns the query parameters carrying API keys.
+func (r *sampleRequest) addAuth(doc *domain.OpenAPIDocument, op domain.Operation) []string {
+	if len(op.Security) == 0 {
+		return nil
+	}
+
+	names := make([]string, 0, len(op.Security[0]))
+	for name := range op.Security[0] {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	query := []string{}
+
+	for _, name := range names {
+		scheme, exists := doc.SecuritySchemes[name]
+		if !exists {
+			continue
+		}
+
+		switch scheme.Type {
+		case "http":
+			if strings.EqualFold(scheme.Scheme, "basic") {
+				r.BasicAuth = true
+			} else {
+				r.Headers = append(r.Headers, sampleField{Name: "Authorization", Value: "Bearer <token>"})
+			}
+		case "apiKey":
+			placeholder := fmt.Sprintf("<%s>", name)
+
+			switch scheme.In {
+			case "query":
+				query = append(query, url.QueryEscape(scheme.Name)+"="+url.QueryEscape(placeholder))
+			case "cookie":
+				r.Cookies = append(r.Cookies, sampleField{Name: scheme.Name, Value: placeholder})
+			default:
+				r.Headers = append(r.Headers, sampleField{Name: scheme.Name, Value: placeholder})
+			}
+		case "oauth2", "openIdConnect":
+			r.Headers = append(r.Headers, sampleField{Name: "Authorization", Value: "Bearer <access-token>"})
+		}
+	}
+
+	return query
+}
+
+// addBody adds a sample payload: the first example of the media type, otherwise a value derived from its schema.
+func (r *sampleRequest) addBody(contentType string, media domain.MediaType, samples sampler) {
+	var value any
+	if examples := mediaExamples(media); len(examples) > 0 {
+		value = examples[0].value
+	} else {
+		value = samples.value(media.Schema, 0)
+	}
+
+	// HTTP clients set the multipart boundary themselves
+	if contentType == "multipart/form-data" {
+		r.Form = sampleFields(value)
+
+		return
+	}
+
+	r.Headers = append(r.Headers, sampleField{Name: "Content-Type", Value: contentType})
+
+	switch {
+	case strings.Contains(contentType, "json"):
+		r.Body = formatExampleValue(value)
+	case contentType == "application/x-www-form-urlencoded":
+		values := url.Values{}
+		for _, field := range sampleFields(value) {
+			values.Set(field.Name, field.Value)
+		}
+
+		r.Body = values.Encode()
+	default:
+		if text, ok := value.(string); ok {
+			r.Body = text
+		}
+	}
+}
+
+// sampleFields flattens a sample object into form fields sorted by name.
+func sampleFields(value any) []sampleField {
+	object, ok := value.(map[string]any)
+	if !ok {
+		return nil
+	}
+
+	names := make([]string, 0, len(object))
+	for name := range object {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fields := make([]sampleField, 0, len(names))
+	for _, name := range names {
+		fields = append(fields, sampleField{Name: name, Value: formatSampleParam(object[name])})
+	}
+
+	return fields
+}
+
+// formatSampleParam renders a sample value as a parameter or form field value.
+func formatSampleParam(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case map[string]any, []any:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprintf("%v", v)
+		}
+
+		return string(data)
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
@@ -0,0 +1,81 @@
+package converters
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// extensionField is a vendor extension chosen for rendering, shown under a label.
+type extensionField struct {
+	Name  string // Field name such as "x-rate-limit"
+	Label string // Text shown before the value, such as "Rate limit"
+}
+
+// extensionValue is the rendered value of an extension field.
+type extensionValue struct {
+	Label string
+	Value string
+}
+
+// extensionFieldsFor parses the extensions to render, each given as "x-name" or "x-name=Label".
+// Without a label the field name itself is shown.
+func extensionFieldsFor(specs []string) ([]extensionField, error) {
+	fields := make([]extensionField, 0, len(specs))
+
+	for _, spec := range specs {
+		name, label, _ := strings.Cut(spec, "=")
+		name = strings.TrimSpace(name)
+		label = strings.TrimSpace(label)
+
+		if !strings.HasPrefix(name, "x-") {
+			return nil, fmt.Errorf("invalid extension %q: vendor extension names start with x-", spec)
+		}
+
+		if label == "" {
+			label = name
+		}
+
+		fields = append(fields, extensionField{Name: name, Label: label})
+	}
+
+	return fields, nil
+}
+
+// selectExtensions returns the values of the chosen fields present in extensions, in the order the fields were given.
+func selectExtensions(fields []extensionField, extensions map[string]any) []extensionValue {
+	var values []extensionValue
+
+	for _, field := range fields {
+		value, exists := extensions[field.Name]
+		if !exists || value == nil {
+			continue
+		}
+
+		values = append(values, extensionValue{Label: field.Label, Value: formatExtensionValue(value)})
+	}
+
+	return values
+}
+
+// formatExtensionValue renders an extension value. Objects are listed as "key: value" pairs in key order,
+// anything else as in formatConstraintValue.
+func formatExtensionValue(value any) string {
+	object, ok := value.(map[string]any)
+	if !ok {
+		return formatConstraintValue(value)
+	}
+
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, 0, len(keys))
+	for _, key := range keys {
+		pairs = append(pairs, key+": "+formatConstraintValue(object[key]))
+	}
+
+	return strings.Join(pairs, ", ")
+}
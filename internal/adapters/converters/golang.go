@@ -0,0 +1,727 @@
+package converters
+
+import (
+	"fmt"
+	"go/format"
+	"go/token"
+	"io"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/GabrielNunesIT/openapi-converter/internal/domain"
+)
+
+const goFormat = "golang"
+
+// goInitialisms are the words Go names keep in upper case, e.g. "ID" in "PetID".
+var goInitialisms = []string{ //nolint:gochecknoglobals // read-only lookup table
+	"ACL", "API", "ASCII", "CPU", "CSS", "DNS", "EOF", "GUID", "HTML", "HTTP", "HTTPS", "ID", "IP", "JSON", "RPC",
+	"SLA", "SMTP", "SQL", "SSH", "TCP", "TLS", "TTL", "UDP", "UI", "UID", "URI", "URL", "UTF8", "UUID", "XML",
+}
+
+// goClientNames are the names the client declares, which component schemas are renamed away from.
+var goClientNames = []string{"Client", "NewClient", "ResponseError"} //nolint:gochecknoglobals // read-only
+
+// goClientImports are the packages the client uses.
+var goClientImports = []string{ //nolint:gochecknoglobals // read-only
+	"bytes", "context", "encoding/json", "fmt", "io", "net/http", "net/url", "strings", "time",
+}
+
+// goClientParameterNames are the variables of the client methods, which parameters are renamed away from.
+var goClientParameterNames = []string{ //nolint:gochecknoglobals // read-only
+	"body", "c", "ctx", "err", "header", "path", "payload", "query", "result",
+}
+
+// goClient is the part of the client shared by the operation methods.
+const goClient = `// Client calls the operations of the API, sending and receiving JSON.
+type Client struct {
+	// BaseURL is the URL the paths of the operations are relative to, e.g. "https://api.example.com/v1".
+	BaseURL string
+	// HTTPClient sends the requests.
+	HTTPClient *http.Client
+}
+
+// NewClient creates a client of the API served at baseURL.
+func NewClient(baseURL string) *Client {
+	return &Client{BaseURL: strings.TrimSuffix(baseURL, "/"), HTTPClient: http.DefaultClient}
+}
+
+// ResponseError is the error of a response with a 4xx or 5xx status.
+type ResponseError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *ResponseError) Error() string {
+	return fmt.Sprintf("unexpected status %d: %s", e.StatusCode, e.Body)
+}
+
+// do sends a request with body, when not nil, as JSON and decodes the JSON response into result, when not nil.
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, header http.Header, body, result any) error {
+	var reader io.Reader
+
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to encode request body: %w", err)
+		}
+
+		reader = bytes.NewReader(data)
+	}
+
+	endpoint := c.BaseURL + path
+	if len(query) > 0 {
+		endpoint += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, reader)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	for name, values := range header {
+		req.Header[name] = values
+	}
+
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		data, _ := io.ReadAll(resp.Body)
+
+		return &ResponseError{StatusCode: resp.StatusCode, Body: string(data)}
+	}
+
+	if result == nil || resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return fmt.Errorf("failed to decode response body: %w", err)
+	}
+
+	return nil
+}
+
+// parameterValue formats the value of a path, query or header parameter, date-times as RFC 3339.
+func parameterValue(value any) string {
+	if t, ok := value.(time.Time); ok {
+		return t.Format(time.RFC3339)
+	}
+
+	return fmt.Sprint(value)
+}
+`
+
+// GoConverter converts OpenAPI documents to a Go source file: component schemas become structs with json tags,
+// and string enums string types with a constant per value. Optionally, a thin client calls each operation with
+// its path, query and header parameters and JSON request body, and decodes its first successful JSON response.
+// Integers map to int, or int32 and int64 with those formats, numbers to float64, or float32, binary strings
+// to []byte and date-times to time.Time. Optional properties are pointers, unless slices or maps, tagged omitempty.
+type GoConverter struct {
+	client bool // Generate a client with a method per operation besides the types
+}
+
+// GoOptions configures a GoConverter. The fields tagged option can also be set by name, e.g. with
+// --option golang.go-client=true, see RegisterFormat.
+type GoOptions struct {
+	// Client generates a Client type with a method per operation besides the types.
+	Client bool `option:"go-client"`
+}
+
+// NewGoConverter creates a new Go converter.
+func NewGoConverter(opts GoOptions) *GoConverter {
+	return &GoConverter{client: opts.Client}
+}
+
+func init() { //nolint:gochecknoinits // converters self-register with the default registry
+	RegisterFormat(goFormat, func(opts Options) GoOptions {
+		return GoOptions{Client: opts.GoClient}
+	}, func(opts GoOptions) domain.Converter { return NewGoConverter(opts) }, "go")
+}
+
+// Format returns the output format name.
+func (c *GoConverter) Format() string {
+	return goFormat
+}
+
+// Convert transforms an OpenAPI document to a Go source file.
+func (c *GoConverter) Convert(doc *domain.OpenAPIDocument, output io.Writer) error {
+	g := &goFile{
+		components: doc.Components,
+		types:      make(map[string]string),
+		imports:    make(map[string]struct{}),
+		client:     c.client,
+	}
+
+	// Every component schema, whether used by an operation or not
+	for _, name := range sortedComponentNames(doc.Components) {
+		g.typeOf(doc.Components[name], name)
+	}
+
+	methods := []string{}
+
+	if c.client {
+		names := make(map[string]int) // Method names taken, with the number of operations wanting each
+
+		for _, path := range doc.Paths {
+			for _, op := range path.Operations {
+				methods = append(methods, g.method(path.Path, op, names))
+			}
+		}
+
+		for _, pkg := range goClientImports {
+			g.imports[pkg] = struct{}{}
+		}
+	}
+
+	pkg := goPackage(doc)
+
+	var src strings.Builder
+
+	fmt.Fprintf(&src, "// Code generated by openapi-converter from %s, version %s. DO NOT EDIT.\n\n", doc.Title, doc.Version)
+
+	if c.client {
+		fmt.Fprintf(&src, "// Package %s holds the types of the %s API and a client calling its operations.\n", pkg, doc.Title)
+	} else {
+		fmt.Fprintf(&src, "// Package %s holds the types of the %s API.\n", pkg, doc.Title)
+	}
+
+	src.WriteString("package " + pkg + "\n")
+
+	if len(g.imports) > 0 {
+		src.WriteString("\nimport (\n")
+
+		for _, path := range slices.Sorted(maps.Keys(g.imports)) {
+			src.WriteString("\t" + strconv.Quote(path) + "\n")
+		}
+
+		src.WriteString(")\n")
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(g.types)) {
+		src.WriteString("\n" + g.types[name])
+	}
+
+	if c.client {
+		src.WriteString("\n" + goClient)
+
+		for _, method := range methods {
+			src.WriteString("\n" + method)
+		}
+	}
+
+	formatted, err := format.Source([]byte(src.String()))
+	if err != nil {
+		return fmt.Errorf("failed to format Go source: %w", err)
+	}
+
+	if _, err := output.Write(formatted); err != nil {
+		return fmt.Errorf("failed to write Go source: %w", err)
+	}
+
+	return nil
+}
+
+// goFile collects the type declarations of a Go file while schemas are mapped to types.
+type goFile struct {
+	components map[string]domain.Schema
+	types      map[string]string   // Declaration by type name, empty while the fields of a struct are mapped
+	imports    map[string]struct{} // Import paths of the packages used
+	client     bool                // The client is generated, its names taken
+}
+
+// goParameter is a parameter of a client method.
+type goParameter struct {
+	Name     string // Go variable name
+	Type     string
+	Param    domain.Parameter
+	Optional bool // Passed as a pointer, or a nil slice or map, when absent
+}
+
+// method returns the client method calling an operation, named after its operationId, or its method and path
+// without one. Its arguments are the path, query and header parameters, then the request body as "body".
+// It returns the JSON body of the first successful response, or only an error when there is none.
+func (g *goFile) method(path string, op domain.Operation, names map[string]int) string {
+	name := goIdentifier(op.OperationID)
+	if op.OperationID == "" {
+		name = goIdentifier(strings.ToLower(op.Method) + " " + path)
+	}
+
+	if names[name]++; names[name] > 1 {
+		name = fmt.Sprintf("%s%d", name, names[name])
+	}
+
+	params := []goParameter{}
+	taken := make(map[string]int) // Variable names taken, with the number of parameters wanting each
+
+	for _, param := range op.Parameters {
+		if param.In != "path" && param.In != "query" && param.In != "header" {
+			continue
+		}
+
+		varName := goVariableName(param.Name)
+		if taken[varName]++; taken[varName] > 1 {
+			varName = fmt.Sprintf("%s%d", varName, taken[varName])
+		}
+
+		goType := g.typeOf(param.Schema, name+" "+param.Name)
+		optional := !param.Required && param.In != "path"
+
+		if optional && !isGoNillable(goType) {
+			goType = "*" + goType
+		}
+
+		params = append(params, goParameter{Name: varName, Type: goType, Param: param, Optional: optional})
+	}
+
+	args := []string{"ctx context.Context"}
+	for _, param := range params {
+		args = append(args, param.Name+" "+param.Type)
+	}
+
+	bodyType := ""
+	optionalBody := false
+
+	if body := op.RequestBody; body != nil && len(body.Content) > 0 {
+		media := body.Content[preferredContentType(sortedContentTypes(body.Content))]
+		bodyType = g.typeOf(media.Schema, name+" request")
+		optionalBody = !body.Required && bodyType != "any"
+
+		if optionalBody && !isGoNillable(bodyType) {
+			bodyType = "*" + bodyType
+		}
+
+		args = append(args, "body "+bodyType)
+	}
+
+	resultType := ""
+
+	for _, resp := range sortedResponses(op.Responses) {
+		if strings.HasPrefix(resp.StatusCode, "2") && len(resp.Content) > 0 {
+			media := resp.Content[preferredContentType(sortedContentTypes(resp.Content))]
+			resultType = g.typeOf(media.Schema, name+" response")
+
+			break
+		}
+	}
+
+	var m strings.Builder
+
+	fmt.Fprintf(&m, "// %s calls %s %s.\n", name, formatMethod(op.Method), path)
+
+	// The summary continues the first paragraph, which gofmt would otherwise turn into a heading
+	writeLineComment(&m, op.Summary, "")
+
+	if strings.TrimSpace(op.Description) != "" {
+		m.WriteString("//\n")
+		writeLineComment(&m, op.Description, "")
+	}
+
+	if op.Deprecated {
+		m.WriteString("//\n// Deprecated: the API marks this operation as deprecated.\n")
+	}
+
+	fmt.Fprintf(&m, "func (c *Client) %s(%s) ", name, strings.Join(args, ", "))
+
+	if resultType != "" {
+		m.WriteString("(" + resultType + ", error) {\n")
+	} else {
+		m.WriteString("error {\n")
+	}
+
+	g.writeRequest(&m, path, params)
+
+	payload := "nil"
+
+	switch {
+	case optionalBody:
+		m.WriteString("\tvar payload any\n\tif body != nil {\n\t\tpayload = body\n\t}\n\n")
+
+		payload = "payload"
+	case bodyType != "":
+		payload = "body"
+	}
+
+	query, header := "nil", "nil"
+	if hasGoParameter(params, "query") {
+		query = "query"
+	}
+
+	if hasGoParameter(params, "header") {
+		header = "header"
+	}
+
+	call := fmt.Sprintf("c.do(ctx, %s, path, %s, %s, %s", goHTTPMethod(op.Method), query, header, payload)
+
+	if resultType != "" {
+		fmt.Fprintf(&m, "\tvar result %s\n\terr := %s, &result)\n\n\treturn result, err\n}\n", resultType, call)
+	} else {
+		fmt.Fprintf(&m, "\treturn %s, nil)\n}\n", call)
+	}
+
+	return m.String()
+}
+
+// writeRequest writes the statements of a client method building the path, query and header of its request.
+func (g *goFile) writeRequest(m *strings.Builder, path string, params []goParameter) {
+	m.WriteString("\tpath := " + strconv.Quote(path) + "\n")
+
+	for _, param := range params {
+		if param.Param.In == "path" {
+			fmt.Fprintf(m, "\tpath = strings.ReplaceAll(path, %s, url.PathEscape(parameterValue(%s)))\n",
+				strconv.Quote("{"+param.Param.Name+"}"), param.Name)
+		}
+	}
+
+	for _, in := range []string{"query", "header"} {
+		if !hasGoParameter(params, in) {
+			continue
+		}
+
+		if in == "query" {
+			m.WriteString("\n\tquery := url.Values{}\n")
+		} else {
+			m.WriteString("\n\theader := http.Header{}\n")
+		}
+
+		for _, param := range params {
+			if param.Param.In != in {
+				continue
+			}
+
+			key := strconv.Quote(param.Param.Name)
+
+			switch {
+			case strings.HasPrefix(param.Type, "[]") && param.Type != "[]byte":
+				fmt.Fprintf(m, "\tfor _, value := range %s {\n\t\t%s.Add(%s, parameterValue(value))\n\t}\n", param.Name, in, key)
+			case param.Optional && strings.HasPrefix(param.Type, "*"):
+				fmt.Fprintf(m, "\tif %s != nil {\n\t\t%s.Set(%s, parameterValue(*%s))\n\t}\n", param.Name, in, key, param.Name)
+			case param.Optional:
+				fmt.Fprintf(m, "\tif %s != nil {\n\t\t%s.Set(%s, parameterValue(%s))\n\t}\n", param.Name, in, key, param.Name)
+			default:
+				fmt.Fprintf(m, "\t%s.Set(%s, parameterValue(%s))\n", in, key, param.Name)
+			}
+		}
+	}
+
+	m.WriteString("\n")
+}
+
+// typeOf returns the Go type of a schema, declaring the structs and enums it needs. Inline objects and enums
+// are named after name, such as the type and property holding them, and component schemas after the component.
+// Values of several types, from oneOf or anyOf, are left undecoded as json.RawMessage.
+func (g *goFile) typeOf(schema domain.Schema, name string) string {
+	if schema.Ref != "" {
+		refName := extractRefName(schema.Ref)
+		if component, ok := g.components[refName]; ok {
+			name = refName
+			schema = component
+		}
+	}
+
+	schema = flattenAllOf(schema, g.components)
+
+	if _, variants := schemaVariants(schema); len(variants) > 0 {
+		return g.variantType(name, schema, variants)
+	}
+
+	switch {
+	case len(schema.Enum) > 0 && isStringEnum(schema.Enum):
+		return g.enumType(name, schema)
+	case schema.Type == "array":
+		if schema.Items == nil {
+			return "[]any"
+		}
+
+		return "[]" + g.typeOf(*schema.Items, name+" item")
+	case len(schema.Properties) > 0:
+		return g.structType(name, schema)
+	case schema.Type == "object":
+		return "map[string]any"
+	case schema.Type == "string":
+		return g.stringType(schema.Format)
+	case schema.Type == "integer" && (schema.Format == "int32" || schema.Format == "int64"):
+		return schema.Format
+	case schema.Type == "integer":
+		return "int"
+	case schema.Type == "number" && schema.Format == "float":
+		return "float32"
+	case schema.Type == "number":
+		return "float64"
+	case schema.Type == "boolean":
+		return "bool"
+	default:
+		return "any"
+	}
+}
+
+// stringType returns the Go type of a string of the given format.
+func (g *goFile) stringType(format string) string {
+	switch format {
+	case "byte", "binary":
+		return "[]byte"
+	case "date-time":
+		return g.use("time", "time.Time")
+	default:
+		return "string"
+	}
+}
+
+// structType declares the struct of a schema with properties.
+func (g *goFile) structType(name string, schema domain.Schema) string {
+	typeName := g.typeName(name)
+	if _, declared := g.types[typeName]; declared {
+		return typeName
+	}
+
+	g.types[typeName] = "" // Reserved while the properties are mapped, for schemas referencing themselves
+
+	var decl strings.Builder
+
+	writeLineComment(&decl, schema.Description, "")
+	decl.WriteString("type " + typeName + " struct {\n")
+
+	taken := make(map[string]int) // Field names taken, with the number of properties wanting each
+
+	for _, propName := range slices.Sorted(maps.Keys(schema.Properties)) {
+		prop := schema.Properties[propName]
+		required := slices.Contains(schema.Required, propName)
+		fieldType := g.typeOf(prop, name+" "+propName)
+
+		// Optional and null values are pointers, as are the structs being declared, which a value cannot hold
+		declaration, declared := g.types[fieldType]
+		if (!required || prop.Nullable || declared && declaration == "") && !isGoNillable(fieldType) {
+			fieldType = "*" + fieldType
+		}
+
+		fieldName := goIdentifier(propName)
+		if taken[fieldName]++; taken[fieldName] > 1 {
+			fieldName = fmt.Sprintf("%s%d", fieldName, taken[fieldName])
+		}
+
+		tag := propName
+		if !required {
+			tag += ",omitempty"
+		}
+
+		writeLineComment(&decl, prop.Description, "\t")
+
+		if prop.Deprecated {
+			if strings.TrimSpace(prop.Description) != "" {
+				decl.WriteString("\t//\n")
+			}
+
+			decl.WriteString("\t// Deprecated: the API marks this property as deprecated.\n")
+		}
+
+		fmt.Fprintf(&decl, "\t%s %s `json:%s`\n", fieldName, fieldType, strconv.Quote(tag))
+	}
+
+	decl.WriteString("}\n")
+	g.types[typeName] = decl.String()
+
+	return typeName
+}
+
+// enumType declares the string type of a schema allowing a list of strings, with a constant per value.
+func (g *goFile) enumType(name string, schema domain.Schema) string {
+	typeName := g.typeName(name)
+	if _, declared := g.types[typeName]; declared {
+		return typeName
+	}
+
+	var decl strings.Builder
+
+	writeLineComment(&decl, schema.Description, "")
+	decl.WriteString("type " + typeName + " string\n\n")
+	fmt.Fprintf(&decl, "// Values of %s.\nconst (\n", typeName)
+
+	taken := make(map[string]int) // Constant names taken, with the number of values wanting each
+
+	for _, value := range schema.Enum {
+		suffix := goName(fmt.Sprint(value))
+		if suffix == "" {
+			suffix = "Empty"
+		}
+
+		constName := typeName + suffix
+		if taken[constName]++; taken[constName] > 1 {
+			constName = fmt.Sprintf("%s%d", constName, taken[constName])
+		}
+
+		fmt.Fprintf(&decl, "\t%s %s = %s\n", constName, typeName, strconv.Quote(fmt.Sprint(value)))
+	}
+
+	decl.WriteString(")\n")
+	g.types[typeName] = decl.String()
+
+	return typeName
+}
+
+// variantType returns the type of oneOf or anyOf variants, json.RawMessage, declaring an alias of it named after
+// component schemas so that their name remains.
+func (g *goFile) variantType(name string, schema domain.Schema, variants []domain.Schema) string {
+	raw := g.use("encoding/json", "json.RawMessage")
+	if _, component := g.components[name]; !component {
+		return raw
+	}
+
+	typeName := g.typeName(name)
+	if _, declared := g.types[typeName]; declared {
+		return typeName
+	}
+
+	refs := []string{}
+
+	for _, variant := range variants {
+		if _, ok := g.components[extractRefName(variant.Ref)]; variant.Ref != "" && ok {
+			refs = append(refs, g.typeOf(variant, name))
+		}
+	}
+
+	var decl strings.Builder
+
+	writeLineComment(&decl, schema.Description, "")
+
+	if len(refs) > 0 {
+		if strings.TrimSpace(schema.Description) != "" {
+			decl.WriteString("//\n")
+		}
+
+		fmt.Fprintf(&decl, "// The JSON value, left undecoded, is one of: %s.\n", strings.Join(refs, ", "))
+	}
+
+	decl.WriteString("type " + typeName + " = " + raw + "\n")
+	g.types[typeName] = decl.String()
+
+	return typeName
+}
+
+// typeName returns the name of the type declared for name, renamed with a "Model" suffix when the client uses it.
+func (g *goFile) typeName(name string) string {
+	typeName := goIdentifier(name)
+	if g.client && slices.Contains(goClientNames, typeName) {
+		return typeName + "Model"
+	}
+
+	return typeName
+}
+
+// use returns a type of an imported package, importing it.
+func (g *goFile) use(pkg, goType string) string {
+	g.imports[pkg] = struct{}{}
+
+	return goType
+}
+
+// isGoNillable reports whether values of a Go type can be nil themselves, without a pointer.
+func isGoNillable(goType string) bool {
+	return strings.HasPrefix(goType, "[]") || strings.HasPrefix(goType, "map[") ||
+		goType == "any" || goType == "json.RawMessage"
+}
+
+// hasGoParameter reports whether one of the parameters of a client method is in a location, such as "query".
+func hasGoParameter(params []goParameter, in string) bool {
+	return slices.ContainsFunc(params, func(param goParameter) bool { return param.Param.In == in })
+}
+
+// goHTTPMethod returns the net/http constant of an HTTP method, or the method quoted when there is none.
+func goHTTPMethod(method string) string {
+	switch method = formatMethod(method); method {
+	case "GET", "HEAD", "POST", "PUT", "PATCH", "DELETE", "CONNECT", "OPTIONS", "TRACE":
+		return "http.Method" + method[:1] + strings.ToLower(method[1:])
+	default:
+		return strconv.Quote(method)
+	}
+}
+
+// goPackage returns the package name of the Go file, the title of the API in lower case without separators,
+// e.g. "petstore" for Pet Store.
+func goPackage(doc *domain.OpenAPIDocument) string {
+	pkg := strings.ToLower(wordSeparators.ReplaceAllString(doc.Title, ""))
+	if pkg == "" || isDigit(pkg[0]) || token.IsKeyword(pkg) {
+		return "api" + pkg
+	}
+
+	return pkg
+}
+
+// goWords splits a name into words at separators and where a lower-case letter or digit precedes an upper-case one,
+// e.g. "pet_id" and "petId" into "pet" and "id" or "Id".
+func goWords(name string) []string {
+	words := []string{}
+
+	for _, part := range wordSeparators.Split(name, -1) {
+		start := 0
+		runes := []rune(part)
+
+		for i := 1; i < len(runes); i++ {
+			if unicode.IsUpper(runes[i]) && (unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1])) {
+				words = append(words, string(runes[start:i]))
+				start = i
+			}
+		}
+
+		if start < len(runes) {
+			words = append(words, string(runes[start:]))
+		}
+	}
+
+	return words
+}
+
+// goIdentifier turns a name into an exported Go identifier, e.g. "pet_id" or "petId" into "PetID",
+// prefixed with "T" when that would be empty or start with a digit.
+func goIdentifier(name string) string {
+	id := goName(name)
+	if id == "" || isDigit(id[0]) {
+		return "T" + id
+	}
+
+	return id
+}
+
+// goName joins the words of a name capitalised, keeping initialisms in upper case.
+func goName(name string) string {
+	var id strings.Builder
+
+	for _, word := range goWords(name) {
+		if slices.Contains(goInitialisms, strings.ToUpper(word)) {
+			id.WriteString(strings.ToUpper(word))
+		} else {
+			id.WriteString(strings.ToUpper(word[:1]) + word[1:])
+		}
+	}
+
+	return id.String()
+}
+
+// goVariableName turns a parameter name into an unexported Go variable name, e.g. "pet_id" into "petID",
+// suffixed with "Param" when it is a keyword or a variable of the client methods.
+func goVariableName(name string) string {
+	words := goWords(name)
+	if len(words) == 0 || isDigit(words[0][0]) {
+		return "param" + goName(name)
+	}
+
+	varName := strings.ToLower(words[0]) + goName(strings.Join(words[1:], " "))
+
+	if token.IsKeyword(varName) || slices.Contains(goClientParameterNames, varName) {
+		return varName + "Param"
+	}
+
+	return varName
+}
@@ -0,0 +1,550 @@
+package converters
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"io"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/GabrielNunesIT/openapi-converter/internal/domain"
+)
+
+const goTestFormat = "gotest"
+
+// goTestIgnoredHeaders are the header parameters OpenAPI ignores, as the request sets them otherwise.
+var goTestIgnoredHeaders = []string{"accept", "authorization", "content-type"} //nolint:gochecknoglobals // read-only
+
+// goTestHelpers is the part of the contract tests shared by the tests of the operations.
+const goTestHelpers = `// maxDepth bounds the nesting of the values checked against a schema, stopping at circular schemas.
+const maxDepth = 32
+
+var (
+	// baseURL is the URL of the API under test, which the paths of the operations are relative to.
+	baseURL = strings.TrimSuffix(os.Getenv("API_BASE_URL"), "/")
+
+	// components are the component schemas of the specification, which the other schemas reference by name.
+	components = sync.OnceValue(func() map[string]any {
+		var schemas map[string]any
+		if err := json.Unmarshal([]byte(componentsJSON), &schemas); err != nil {
+			panic(fmt.Sprintf("invalid component schemas: %v", err))
+		}
+
+		return schemas
+	})
+)
+
+// responses maps the documented status codes, ranges such as "2XX" and "default" to the JSON Schema of the body
+// of each media type, empty for bodies that are not checked.
+type responses map[string]map[string]string
+
+// call sends a request to the API under test, skipping the test when API_BASE_URL is not set.
+// The API_AUTHORIZATION environment variable sets the Authorization header of the request.
+func call(t *testing.T, method, urlPath string, query url.Values, header http.Header, contentType, body string) *http.Response {
+	t.Helper()
+
+	if baseURL == "" {
+		t.Skip("API_BASE_URL is not set")
+	}
+
+	endpoint := baseURL + urlPath
+	if len(query) > 0 {
+		endpoint += "?" + query.Encode()
+	}
+
+	var reader io.Reader
+	if body != "" {
+		reader = strings.NewReader(body)
+	}
+
+	req, err := http.NewRequestWithContext(t.Context(), method, endpoint, reader)
+	if err != nil {
+		t.Fatalf("failed to create request: %v", err)
+	}
+
+	for name, values := range header {
+		req.Header[name] = values
+	}
+
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	if authorization := os.Getenv("API_AUTHORIZATION"); authorization != "" {
+		req.Header.Set("Authorization", authorization)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("failed to send request: %v", err)
+	}
+
+	t.Cleanup(func() { _ = resp.Body.Close() })
+
+	return resp
+}
+
+// checkResponse fails the test when the status of a response is not documented, or its body does not match
+// the schema documented for its status and media type.
+func checkResponse(t *testing.T, resp *http.Response, documented responses) {
+	t.Helper()
+
+	status := strconv.Itoa(resp.StatusCode)
+
+	content, ok := documented[status]
+	if !ok {
+		content, ok = documented[status[:1]+"XX"]
+	}
+
+	if !ok {
+		content, ok = documented["default"]
+	}
+
+	if !ok {
+		t.Fatalf("status %d is not documented", resp.StatusCode)
+	}
+
+	if len(content) == 0 {
+		return
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+
+	schema, ok := content[mediaType]
+	for _, pattern := range slices.Sorted(maps.Keys(content)) {
+		if matched, _ := path.Match(pattern, mediaType); matched && !ok {
+			schema, ok = content[pattern], true
+		}
+	}
+
+	if !ok {
+		t.Fatalf("media type %q is not documented for status %d", mediaType, resp.StatusCode)
+	}
+
+	if schema == "" {
+		return
+	}
+
+	var body any
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatalf("failed to decode response body: %v", err)
+	}
+
+	var parsed map[string]any
+	if err := json.Unmarshal([]byte(schema), &parsed); err != nil {
+		t.Fatalf("invalid schema: %v", err)
+	}
+
+	for _, mismatch := range validate(parsed, body, "body", 0) {
+		t.Error(mismatch)
+	}
+}
+
+// validate checks a JSON value against a JSON Schema: its type, enumeration, required properties, properties
+// and items, and the schemas it is composed of. It describes the mismatches.
+func validate(schema map[string]any, value any, at string, depth int) []string {
+	if depth > maxDepth {
+		return nil
+	}
+
+	if ref, ok := schema["$ref"].(string); ok {
+		component, _ := components()[ref].(map[string]any)
+
+		return validate(component, value, at, depth+1)
+	}
+
+	var mismatches []string
+
+	if types, ok := schema["type"].([]any); ok && !slices.ContainsFunc(types, func(schemaType any) bool {
+		return hasType(schemaType, value)
+	}) {
+		return append(mismatches, fmt.Sprintf("%s: %v is not of type %v", at, value, types))
+	}
+
+	if enum, ok := schema["enum"].([]any); ok && !slices.ContainsFunc(enum, func(allowed any) bool {
+		return fmt.Sprint(allowed) == fmt.Sprint(value)
+	}) {
+		mismatches = append(mismatches, fmt.Sprintf("%s: %v is not one of %v", at, value, enum))
+	}
+
+	for _, part := range schemaList(schema["allOf"]) {
+		mismatches = append(mismatches, validate(part, value, at, depth+1)...)
+	}
+
+	for _, keyword := range []string{"anyOf", "oneOf"} {
+		variants := schemaList(schema[keyword])
+		if len(variants) > 0 && !slices.ContainsFunc(variants, func(variant map[string]any) bool {
+			return len(validate(variant, value, at, depth+1)) == 0
+		}) {
+			mismatches = append(mismatches, fmt.Sprintf("%s: matches none of the %s schemas", at, keyword))
+		}
+	}
+
+	switch typed := value.(type) {
+	case map[string]any:
+		required, _ := schema["required"].([]any)
+		for _, name := range required {
+			if _, ok := typed[fmt.Sprint(name)]; !ok {
+				mismatches = append(mismatches, fmt.Sprintf("%s: missing required property %q", at, name))
+			}
+		}
+
+		properties, _ := schema["properties"].(map[string]any)
+		for _, name := range slices.Sorted(maps.Keys(properties)) {
+			property, _ := properties[name].(map[string]any)
+			if propertyValue, ok := typed[name]; ok {
+				mismatches = append(mismatches, validate(property, propertyValue, at+"."+name, depth+1)...)
+			}
+		}
+	case []any:
+		if items, ok := schema["items"].(map[string]any); ok {
+			for i, item := range typed {
+				mismatches = append(mismatches, validate(items, item, fmt.Sprintf("%s[%d]", at, i), depth+1)...)
+			}
+		}
+	}
+
+	return mismatches
+}
+
+// hasType reports whether a JSON value is of a JSON Schema type.
+func hasType(schemaType, value any) bool {
+	switch typed := value.(type) {
+	case nil:
+		return schemaType == "null"
+	case bool:
+		return schemaType == "boolean"
+	case string:
+		return schemaType == "string"
+	case float64:
+		return schemaType == "number" || schemaType == "integer" && typed == math.Trunc(typed)
+	case []any:
+		return schemaType == "array"
+	case map[string]any:
+		return schemaType == "object"
+	default:
+		return false
+	}
+}
+
+// schemaList returns the schemas of a keyword such as allOf.
+func schemaList(value any) []map[string]any {
+	values, _ := value.([]any)
+
+	schemas := make([]map[string]any, 0, len(values))
+	for _, value := range values {
+		if schema, ok := value.(map[string]any); ok {
+			schemas = append(schemas, schema)
+		}
+	}
+
+	return schemas
+}
+`
+
+// goTestImports are the packages the contract tests use.
+var goTestImports = []string{ //nolint:gochecknoglobals // read-only
+	"encoding/json", "fmt", "io", "maps", "math", "mime", "net/http", "net/url", "os", "path", "slices", "strconv",
+	"strings", "sync", "testing",
+}
+
+// GoTestConverter converts OpenAPI documents to a Go test file of contract tests, one per operation, checking
+// that the API served at the URL of the API_BASE_URL environment variable answers with a documented status and,
+// for JSON, a body matching the schema documented for it. The requests carry the required parameters and the
+// request body, with the examples of the specification or values generated from their schemas: the tests are
+// a skeleton, whose values are meant to be replaced with ones the server under test knows.
+type GoTestConverter struct{}
+
+// NewGoTestConverter creates a new Go contract test converter.
+func NewGoTestConverter() *GoTestConverter {
+	return &GoTestConverter{}
+}
+
+func init() { //nolint:gochecknoinits // converters self-register with the default registry
+	Register(goTestFormat, func(opts Options) domain.Converter {
+		return NewGoTestConverter()
+	}, "go-test", "contract-tests")
+}
+
+// Format returns the output format name.
+func (c *GoTestConverter) Format() string {
+	return goTestFormat
+}
+
+// Convert transforms an OpenAPI document to a Go test file.
+func (c *GoTestConverter) Convert(doc *domain.OpenAPIDocument, output io.Writer) error {
+	schemas := make(map[string]any, len(doc.Components))
+	for name, schema := range doc.Components {
+		schemas[name] = jsonSchema(schema)
+	}
+
+	componentsJSON, err := json.MarshalIndent(schemas, "", "\t")
+	if err != nil {
+		return fmt.Errorf("failed to encode component schemas: %w", err)
+	}
+
+	pkg := goPackage(doc) + "_test"
+
+	var src strings.Builder
+
+	fmt.Fprintf(&src, "// Code generated by openapi-converter from %s, version %s.\n\n", doc.Title, doc.Version)
+	fmt.Fprintf(&src, "// Package %s checks that the %s API answers as its specification documents.\n", pkg, doc.Title)
+	src.WriteString("// The tests call the API served at the URL of the API_BASE_URL environment variable, and are skipped\n" +
+		"// when it is not set; the API_AUTHORIZATION environment variable sets the Authorization header.\n" +
+		"//\n" +
+		"// The parameters and bodies of the requests are the examples of the specification or values generated from\n" +
+		"// their schemas: replace them with values identifying resources that exist on the server under test.\n")
+	src.WriteString("package " + pkg + "\n\nimport (\n")
+
+	for _, pkg := range goTestImports {
+		src.WriteString("\t" + strconv.Quote(pkg) + "\n")
+	}
+
+	src.WriteString(")\n\n// componentsJSON holds the component schemas of the specification as JSON Schema.\n")
+	src.WriteString("const componentsJSON = " + goStringLiteral(string(componentsJSON)) + "\n\n" + goTestHelpers)
+
+	samples := newSampler(doc.Components)
+	names := make(map[string]int) // Test names taken, with the number of operations wanting each
+
+	for _, path := range doc.Paths {
+		for _, op := range path.Operations {
+			test, err := goTest(path.Path, op, samples, names)
+			if err != nil {
+				return err
+			}
+
+			src.WriteString("\n" + test)
+		}
+	}
+
+	formatted, err := format.Source([]byte(src.String()))
+	if err != nil {
+		return fmt.Errorf("failed to format Go source: %w", err)
+	}
+
+	if _, err := output.Write(formatted); err != nil {
+		return fmt.Errorf("failed to write Go source: %w", err)
+	}
+
+	return nil
+}
+
+// goTest returns the test of an operation, sending a request with its required parameters and body and checking
+// the response against the documented ones.
+func goTest(path string, op domain.Operation, samples sampler, names map[string]int) (string, error) {
+	name := "Test" + goName(op.OperationID)
+	if op.OperationID == "" {
+		name = "Test" + goName(strings.ToLower(op.Method)+" "+path)
+	}
+
+	if names[name]++; names[name] > 1 {
+		name = fmt.Sprintf("%s%d", name, names[name])
+	}
+
+	var t strings.Builder
+
+	fmt.Fprintf(&t, "// %s checks %s %s.\n", name, formatMethod(op.Method), path)
+	writeLineComment(&t, op.Summary, "")
+	fmt.Fprintf(&t, "func %s(t *testing.T) {\n", name)
+
+	query, header := "nil", "nil"
+	urlPath := path
+
+	for _, param := range op.Parameters {
+		if !param.Required && param.In != "path" {
+			continue
+		}
+
+		values := goTestValues(param, samples)
+
+		switch param.In {
+		case "path":
+			urlPath = strings.ReplaceAll(urlPath, "{"+param.Name+"}", url.PathEscape(strings.Join(values, ",")))
+		case "query":
+			if query == "nil" {
+				t.WriteString("\tquery := url.Values{}\n")
+
+				query = "query"
+			}
+
+			for _, value := range values {
+				fmt.Fprintf(&t, "\tquery.Add(%s, %s)\n", strconv.Quote(param.Name), strconv.Quote(value))
+			}
+		case "header", "cookie":
+			if param.In == "header" && slices.ContainsFunc(goTestIgnoredHeaders, func(name string) bool {
+				return strings.EqualFold(name, param.Name)
+			}) {
+				continue
+			}
+
+			if header == "nil" {
+				t.WriteString("\theader := http.Header{}\n")
+
+				header = "header"
+			}
+
+			key, value := param.Name, strings.Join(values, ",")
+			if param.In == "cookie" {
+				key, value = "Cookie", param.Name+"="+value
+			}
+
+			fmt.Fprintf(&t, "\theader.Add(%s, %s)\n", strconv.Quote(key), strconv.Quote(value))
+		}
+	}
+
+	contentType, body := "", ""
+
+	if op.RequestBody != nil && len(op.RequestBody.Content) > 0 {
+		contentType = preferredContentType(sortedContentTypes(op.RequestBody.Content))
+
+		if value := MediaExample(op.RequestBody.Content[contentType], "", samples.components); value != nil {
+			text, ok := value.(string)
+			if !ok || strings.Contains(contentType, "json") {
+				encoded, err := json.Marshal(value)
+				if err != nil {
+					return "", fmt.Errorf("failed to encode request body of %s %s: %w", formatMethod(op.Method), path, err)
+				}
+
+				text = string(encoded)
+			}
+
+			body = text
+		}
+	}
+
+	if query != "nil" || header != "nil" {
+		t.WriteString("\n")
+	}
+
+	fmt.Fprintf(&t, "\tresp := call(t, %s, %s, %s, %s, %s, %s)\n\n", goHTTPMethod(op.Method), strconv.Quote(urlPath),
+		query, header, strconv.Quote(contentType), goStringLiteral(body))
+	t.WriteString("\tcheckResponse(t, resp, responses{\n")
+
+	for _, resp := range sortedResponses(op.Responses) {
+		fmt.Fprintf(&t, "\t\t%s: {", strconv.Quote(resp.StatusCode))
+
+		for _, contentType := range sortedContentTypes(resp.Content) {
+			schema := ""
+
+			if strings.Contains(contentType, "json") {
+				encoded, err := json.Marshal(jsonSchema(resp.Content[contentType].Schema))
+				if err != nil {
+					return "", fmt.Errorf("failed to encode response schema of %s %s: %w", formatMethod(op.Method), path, err)
+				}
+
+				schema = string(encoded)
+			}
+
+			fmt.Fprintf(&t, "\n\t\t\t%s: %s,", strconv.Quote(contentType), goStringLiteral(schema))
+		}
+
+		if len(resp.Content) > 0 {
+			t.WriteString("\n\t\t")
+		}
+
+		t.WriteString("},\n")
+	}
+
+	t.WriteString("\t})\n}\n")
+
+	return t.String(), nil
+}
+
+// goTestValues returns the values a test sends for a parameter: its example or a value generated from its schema,
+// with an item per value for arrays.
+func goTestValues(param domain.Parameter, samples sampler) []string {
+	value := samples.named(param.Name, param.Schema, 0)
+
+	items, ok := value.([]any)
+	if !ok {
+		items = []any{value}
+	}
+
+	values := make([]string, 0, len(items))
+
+	for _, item := range items {
+		switch item.(type) {
+		case nil:
+			continue
+		case map[string]any, []any:
+			encoded, _ := json.Marshal(item)
+			values = append(values, string(encoded))
+		default:
+			values = append(values, fmt.Sprint(item))
+		}
+	}
+
+	return values
+}
+
+// jsonSchema returns a schema as JSON Schema, references naming the component schema they point to.
+// Only what the contract tests check is kept: types, enumerations, properties, items and composition.
+func jsonSchema(schema domain.Schema) map[string]any {
+	if schema.Ref != "" {
+		return map[string]any{"$ref": extractRefName(schema.Ref)}
+	}
+
+	result := map[string]any{}
+
+	if schema.Type != "" {
+		types := strings.Split(schema.Type, " | ")
+		if schema.Nullable {
+			types = append(types, "null")
+		}
+
+		result["type"] = types
+	}
+
+	if len(schema.Enum) > 0 {
+		enum := schema.Enum
+		if schema.Nullable {
+			enum = append(enum[:len(enum):len(enum)], nil)
+		}
+
+		result["enum"] = enum
+	}
+
+	if len(schema.Required) > 0 {
+		result["required"] = schema.Required
+	}
+
+	if len(schema.Properties) > 0 {
+		properties := make(map[string]any, len(schema.Properties))
+		for name, property := range schema.Properties {
+			properties[name] = jsonSchema(property)
+		}
+
+		result["properties"] = properties
+	}
+
+	if schema.Items != nil {
+		result["items"] = jsonSchema(*schema.Items)
+	}
+
+	for keyword, schemas := range map[string][]domain.Schema{"allOf": schema.AllOf, "anyOf": schema.AnyOf, "oneOf": schema.OneOf} {
+		if len(schemas) == 0 {
+			continue
+		}
+
+		list := make([]any, 0, len(schemas))
+		for _, part := range schemas {
+			list = append(list, jsonSchema(part))
+		}
+
+		result[keyword] = list
+	}
+
+	return result
+}
+
+// goStringLiteral returns a Go literal of a string, raw when that keeps it readable.
+func goStringLiteral(s string) string {
+	if strings.Contains(s, "`") || strings.Contains(s, "\r") || !strings.Contains(s, "\"") {
+		return strconv.Quote(s)
+	}
+
+	return "`" + s + "`"
+}
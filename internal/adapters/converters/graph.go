@@ -0,0 +1,271 @@
+package converters
+
+import (
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/GabrielNunesIT/openapi-converter/internal/domain"
+)
+
+const (
+	mermaidFormat = "mermaid"
+	dotFormat     = "dot"
+)
+
+// GraphConverter converts OpenAPI documents to a graph of the references between component schemas and of the
+// schemas each operation uses, as a Mermaid flowchart or a Graphviz DOT digraph, to show how the models are coupled.
+// Schemas are boxes and operations rounded nodes; edges are labelled with the properties, parameters, request
+// bodies and response status codes holding the references.
+type GraphConverter struct {
+	dot bool // Write Graphviz DOT rather than Mermaid
+}
+
+// NewMermaidConverter creates a new converter to a Mermaid flowchart of the schemas.
+func NewMermaidConverter() *GraphConverter {
+	return &GraphConverter{}
+}
+
+// NewDotConverter creates a new converter to a Graphviz DOT digraph of the schemas.
+func NewDotConverter() *GraphConverter {
+	return &GraphConverter{dot: true}
+}
+
+func init() { //nolint:gochecknoinits // converters self-register with the default registry
+	Register(mermaidFormat, func(opts Options) domain.Converter {
+		return NewMermaidConverter()
+	}, "mmd")
+	Register(dotFormat, func(opts Options) domain.Converter {
+		return NewDotConverter()
+	}, "graphviz")
+}
+
+// Format returns the output format name.
+func (c *GraphConverter) Format() string {
+	if c.dot {
+		return dotFormat
+	}
+
+	return mermaidFormat
+}
+
+// Convert transforms an OpenAPI document to a graph of its schemas.
+func (c *GraphConverter) Convert(doc *domain.OpenAPIDocument, output io.Writer) error {
+	graph := newSchemaGraph(doc)
+
+	text := fmt.Sprintf("%%%% %s, version %s\n", doc.Title, doc.Version) + graph.mermaid()
+	if c.dot {
+		text = graph.dot(fmt.Sprintf("%s, version %s", doc.Title, doc.Version))
+	}
+
+	if _, err := io.WriteString(output, text); err != nil {
+		return fmt.Errorf("failed to write %s graph: %w", c.Format(), err)
+	}
+
+	return nil
+}
+
+// graphNode is a component schema or an operation of a schema graph.
+type graphNode struct {
+	ID        string // Identifier safe in both Mermaid and DOT, such as "s0" or "op3"
+	Label     string
+	Operation bool
+}
+
+// graphEdge is a reference from a schema or an operation to a component schema.
+type graphEdge struct {
+	From   string
+	To     string
+	Labels []string // Properties, parameters, request bodies or status codes holding the reference
+}
+
+// schemaGraph is the graph of the references between the component schemas of a document and from its operations.
+type schemaGraph struct {
+	nodes   []graphNode
+	edges   []graphEdge
+	schemas map[string]string // Component schema name to node ID
+	index   map[[2]string]int // From and to node IDs to the position of their edge
+}
+
+// newSchemaGraph builds the graph of a document: every component schema, in name order, then the operations and
+// webhooks referring to at least one of them, in document order.
+func newSchemaGraph(doc *domain.OpenAPIDocument) *schemaGraph {
+	g := &schemaGraph{schemas: make(map[string]string), index: make(map[[2]string]int)}
+
+	for i, name := range sortedComponentNames(doc.Components) {
+		g.schemas[name] = "s" + strconv.Itoa(i)
+		g.nodes = append(g.nodes, graphNode{ID: g.schemas[name], Label: name})
+	}
+
+	for _, name := range sortedComponentNames(doc.Components) {
+		g.addSchemaEdges(g.schemas[name], doc.Components[name], "")
+	}
+
+	endpoints := []endpointRef{}
+
+	for _, path := range doc.Paths {
+		for _, op := range path.Operations {
+			endpoints = append(endpoints, endpointRef{path: path.Path, method: op.Method, operation: op})
+		}
+	}
+
+	webhooks := webhookRefs(doc)
+
+	for i, ep := range append(endpoints, webhooks...) {
+		label := strings.ToUpper(ep.method) + " " + ep.path
+		if i >= len(endpoints) {
+			label += " (webhook)"
+		}
+
+		g.addOperation("op"+strconv.Itoa(i), label, ep.operation)
+	}
+
+	return g
+}
+
+// addOperation adds an operation with the edges to the schemas of its parameters, request body and responses,
+// leaving out an operation using no component schema.
+func (g *schemaGraph) addOperation(id, label string, op domain.Operation) {
+	edges := len(g.edges)
+
+	for _, param := range op.Parameters {
+		g.addSchemaEdges(id, param.Schema, param.Name)
+	}
+
+	if op.RequestBody != nil {
+		for _, contentType := range sortedContentTypes(op.RequestBody.Content) {
+			g.addSchemaEdges(id, op.RequestBody.Content[contentType].Schema, "request")
+		}
+	}
+
+	for _, response := range sortedResponses(op.Responses) {
+		for _, contentType := range sortedContentTypes(response.Content) {
+			g.addSchemaEdges(id, response.Content[contentType].Schema, response.StatusCode)
+		}
+	}
+
+	if len(g.edges) > edges {
+		g.nodes = append(g.nodes, graphNode{ID: id, Label: label, Operation: true})
+	}
+}
+
+// addSchemaEdges adds an edge from a node to every component schema a schema refers to, without following the
+// references, labelled with the path of the property holding it, such as "owner.address" or "tags[]".
+func (g *schemaGraph) addSchemaEdges(from string, schema domain.Schema, label string) {
+	if schema.Ref != "" {
+		if to, ok := g.schemas[extractRefName(schema.Ref)]; ok {
+			g.addEdge(from, to, label)
+		}
+
+		return
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(schema.Properties)) {
+		g.addSchemaEdges(from, schema.Properties[name], strings.TrimPrefix(label+"."+name, "."))
+	}
+
+	if schema.Items != nil {
+		g.addSchemaEdges(from, *schema.Items, label+"[]")
+	}
+
+	// Members of a composed schema are labelled with the keyword composing them at the top of a schema
+	composed := []struct {
+		keyword string
+		members []domain.Schema
+	}{{"allOf", schema.AllOf}, {"anyOf", schema.AnyOf}, {"oneOf", schema.OneOf}}
+
+	for _, composition := range composed {
+		memberLabel := label
+		if memberLabel == "" {
+			memberLabel = composition.keyword
+		}
+
+		for _, member := range composition.members {
+			g.addSchemaEdges(from, member, memberLabel)
+		}
+	}
+}
+
+// addEdge adds a labelled edge between two nodes, adding the label to the edge already joining them if any.
+func (g *schemaGraph) addEdge(from, to, label string) {
+	i, ok := g.index[[2]string{from, to}]
+	if !ok {
+		i = len(g.edges)
+		g.index[[2]string{from, to}] = i
+		g.edges = append(g.edges, graphEdge{From: from, To: to})
+	}
+
+	if label != "" && !slices.Contains(g.edges[i].Labels, label) {
+		g.edges[i].Labels = append(g.edges[i].Labels, label)
+	}
+}
+
+// mermaid renders the graph as a Mermaid flowchart, from left to right.
+func (g *schemaGraph) mermaid() string {
+	var out strings.Builder
+
+	out.WriteString("flowchart LR\n")
+
+	for _, node := range g.nodes {
+		if node.Operation {
+			fmt.Fprintf(&out, "  %s([\"%s\"])\n", node.ID, mermaidText(node.Label))
+		} else {
+			fmt.Fprintf(&out, "  %s[\"%s\"]\n", node.ID, mermaidText(node.Label))
+		}
+	}
+
+	for _, edge := range g.edges {
+		if len(edge.Labels) == 0 {
+			fmt.Fprintf(&out, "  %s --> %s\n", edge.From, edge.To)
+		} else {
+			fmt.Fprintf(&out, "  %s -->|\"%s\"| %s\n", edge.From, mermaidText(strings.Join(edge.Labels, ", ")), edge.To)
+		}
+	}
+
+	return out.String()
+}
+
+// dot renders the graph as a Graphviz DOT digraph named title, from left to right.
+func (g *schemaGraph) dot(title string) string {
+	var out strings.Builder
+
+	fmt.Fprintf(&out, "digraph %s {\n", dotText(title))
+	out.WriteString("  rankdir=LR;\n  node [shape=box];\n\n")
+
+	for _, node := range g.nodes {
+		if node.Operation {
+			fmt.Fprintf(&out, "  %s [label=%s, shape=ellipse];\n", node.ID, dotText(node.Label))
+		} else {
+			fmt.Fprintf(&out, "  %s [label=%s];\n", node.ID, dotText(node.Label))
+		}
+	}
+
+	if len(g.edges) > 0 {
+		out.WriteString("\n")
+	}
+
+	for _, edge := range g.edges {
+		if len(edge.Labels) == 0 {
+			fmt.Fprintf(&out, "  %s -> %s;\n", edge.From, edge.To)
+		} else {
+			fmt.Fprintf(&out, "  %s -> %s [label=%s];\n", edge.From, edge.To, dotText(strings.Join(edge.Labels, ", ")))
+		}
+	}
+
+	out.WriteString("}\n")
+
+	return out.String()
+}
+
+// mermaidText escapes text for a quoted Mermaid label, where quotes are written as entity codes.
+func mermaidText(text string) string {
+	return strings.ReplaceAll(text, `"`, "#quot;")
+}
+
+// dotText returns text as a quoted DOT identifier.
+func dotText(text string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(text) + `"`
+}
@@ -0,0 +1,384 @@
+package converters
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/GabrielNunesIT/openapi-converter/internal/domain"
+)
+
+const (
+	graphqlFormat = "graphql"
+
+	// graphqlJSON is the custom scalar standing for the values GraphQL types cannot describe,
+	// such as free-form objects or values of several types.
+	graphqlJSON = "JSON"
+)
+
+// GraphQLConverter converts OpenAPI documents to a GraphQL schema (SDL) sketching a GraphQL façade over the API:
+// component schemas become types, GET operations fields of the Query type and the other operations
+// fields of the Mutation type. Webhooks and callbacks, which the API sends, are left out.
+type GraphQLConverter struct{}
+
+// NewGraphQLConverter creates a new GraphQL SDL converter.
+func NewGraphQLConverter() *GraphQLConverter {
+	return &GraphQLConverter{}
+}
+
+func init() { //nolint:gochecknoinits // converters self-register with the default registry
+	Register(graphqlFormat, func(opts Options) domain.Converter {
+		return NewGraphQLConverter()
+	}, "sdl")
+}
+
+// Format returns the output format name.
+func (c *GraphQLConverter) Format() string {
+	return graphqlFormat
+}
+
+// Convert transforms an OpenAPI document to a GraphQL schema.
+func (c *GraphQLConverter) Convert(doc *domain.OpenAPIDocument, output io.Writer) error {
+	s := &graphqlSchema{components: doc.Components, types: make(map[string]string)}
+
+	// Every component schema, whether used by an operation or not
+	for _, name := range sortedComponentNames(doc.Components) {
+		s.typeOf(doc.Components[name], name, false)
+	}
+
+	queries := []string{}
+	mutations := []string{}
+	names := make(map[string]int) // Operation field names taken, with the number of operations wanting each
+
+	for _, path := range doc.Paths {
+		for _, op := range path.Operations {
+			field := s.operationField(path.Path, op, names)
+			if strings.EqualFold(op.Method, "get") {
+				queries = append(queries, field)
+			} else {
+				mutations = append(mutations, field)
+			}
+		}
+	}
+
+	// GraphQL requires a Query type with at least one field
+	if len(queries) == 0 {
+		queries = append(queries, "  \"\"\"\n  Placeholder, as the API has no GET operation.\n  \"\"\"\n  _empty: Boolean\n")
+	}
+
+	var sdl strings.Builder
+
+	fmt.Fprintf(&sdl, "# %s, version %s\n", doc.Title, doc.Version)
+	sdl.WriteString("# GraphQL schema generated from the OpenAPI specification: GET operations are queries, others mutations.\n\n")
+
+	sdl.WriteString("type Query {\n" + strings.Join(queries, "\n") + "}\n")
+
+	if len(mutations) > 0 {
+		sdl.WriteString("\ntype Mutation {\n" + strings.Join(mutations, "\n") + "}\n")
+	}
+
+	typeNames := make([]string, 0, len(s.types))
+	for name := range s.types {
+		typeNames = append(typeNames, name)
+	}
+	sort.Strings(typeNames)
+
+	for _, name := range typeNames {
+		sdl.WriteString("\n" + s.types[name])
+	}
+
+	if s.json {
+		sdl.WriteString("\n\"\"\"\nAny JSON value, for the values the specification does not describe with a single type.\n\"\"\"\n")
+		sdl.WriteString("scalar " + graphqlJSON + "\n")
+	}
+
+	if _, err := io.WriteString(output, sdl.String()); err != nil {
+		return fmt.Errorf("failed to write GraphQL schema: %w", err)
+	}
+
+	return nil
+}
+
+// graphqlSchema collects the type definitions of a GraphQL schema while schemas are mapped to types.
+type graphqlSchema struct {
+	components map[string]domain.Schema
+	types      map[string]string // SDL definition by type name, empty while the fields of a type are mapped
+	json       bool              // The JSON scalar is used
+}
+
+// operationField returns the SDL field standing for an operation, named after its operationId, or its method
+// and path without one. Path and query parameters become arguments and the request body an "input" argument;
+// the field returns the JSON body of the first successful response, or Boolean when there is none.
+func (s *graphqlSchema) operationField(path string, op domain.Operation, names map[string]int) string {
+	name := graphqlFieldName(op.OperationID)
+	if op.OperationID == "" {
+		name = strings.ToLower(op.Method) + pascalCase(path)
+	}
+
+	if names[name]++; names[name] > 1 {
+		name = fmt.Sprintf("%s%d", name, names[name])
+	}
+
+	args := []string{}
+
+	for _, param := range op.Parameters {
+		if param.In != "path" && param.In != "query" {
+			continue
+		}
+
+		argType := s.typeOf(param.Schema, name+" "+param.Name, true) + nonNull(param.Required)
+		args = append(args, graphqlFieldName(param.Name)+": "+argType)
+	}
+
+	if body := op.RequestBody; body != nil && len(body.Content) > 0 {
+		media := body.Content[preferredContentType(sortedContentTypes(body.Content))]
+		args = append(args, "input: "+s.typeOf(media.Schema, name, true)+nonNull(body.Required))
+	}
+
+	returnType := "Boolean"
+
+	for _, resp := range sortedResponses(op.Responses) {
+		if strings.HasPrefix(resp.StatusCode, "2") && len(resp.Content) > 0 {
+			media := resp.Content[preferredContentType(sortedContentTypes(resp.Content))]
+			returnType = s.typeOf(media.Schema, name+" response", false)
+
+			break
+		}
+	}
+
+	var field strings.Builder
+
+	description := strings.TrimSpace(strings.Join([]string{op.Summary, op.Description}, "\n\n"))
+	writeGraphQLDescription(&field, strings.TrimSpace(description+"\n\n"+formatMethod(op.Method)+" "+path), "  ")
+
+	field.WriteString("  " + name)
+
+	if len(args) > 0 {
+		field.WriteString("(" + strings.Join(args, ", ") + ")")
+	}
+
+	field.WriteString(": " + returnType + deprecatedDirective(op.Deprecated) + "\n")
+
+	return field.String()
+}
+
+// typeOf returns the GraphQL type of a schema, defining the object, input, enum and union types it needs.
+// Inline objects and enums are named after name, such as the type and property holding them, and component
+// schemas after the component. Input objects, used by arguments, are suffixed with "Input".
+func (s *graphqlSchema) typeOf(schema domain.Schema, name string, input bool) string {
+	if schema.Ref != "" {
+		refName := extractRefName(schema.Ref)
+		if component, ok := s.components[refName]; ok {
+			name = refName
+			schema = component
+		}
+	}
+
+	schema = flattenAllOf(schema, s.components)
+
+	if _, variants := schemaVariants(schema); len(variants) > 0 {
+		if union := s.unionType(name, variants, input); union != "" {
+			return union
+		}
+
+		return s.jsonType()
+	}
+
+	switch {
+	case len(schema.Enum) > 0 && isStringEnum(schema.Enum):
+		return s.enumType(name, schema)
+	case schema.Type == "array":
+		if schema.Items == nil {
+			return "[" + s.jsonType() + "]"
+		}
+
+		return "[" + s.typeOf(*schema.Items, name+" item", input) + "]"
+	case len(schema.Properties) > 0:
+		return s.objectType(name, schema, input)
+	case schema.Type == "string":
+		return "String"
+	case schema.Type == "integer":
+		return "Int"
+	case schema.Type == "number":
+		return "Float"
+	case schema.Type == "boolean":
+		return "Boolean"
+	default:
+		return s.jsonType()
+	}
+}
+
+// objectType defines the object, or input object, type of a schema with properties.
+func (s *graphqlSchema) objectType(name string, schema domain.Schema, input bool) string {
+	typeName, keyword := pascalCase(name), "type"
+	if input {
+		typeName, keyword = typeName+"Input", "input"
+	}
+
+	if _, defined := s.types[typeName]; defined {
+		return typeName
+	}
+
+	s.types[typeName] = "" // Reserved while the properties are mapped, for schemas referencing themselves
+
+	propNames := make([]string, 0, len(schema.Properties))
+	for propName := range schema.Properties {
+		propNames = append(propNames, propName)
+	}
+	sort.Strings(propNames)
+
+	var def strings.Builder
+
+	writeGraphQLDescription(&def, schema.Description, "")
+	def.WriteString(keyword + " " + typeName + " {\n")
+
+	for _, propName := range propNames {
+		prop := schema.Properties[propName]
+		required := slices.Contains(schema.Required, propName) && !prop.Nullable
+		fieldType := s.typeOf(prop, name+" "+propName, input) + nonNull(required)
+
+		writeGraphQLDescription(&def, prop.Description, "  ")
+		def.WriteString("  " + graphqlFieldName(propName) + ": " + fieldType)
+
+		// Input fields are left without directives, which older servers do not accept on them
+		if !input {
+			def.WriteString(deprecatedDirective(prop.Deprecated))
+		}
+
+		def.WriteString("\n")
+	}
+
+	def.WriteString("}\n")
+	s.types[typeName] = def.String()
+
+	return typeName
+}
+
+// enumType defines the enum type of a schema allowing a list of strings.
+func (s *graphqlSchema) enumType(name string, schema domain.Schema) string {
+	typeName := pascalCase(name)
+	if _, defined := s.types[typeName]; defined {
+		return typeName
+	}
+
+	var def strings.Builder
+
+	writeGraphQLDescription(&def, schema.Description, "")
+	def.WriteString("enum " + typeName + " {\n")
+
+	listed := make(map[string]struct{}, len(schema.Enum))
+
+	for _, value := range schema.Enum {
+		enumValue := graphqlEnumValue(fmt.Sprint(value))
+		if _, ok := listed[enumValue]; !ok {
+			listed[enumValue] = struct{}{}
+			def.WriteString("  " + enumValue + "\n")
+		}
+	}
+
+	def.WriteString("}\n")
+	s.types[typeName] = def.String()
+
+	return typeName
+}
+
+// unionType defines the union type of oneOf or anyOf variants, which GraphQL only allows between object types
+// and not as input. It returns an empty name when the variants cannot form a union.
+func (s *graphqlSchema) unionType(name string, variants []domain.Schema, input bool) string {
+	if input {
+		return ""
+	}
+
+	members := make([]string, 0, len(variants))
+
+	for _, variant := range variants {
+		component, ok := s.components[extractRefName(variant.Ref)]
+		if variant.Ref == "" || !ok || len(flattenAllOf(component, s.components).Properties) == 0 {
+			return ""
+		}
+
+		members = append(members, s.typeOf(variant, name, false))
+	}
+
+	typeName := pascalCase(name)
+	if _, defined := s.types[typeName]; !defined {
+		s.types[typeName] = "union " + typeName + " = " + strings.Join(members, " | ") + "\n"
+	}
+
+	return typeName
+}
+
+// jsonType returns the JSON scalar, declaring it.
+func (s *graphqlSchema) jsonType() string {
+	s.json = true
+
+	return graphqlJSON
+}
+
+// isStringEnum reports whether every allowed value is a string, as GraphQL enums only name values.
+func isStringEnum(values []any) bool {
+	for _, value := range values {
+		if _, ok := value.(string); !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+// nonNull returns the non-null marker of a required value.
+func nonNull(required bool) string {
+	if required {
+		return "!"
+	}
+
+	return ""
+}
+
+// deprecatedDirective returns the directive marking a deprecated field.
+func deprecatedDirective(deprecated bool) string {
+	if deprecated {
+		return " @deprecated"
+	}
+
+	return ""
+}
+
+// graphqlFieldName turns a property, parameter or operationId into a GraphQL field or argument name,
+// replacing the characters GraphQL names cannot hold with underscores.
+func graphqlFieldName(name string) string {
+	name = invalidIdentifierChars.ReplaceAllString(name, "_")
+	if name == "" || isDigit(name[0]) {
+		return "_" + name
+	}
+
+	return name
+}
+
+// graphqlEnumValue turns an allowed string into a GraphQL enum value, which cannot be true, false or null.
+func graphqlEnumValue(value string) string {
+	switch value = graphqlFieldName(value); value {
+	case "true", "false", "null":
+		return "_" + value
+	default:
+		return value
+	}
+}
+
+// writeGraphQLDescription writes a description as an SDL block string, each line indented by indent.
+func writeGraphQLDescription(b *strings.Builder, description, indent string) {
+	description = strings.TrimSpace(description)
+	if description == "" {
+		return
+	}
+
+	b.WriteString(indent + "\"\"\"\n")
+
+	for line := range strings.SplitSeq(strings.ReplaceAll(description, `"""`, `\"""`), "\n") {
+		b.WriteString(strings.TrimRight(indent+line, " ") + "\n")
+	}
+
+	b.WriteString(indent + "\"\"\"\n")
+}
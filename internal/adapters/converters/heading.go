@@ -0,0 +1,56 @@
+package converters
+
+import (
+	"strings"
+)
+
+// maxHeadingLevel is the deepest heading level of the formats, h6.
+const maxHeadingLevel = 6
+
+// headingLevels shifts the levels of the headings of a document and caps them, for documents embedded in pages
+// with headings of their own or rendered where the deepest levels are too small to read.
+type headingLevels struct {
+	offset int // Levels added to every heading, e.g. 1 to start at h2
+	max    int // Deepest level, deeper headings being raised to it, 0 for h6
+}
+
+// level returns the level a heading of the given level is rendered at, between h1 and the deepest level.
+func (h headingLevels) level(level int) int {
+	deepest := h.max
+	if deepest <= 0 || deepest > maxHeadingLevel {
+		deepest = maxHeadingLevel
+	}
+
+	return min(max(level+h.offset, 1), deepest)
+}
+
+// shiftMarkdownHeadings renders the ATX headings of Markdown, such as "## Servers", at the levels of h, outside
+// of fenced code blocks.
+func shiftMarkdownHeadings(markdown string, h headingLevels) string {
+	if h == (headingLevels{}) {
+		return markdown
+	}
+
+	lines := strings.Split(markdown, "\n")
+	fence := "" // Marker of the fenced code block the line is in, if any
+
+	for i, line := range lines {
+		trimmed := strings.TrimLeft(line, " ")
+
+		switch {
+		case fence != "":
+			if strings.HasPrefix(trimmed, fence) {
+				fence = ""
+			}
+		case strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~"):
+			fence = trimmed[:3]
+		case strings.HasPrefix(line, "#"):
+			text := strings.TrimLeft(line, "#")
+			if level := len(line) - len(text); level <= maxHeadingLevel && (text == "" || text[0] == ' ') {
+				lines[i] = strings.Repeat("#", h.level(level)) + text
+			}
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
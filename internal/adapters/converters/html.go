@@ -0,0 +1,477 @@
+package converters
+
+import (
+	"fmt"
+	"html"
+	htmltemplate "html/template"
+	"io"
+	"strings"
+
+	"github.com/GabrielNunesIT/openapi-converter/internal/domain"
+)
+
+const htmlFormat = "html"
+
+// htmlStyles is the stylesheet embedded into every generated page so the output stays self-contained.
+const htmlStyles = `
+body { font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; color: #222; margin: 0; background: #fafafa; }
+main { max-width: 1100px; margin: 0 auto; padding: 24px 32px 64px; background: #fff; }
+h1 { margin-bottom: 4px; }
+h2 { border-bottom: 1px solid #ddd; padding-bottom: 6px; margin-top: 40px; }
+h3 { background: #f0f0f0; padding: 8px 12px; border-radius: 4px; }
+p.version { color: #666; margin-top: 0; }
+.text { white-space: pre-line; }
+code, pre { font-family: SFMono-Regular, Consolas, "Liberation Mono", Menlo, monospace; font-size: 13px; }
+pre { background: #f6f8fa; border: 1px solid #e1e4e8; border-radius: 4px; padding: 12px; overflow-x: auto; }
+table { border-collapse: collapse; width: 100%; margin: 8px 0 16px; font-size: 14px; }
+th, td { border: 1px solid #ddd; padding: 6px 8px; text-align: left; vertical-align: top; }
+th { background: #f5f5f5; }
+details.endpoint { border: 1px solid #ddd; border-radius: 4px; margin: 12px 0; }
+details.endpoint > summary { cursor: pointer; padding: 8px 12px; font-weight: 600; }
+details.endpoint[open] > summary { border-bottom: 1px solid #ddd; }
+.endpoint-body { padding: 4px 16px 12px; }
+.method { display: inline-block; min-width: 64px; text-align: center; color: #fff; border-radius: 3px; padding: 2px 6px; margin-right: 8px; background: #808080; }
+.method-get { background: #61affe; }
+.method-post { background: #49cc90; }
+.method-put { background: #fca130; }
+.method-delete { background: #f93e3e; }
+.method-patch { background: #50e3c2; }
+.method-head { background: #9061f9; }
+.op-summary { font-weight: normal; color: #555; margin-left: 8px; }
+.status-2 { color: #008000; }
+.status-4 { color: #c86400; }
+.status-5 { color: #b40000; }
+.required { color: #b40000; font-style: italic; }
+.breaking { display: inline-block; background: #ffebe6; color: #bf2600; border-radius: 3px; padding: 1px 6px; font-size: 12px; font-weight: 600; }
+.deprecated { display: inline-block; background: #fff0b3; color: #7a5d00; border-radius: 3px; padding: 1px 6px; font-size: 12px; font-weight: 600; }
+nav.toc ul { margin: 4px 0; padding-left: 20px; }
+nav.toc a { color: #0366d6; text-decoration: none; }
+.auth { display: inline-block; background: #eae6ff; color: #403294; border-radius: 3px; padding: 1px 6px; font-size: 12px; font-weight: 600; }
+`
+
+// htmlMermaidScript loads Mermaid, which draws the diagrams of the pre elements of the "mermaid" class.
+const htmlMermaidScript = `<script type="module">
+import mermaid from "https://cdn.jsdelivr.net/npm/mermaid@11/dist/mermaid.esm.min.mjs";
+mermaid.initialize({ startOnLoad: true });
+</script>
+`
+
+// HTMLConverter converts OpenAPI documents to a single self-contained HTML page.
+// Headings, operations and schemas are rendered through html/template templates.
+type HTMLConverter struct {
+	templateDir string   // Directory of templates replacing the embedded defaults
+	extensions  []string // Vendor extensions to render, as "x-name" or "x-name=Label"
+	appendix    bool     // Render component schemas once in an appendix rather than under every tag
+	stable      bool     // Anchor operations at their operationId, see documentTOC
+	schemaRefs  string   // Rendering of the schemas bodies refer to, among SchemaRefModes
+	directional bool     // Leave read-only properties out of request fields and write-only ones out of response fields
+	sequences   bool     // Render a Mermaid sequence diagram of the links and callbacks of each operation
+	locale      Locale   // Translations of the fixed strings, nil for English
+}
+
+// HTMLOptions configures an HTMLConverter. The fields tagged option can also be set by name, e.g. with
+// --option html.schema-refs=both, see RegisterFormat.
+type HTMLOptions struct {
+	// TemplateDir is a directory of *.tmpl files, which may redefine the "heading", "tag", "operation" and "schema"
+	// templates.
+	TemplateDir string `option:"template-dir"`
+
+	// Extensions are the vendor extensions of the API, its operations and schemas to render, each given as
+	// "x-name" or "x-name=Label".
+	Extensions []string `option:"extensions"`
+
+	// SchemaAppendix renders every component schema once in a "Schemas" appendix, the "Schemas Used" section of
+	// each tag linking to it.
+	SchemaAppendix bool `option:"schema-appendix"`
+
+	// StableAnchors anchors the first occurrence of each operation with an operationId at "op-" followed by the
+	// operationId, e.g. "op-listPets", whatever else the document holds, so that other documents can link to it.
+	StableAnchors bool `option:"stable-anchors"`
+
+	// SchemaRefs sets how request and response bodies render the component schemas they refer to, among
+	// SchemaRefModes: listing the fields of request bodies, linking schema names to the schemas appendix, which is
+	// then rendered, or both.
+	SchemaRefs string `option:"schema-refs"`
+
+	// Directional leaves the read-only properties out of the request body field tables and the write-only
+	// properties out of the response field tables, as they are not sent that way.
+	Directional bool `option:"directional-fields"`
+
+	// Sequences renders a "Sequence" section under each operation with links or callbacks, holding a Mermaid
+	// sequence diagram of the requests the client sends and the callbacks it receives. Pages with diagrams load
+	// Mermaid from htmlMermaidScript to draw them, and so are no longer self-contained.
+	Sequences bool `option:"sequence-diagrams"`
+
+	// Locale translates the section headings and other fixed strings, which the templates translate with the "t"
+	// function, e.g. {{t "Parameters"}}; nil for English.
+	Locale Locale
+}
+
+// NewHTMLConverter creates a new HTML converter.
+func NewHTMLConverter(opts HTMLOptions) *HTMLConverter {
+	return &HTMLConverter{
+		templateDir: opts.TemplateDir,
+		extensions:  opts.Extensions,
+		appendix:    opts.SchemaAppendix,
+		stable:      opts.StableAnchors,
+		schemaRefs:  opts.SchemaRefs,
+		directional: opts.Directional,
+		sequences:   opts.Sequences,
+		locale:      opts.Locale,
+	}
+}
+
+func init() { //nolint:gochecknoinits // converters self-register with the default registry
+	RegisterFormat(htmlFormat, func(opts Options) HTMLOptions {
+		return HTMLOptions{
+			TemplateDir:    opts.TemplateDir,
+			Extensions:     opts.Extensions,
+			SchemaAppendix: opts.SchemaAppendix,
+			StableAnchors:  opts.StableAnchors,
+			SchemaRefs:     opts.SchemaRefs,
+			Directional:    opts.Directional,
+			Sequences:      opts.Sequences,
+			Locale:         opts.Locale,
+		}
+	}, func(opts HTMLOptions) domain.Converter { return NewHTMLConverter(opts) })
+}
+
+// Format returns the output format name.
+func (c *HTMLConverter) Format() string {
+	return htmlFormat
+}
+
+// Convert transforms an OpenAPI document to HTML format.
+func (c *HTMLConverter) Convert(doc *domain.OpenAPIDocument, output io.Writer) error {
+	tmpl, err := loadHTMLTemplates(htmlFormat, c.templateDir, htmlFuncs(c.locale))
+	if err != nil {
+		return err
+	}
+
+	extensions, err := extensionFieldsFor(c.extensions)
+	if err != nil {
+		return err
+	}
+
+	refs, err := newSchemaRefRendering(c.schemaRefs)
+	if err != nil {
+		return err
+	}
+
+	appendix := c.appendix || refs.links
+
+	var page strings.Builder
+
+	w := &templateWriter{out: &page, tmpl: tmpl, locale: c.locale}
+
+	page.WriteString("<!DOCTYPE html>\n<html lang=\"en\">\n<head>\n<meta charset=\"utf-8\">\n")
+	page.WriteString("<meta name=\"viewport\" content=\"width=device-width, initial-scale=1\">\n")
+	page.WriteString(fmt.Sprintf("<title>%s</title>\n", html.EscapeString(doc.Title)))
+	page.WriteString(fmt.Sprintf("<style>%s</style>\n", htmlStyles))
+	page.WriteString("</head>\n<body>\n<main>\n")
+
+	// Title
+	w.heading(1, doc.Title)
+	page.WriteString(fmt.Sprintf("<p class=\"version\">Version %s</p>\n", html.EscapeString(doc.Version)))
+
+	for _, extension := range selectExtensions(extensions, doc.Extensions) {
+		page.WriteString(fmt.Sprintf("<p>%s: %s</p>\n", html.EscapeString(extension.Label), html.EscapeString(extension.Value)))
+	}
+
+	if preamble := strings.TrimSpace(doc.Preamble); preamble != "" {
+		page.WriteString(htmlText(preamble))
+	}
+
+	// Table of contents
+	toc := newDocumentTOC(doc, c.stable)
+	if appendix {
+		toc.addSchemaAppendix(doc)
+	}
+
+	toc.localize(c.locale)
+
+	if len(toc.Entries) > 0 {
+		page.WriteString("<nav class=\"toc\">\n")
+		w.heading(2, c.locale.T("Contents"))
+		writeHTMLTOC(&page, toc.Entries)
+		page.WriteString("</nav>\n")
+	}
+
+	// About
+	if about := aboutMarkdown(doc); about != "" {
+		w.heading(2, c.locale.T("About"))
+		page.WriteString(htmlText(about))
+	}
+
+	// Description
+	if description := withExternalDocs(doc.Description, doc.ExternalDocs); description != "" {
+		w.heading(2, c.locale.T("Description"))
+		page.WriteString(htmlText(description))
+	}
+
+	// Servers
+	if len(doc.Servers) > 0 {
+		w.heading(2, c.locale.T("Servers"))
+		page.WriteString("<ul>\n")
+
+		for _, server := range doc.Servers {
+			page.WriteString(fmt.Sprintf("<li><code>%s</code>", html.EscapeString(server.URL)))
+
+			if server.Description != "" {
+				page.WriteString(" - " + html.EscapeString(server.Description))
+			}
+
+			page.WriteString("</li>\n")
+		}
+
+		page.WriteString("</ul>\n")
+	}
+
+	// Authentication
+	if len(doc.SecuritySchemes) > 0 {
+		w.heading(2, c.locale.T("Authentication"))
+		c.writeSecuritySchemes(w, doc)
+	}
+
+	// Endpoints grouped by tags
+	if len(doc.Paths) > 0 {
+		w.heading(2, c.locale.T("API Endpoints"))
+
+		tagPaths := groupPathsByTag(doc)
+		for _, tag := range orderedTags(doc, tagPaths) {
+			page.WriteString("<section class=\"tag\">\n")
+			w.tag(3, tagInfo(doc, tag), toc.tagAnchor(tag))
+
+			// Add components used by this tag's endpoints
+			tagComponents := collectTagComponents(tagPaths[tag])
+			if len(tagComponents) > 0 {
+				if appendix {
+					w.heading(4, c.locale.T("Schemas Used"))
+					writeHTMLTOC(&page, toc.schemaLinks(tagComponents))
+				} else {
+					c.writeTagComponents(w, tagComponents, doc.Components, extensions)
+				}
+			}
+
+			// Add endpoints
+			for _, ep := range tagPaths[tag] {
+				w.execute("operation", operationData{
+					Path:           ep.path,
+					Operation:      ep.operation,
+					Anchor:         toc.endpointAnchor(tag, ep),
+					Links:          relatedOperations(ep.operation, toc),
+					Extensions:     selectExtensions(extensions, ep.operation.Extensions),
+					BodyFields:     refs.bodyFields(ep.operation, doc.Components, c.directional),
+					ResponseFields: refs.responseFields(ep.operation, doc.Components, c.directional),
+					SchemaAnchors:  refs.anchors(toc),
+					Sequence:       operationSequence(c.sequences, doc, ep),
+				})
+			}
+
+			page.WriteString("</section>\n")
+		}
+	}
+
+	// Webhooks
+	if len(doc.Webhooks) > 0 {
+		w.anchoredHeading(2, c.locale.T("Webhooks"), webhooksAnchor)
+
+		for _, ep := range webhookRefs(doc) {
+			w.execute("operation", operationData{
+				Path:           ep.path,
+				Operation:      ep.operation,
+				Anchor:         toc.endpointAnchor("", ep),
+				Links:          relatedOperations(ep.operation, toc),
+				Extensions:     selectExtensions(extensions, ep.operation.Extensions),
+				BodyFields:     refs.bodyFields(ep.operation, doc.Components, c.directional),
+				ResponseFields: refs.responseFields(ep.operation, doc.Components, c.directional),
+				SchemaAnchors:  refs.anchors(toc),
+				Sequence:       operationSequence(c.sequences, doc, ep),
+			})
+		}
+	}
+
+	// Schemas appendix
+	if appendix {
+		w.schemaAppendix(doc, toc, extensions)
+	}
+
+	if epilogue := strings.TrimSpace(doc.Epilogue); epilogue != "" {
+		page.WriteString(htmlText(epilogue))
+	}
+
+	if w.err != nil {
+		return w.err
+	}
+
+	page.WriteString("</main>\n")
+
+	if c.sequences && hasSequenceDiagrams(doc) {
+		page.WriteString(htmlMermaidScript)
+	}
+
+	page.WriteString("</body>\n</html>\n")
+
+	if _, err := io.WriteString(output, page.String()); err != nil {
+		return fmt.Errorf("failed to write html: %w", err)
+	}
+
+	return nil
+}
+
+// writeTagComponents renders the component schemas used by endpoints in a tag.
+func (c *HTMLConverter) writeTagComponents(w *templateWriter, componentNames []string, components map[string]domain.Schema,
+	extensions []extensionField,
+) {
+	w.heading(4, c.locale.T("Schemas Used"))
+
+	for _, name := range componentNames {
+		schema, exists := components[name]
+		if !exists {
+			continue
+		}
+
+		w.execute("schema", schemaData{
+			Name:       name,
+			Schema:     flattenAllOf(schema, components),
+			Extensions: selectExtensions(extensions, schema.Extensions),
+		})
+	}
+}
+
+// writeHTMLTOC renders table of contents entries as nested lists of links.
+func writeHTMLTOC(page *strings.Builder, entries []tocEntry) {
+	page.WriteString("<ul>\n")
+
+	for _, entry := range entries {
+		page.WriteString(fmt.Sprintf("<li><a href=\"#%s\">%s</a>", html.EscapeString(entry.Anchor), html.EscapeString(entry.Title)))
+
+		if len(entry.Children) > 0 {
+			page.WriteString("\n")
+			writeHTMLTOC(page, entry.Children)
+		}
+
+		page.WriteString("</li>\n")
+	}
+
+	page.WriteString("</ul>\n")
+}
+
+// writeSecuritySchemes renders the security schemes of the document with their OAuth flows and scopes.
+func (c *HTMLConverter) writeSecuritySchemes(w *templateWriter, doc *domain.OpenAPIDocument) {
+	page := w.out
+
+	for _, name := range sortedSecuritySchemes(doc) {
+		scheme := doc.SecuritySchemes[name]
+
+		w.heading(3, name)
+		page.WriteString(fmt.Sprintf("<p>Type: %s</p>\n", html.EscapeString(formatSecurityScheme(scheme))))
+
+		if scheme.Description != "" {
+			page.WriteString(htmlText(scheme.Description))
+		}
+
+		for _, flow := range scheme.Flows {
+			w.heading(4, formatOAuthFlow(flow.Type)+" flow")
+			page.WriteString("<ul>\n")
+
+			urls := []struct{ label, url string }{
+				{"Authorization URL", flow.AuthorizationURL},
+				{"Token URL", flow.TokenURL},
+				{"Refresh URL", flow.RefreshURL},
+			}
+
+			for _, u := range urls {
+				if u.url != "" {
+					page.WriteString(fmt.Sprintf("<li>%s: <code>%s</code></li>\n", u.label, html.EscapeString(u.url)))
+				}
+			}
+
+			page.WriteString("</ul>\n")
+
+			if len(flow.Scopes) > 0 {
+				page.WriteString("<table>\n<tr><th>Scope</th><th>Description</th></tr>\n")
+
+				for _, scope := range sortedScopes(flow) {
+					page.WriteString(fmt.Sprintf("<tr><td><code>%s</code></td><td>%s</td></tr>\n",
+						html.EscapeString(scope), html.EscapeString(flow.Scopes[scope])))
+				}
+
+				page.WriteString("</table>\n")
+			}
+		}
+	}
+}
+
+// htmlFuncs returns the template helpers of the HTML templates.
+func htmlFuncs(locale Locale) htmltemplate.FuncMap {
+	funcs := templateFuncs(locale)
+	funcs["markdown"] = func(text string) htmltemplate.HTML {
+		return htmltemplate.HTML(markdownHTML(text)) //nolint:gosec // descriptions are escaped by the Markdown renderer
+	}
+	funcs["inlineMarkdown"] = func(text string) htmltemplate.HTML {
+		return htmltemplate.HTML(inlineMarkdownHTML(text)) //nolint:gosec // descriptions are escaped by the Markdown renderer
+	}
+	funcs["statusClass"] = func(statusCode string) string {
+		if statusCode == "" {
+			return ""
+		}
+
+		return "status-" + statusCode[:1]
+	}
+
+	return funcs
+}
+
+// htmlText renders a CommonMark description as HTML.
+func htmlText(text string) string {
+	return markdownHTML(text)
+}
+
+// ConvertChangelog transforms a changelog between two specifications to HTML format.
+func (c *HTMLConverter) ConvertChangelog(changelog *domain.Changelog, output io.Writer) error {
+	var page strings.Builder
+
+	title := ChangelogTitle(changelog)
+
+	page.WriteString("<!DOCTYPE html>\n<html lang=\"en\">\n<head>\n<meta charset=\"utf-8\">\n")
+	page.WriteString("<meta name=\"viewport\" content=\"width=device-width, initial-scale=1\">\n")
+	page.WriteString(fmt.Sprintf("<title>%s</title>\n", html.EscapeString(title)))
+	page.WriteString(fmt.Sprintf("<style>%s</style>\n", htmlStyles))
+	page.WriteString("</head>\n<body>\n<main>\n")
+
+	page.WriteString(fmt.Sprintf("<h1>%s</h1>\n", html.EscapeString(title)))
+	page.WriteString(fmt.Sprintf("<p class=\"version\">%s</p>\n", html.EscapeString(ChangelogSummary(changelog))))
+
+	for _, section := range changelogSections(changelog) {
+		page.WriteString(fmt.Sprintf("<h2>%s</h2>\n", html.EscapeString(section.title)))
+
+		for _, group := range section.groups {
+			if group.title != "" {
+				page.WriteString(fmt.Sprintf("<h3><code>%s</code></h3>\n", html.EscapeString(group.title)))
+			}
+
+			page.WriteString("<ul>\n")
+
+			for _, item := range group.items {
+				page.WriteString("<li>" + html.EscapeString(item.text))
+
+				if item.breaking && section.title != breakingSectionTitle {
+					page.WriteString(" <span class=\"breaking\">breaking</span>")
+				}
+
+				page.WriteString("</li>\n")
+			}
+
+			page.WriteString("</ul>\n")
+		}
+	}
+
+	page.WriteString("</main>\n</body>\n</html>\n")
+
+	if _, err := io.WriteString(output, page.String()); err != nil {
+		return fmt.Errorf("failed to write html: %w", err)
+	}
+
+	return nil
+}
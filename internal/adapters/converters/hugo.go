@@ -0,0 +1,109 @@
+package converters
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/GabrielNunesIT/openapi-converter/internal/domain"
+)
+
+const (
+	hugoFormat    = "hugo"
+	hugoIndexPage = "_index.md"
+)
+
+// HugoConverter converts OpenAPI documents to Markdown pages for static site generators such as Hugo: the Markdown
+// output under YAML front matter giving the title, weight and tags of the page. Split by tag, it lays the pages
+// out as a content section, an _index.md page introducing the API followed by a page per tag.
+type HugoConverter struct {
+	markdown *MarkdownConverter
+}
+
+// NewHugoConverter creates a new Hugo converter, rendering the pages as the Markdown converter configured by opts.
+func NewHugoConverter(opts MarkdownOptions) *HugoConverter {
+	return &HugoConverter{markdown: NewMarkdownConverter(opts)}
+}
+
+func init() { //nolint:gochecknoinits // converters self-register with the default registry
+	RegisterFormat(hugoFormat, markdownOptions,
+		func(opts MarkdownOptions) domain.Converter { return NewHugoConverter(opts) })
+}
+
+// Format returns the output format name.
+func (c *HugoConverter) Format() string {
+	return hugoFormat
+}
+
+// Convert transforms an OpenAPI document to a single page tagged with every tag of its operations.
+func (c *HugoConverter) Convert(doc *domain.OpenAPIDocument, output io.Writer) error {
+	page, err := c.page(doc, doc.Title, 1, orderedTags(doc, groupPathsByTag(doc)))
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.WriteString(output, page); err != nil {
+		return fmt.Errorf("failed to write markdown: %w", err)
+	}
+
+	return nil
+}
+
+// MultiConvert writes the _index.md page of the API and a page per tag into outputDir, creating it if needed.
+func (c *HugoConverter) MultiConvert(doc *domain.OpenAPIDocument, outputDir string) ([]string, error) {
+	if err := os.MkdirAll(outputDir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	parts := SplitByTag(doc)
+	files := make([]string, 0, len(parts))
+
+	for i, part := range parts {
+		title, tags, name := part.Name, []string{part.Name}, part.Slug+".md"
+		if part.Name == "" {
+			title, tags, name = doc.Title, nil, hugoIndexPage
+		}
+
+		page, err := c.page(part.Document, title, i+1, tags)
+		if err != nil {
+			return files, fmt.Errorf("failed to convert %s: %w", part.Slug, err)
+		}
+
+		path := filepath.Join(outputDir, name)
+
+		if err := os.WriteFile(path, []byte(page), 0o644); err != nil { //nolint:gosec // pages are meant to be published
+			return files, fmt.Errorf("failed to write output file: %w", err)
+		}
+
+		files = append(files, path)
+	}
+
+	return files, nil
+}
+
+// page returns the Markdown of doc under front matter giving the title, weight and tags of the page.
+func (c *HugoConverter) page(doc *domain.OpenAPIDocument, title string, weight int, tags []string) (string, error) {
+	var page strings.Builder
+
+	page.WriteString("---\ntitle: " + jsonString(title) + "\n")
+	fmt.Fprintf(&page, "weight: %d\n", weight)
+
+	if len(tags) > 0 {
+		quoted := make([]string, 0, len(tags))
+		for _, tag := range tags {
+			quoted = append(quoted, jsonString(tag))
+		}
+
+		page.WriteString("tags: [" + strings.Join(quoted, ", ") + "]\n")
+	}
+
+	page.WriteString("---\n\n")
+
+	if err := c.markdown.Convert(doc, &page); err != nil {
+		return "", err
+	}
+
+	return page.String(), nil
+}
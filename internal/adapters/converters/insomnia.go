@@ -0,0 +1,289 @@
+package converters
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/GabrielNunesIT/openapi-converter/internal/domain"
+)
+
+const (
+	insomniaFormat     = "insomnia"
+	insomniaSource     = "openapi-converter"
+	insomniaBaseURLVar = "base_url"
+)
+
+// InsomniaConverter converts OpenAPI documents to an Insomnia v4 export: a workspace holding a request group per tag,
+// a base environment with the first server URL and the credential placeholders, and a sub-environment per server.
+type InsomniaConverter struct{}
+
+// NewInsomniaConverter creates a new Insomnia export converter.
+func NewInsomniaConverter() *InsomniaConverter {
+	return &InsomniaConverter{}
+}
+
+func init() { //nolint:gochecknoinits // converters self-register with the default registry
+	Register(insomniaFormat, func(opts Options) domain.Converter {
+		return NewInsomniaConverter()
+	})
+}
+
+// Format returns the output format name.
+func (c *InsomniaConverter) Format() string {
+	return insomniaFormat
+}
+
+// Insomnia export types.
+type insomniaExport struct {
+	Type      string             `json:"_type"`
+	Format    int                `json:"__export_format"`
+	Source    string             `json:"__export_source"`
+	Resources []insomniaResource `json:"resources"`
+}
+
+// insomniaResource is a workspace, environment, request group or request, told apart by Type.
+type insomniaResource struct {
+	ID             string            `json:"_id"`
+	Type           string            `json:"_type"`
+	ParentID       *string           `json:"parentId"`
+	Name           string            `json:"name"`
+	Description    string            `json:"description,omitempty"`
+	Scope          string            `json:"scope,omitempty"`
+	Data           map[string]string `json:"data,omitempty"`
+	MetaSortKey    int               `json:"metaSortKey,omitempty"`
+	Method         string            `json:"method,omitempty"`
+	URL            string            `json:"url,omitempty"`
+	Body           *insomniaBody     `json:"body,omitempty"`
+	Parameters     []insomniaPair    `json:"parameters,omitempty"`
+	PathParameters []insomniaPair    `json:"pathParameters,omitempty"`
+	Headers        []insomniaPair    `json:"headers,omitempty"`
+	Authentication *insomniaAuth     `json:"authentication,omitempty"`
+}
+
+type insomniaPair struct {
+	Name        string `json:"name"`
+	Value       string `json:"value"`
+	Description string `json:"description,omitempty"`
+	Disabled    bool   `json:"disabled,omitempty"`
+}
+
+type insomniaBody struct {
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+}
+
+type insomniaAuth struct {
+	Type     string `json:"type"`
+	Token    string `json:"token,omitempty"`
+	Username string `json:"username,omitempty"`
+	Password string `json:"password,omitempty"`
+	Key      string `json:"key,omitempty"`
+	Value    string `json:"value,omitempty"`
+	AddTo    string `json:"addTo,omitempty"`
+}
+
+// Convert transforms an OpenAPI document to an Insomnia export.
+func (c *InsomniaConverter) Convert(doc *domain.OpenAPIDocument, output io.Writer) error {
+	// Identifiers derive from the title, so that importing the export again updates the same workspace
+	sum := sha256.Sum256([]byte(doc.Title))
+	prefix := hex.EncodeToString(sum[:6])
+	workspaceID := "wrk_" + prefix
+	baseEnvID := "env_" + prefix
+
+	// The About block leads the description, as workspaces have no other place for it
+	description := withExternalDocs(doc.Description, doc.ExternalDocs)
+	if about := aboutMarkdown(doc); about != "" {
+		description = strings.TrimSpace(about + "\n\n" + description)
+	}
+
+	baseURL := ""
+	if len(doc.Servers) > 0 {
+		baseURL = strings.TrimRight(doc.Servers[0].URL, "/")
+	}
+
+	baseEnv := insomniaResource{
+		ID:       baseEnvID,
+		Type:     "environment",
+		ParentID: &workspaceID,
+		Name:     "Base Environment",
+		Data:     map[string]string{insomniaBaseURLVar: baseURL},
+	}
+
+	// The base environment also receives the credential placeholders of the requests, to be filled in by the user
+	resources := []insomniaResource{
+		{ID: workspaceID, Type: "workspace", Name: doc.Title, Description: description, Scope: "collection"},
+		baseEnv,
+	}
+
+	for i, server := range doc.Servers {
+		name := server.Description
+		if name == "" {
+			name = server.URL
+		}
+
+		resources = append(resources, insomniaResource{
+			ID:          fmt.Sprintf("env_%s_%d", prefix, i+1),
+			Type:        "environment",
+			ParentID:    &baseEnvID,
+			Name:        name,
+			Data:        map[string]string{insomniaBaseURLVar: strings.TrimRight(server.URL, "/")},
+			MetaSortKey: i + 1,
+		})
+	}
+
+	requests := 0
+
+	// One request group per tag
+	tagPaths := groupPathsByTag(doc)
+	for i, tag := range orderedTags(doc, tagPaths) {
+		info := tagInfo(doc, tag)
+		groupID := fmt.Sprintf("fld_%s_%d", prefix, i+1)
+
+		resources = append(resources, insomniaResource{
+			ID:          groupID,
+			Type:        "request_group",
+			ParentID:    &workspaceID,
+			Name:        tag,
+			Description: withExternalDocs(info.Description, info.ExternalDocs),
+			MetaSortKey: i + 1,
+		})
+
+		for j, ep := range tagPaths[tag] {
+			requests++
+
+			request := c.request(doc, ep, baseEnv.Data)
+			request.ID = fmt.Sprintf("req_%s_%d", prefix, requests)
+			request.ParentID = &groupID
+			request.MetaSortKey = j + 1
+
+			resources = append(resources, request)
+		}
+	}
+
+	encoder := json.NewEncoder(output)
+	encoder.SetIndent("", "  ")
+
+	export := insomniaExport{Type: "export", Format: 4, Source: insomniaSource, Resources: resources}
+	if err := encoder.Encode(export); err != nil {
+		return fmt.Errorf("failed to encode insomnia export: %w", err)
+	}
+
+	return nil
+}
+
+// request builds the request of an operation, its path parameters as Insomnia :param segments.
+func (c *InsomniaConverter) request(doc *domain.OpenAPIDocument, ep endpointRef, variables map[string]string) insomniaResource {
+	op := ep.operation
+
+	name := op.Summary
+	if name == "" {
+		name = fmt.Sprintf("%s %s", formatMethod(op.Method), ep.path)
+	}
+
+	request := insomniaResource{
+		Type:           "request",
+		Name:           name + deprecatedSuffix(op.Deprecated),
+		Description:    withExternalDocs(op.Description, op.ExternalDocs),
+		Method:         formatMethod(op.Method),
+		URL:            insomniaVariable(insomniaBaseURLVar) + postmanPathParam.ReplaceAllString(ep.path, ":$1"),
+		Authentication: c.authentication(doc, op, variables),
+	}
+
+	for _, param := range op.Parameters {
+		pair := insomniaPair{
+			Name:        param.Name,
+			Value:       parameterPlaceholder(param),
+			Description: param.Description,
+			Disabled:    !param.Required,
+		}
+
+		switch param.In {
+		case "path":
+			pair.Disabled = false
+			request.PathParameters = append(request.PathParameters, pair)
+		case "query":
+			request.Parameters = append(request.Parameters, pair)
+		case "header":
+			request.Headers = append(request.Headers, pair)
+		}
+	}
+
+	if op.RequestBody != nil {
+		if contentTypes := sortedContentTypes(op.RequestBody.Content); len(contentTypes) > 0 {
+			contentType := preferredContentType(contentTypes)
+			request.Headers = append(request.Headers, insomniaPair{Name: "Content-Type", Value: contentType})
+			request.Body = &insomniaBody{MimeType: contentType}
+
+			samples := newSampler(doc.Components)
+			if examples := samples.examples(contentType, op.RequestBody.Content[contentType]); len(examples) > 0 {
+				request.Body.Text = formatExampleValue(examples[0].value)
+			}
+		}
+	}
+
+	return request
+}
+
+// authentication maps the first security requirement of an operation to Insomnia authentication settings.
+// Secrets are left as variables of the base environment such as {{ _.bearer_token }}.
+func (c *InsomniaConverter) authentication(doc *domain.OpenAPIDocument, op domain.Operation,
+	variables map[string]string,
+) *insomniaAuth {
+	if len(op.Security) == 0 {
+		return nil
+	}
+
+	requirement := op.Security[0]
+	if len(requirement) == 0 {
+		return &insomniaAuth{Type: "none"}
+	}
+
+	names := make([]string, 0, len(requirement))
+	for name := range requirement {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	scheme, exists := doc.SecuritySchemes[names[0]]
+	if !exists {
+		return nil
+	}
+
+	variable := func(name string) string {
+		variables[name] = ""
+
+		return insomniaVariable(name)
+	}
+
+	switch {
+	case scheme.Type == "http" && strings.EqualFold(scheme.Scheme, "basic"):
+		return &insomniaAuth{Type: "basic", Username: variable("username"), Password: variable("password")}
+	case scheme.Type == "http":
+		return &insomniaAuth{Type: "bearer", Token: variable("bearer_token")}
+	case scheme.Type == "apiKey":
+		addTo := "header"
+
+		switch scheme.In {
+		case "query":
+			addTo = "queryParams"
+		case "cookie":
+			addTo = "cookie"
+		}
+
+		return &insomniaAuth{Type: "apikey", Key: scheme.Name, Value: variable("api_key"), AddTo: addTo}
+	case scheme.Type == "oauth2" || scheme.Type == "openIdConnect":
+		return &insomniaAuth{Type: "bearer", Token: variable("access_token")}
+	default:
+		return nil
+	}
+}
+
+// insomniaVariable returns the template referencing an environment variable.
+func insomniaVariable(name string) string {
+	return "{{ _." + name + " }}"
+}
@@ -0,0 +1,132 @@
+package converters
+
+import (
+	"slices"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/GabrielNunesIT/openapi-converter/internal/domain"
+)
+
+// Vendor extensions of the operations rendered in their Limits table, as API gateways stamp them.
+const (
+	extRateLimit = "x-rate-limit"
+	extSLA       = "x-sla"
+	extCost      = "x-cost"
+)
+
+// operationLimit is a row of the Limits table of an operation.
+type operationLimit struct {
+	Label string // Fixed string such as "Rate limit", translated when rendered, or the name of an object key
+	Value string
+}
+
+// rateLimitCounts and rateLimitPeriods are the keys of an x-rate-limit object giving the number of requests allowed
+// and the period they are counted over, rendered together as "100 / minute".
+var (
+	rateLimitCounts  = []string{"limit", "requests", "quota"} //nolint:gochecknoglobals // read-only lookup table
+	rateLimitPeriods = []string{"period", "window", "per"}    //nolint:gochecknoglobals // read-only lookup table
+)
+
+// operationLimits returns the rows of the Limits table of an operation, from its x-rate-limit, x-sla and x-cost
+// extensions in that order; nil when it has none. Scalar values make a row each, labelled "Rate limit", "SLA" and
+// "Cost"; objects a row per key, in key order, except that the count and period of a rate limit share its row.
+func operationLimits(op domain.Operation) []operationLimit {
+	var limits []operationLimit
+
+	for _, ext := range []struct{ name, label string }{
+		{extRateLimit, "Rate limit"},
+		{extSLA, "SLA"},
+		{extCost, "Cost"},
+	} {
+		value, ok := op.Extensions[ext.name]
+		if !ok || value == nil {
+			continue
+		}
+
+		object, ok := value.(map[string]any)
+		if !ok {
+			limits = append(limits, operationLimit{Label: ext.label, Value: formatConstraintValue(value)})
+
+			continue
+		}
+
+		keys := make([]string, 0, len(object))
+		for key := range object {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		if ext.name == extRateLimit {
+			if rate, used := formatRate(object); rate != "" {
+				limits = append(limits, operationLimit{Label: ext.label, Value: rate})
+				keys = slices.DeleteFunc(keys, func(key string) bool { return slices.Contains(used, key) })
+			}
+		}
+
+		for _, key := range keys {
+			limits = append(limits, operationLimit{Label: humanizeKey(key), Value: formatExtensionValue(object[key])})
+		}
+	}
+
+	return limits
+}
+
+// formatRate returns the rate of an x-rate-limit object, e.g. "100 / minute", with the keys it was read from;
+// empty when the object gives no count.
+func formatRate(object map[string]any) (string, []string) {
+	for _, count := range rateLimitCounts {
+		value, ok := object[count]
+		if !ok {
+			continue
+		}
+
+		for _, period := range rateLimitPeriods {
+			if per, ok := object[period]; ok {
+				return formatConstraintValue(value) + " / " + formatConstraintValue(per), []string{count, period}
+			}
+		}
+
+		return formatConstraintValue(value), []string{count}
+	}
+
+	return "", nil
+}
+
+// humanizeKey turns the key of an extension object into a label, e.g. "responseTime" or "response-time" into
+// "Response time".
+func humanizeKey(key string) string {
+	var words []string
+
+	var word []rune
+
+	for i, r := range key {
+		switch {
+		case r == '-' || r == '_' || r == ' ':
+			words, word = appendWord(words, word), nil
+		case unicode.IsUpper(r) && i > 0 && len(word) > 0 && !unicode.IsUpper(word[len(word)-1]):
+			words, word = appendWord(words, word), []rune{unicode.ToLower(r)}
+		default:
+			word = append(word, r)
+		}
+	}
+
+	label := strings.Join(appendWord(words, word), " ")
+	if label == "" {
+		return key
+	}
+
+	first := []rune(label)
+	first[0] = unicode.ToUpper(first[0])
+
+	return string(first)
+}
+
+func appendWord(words []string, word []rune) []string {
+	if len(word) == 0 {
+		return words
+	}
+
+	return append(words, string(word))
+}
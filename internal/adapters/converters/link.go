@@ -0,0 +1,124 @@
+package converters
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/GabrielNunesIT/openapi-converter/internal/domain"
+)
+
+// relatedOperation is an operation that can follow a response, as described by one of its links.
+type relatedOperation struct {
+	StatusCode  string
+	Target      string // operationId of the target, or "METHOD /path" when it is given by reference
+	Anchor      string // Anchor of the target endpoint, empty when the output has none or the target is not documented
+	Parameters  []linkParameter
+	RequestBody string // Expression or value sent as the request body, empty for none
+	Description string
+}
+
+// linkParameter is a parameter of a related operation and the expression or value it is given.
+type linkParameter struct {
+	Name  string
+	Value string
+}
+
+// relatedOperations lists the links of the responses of an operation, in status code order.
+// Anchors of the targets are looked up in toc, which is nil for outputs without one.
+func relatedOperations(op domain.Operation, toc *documentTOC) []relatedOperation {
+	var related []relatedOperation
+
+	for _, resp := range sortedResponses(op.Responses) {
+		for _, link := range resp.Links {
+			target := linkTarget(link)
+
+			operation := relatedOperation{
+				StatusCode:  resp.StatusCode,
+				Target:      target,
+				Anchor:      toc.operationAnchor(target),
+				Description: link.Description,
+			}
+
+			names := make([]string, 0, len(link.Parameters))
+			for name := range link.Parameters {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+
+			for _, name := range names {
+				operation.Parameters = append(operation.Parameters, linkParameter{
+					Name:  name,
+					Value: formatConstraintValue(link.Parameters[name]),
+				})
+			}
+
+			if link.RequestBody != nil {
+				operation.RequestBody = formatConstraintValue(link.RequestBody)
+			}
+
+			related = append(related, operation)
+		}
+	}
+
+	return related
+}
+
+// linkTarget names the operation of a link: its operationId, otherwise "METHOD /path" read from an operationRef
+// pointing into the paths of a document, such as "#/paths/~1pets~1{petId}/get". Other references are kept as is.
+func linkTarget(link domain.Link) string {
+	if link.OperationID != "" {
+		return link.OperationID
+	}
+
+	_, pointer, _ := strings.Cut(link.OperationRef, "#")
+
+	tokens := strings.Split(pointer, "/")
+	if len(tokens) != 4 || tokens[0] != "" || tokens[1] != "paths" {
+		return link.OperationRef
+	}
+
+	path := strings.NewReplacer("~1", "/", "~0", "~").Replace(tokens[2])
+	if unescaped, err := url.PathUnescape(path); err == nil {
+		path = unescaped
+	}
+
+	return formatMethod(tokens[3]) + " " + path
+}
+
+// formatRelatedOperation describes a related operation on one line,
+// such as "getPet (petId = $response.body#/id) - Fetch the created pet".
+func formatRelatedOperation(related relatedOperation) string {
+	text := related.Target
+
+	if values := formatLinkValues(related); values != "" {
+		text += fmt.Sprintf(" (%s)", values)
+	}
+
+	if related.Description != "" {
+		text += " - " + related.Description
+	}
+
+	return text
+}
+
+// formatLinkValues lists the values a related operation is called with: "petId = $response.body#/id".
+func formatLinkValues(related relatedOperation) string {
+	values := []string{}
+	for _, value := range linkValues(related) {
+		values = append(values, value.Name+" = "+value.Value)
+	}
+
+	return strings.Join(values, ", ")
+}
+
+// linkValues returns the parameters of a related operation followed by its request body, if it has one.
+func linkValues(related relatedOperation) []linkParameter {
+	values := related.Parameters
+	if related.RequestBody != "" {
+		values = append(values[:len(values):len(values)], linkParameter{Name: "request body", Value: related.RequestBody})
+	}
+
+	return values
+}
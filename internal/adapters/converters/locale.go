@@ -0,0 +1,87 @@
+package converters
+
+import (
+	"embed"
+	"fmt"
+	"os"
+	"path"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultLocale is the locale of the fixed strings as the converters write them.
+const DefaultLocale = "en"
+
+// builtinLocales holds the translations shipped with the converters, one YAML file per language.
+//
+//go:embed locales
+var builtinLocales embed.FS
+
+// Locale translates the fixed strings of the generated documentation, such as section headings and required labels,
+// by their English text. Strings without a translation are written in English.
+type Locale map[string]string
+
+// Locales returns the names of the built-in locales, DefaultLocale first.
+func Locales() []string {
+	entries, _ := builtinLocales.ReadDir("locales")
+
+	names := []string{DefaultLocale}
+	for _, entry := range entries {
+		names = append(names, strings.TrimSuffix(entry.Name(), path.Ext(entry.Name())))
+	}
+
+	return names
+}
+
+// LoadLocale returns the built-in locale of a language, e.g. "de" or "pt-BR", completed by the translations
+// of stringsFile, a YAML or JSON file mapping English strings to their translation, when not empty.
+func LoadLocale(name, stringsFile string) (Locale, error) {
+	locale := Locale{}
+
+	language := strings.ToLower(name)
+	if i := strings.IndexAny(language, "-_"); i >= 0 {
+		language = language[:i]
+	}
+
+	if language != "" && language != DefaultLocale {
+		if !slices.Contains(Locales(), language) {
+			return nil, fmt.Errorf("unknown locale %q, expected one of: %s", name, strings.Join(Locales(), ", "))
+		}
+
+		data, err := builtinLocales.ReadFile("locales/" + language + ".yaml")
+		if err != nil {
+			return nil, fmt.Errorf("failed to read locale %s: %w", language, err)
+		}
+
+		if err := yaml.Unmarshal(data, &locale); err != nil {
+			return nil, fmt.Errorf("failed to parse locale %s: %w", language, err)
+		}
+	}
+
+	if stringsFile == "" {
+		return locale, nil
+	}
+
+	data, err := os.ReadFile(stringsFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read strings file: %w", err)
+	}
+
+	// Translations of the file replace those of the language
+	if err := yaml.Unmarshal(data, &locale); err != nil {
+		return nil, fmt.Errorf("failed to parse strings file %s: %w", stringsFile, err)
+	}
+
+	return locale, nil
+}
+
+// T returns the translation of text, or text itself when the locale does not translate it.
+func (l Locale) T(text string) string {
+	if translation := l[text]; translation != "" {
+		return translation
+	}
+
+	return text
+}
@@ -0,0 +1,490 @@
+package converters
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"strings"
+	texttemplate "text/template"
+
+	"github.com/GabrielNunesIT/openapi-converter/internal/domain"
+)
+
+const markdownFormat = "markdown"
+
+// MarkdownConverter converts OpenAPI documents to GitHub-flavored Markdown.
+// Headings, operations and schemas are rendered through text/template templates.
+type MarkdownConverter struct {
+	templateDir string   // Directory of templates replacing the embedded defaults
+	snippets    []string // Languages of the sample requests, nil for curl only
+	extensions  []string // Vendor extensions to render, as "x-name" or "x-name=Label"
+	appendix    bool     // Render component schemas once in an appendix rather than under every tag
+	stable      bool     // Anchor operations at their operationId, see documentTOC
+	schemaRefs  string   // Rendering of the schemas bodies refer to, among SchemaRefModes
+	directional bool     // Leave read-only properties out of request fields and write-only ones out of response fields
+	graph       bool     // Render a Mermaid graph of the schema references after the endpoints and schemas
+	sequences   bool     // Render a Mermaid sequence diagram of the links and callbacks of each operation
+	locale      Locale   // Translations of the fixed strings, nil for English
+	headings    headingLevels
+}
+
+// MarkdownOptions configures a MarkdownConverter, and the Hugo, Docusaurus and MkDocs converters rendering their
+// pages with it. The fields tagged option can also be set by name, e.g. with --option markdown.schema-refs=both,
+// see RegisterFormat.
+type MarkdownOptions struct {
+	// TemplateDir is a directory of *.tmpl files, which may redefine the "heading", "tag", "operation" and "schema"
+	// templates.
+	TemplateDir string `option:"template-dir"`
+
+	// Snippets are the languages of the sample request of each endpoint, such as "curl" or "python", nil for curl
+	// only. An empty list disables sample requests.
+	Snippets []string `option:"snippets"`
+
+	// Extensions are the vendor extensions of the API, its operations and schemas to render, each given as
+	// "x-name" or "x-name=Label".
+	Extensions []string `option:"extensions"`
+
+	// SchemaAppendix renders every component schema once in a "Schemas" appendix, the "Schemas Used" section of
+	// each tag linking to it.
+	SchemaAppendix bool `option:"schema-appendix"`
+
+	// StableAnchors anchors the first occurrence of each operation with an operationId at "op-" followed by the
+	// operationId, e.g. "op-listPets", whatever else the document holds, so that other documents can link to it.
+	StableAnchors bool `option:"stable-anchors"`
+
+	// SchemaRefs sets how request and response bodies render the component schemas they refer to, among
+	// SchemaRefModes: listing the fields of request bodies, linking schema names to the schemas appendix, which is
+	// then rendered, or both.
+	SchemaRefs string `option:"schema-refs"`
+
+	// Directional leaves the read-only properties out of the request body field tables and the write-only
+	// properties out of the response field tables, as they are not sent that way.
+	Directional bool `option:"directional-fields"`
+
+	// SchemaGraph renders a "Schema Graph" section after the endpoints and schemas, holding a Mermaid flowchart of
+	// the references between component schemas and from the operations, as the mermaid format writes it.
+	SchemaGraph bool `option:"schema-graph"`
+
+	// Sequences renders a "Sequence" section under each operation with links or callbacks, holding a Mermaid
+	// sequence diagram of the requests the client sends and the callbacks it receives.
+	Sequences bool `option:"sequence-diagrams"`
+
+	// HeadingOffset adds levels to every heading of the document, e.g. 1 to start at h2 when it is embedded under a
+	// heading of its own. Headings of custom templates and descriptions are shifted alike.
+	HeadingOffset int `option:"heading-offset"`
+
+	// MaxHeadingLevel raises the headings deeper than this level to it, 0 for h6.
+	MaxHeadingLevel int `option:"max-heading-level"`
+
+	// Locale translates the section headings and other fixed strings, which the templates translate with the "t"
+	// function, e.g. {{t "Parameters"}}; nil for English.
+	Locale Locale
+}
+
+// NewMarkdownConverter creates a new Markdown converter.
+func NewMarkdownConverter(opts MarkdownOptions) *MarkdownConverter {
+	return &MarkdownConverter{
+		templateDir: opts.TemplateDir,
+		snippets:    opts.Snippets,
+		extensions:  opts.Extensions,
+		appendix:    opts.SchemaAppendix,
+		stable:      opts.StableAnchors,
+		schemaRefs:  opts.SchemaRefs,
+		directional: opts.Directional,
+		graph:       opts.SchemaGraph,
+		sequences:   opts.Sequences,
+		locale:      opts.Locale,
+		headings:    headingLevels{offset: opts.HeadingOffset, max: opts.MaxHeadingLevel},
+	}
+}
+
+// markdownOptions returns the options of the Markdown converter set by the options of every format.
+func markdownOptions(opts Options) MarkdownOptions {
+	return MarkdownOptions{
+		TemplateDir:    opts.TemplateDir,
+		Snippets:       opts.Snippets,
+		Extensions:     opts.Extensions,
+		SchemaAppendix: opts.SchemaAppendix,
+		StableAnchors:  opts.StableAnchors,
+		SchemaRefs:     opts.SchemaRefs,
+		Directional:    opts.Directional,
+		SchemaGraph:    opts.SchemaGraph,
+		Sequences:      opts.Sequences,
+		Locale:         opts.Locale,
+	}
+}
+
+func init() { //nolint:gochecknoinits // converters self-register with the default registry
+	RegisterFormat(markdownFormat, markdownOptions,
+		func(opts MarkdownOptions) domain.Converter { return NewMarkdownConverter(opts) }, "md")
+}
+
+// Format returns the output format name.
+func (c *MarkdownConverter) Format() string {
+	return markdownFormat
+}
+
+// Convert transforms an OpenAPI document to Markdown format.
+func (c *MarkdownConverter) Convert(doc *domain.OpenAPIDocument, output io.Writer) error {
+	tmpl, err := loadTextTemplates(markdownFormat, c.templateDir, markdownFuncs(c.locale))
+	if err != nil {
+		return err
+	}
+
+	generators, err := snippetGeneratorsFor(c.snippets)
+	if err != nil {
+		return err
+	}
+
+	extensions, err := extensionFieldsFor(c.extensions)
+	if err != nil {
+		return err
+	}
+
+	refs, err := newSchemaRefRendering(c.schemaRefs)
+	if err != nil {
+		return err
+	}
+
+	appendix := c.appendix || refs.links
+
+	var md strings.Builder
+
+	w := &templateWriter{out: &md, tmpl: tmpl, locale: c.locale}
+
+	// Title
+	w.heading(1, doc.Title)
+	md.WriteString(fmt.Sprintf("Version: %s\n\n", doc.Version))
+
+	for _, extension := range selectExtensions(extensions, doc.Extensions) {
+		md.WriteString(fmt.Sprintf("%s: %s\n\n", extension.Label, extension.Value))
+	}
+
+	if preamble := strings.TrimSpace(doc.Preamble); preamble != "" {
+		md.WriteString(preamble + "\n\n")
+	}
+
+	// Table of contents
+	toc := newDocumentTOC(doc, c.stable)
+	if appendix {
+		toc.addSchemaAppendix(doc)
+	}
+
+	if c.graph {
+		toc.addSchemaGraph(doc)
+	}
+
+	toc.localize(c.locale)
+
+	if len(toc.Entries) > 0 {
+		w.heading(2, c.locale.T("Contents"))
+		writeMarkdownTOC(&md, toc.Entries, 0)
+		md.WriteString("\n")
+	}
+
+	// About
+	if about := aboutMarkdown(doc); about != "" {
+		w.heading(2, c.locale.T("About"))
+		md.WriteString(about + "\n\n")
+	}
+
+	// Description
+	if description := withExternalDocs(doc.Description, doc.ExternalDocs); description != "" {
+		w.heading(2, c.locale.T("Description"))
+		md.WriteString(description + "\n\n")
+	}
+
+	// Servers
+	if len(doc.Servers) > 0 {
+		w.heading(2, c.locale.T("Servers"))
+
+		for _, server := range doc.Servers {
+			if server.Description != "" {
+				md.WriteString(fmt.Sprintf("- `%s` - %s\n", server.URL, server.Description))
+			} else {
+				md.WriteString(fmt.Sprintf("- `%s`\n", server.URL))
+			}
+		}
+
+		md.WriteString("\n")
+	}
+
+	// Authentication
+	if len(doc.SecuritySchemes) > 0 {
+		w.heading(2, c.locale.T("Authentication"))
+		writeMarkdownSecuritySchemes(w, doc, 3)
+	}
+
+	// Endpoints grouped by tags
+	if len(doc.Paths) > 0 {
+		w.heading(2, c.locale.T("API Endpoints"))
+
+		tagPaths := groupPathsByTag(doc)
+		for _, tag := range orderedTags(doc, tagPaths) {
+			w.tag(3, tagInfo(doc, tag), toc.tagAnchor(tag))
+
+			// Add components used by this tag's endpoints
+			tagComponents := collectTagComponents(tagPaths[tag])
+			if len(tagComponents) > 0 {
+				if appendix {
+					w.heading(4, c.locale.T("Schemas Used"))
+					writeMarkdownTOC(&md, toc.schemaLinks(tagComponents), 0)
+					md.WriteString("\n")
+				} else {
+					c.writeTagComponents(w, tagComponents, doc.Components, extensions)
+				}
+			}
+
+			// Add endpoints
+			for _, ep := range tagPaths[tag] {
+				w.execute("operation", operationData{
+					Path:           ep.path,
+					Operation:      ep.operation,
+					Anchor:         toc.endpointAnchor(tag, ep),
+					Links:          relatedOperations(ep.operation, toc),
+					Snippets:       requestSnippets(generators, doc, ep.path, ep.operation),
+					Extensions:     selectExtensions(extensions, ep.operation.Extensions),
+					BodyFields:     refs.bodyFields(ep.operation, doc.Components, c.directional),
+					ResponseFields: refs.responseFields(ep.operation, doc.Components, c.directional),
+					SchemaAnchors:  refs.anchors(toc),
+					Sequence:       operationSequence(c.sequences, doc, ep),
+				})
+			}
+		}
+	}
+
+	// Webhooks
+	if len(doc.Webhooks) > 0 {
+		w.anchoredHeading(2, c.locale.T("Webhooks"), webhooksAnchor)
+
+		for _, ep := range webhookRefs(doc) {
+			w.execute("operation", operationData{
+				Path:           ep.path,
+				Operation:      ep.operation,
+				Anchor:         toc.endpointAnchor("", ep),
+				Links:          relatedOperations(ep.operation, toc),
+				Extensions:     selectExtensions(extensions, ep.operation.Extensions),
+				BodyFields:     refs.bodyFields(ep.operation, doc.Components, c.directional),
+				ResponseFields: refs.responseFields(ep.operation, doc.Components, c.directional),
+				SchemaAnchors:  refs.anchors(toc),
+				Sequence:       operationSequence(c.sequences, doc, ep),
+			})
+		}
+	}
+
+	// Schemas appendix
+	if appendix {
+		w.schemaAppendix(doc, toc, extensions)
+	}
+
+	// Schema graph
+	if c.graph && len(doc.Components) > 0 {
+		w.anchoredHeading(2, c.locale.T("Schema Graph"), schemaGraphAnchor)
+		md.WriteString("```mermaid\n" + newSchemaGraph(doc).mermaid() + "```\n\n")
+	}
+
+	if epilogue := strings.TrimSpace(doc.Epilogue); epilogue != "" {
+		md.WriteString(epilogue + "\n\n")
+	}
+
+	if w.err != nil {
+		return w.err
+	}
+
+	if _, err := io.WriteString(output, shiftMarkdownHeadings(md.String(), c.headings)); err != nil {
+		return fmt.Errorf("failed to write markdown: %w", err)
+	}
+
+	return nil
+}
+
+// writeTagComponents renders the component schemas used by endpoints in a tag.
+func (c *MarkdownConverter) writeTagComponents(w *templateWriter, componentNames []string, components map[string]domain.Schema,
+	extensions []extensionField,
+) {
+	w.heading(4, c.locale.T("Schemas Used"))
+
+	for _, name := range componentNames {
+		schema, exists := components[name]
+		if !exists {
+			continue
+		}
+
+		w.execute("schema", schemaData{
+			Name:       name,
+			Schema:     flattenAllOf(schema, components),
+			Extensions: selectExtensions(extensions, schema.Extensions),
+		})
+	}
+}
+
+// writeMarkdownSecuritySchemes renders the security schemes of the document with their OAuth flows and scopes,
+// each scheme under a heading of the given level.
+func writeMarkdownSecuritySchemes(w *templateWriter, doc *domain.OpenAPIDocument, level int) {
+	md := w.out
+
+	for _, name := range sortedSecuritySchemes(doc) {
+		scheme := doc.SecuritySchemes[name]
+
+		w.heading(level, name)
+		md.WriteString(fmt.Sprintf("Type: %s\n\n", formatSecurityScheme(scheme)))
+
+		if scheme.Description != "" {
+			md.WriteString(strings.TrimSpace(scheme.Description) + "\n\n")
+		}
+
+		for _, flow := range scheme.Flows {
+			w.heading(level+1, formatOAuthFlow(flow.Type)+" flow")
+
+			if flow.AuthorizationURL != "" {
+				md.WriteString(fmt.Sprintf("- Authorization URL: `%s`\n", flow.AuthorizationURL))
+			}
+
+			if flow.TokenURL != "" {
+				md.WriteString(fmt.Sprintf("- Token URL: `%s`\n", flow.TokenURL))
+			}
+
+			if flow.RefreshURL != "" {
+				md.WriteString(fmt.Sprintf("- Refresh URL: `%s`\n", flow.RefreshURL))
+			}
+
+			md.WriteString("\n")
+
+			if len(flow.Scopes) > 0 {
+				md.WriteString("| Scope | Description |\n")
+				md.WriteString("| --- | --- |\n")
+
+				for _, scope := range sortedScopes(flow) {
+					md.WriteString(fmt.Sprintf("| `%s` | %s |\n", scope, markdownCell(flow.Scopes[scope])))
+				}
+
+				md.WriteString("\n")
+			}
+		}
+	}
+}
+
+// writeMarkdownTOC renders table of contents entries as a nested list of links.
+func writeMarkdownTOC(md *strings.Builder, entries []tocEntry, depth int) {
+	for _, entry := range entries {
+		title := strings.NewReplacer("[", "\\[", "]", "\\]").Replace(entry.Title)
+		md.WriteString(fmt.Sprintf("%s- [%s](#%s)\n", strings.Repeat("  ", depth), title, entry.Anchor))
+
+		writeMarkdownTOC(md, entry.Children, depth+1)
+	}
+}
+
+// schemaOutline renders a JSON-like skeleton of a schema with type names as values.
+func schemaOutline(schema domain.Schema, indent int) string {
+	return propertyOutline(schema, indent, false)
+}
+
+// propertyOutline is schemaOutline for a property, its type flagged as required when the object holding it
+// requires it. Objects and arrays are left unflagged, their skeleton standing for the type.
+func propertyOutline(schema domain.Schema, indent int, required bool) string {
+	if schema.Ref != "" {
+		return fmt.Sprintf("%q", joinConstraints(extractRefName(schema.Ref), propertyConstraints(domain.Schema{}, required, nil)))
+	}
+
+	pad := strings.Repeat("  ", indent)
+
+	if len(schema.Properties) > 0 {
+		propNames := make([]string, 0, len(schema.Properties))
+		for propName := range schema.Properties {
+			propNames = append(propNames, propName)
+		}
+		sort.Strings(propNames)
+
+		lines := make([]string, 0, len(propNames))
+		for _, propName := range propNames {
+			property := propertyOutline(schema.Properties[propName], indent+1, slices.Contains(schema.Required, propName))
+			lines = append(lines, fmt.Sprintf("%s  %q: %s", pad, propName, property))
+		}
+
+		return fmt.Sprintf("{\n%s\n%s}", strings.Join(lines, ",\n"), pad)
+	}
+
+	if schema.Items != nil {
+		return fmt.Sprintf("[\n%s  %s\n%s]", pad, schemaOutline(*schema.Items, indent+1), pad)
+	}
+
+	// Constants are shown as their literal JSON value
+	if schema.Const != nil {
+		if value, err := json.Marshal(schema.Const); err == nil {
+			return string(value)
+		}
+	}
+
+	schemaType := formatSchemaType(schema)
+	if schemaType == "" {
+		schemaType = "any"
+	}
+
+	if schema.Nullable {
+		schemaType += " | null"
+	}
+
+	// Allowed values and bounds follow the type, e.g. "string, one of: active, suspended", nullability being
+	// shown by the type already
+	constraints := slices.DeleteFunc(propertyConstraints(schema, required, nil), func(constraint string) bool {
+		return constraint == nullableLabel
+	})
+	schemaType = joinConstraints(schemaType, constraints)
+
+	return fmt.Sprintf("%q", schemaType)
+}
+
+// markdownCell escapes text so it can be placed inside a Markdown table cell.
+func markdownCell(text string) string {
+	text = strings.TrimSpace(text)
+	text = strings.ReplaceAll(text, "|", "\\|")
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+
+	return strings.ReplaceAll(text, "\n", "<br>")
+}
+
+// markdownFuncs returns the template helpers of the Markdown templates.
+func markdownFuncs(locale Locale) texttemplate.FuncMap {
+	funcs := templateFuncs(locale)
+	funcs["cell"] = markdownCell
+
+	return funcs
+}
+
+// ConvertChangelog transforms a changelog between two specifications to Markdown format.
+func (c *MarkdownConverter) ConvertChangelog(changelog *domain.Changelog, output io.Writer) error {
+	var md strings.Builder
+
+	md.WriteString(fmt.Sprintf("# %s\n\n", ChangelogTitle(changelog)))
+	md.WriteString(ChangelogSummary(changelog) + "\n\n")
+
+	for _, section := range changelogSections(changelog) {
+		md.WriteString(fmt.Sprintf("## %s\n\n", section.title))
+
+		for _, group := range section.groups {
+			if group.title != "" {
+				md.WriteString(fmt.Sprintf("### `%s`\n\n", group.title))
+			}
+
+			for _, item := range group.items {
+				md.WriteString("- " + item.text)
+
+				if item.breaking && section.title != breakingSectionTitle {
+					md.WriteString(" **(breaking)**")
+				}
+
+				md.WriteString("\n")
+			}
+
+			md.WriteString("\n")
+		}
+	}
+
+	if _, err := io.WriteString(output, md.String()); err != nil {
+		return fmt.Errorf("failed to write markdown: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,97 @@
+package converters
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/GabrielNunesIT/openapi-converter/internal/domain"
+)
+
+const (
+	mkdocsFormat = "mkdocs"
+	mkdocsConfig = "mkdocs.yml"
+	mkdocsDocs   = "docs"
+)
+
+// MkDocsConverter converts OpenAPI documents to the Markdown output, which split by tag it lays out as an MkDocs site:
+// an mkdocs.yml file naming the site and listing the pages in its nav, and a docs directory holding an index page
+// and a page per tag, so that mkdocs build runs on the output as is.
+type MkDocsConverter struct {
+	markdown *MarkdownConverter
+}
+
+// NewMkDocsConverter creates a new MkDocs converter, rendering the pages as the Markdown converter configured by opts.
+func NewMkDocsConverter(opts MarkdownOptions) *MkDocsConverter {
+	return &MkDocsConverter{markdown: NewMarkdownConverter(opts)}
+}
+
+func init() { //nolint:gochecknoinits // converters self-register with the default registry
+	RegisterFormat(mkdocsFormat, markdownOptions,
+		func(opts MarkdownOptions) domain.Converter { return NewMkDocsConverter(opts) })
+}
+
+// Format returns the output format name.
+func (c *MkDocsConverter) Format() string {
+	return mkdocsFormat
+}
+
+// Convert transforms an OpenAPI document to a single Markdown page.
+func (c *MkDocsConverter) Convert(doc *domain.OpenAPIDocument, output io.Writer) error {
+	return c.markdown.Convert(doc, output)
+}
+
+// MultiConvert writes the mkdocs.yml file of the site into outputDir, and the index page and a page per tag into
+// its docs directory, creating them if needed.
+func (c *MkDocsConverter) MultiConvert(doc *domain.OpenAPIDocument, outputDir string) ([]string, error) {
+	docsDir := filepath.Join(outputDir, mkdocsDocs)
+	if err := os.MkdirAll(docsDir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	parts := SplitByTag(doc)
+	files := make([]string, 0, len(parts)+1)
+
+	var config strings.Builder
+
+	config.WriteString("# MkDocs site of the " + doc.Title + " API documentation, generated by openapi-converter.\n")
+	config.WriteString("site_name: " + jsonString(doc.Title) + "\n")
+	config.WriteString("docs_dir: " + mkdocsDocs + "\n")
+	config.WriteString("nav:\n")
+
+	for _, part := range parts {
+		label := part.Name
+		if part.Name == "" {
+			label = "Overview"
+		}
+
+		config.WriteString("  - " + jsonString(label) + ": " + part.Slug + ".md\n")
+	}
+
+	path := filepath.Join(outputDir, mkdocsConfig)
+
+	if err := os.WriteFile(path, []byte(config.String()), 0o644); err != nil { //nolint:gosec // the site configuration is not secret
+		return nil, fmt.Errorf("failed to write %s: %w", mkdocsConfig, err)
+	}
+
+	files = append(files, path)
+
+	for _, part := range parts {
+		var page strings.Builder
+		if err := c.markdown.Convert(part.Document, &page); err != nil {
+			return files, fmt.Errorf("failed to convert %s: %w", part.Slug, err)
+		}
+
+		path := filepath.Join(docsDir, part.Slug+".md")
+
+		if err := os.WriteFile(path, []byte(page.String()), 0o644); err != nil { //nolint:gosec // pages are meant to be published
+			return files, fmt.Errorf("failed to write output file: %w", err)
+		}
+
+		files = append(files, path)
+	}
+
+	return files, nil
+}
@@ -0,0 +1,807 @@
+package converters
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/GabrielNunesIT/openapi-converter/internal/domain"
+)
+
+const (
+	notionFormat = "notion"
+
+	// notionMaxText is the length limit of a single rich text object of the Notion API.
+	notionMaxText = 2000
+
+	// notionMaxNesting is the number of block levels the Notion API accepts in a single request,
+	// which caps the nesting of property lists.
+	notionMaxNesting = 2
+)
+
+// NotionConverter converts OpenAPI documents to Notion blocks, laid out like the Confluence output:
+// headings per section and tag, one toggle per endpoint, and tables for parameters and responses.
+// The output is the body of a Notion "append block children" request.
+type NotionConverter struct {
+	snippets    []string // Languages of the sample requests, nil for curl only
+	extensions  []string // Vendor extensions to render, as "x-name" or "x-name=Label"
+	depth       int      // Property levels of inline objects listed under a schema
+	appendix    bool     // Render component schemas once in an appendix rather than under every tag
+	directional bool     // Leave read-only properties out of request fields and write-only ones out of response fields
+	locale      Locale   // Translations of the fixed strings, nil for English
+}
+
+// NotionOptions configures a NotionConverter. The fields tagged option can also be set by name, e.g. with
+// --option notion.schema-depth=2, see RegisterFormat.
+type NotionOptions struct {
+	// Snippets are the languages of the sample request of each endpoint, such as "curl" or "python", nil for curl
+	// only. An empty list disables sample requests.
+	Snippets []string `option:"snippets"`
+
+	// Extensions are the vendor extensions of the API, its operations and schemas to render, each given as
+	// "x-name" or "x-name=Label".
+	Extensions []string `option:"extensions"`
+
+	// SchemaDepth lists the properties of inline objects nested below their property, down to that many levels.
+	// A depth below 1 keeps DefaultSchemaDepth.
+	SchemaDepth int `option:"schema-depth"`
+
+	// SchemaAppendix renders every component schema once in a "Schemas" appendix, the "Schemas Used" section of
+	// each tag only naming them.
+	SchemaAppendix bool `option:"schema-appendix"`
+
+	// Directional leaves the read-only properties out of the request body field tables and the write-only
+	// properties out of the response field tables, as they are not sent that way.
+	Directional bool `option:"directional-fields"`
+
+	// Locale translates the section headings and other fixed strings of the page, nil for English.
+	Locale Locale
+}
+
+// NewNotionConverter creates a new Notion converter.
+func NewNotionConverter(opts NotionOptions) *NotionConverter {
+	c := &NotionConverter{
+		snippets:    opts.Snippets,
+		extensions:  opts.Extensions,
+		depth:       DefaultSchemaDepth,
+		appendix:    opts.SchemaAppendix,
+		directional: opts.Directional,
+		locale:      opts.Locale,
+	}
+
+	if opts.SchemaDepth > 0 {
+		c.depth = opts.SchemaDepth
+	}
+
+	return c
+}
+
+func init() { //nolint:gochecknoinits // converters self-register with the default registry
+	RegisterFormat(notionFormat, func(opts Options) NotionOptions {
+		return NotionOptions{
+			Snippets:       opts.Snippets,
+			Extensions:     opts.Extensions,
+			SchemaDepth:    opts.SchemaDepth,
+			SchemaAppendix: opts.SchemaAppendix,
+			Directional:    opts.Directional,
+			Locale:         opts.Locale,
+		}
+	}, func(opts NotionOptions) domain.Converter { return NewNotionConverter(opts) })
+}
+
+// Format returns the output format name.
+func (c *NotionConverter) Format() string {
+	return notionFormat
+}
+
+// Notion block types, limited to the properties this converter writes.
+type notionDocument struct {
+	Children []notionBlock `json:"children"`
+}
+
+// notionBlock is a Notion block whose content is stored under a key named after its type.
+type notionBlock struct {
+	Type    string
+	Content notionContent
+}
+
+type notionContent struct {
+	RichText        []notionText   `json:"rich_text,omitempty"`
+	Language        string         `json:"language,omitempty"`
+	TableWidth      int            `json:"table_width,omitempty"`
+	HasColumnHeader bool           `json:"has_column_header,omitempty"`
+	Cells           [][]notionText `json:"cells,omitempty"`
+	Children        []notionBlock  `json:"children,omitempty"`
+}
+
+type notionText struct {
+	Type        string             `json:"type"`
+	Text        notionTextContent  `json:"text"`
+	Annotations *notionAnnotations `json:"annotations,omitempty"`
+}
+
+type notionTextContent struct {
+	Content string `json:"content"`
+}
+
+type notionAnnotations struct {
+	Bold  bool   `json:"bold,omitempty"`
+	Code  bool   `json:"code,omitempty"`
+	Color string `json:"color,omitempty"`
+}
+
+// MarshalJSON writes the block in the Notion API layout, e.g. {"object": "block", "type": "paragraph", "paragraph": {...}}.
+func (b notionBlock) MarshalJSON() ([]byte, error) {
+	data, err := json.Marshal(map[string]any{
+		"object": "block",
+		"type":   b.Type,
+		b.Type:   b.Content,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode notion block: %w", err)
+	}
+
+	return data, nil
+}
+
+// Convert transforms an OpenAPI document to Notion block JSON.
+func (c *NotionConverter) Convert(doc *domain.OpenAPIDocument, output io.Writer) error {
+	generators, err := snippetGeneratorsFor(c.snippets)
+	if err != nil {
+		return err
+	}
+
+	extensions, err := extensionFieldsFor(c.extensions)
+	if err != nil {
+		return err
+	}
+
+	samples := newSampler(doc.Components)
+	blocks := []notionBlock{}
+
+	// Title
+	blocks = append(blocks, c.heading(doc.Title, 1))
+	blocks = append(blocks, c.paragraph(c.text(fmt.Sprintf("Version: %s", doc.Version))))
+	blocks = append(blocks, c.extensionBlocks(selectExtensions(extensions, doc.Extensions))...)
+
+	if preamble := strings.TrimSpace(doc.Preamble); preamble != "" {
+		blocks = append(blocks, c.paragraph(c.text(preamble)))
+	}
+
+	// About
+	if entries := aboutEntries(doc); len(entries) > 0 {
+		blocks = append(blocks, c.heading(c.locale.T("About"), 2))
+		for _, entry := range entries {
+			blocks = append(blocks, c.bulletItem(c.text(entry.plain())))
+		}
+	}
+
+	// Description
+	if description := withPlainExternalDocs(doc.Description, doc.ExternalDocs); description != "" {
+		blocks = append(blocks, c.heading(c.locale.T("Description"), 2))
+		blocks = append(blocks, c.paragraph(c.text(description)))
+	}
+
+	// Servers
+	if len(doc.Servers) > 0 {
+		blocks = append(blocks, c.heading(c.locale.T("Servers"), 2))
+
+		for _, server := range doc.Servers {
+			item := c.code(server.URL)
+			if server.Description != "" {
+				item = append(item, c.text(" - "+server.Description)...)
+			}
+
+			blocks = append(blocks, c.bulletItem(item))
+		}
+	}
+
+	// Authentication
+	if len(doc.SecuritySchemes) > 0 {
+		blocks = append(blocks, c.heading(c.locale.T("Authentication"), 2))
+		blocks = append(blocks, c.securitySchemeBlocks(doc)...)
+	}
+
+	// Endpoints grouped by tags
+	if len(doc.Paths) > 0 {
+		blocks = append(blocks, c.heading(c.locale.T("API Endpoints"), 2))
+
+		tagPaths := groupPathsByTag(doc)
+		for _, tag := range orderedTags(doc, tagPaths) {
+			blocks = append(blocks, c.heading(tag, 3))
+
+			info := tagInfo(doc, tag)
+			if description := withPlainExternalDocs(info.Description, info.ExternalDocs); description != "" {
+				blocks = append(blocks, c.paragraph(c.text(description)))
+			}
+
+			// Add components used by this tag's endpoints
+			if tagComponents := collectTagComponents(tagPaths[tag]); len(tagComponents) > 0 {
+				if c.appendix {
+					blocks = append(blocks, c.schemaNameBlocks(tagComponents)...)
+				} else {
+					blocks = append(blocks, c.tagComponentBlocks(tagComponents, doc.Components, extensions)...)
+				}
+			}
+
+			// Add endpoints
+			for _, ep := range tagPaths[tag] {
+				snippets := requestSnippets(generators, doc, ep.path, ep.operation)
+				blocks = append(blocks, c.operationToggle(ep.path, ep.operation, snippets, samples.operationExamples(ep.operation),
+					bodyFieldsByContentType(ep.operation, doc.Components, c.depth, c.directional),
+					responseFieldsByStatus(ep.operation, doc.Components, c.depth, c.directional),
+					selectExtensions(extensions, ep.operation.Extensions)))
+			}
+		}
+	}
+
+	// Webhooks
+	if len(doc.Webhooks) > 0 {
+		blocks = append(blocks, c.heading(c.locale.T("Webhooks"), 2))
+
+		for _, ep := range webhookRefs(doc) {
+			blocks = append(blocks, c.operationToggle(ep.path, ep.operation, nil, samples.operationExamples(ep.operation),
+				bodyFieldsByContentType(ep.operation, doc.Components, c.depth, c.directional),
+				responseFieldsByStatus(ep.operation, doc.Components, c.depth, c.directional),
+				selectExtensions(extensions, ep.operation.Extensions)))
+		}
+	}
+
+	// Schemas appendix
+	if c.appendix && len(doc.Components) > 0 {
+		blocks = append(blocks, c.heading(c.locale.T("Schemas"), 2))
+
+		for _, name := range sortedComponentNames(doc.Components) {
+			schema := doc.Components[name]
+			blocks = append(blocks, c.componentSchemaBlocks(name, flattenAllOf(schema, doc.Components),
+				selectExtensions(extensions, schema.Extensions))...)
+		}
+	}
+
+	if epilogue := strings.TrimSpace(doc.Epilogue); epilogue != "" {
+		blocks = append(blocks, c.paragraph(c.text(epilogue)))
+	}
+
+	encoder := json.NewEncoder(output)
+	encoder.SetIndent("", "  ")
+
+	if err := encoder.Encode(notionDocument{Children: blocks}); err != nil {
+		return fmt.Errorf("failed to encode Notion blocks: %w", err)
+	}
+
+	return nil
+}
+
+// tagComponentBlocks generates blocks for the component schemas used in a tag.
+func (c *NotionConverter) tagComponentBlocks(componentNames []string, components map[string]domain.Schema,
+	extensions []extensionField,
+) []notionBlock {
+	blocks := []notionBlock{c.heading(c.locale.T("Schemas Used"), 4)}
+
+	for _, name := range componentNames {
+		schema, exists := components[name]
+		if !exists {
+			continue
+		}
+
+		schemaExtensions := selectExtensions(extensions, schema.Extensions)
+		blocks = append(blocks, c.componentSchemaBlocks(name, flattenAllOf(schema, components), schemaExtensions)...)
+	}
+
+	return blocks
+}
+
+// schemaNameBlocks lists the component schemas used in a tag, which are documented in the appendix.
+// Notion offers no anchors to link them to.
+func (c *NotionConverter) schemaNameBlocks(componentNames []string) []notionBlock {
+	blocks := []notionBlock{c.heading(c.locale.T("Schemas Used"), 4), c.paragraph(c.text("Documented in the Schemas section:"))}
+
+	for _, name := range componentNames {
+		blocks = append(blocks, c.bulletItem(c.code(name)))
+	}
+
+	return blocks
+}
+
+// componentSchemaBlocks generates blocks for a single component schema.
+func (c *NotionConverter) componentSchemaBlocks(name string, schema domain.Schema, extensions []extensionValue) []notionBlock {
+	title := c.bold(name)
+	if schema.Deprecated {
+		title = append(title, c.text(" ")...)
+		title = append(title, c.deprecatedLabel()...)
+	}
+
+	blocks := []notionBlock{c.paragraph(title)}
+
+	if typeStr := formatSchemaType(schema); typeStr != "" {
+		blocks = append(blocks, c.paragraph(c.text(fmt.Sprintf("Type: %s", typeStr))))
+	}
+
+	if description := withPlainExternalDocs(schema.Description, schema.ExternalDocs); description != "" {
+		blocks = append(blocks, c.paragraph(c.text(description)))
+	}
+
+	blocks = append(blocks, c.extensionBlocks(extensions)...)
+
+	// Properties as bullet list, with those of inline objects nested below them
+	blocks = append(blocks, c.propertyItems(nestedProperties(schema, min(c.depth, notionMaxNesting)))...)
+
+	// Alternatives of oneOf/anyOf schemas
+	if label, variants := schemaVariants(schema); len(variants) > 0 {
+		blocks = append(blocks, c.paragraph(c.text(label)))
+
+		for _, variant := range variants {
+			item := c.code(formatSchemaType(variant))
+			if variant.Ref == "" && variant.Description != "" {
+				item = append(item, c.text(": "+variant.Description)...)
+			}
+
+			blocks = append(blocks, c.bulletItem(item))
+		}
+	}
+
+	if discriminator := schema.Discriminator; discriminator != nil {
+		blocks = append(blocks, c.paragraph(append(c.text("Discriminator: "), c.code(discriminator.PropertyName)...)))
+
+		if len(discriminator.Mapping) > 0 {
+			rows := [][][]notionText{{c.text("Value"), c.text("Schema")}}
+			for _, value := range sortedDiscriminatorValues(discriminator) {
+				rows = append(rows, [][]notionText{c.code(value), c.text(extractRefName(discriminator.Mapping[value]))})
+			}
+
+			blocks = append(blocks, c.table(rows))
+		}
+	}
+
+	return blocks
+}
+
+// securitySchemeBlocks generates blocks describing every security scheme of the document.
+func (c *NotionConverter) securitySchemeBlocks(doc *domain.OpenAPIDocument) []notionBlock {
+	blocks := []notionBlock{}
+
+	for _, name := range sortedSecuritySchemes(doc) {
+		scheme := doc.SecuritySchemes[name]
+
+		blocks = append(blocks, c.paragraph(c.bold(name)))
+		blocks = append(blocks, c.paragraph(c.text(fmt.Sprintf("Type: %s", formatSecurityScheme(scheme)))))
+
+		if scheme.Description != "" {
+			blocks = append(blocks, c.paragraph(c.text(scheme.Description)))
+		}
+
+		for _, flow := range scheme.Flows {
+			blocks = append(blocks, c.paragraph(c.text(fmt.Sprintf("%s flow", formatOAuthFlow(flow.Type)))))
+
+			urls := []struct{ label, url string }{
+				{"Authorization URL", flow.AuthorizationURL},
+				{"Token URL", flow.TokenURL},
+				{"Refresh URL", flow.RefreshURL},
+			}
+
+			for _, u := range urls {
+				if u.url != "" {
+					blocks = append(blocks, c.bulletItem(c.text(fmt.Sprintf("%s: %s", u.label, u.url))))
+				}
+			}
+
+			for _, scope := range sortedScopes(flow) {
+				blocks = append(blocks, c.bulletItem(append(c.code(scope), c.text(": "+flow.Scopes[scope])...)))
+			}
+		}
+	}
+
+	return blocks
+}
+
+// operationToggle renders an endpoint as a toggle titled "METHOD /path — summary", mirroring the Confluence expands.
+// Notion accepts two levels of nested blocks per request, which fits a toggle holding tables.
+func (c *NotionConverter) operationToggle(pathStr string, operation domain.Operation, snippets []codeSnippet,
+	examples []operationExample, fields map[string][]bodyField, responses []responseFields, extensions []extensionValue,
+) notionBlock {
+	details := []notionBlock{}
+
+	if operation.Deprecated {
+		details = append(details, c.paragraph(c.deprecatedLabel()))
+	}
+
+	if description := withPlainExternalDocs(operation.Description, operation.ExternalDocs); description != "" {
+		details = append(details, c.paragraph(c.text(description)))
+	}
+
+	// Required authentication
+	if len(operation.Security) > 0 {
+		auth := c.bold("Auth: ")
+
+		for i, requirement := range operation.Security {
+			if i > 0 {
+				auth = append(auth, c.text(" or ")...)
+			}
+
+			auth = append(auth, c.styled(formatSecurityRequirement(requirement), notionAnnotations{Code: true, Color: "purple"})...)
+		}
+
+		details = append(details, c.paragraph(auth))
+	}
+
+	details = append(details, c.extensionBlocks(extensions)...)
+
+	// Parameters
+	if len(operation.Parameters) > 0 {
+		details = append(details, c.heading(c.locale.T("Parameters"), 6), c.parameterTable(operation.Parameters))
+	}
+
+	// Request body
+	if body := operation.RequestBody; body != nil {
+		details = append(details, c.heading(c.locale.T("Request Body"), 6), c.paragraph(c.bold(c.locale.T(requiredLabel(body.Required)))))
+
+		if body.Description != "" {
+			details = append(details, c.paragraph(c.text(body.Description)))
+		}
+
+		if summaries := contentSummaries(body.Content); len(summaries) > 0 {
+			rows := [][][]notionText{{c.text("Content Type"), c.text("Schema")}}
+			for _, summary := range summaries {
+				rows = append(rows, [][]notionText{c.code(summary.ContentType), c.code(summary.Schema)})
+			}
+
+			details = append(details, c.table(rows))
+
+			for _, summary := range summaries {
+				if flat, ok := fields[summary.ContentType]; ok {
+					details = append(details, c.paragraph(append(c.bold("Fields: "), c.code(summary.ContentType)...)), c.bodyFieldTable(flat))
+				}
+			}
+		}
+	}
+
+	// Responses
+	if len(operation.Responses) > 0 {
+		details = append(details, c.heading(c.locale.T("Responses"), 6), c.responseTable(operation.Responses))
+	}
+
+	// Flattened response fields, by status code and content type
+	if len(responses) > 0 {
+		details = append(details, c.heading(c.locale.T("Response Fields"), 6))
+		details = append(details, c.responseFieldBlocks(responses)...)
+	}
+
+	// Response headers
+	if headers := responseHeaders(operation.Responses); len(headers) > 0 {
+		details = append(details, c.heading(c.locale.T("Response Headers"), 6), c.responseHeaderTable(headers))
+	}
+
+	// Limits stamped by the gateway
+	if limits := operationLimits(operation); len(limits) > 0 {
+		details = append(details, c.heading(c.locale.T("Limits"), 6), c.limitTable(limits))
+	}
+
+	// Links to the operations that can follow
+	if related := relatedOperations(operation, nil); len(related) > 0 {
+		details = append(details, c.heading(c.locale.T("Related Operations"), 6))
+
+		for _, operation := range related {
+			item := append(c.text(operation.StatusCode+" \u2192 "), c.code(operation.Target)...)
+			if values := formatLinkValues(operation); values != "" {
+				item = append(item, c.text(" ("+values+")")...)
+			}
+
+			if operation.Description != "" {
+				item = append(item, c.text(" - "+operation.Description)...)
+			}
+
+			details = append(details, c.bulletItem(item))
+		}
+	}
+
+	// Callbacks
+	if callbacks := callbackRequests(operation); len(callbacks) > 0 {
+		details = append(details, c.heading(c.locale.T("Callbacks"), 6))
+		details = append(details, c.callbackBlocks(callbacks)...)
+	}
+
+	// Sample requests
+	if len(snippets) > 0 {
+		details = append(details, c.heading(c.locale.T("Example Request"), 6))
+
+		for _, snippet := range snippets {
+			details = append(details, c.paragraph(c.bold(snippet.Label)), c.codeBlock(snippet.Code, snippet.Language))
+		}
+	}
+
+	// Request and response examples
+	if examples := c.exampleBlocks(examples); len(examples) > 0 {
+		details = append(details, c.heading(c.locale.T("Examples"), 6))
+		details = append(details, examples...)
+	}
+
+	return notionBlock{
+		Type:    "toggle",
+		Content: notionContent{RichText: c.text(endpointTitle(pathStr, operation)), Children: details},
+	}
+}
+
+// callbackBlocks describes each callback request by its URL expression, payload and expected responses.
+func (c *NotionConverter) callbackBlocks(callbacks []callbackRequest) []notionBlock {
+	blocks := []notionBlock{}
+
+	for _, callback := range callbacks {
+		op := callback.Operation
+
+		title := append(c.bold(callback.Name), c.text(": ")...)
+		blocks = append(blocks, c.paragraph(append(title, c.code(formatMethod(op.Method)+" "+callback.Expression)...)))
+
+		if op.Summary != "" {
+			blocks = append(blocks, c.paragraph(c.text(op.Summary)))
+		}
+
+		if op.Description != "" {
+			blocks = append(blocks, c.paragraph(c.text(op.Description)))
+		}
+
+		if op.RequestBody != nil {
+			for _, summary := range contentSummaries(op.RequestBody.Content) {
+				blocks = append(blocks, c.bulletItem(append(c.text("Payload: "), c.code(formatMediaSummary(summary))...)))
+			}
+		}
+
+		if len(op.Responses) > 0 {
+			blocks = append(blocks, c.bulletItem(c.text("Expected responses: "+callbackResponses(op))))
+		}
+	}
+
+	return blocks
+}
+
+// exampleBlocks renders the request and response examples of an operation as labelled code blocks.
+func (c *NotionConverter) exampleBlocks(examples []operationExample) []notionBlock {
+	blocks := []notionBlock{}
+
+	for _, example := range examples {
+		blocks = append(blocks, c.paragraph(c.bold(example.Title)), c.codeBlock(formatExampleValue(example.Value), "json"))
+	}
+
+	return blocks
+}
+
+func (c *NotionConverter) parameterTable(params []domain.Parameter) notionBlock {
+	rows := [][][]notionText{{c.text("Name"), c.text("In"), c.text("Type"), c.text(c.locale.T("Required")), c.text("Description")}}
+
+	for _, param := range params {
+		required := "No"
+		if param.Required {
+			required = "Yes"
+		}
+
+		name := c.code(param.Name)
+		if param.Deprecated {
+			name = append(name, c.text(" ")...)
+			name = append(name, c.deprecatedLabel()...)
+		}
+
+		rows = append(rows, [][]notionText{
+			name,
+			c.text(param.In),
+			c.text(formatSchemaDetails(param.Schema)),
+			c.text(required),
+			c.text(param.Description),
+		})
+	}
+
+	return c.table(rows)
+}
+
+// bodyFieldTable lists request body fields with their type, whether they are required, description and constraints.
+func (c *NotionConverter) bodyFieldTable(fields []bodyField) notionBlock {
+	rows := [][][]notionText{{c.text("Field"), c.text("Type"), c.text(c.locale.T("Required")), c.text("Description"), c.text("Constraints")}}
+
+	for _, field := range fields {
+		required := "No"
+		if field.Required {
+			required = "Yes"
+		}
+
+		rows = append(rows, [][]notionText{
+			c.code(field.Path),
+			c.text(formatSchemaType(field.Schema)),
+			c.text(required),
+			c.text(field.Schema.Description),
+			c.text(joinConstraints("", schemaConstraints(field.Schema))),
+		})
+	}
+
+	return c.table(rows)
+}
+
+// responseFieldBlocks labels the flattened fields of each response with its status code, content type and schema,
+// each followed by a table of the fields with their type, format, nullability, whether they are required and description.
+func (c *NotionConverter) responseFieldBlocks(responses []responseFields) []notionBlock {
+	blocks := make([]notionBlock, 0, 2*len(responses))
+
+	for _, resp := range responses {
+		label := append(c.bold(resp.StatusCode+" "), c.code(resp.ContentType)...)
+		if resp.Schema != "" {
+			label = append(append(label, c.text(": ")...), c.code(resp.Schema)...)
+		}
+
+		rows := [][][]notionText{{
+			c.text("Field"), c.text("Type"), c.text("Format"), c.text("Nullable"), c.text(c.locale.T("Required")), c.text("Description"),
+		}}
+
+		for _, field := range resp.Fields {
+			format := []notionText{}
+			if f := fieldFormat(field.Schema); f != "" {
+				format = c.code(f)
+			}
+
+			rows = append(rows, [][]notionText{
+				c.code(field.Path),
+				c.text(fieldType(field.Schema)),
+				format,
+				c.text(yesNo(field.Schema.Nullable)),
+				c.text(yesNo(field.Required)),
+				c.text(field.Schema.Description),
+			})
+		}
+
+		blocks = append(blocks, c.paragraph(label), c.table(rows))
+	}
+
+	return blocks
+}
+
+func (c *NotionConverter) responseTable(responses []domain.Response) notionBlock {
+	rows := [][][]notionText{{c.text("Status"), c.text("Description"), c.text("Content")}}
+
+	for _, resp := range sortedResponses(responses) {
+		content := []notionText{}
+
+		for i, summary := range contentSummaries(resp.Content) {
+			if i > 0 {
+				content = append(content, c.text(", ")...)
+			}
+
+			content = append(content, c.code(summary.ContentType)...)
+			if summary.Schema != "" {
+				content = append(content, c.text(": ")...)
+				content = append(content, c.code(summary.Schema)...)
+			}
+		}
+
+		rows = append(rows, [][]notionText{c.code(resp.StatusCode), c.text(resp.Description), content})
+	}
+
+	return c.table(rows)
+}
+
+func (c *NotionConverter) responseHeaderTable(headers []responseHeader) notionBlock {
+	rows := [][][]notionText{{c.text("Status"), c.text("Header"), c.text("Type"), c.text("Description")}}
+
+	for _, header := range headers {
+		name := c.code(header.Name)
+		if header.Deprecated {
+			name = append(name, c.text(" ")...)
+			name = append(name, c.deprecatedLabel()...)
+		}
+
+		rows = append(rows, [][]notionText{
+			c.code(header.StatusCode),
+			name,
+			c.text(formatSchemaDetails(header.Schema)),
+			c.text(header.Description),
+		})
+	}
+
+	return c.table(rows)
+}
+
+func (c *NotionConverter) limitTable(limits []operationLimit) notionBlock {
+	rows := [][][]notionText{{c.text("Limit"), c.text("Value")}}
+
+	for _, limit := range limits {
+		rows = append(rows, [][]notionText{c.text(c.locale.T(limit.Label)), c.text(limit.Value)})
+	}
+
+	return c.table(rows)
+}
+
+func (c *NotionConverter) propertyItems(properties []schemaProperty) []notionBlock {
+	items := make([]notionBlock, 0, len(properties))
+
+	for _, property := range properties {
+		details := formatPropertyDetails(property.Schema, property.Required, c.locale)
+		item := c.bulletItem(append(c.code(property.Name), c.text(fmt.Sprintf(" (%s)", details))...))
+		item.Content.Children = c.propertyItems(property.Children)
+		items = append(items, item)
+	}
+
+	return items
+}
+
+// extensionBlocks renders vendor extensions as "Label: value" paragraphs with a bold label.
+func (c *NotionConverter) extensionBlocks(extensions []extensionValue) []notionBlock {
+	blocks := make([]notionBlock, 0, len(extensions))
+
+	for _, extension := range extensions {
+		blocks = append(blocks, c.paragraph(append(c.bold(extension.Label+":"), c.text(" "+extension.Value)...)))
+	}
+
+	return blocks
+}
+
+// heading returns a heading block. Notion has three heading levels, so deeper headings become bold paragraphs.
+func (c *NotionConverter) heading(text string, level int) notionBlock {
+	if level > 3 {
+		return c.paragraph(c.bold(text))
+	}
+
+	return notionBlock{Type: fmt.Sprintf("heading_%d", level), Content: notionContent{RichText: c.text(text)}}
+}
+
+func (c *NotionConverter) paragraph(content []notionText) notionBlock {
+	return notionBlock{Type: "paragraph", Content: notionContent{RichText: content}}
+}
+
+func (c *NotionConverter) bulletItem(content []notionText) notionBlock {
+	return notionBlock{Type: "bulleted_list_item", Content: notionContent{RichText: content}}
+}
+
+func (c *NotionConverter) codeBlock(code, language string) notionBlock {
+	return notionBlock{Type: "code", Content: notionContent{RichText: c.text(code), Language: language}}
+}
+
+// table builds a table block whose first row is the column header.
+func (c *NotionConverter) table(rows [][][]notionText) notionBlock {
+	table := notionBlock{Type: "table", Content: notionContent{HasColumnHeader: true}}
+
+	for _, cells := range rows {
+		table.Content.TableWidth = max(table.Content.TableWidth, len(cells))
+
+		// Empty cells must still be written as empty lists
+		row := make([][]notionText, 0, len(cells))
+		for _, cell := range cells {
+			row = append(row, append([]notionText{}, cell...))
+		}
+
+		table.Content.Children = append(table.Content.Children, notionBlock{Type: "table_row", Content: notionContent{Cells: row}})
+	}
+
+	return table
+}
+
+func (c *NotionConverter) deprecatedLabel() []notionText {
+	return c.styled(deprecatedLabel, notionAnnotations{Bold: true, Color: "red"})
+}
+
+func (c *NotionConverter) text(content string) []notionText {
+	return c.styled(content, notionAnnotations{})
+}
+
+func (c *NotionConverter) bold(content string) []notionText {
+	return c.styled(content, notionAnnotations{Bold: true})
+}
+
+func (c *NotionConverter) code(content string) []notionText {
+	return c.styled(content, notionAnnotations{Code: true})
+}
+
+// styled returns rich text with the given annotations, split into pieces within the Notion length limit.
+// Empty content yields no rich text.
+func (c *NotionConverter) styled(content string, annotations notionAnnotations) []notionText {
+	var texts []notionText
+
+	runes := []rune(content)
+	for start := 0; start < len(runes); start += notionMaxText {
+		end := min(start+notionMaxText, len(runes))
+
+		text := notionText{Type: "text", Text: notionTextContent{Content: string(runes[start:end])}}
+		if annotations != (notionAnnotations{}) {
+			text.Annotations = &annotations
+		}
+
+		texts = append(texts, text)
+	}
+
+	return texts
+}
@@ -0,0 +1,190 @@
+package converters
+
+import (
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/GabrielNunesIT/openapi-converter/internal/domain"
+)
+
+// OptionKind is the type of the value of a format option.
+type OptionKind int
+
+const (
+	BoolOption   OptionKind = iota // true or false
+	IntOption                      // Decimal integer
+	StringOption                   // Any text
+	ListOption                     // Comma-separated values
+)
+
+// optionTag is the struct tag naming the fields of an options struct that are format options.
+const optionTag = "option"
+
+// FormatOption is an option of a single output format: a field of the options struct of its converter tagged
+// option, e.g. `option:"tables"`, which is set by name from Options.Values, e.g. with --option confluence.tables=true,
+// so that formats gain options without new flags.
+type FormatOption struct {
+	Name  string
+	Kind  OptionKind
+	field []int // Index of the field in the options struct
+}
+
+// RegisterFormat adds a converter configured by the options struct O to the default registry, see
+// RegisterFormatTo.
+func RegisterFormat[O any](format string, defaults func(Options) O, build func(O) domain.Converter, aliases ...string) {
+	RegisterFormatTo(DefaultRegistry, format, defaults, build, aliases...)
+}
+
+// RegisterFormatTo makes a converter configured by the options struct O available in r under a format name and
+// optional aliases, as Registry.Register does. Registry.New fills the struct from Options with defaults, sets its
+// fields tagged option from Options.Values and builds the converter from it with build. Tagged fields must be of
+// type bool, int, string or []string; Registry.New reports the others.
+func RegisterFormatTo[O any](r *Registry, format string, defaults func(Options) O, build func(O) domain.Converter,
+	aliases ...string,
+) {
+	options, err := structOptions(reflect.TypeFor[O]())
+
+	r.register(format, options, func(opts Options) (domain.Converter, error) {
+		if err != nil {
+			return nil, fmt.Errorf("invalid options of format %s: %w", format, err)
+		}
+
+		typed := defaults(opts)
+		if err := setOptions(format, options, reflect.ValueOf(&typed).Elem(), opts.Values); err != nil {
+			return nil, err
+		}
+
+		return build(typed), nil
+	}, aliases...)
+}
+
+// FormatOptions returns the options of a format, or one of its aliases, in the order of the fields of its options
+// struct.
+func (r *Registry) FormatOptions(format string) []FormatOption {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return slices.Clone(r.options[r.aliases[strings.ToLower(format)]])
+}
+
+// Canonical returns the format name a format name or alias stands for, reporting false for an unknown format.
+func (r *Registry) Canonical(format string) (string, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	canonical, ok := r.aliases[strings.ToLower(format)]
+
+	return canonical, ok
+}
+
+// structOptions returns the options of the fields of an options struct tagged option.
+func structOptions(t reflect.Type) ([]FormatOption, error) {
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("%s is not a struct", t)
+	}
+
+	options := []FormatOption{}
+
+	for _, field := range reflect.VisibleFields(t) {
+		name, tagged := field.Tag.Lookup(optionTag)
+		if !tagged {
+			continue
+		}
+
+		var kind OptionKind
+
+		switch {
+		case field.Type.Kind() == reflect.Bool:
+			kind = BoolOption
+		case field.Type.Kind() == reflect.Int:
+			kind = IntOption
+		case field.Type.Kind() == reflect.String:
+			kind = StringOption
+		case field.Type.Kind() == reflect.Slice && field.Type.Elem().Kind() == reflect.String:
+			kind = ListOption
+		default:
+			return nil, fmt.Errorf("option %s of field %s has unsupported type %s", name, field.Name, field.Type)
+		}
+
+		if name == "" || slices.ContainsFunc(options, func(option FormatOption) bool { return option.Name == name }) {
+			return nil, fmt.Errorf("field %s has an empty or duplicate option name %q", field.Name, name)
+		}
+
+		options = append(options, FormatOption{Name: name, Kind: kind, field: field.Index})
+	}
+
+	return options, nil
+}
+
+// setOptions sets the fields of the options struct s standing for the options values are given for.
+func setOptions(format string, options []FormatOption, s reflect.Value, values map[string]string) error {
+	for _, name := range slices.Sorted(maps.Keys(values)) {
+		i := slices.IndexFunc(options, func(option FormatOption) bool { return option.Name == name })
+		if i < 0 {
+			return unknownOption(format, name, options)
+		}
+
+		if err := options[i].set(s.FieldByIndex(options[i].field), values[name]); err != nil {
+			return fmt.Errorf("invalid option %s of format %s: %w", name, format, err)
+		}
+	}
+
+	return nil
+}
+
+// unknownOption returns the error of an option a format does not have, listing those it has.
+func unknownOption(format, name string, options []FormatOption) error {
+	if len(options) == 0 {
+		return fmt.Errorf("unknown option %s: format %s has no options", name, format)
+	}
+
+	names := make([]string, 0, len(options))
+	for _, option := range options {
+		names = append(names, option.Name)
+	}
+
+	return fmt.Errorf("unknown option %s of format %s (options: %s)", name, format, strings.Join(names, ", "))
+}
+
+// set parses a value of the kind of the option into a field.
+func (o FormatOption) set(field reflect.Value, value string) error {
+	switch o.Kind {
+	case BoolOption:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("%q is not a boolean", value)
+		}
+
+		field.SetBool(b)
+	case IntOption:
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("%q is not an integer", value)
+		}
+
+		field.SetInt(int64(n))
+	case StringOption:
+		field.SetString(value)
+	case ListOption:
+		field.Set(reflect.ValueOf(parseList(value)).Convert(field.Type()))
+	}
+
+	return nil
+}
+
+// parseList splits a list option on commas, trimming the values and dropping empty ones.
+func parseList(value string) []string {
+	values := []string{}
+
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			values = append(values, item)
+		}
+	}
+
+	return values
+}
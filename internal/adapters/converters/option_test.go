@@ -0,0 +1,208 @@
+package converters_test
+
+import (
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/GabrielNunesIT/openapi-converter/internal/adapters/converters"
+	"github.com/GabrielNunesIT/openapi-converter/internal/domain"
+)
+
+// testOptions is the options struct of the test format.
+type testOptions struct {
+	Tables   bool     `option:"tables"`
+	Depth    int      `option:"depth"`
+	Heading  string   `option:"heading"`
+	Labels   []string `option:"labels"`
+	Untagged string
+}
+
+// optionsConverter is a converter holding the options it was built with.
+type optionsConverter struct {
+	opts testOptions
+}
+
+func (c *optionsConverter) Convert(*domain.OpenAPIDocument, io.Writer) error { return nil }
+
+func (c *optionsConverter) Format() string { return "test" }
+
+// testRegistry returns a registry of the test format, aliased tst, whose options default to the matching Options.
+func testRegistry() *converters.Registry {
+	registry := converters.NewRegistry()
+
+	converters.RegisterFormatTo(registry, "test", func(opts converters.Options) testOptions {
+		return testOptions{Tables: opts.Tables, Depth: opts.SchemaDepth, Labels: opts.Snippets, Untagged: "default"}
+	}, func(opts testOptions) domain.Converter { return &optionsConverter{opts: opts} }, "tst")
+
+	return registry
+}
+
+func TestRegistryNew(t *testing.T) {
+	tests := []struct {
+		name    string
+		format  string
+		opts    converters.Options
+		want    testOptions
+		wantErr string
+	}{
+		{
+			name:   "defaults",
+			format: "test",
+			opts:   converters.Options{Tables: true, SchemaDepth: 2, Snippets: []string{"go"}},
+			want:   testOptions{Tables: true, Depth: 2, Labels: []string{"go"}, Untagged: "default"},
+		},
+		{
+			name:   "alias",
+			format: "TST",
+			want:   testOptions{Untagged: "default"},
+		},
+		{
+			name:   "values",
+			format: "test",
+			opts: converters.Options{Values: map[string]string{
+				"tables": "true", "depth": "4", "heading": "API", "labels": "a, b,,c ",
+			}},
+			want: testOptions{Tables: true, Depth: 4, Heading: "API", Labels: []string{"a", "b", "c"}, Untagged: "default"},
+		},
+		{
+			name:   "values override the fields",
+			format: "test",
+			opts: converters.Options{Tables: true, SchemaDepth: 2, Values: map[string]string{
+				"tables": "false", "depth": "0", "labels": "",
+			}},
+			want: testOptions{Labels: []string{}, Untagged: "default"},
+		},
+		{
+			name:    "unknown format",
+			format:  "bogus",
+			wantErr: "unsupported format: bogus (supported: test)",
+		},
+		{
+			name:    "unknown option",
+			format:  "test",
+			opts:    converters.Options{Values: map[string]string{"width": "3"}},
+			wantErr: "unknown option width of format test (options: tables, depth, heading, labels)",
+		},
+		{
+			name:    "untagged field",
+			format:  "test",
+			opts:    converters.Options{Values: map[string]string{"Untagged": "x"}},
+			wantErr: "unknown option Untagged of format test",
+		},
+		{
+			name:    "bad bool",
+			format:  "test",
+			opts:    converters.Options{Values: map[string]string{"tables": "yes"}},
+			wantErr: `invalid option tables of format test: "yes" is not a boolean`,
+		},
+		{
+			name:    "bad int",
+			format:  "test",
+			opts:    converters.Options{Values: map[string]string{"depth": "2.5"}},
+			wantErr: `invalid option depth of format test: "2.5" is not an integer`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			converter, err := testRegistry().New(tt.format, tt.opts)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("got error %v, want %q", err, tt.wantErr)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("new: %v", err)
+			}
+
+			got := converter.(*optionsConverter).opts //nolint:forcetypeassert // the test format builds only these
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got options %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRegisterFormatInvalidOptions(t *testing.T) {
+	type unsupported struct {
+		Ratio float64 `option:"ratio"`
+	}
+
+	type duplicate struct {
+		A bool `option:"same"`
+		B bool `option:"same"`
+	}
+
+	tests := []struct {
+		name     string
+		register func(*converters.Registry)
+		wantErr  string
+	}{
+		{
+			name: "unsupported type",
+			register: func(r *converters.Registry) {
+				converters.RegisterFormatTo(r, "bad", func(converters.Options) unsupported { return unsupported{} },
+					func(unsupported) domain.Converter { return &optionsConverter{} })
+			},
+			wantErr: "invalid options of format bad: option ratio of field Ratio has unsupported type float64",
+		},
+		{
+			name: "duplicate name",
+			register: func(r *converters.Registry) {
+				converters.RegisterFormatTo(r, "bad", func(converters.Options) duplicate { return duplicate{} },
+					func(duplicate) domain.Converter { return &optionsConverter{} })
+			},
+			wantErr: `invalid options of format bad: field B has an empty or duplicate option name "same"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registry := converters.NewRegistry()
+			tt.register(registry)
+
+			if _, err := registry.New("bad", converters.Options{}); err == nil || err.Error() != tt.wantErr {
+				t.Errorf("got error %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRegisterFormatTwice(t *testing.T) {
+	for _, name := range []string{"test", "TST", "Test"} {
+		t.Run(name, func(t *testing.T) {
+			registry := testRegistry()
+
+			defer func() {
+				if recover() == nil {
+					t.Errorf("registering %s again did not panic", name)
+				}
+			}()
+
+			converters.RegisterFormatTo(registry, name, func(converters.Options) testOptions { return testOptions{} },
+				func(testOptions) domain.Converter { return &optionsConverter{} })
+		})
+	}
+}
+
+func TestFormatOptions(t *testing.T) {
+	registry := testRegistry()
+
+	var got []string
+	for _, option := range registry.FormatOptions("tst") {
+		got = append(got, option.Name)
+	}
+
+	if want := []string{"tables", "depth", "heading", "labels"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got options %q, want %q", got, want)
+	}
+
+	if canonical, ok := registry.Canonical("TST"); !ok || canonical != "test" {
+		t.Errorf("got canonical format %q, %v, want test", canonical, ok)
+	}
+}
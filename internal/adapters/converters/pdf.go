@@ -3,7 +3,9 @@ package converters
 import (
 	"fmt"
 	"io"
+	"slices"
 	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/GabrielNunesIT/openapi-converter/internal/domain"
@@ -17,27 +19,54 @@ const (
 	pdfMarginTop   = 10.0
 	pdfMarginRight = 10.0
 	pdfLineHeight  = 5.0
+
+	// pdfHeaderHeight is the space kept above the content of each page for the running header.
+	pdfHeaderHeight = 8.0
 )
 
 // PDFConverter converts OpenAPI documents to PDF format.
+// Documents start with a title page and a table of contents with page numbers, followed by one chapter per tag.
+// Every other page has a header with the API title, version and current chapter, and a page number footer.
 type PDFConverter struct {
+	appendix       bool // Render component schemas once in an appendix rather than under every tag
 	pdf            *gofpdf.Fpdf
 	tocItems       []tocItem
 	linkID         int
 	componentLinks map[string]int // Map "tag:component" to link ID
 	currentTag     string         // Current tag context for link resolution
+	chapter        string         // Chapter shown in the page header
+	locale         Locale         // Translations of the fixed strings, nil for English
+	encode         func(string) string
 }
 
 type tocItem struct {
-	title  string
-	level  int
-	linkID int
-	page   int
+	title   string
+	level   int
+	linkID  int
+	page    int
+	outline int // Level in the document outline
+}
+
+// PDFOptions configures a PDFConverter. The fields tagged option can also be set by name, e.g. with
+// --option pdf.schema-appendix=true, see RegisterFormat.
+type PDFOptions struct {
+	// SchemaAppendix renders every component schema once in a "Schemas" chapter, the "Schemas Used" section of each
+	// tag linking to it.
+	SchemaAppendix bool `option:"schema-appendix"`
+
+	// Locale translates the chapter titles, headings and other fixed strings of the document, nil for English.
+	Locale Locale
 }
 
 // NewPDFConverter creates a new PDF converter.
-func NewPDFConverter() *PDFConverter {
-	return &PDFConverter{}
+func NewPDFConverter(opts PDFOptions) *PDFConverter {
+	return &PDFConverter{appendix: opts.SchemaAppendix, locale: opts.Locale}
+}
+
+func init() { //nolint:gochecknoinits // converters self-register with the default registry
+	RegisterFormat(pdfFormat, func(opts Options) PDFOptions {
+		return PDFOptions{SchemaAppendix: opts.SchemaAppendix, Locale: opts.Locale}
+	}, func(opts PDFOptions) domain.Converter { return NewPDFConverter(opts) })
 }
 
 // Format returns the output format name.
@@ -48,12 +77,18 @@ func (c *PDFConverter) Format() string {
 // Convert transforms an OpenAPI document to PDF format.
 func (c *PDFConverter) Convert(doc *domain.OpenAPIDocument, output io.Writer) error {
 	c.pdf = gofpdf.New("P", "mm", "A4", "")
-	c.pdf.SetMargins(pdfMarginLeft, pdfMarginTop, pdfMarginRight)
+	c.pdf.SetMargins(pdfMarginLeft, pdfMarginTop+pdfHeaderHeight, pdfMarginRight)
 	c.pdf.SetDrawColor(180, 180, 180) // Light gray for all borders
 	c.tocItems = nil
 	c.linkID = 0
 	c.componentLinks = make(map[string]int)
 	c.currentTag = ""
+	c.chapter = ""
+	c.encode = c.pdf.UnicodeTranslatorFromDescriptor("")
+
+	c.pdf.AliasNbPages("")
+	c.pdf.SetHeaderFuncMode(func() { c.addPageHeader(doc) }, true)
+	c.pdf.SetFooterFunc(c.addPageFooter)
 
 	// First pass: collect TOC items with placeholder pages
 	c.collectTOC(doc)
@@ -67,36 +102,89 @@ func (c *PDFConverter) Convert(doc *domain.OpenAPIDocument, output io.Writer) er
 	// Content pages
 	c.addContent(doc)
 
+	// The table of contents was written before the pages were known
+	for i, item := range c.tocItems {
+		c.pdf.RegisterAlias(tocPageAlias(i), strconv.Itoa(item.page))
+	}
+
 	return c.pdf.Output(output)
 }
 
+// addPageHeader writes the API title and version, and the current chapter, above the content of every page but the title page.
+func (c *PDFConverter) addPageHeader(doc *domain.OpenAPIDocument) {
+	if c.pdf.PageNo() == 1 {
+		return
+	}
+
+	c.pdf.SetY(pdfMarginTop)
+	c.pdf.SetFont("Arial", "", 8)
+	c.pdf.SetTextColor(128, 128, 128)
+	c.pdf.CellFormat(pdfPageWidth/2, 4, fmt.Sprintf("%s - Version %s", doc.Title, doc.Version), "", 0, "L", false, 0, "")
+	c.pdf.CellFormat(pdfPageWidth/2, 4, c.chapter, "", 0, "R", false, 0, "")
+	c.pdf.SetTextColor(0, 0, 0)
+
+	lineY := pdfMarginTop + 5
+	c.pdf.Line(pdfMarginLeft, lineY, pdfMarginLeft+pdfPageWidth, lineY)
+}
+
+// addPageFooter writes "Page N of M" at the bottom of every page but the title page.
+func (c *PDFConverter) addPageFooter() {
+	if c.pdf.PageNo() == 1 {
+		return
+	}
+
+	c.pdf.SetY(-12)
+	c.pdf.SetFont("Arial", "", 8)
+	c.pdf.SetTextColor(128, 128, 128)
+	c.pdf.CellFormat(pdfPageWidth, 5, fmt.Sprintf("Page %d of {nb}", c.pdf.PageNo()), "", 0, "C", false, 0, "")
+	c.pdf.SetTextColor(0, 0, 0)
+}
+
+// tocPageAlias is the placeholder written in the table of contents for the page number of an entry.
+func tocPageAlias(index int) string {
+	return fmt.Sprintf("{toc:%d}", index)
+}
+
 func (c *PDFConverter) collectTOC(doc *domain.OpenAPIDocument) {
 	// Add main sections to TOC
-	c.tocItems = append(c.tocItems, tocItem{title: "Overview", level: 1, linkID: c.pdf.AddLink()})
+	c.tocItems = append(c.tocItems, tocItem{title: c.t("Overview"), level: 1, linkID: c.pdf.AddLink()})
 
 	if len(doc.Servers) > 0 {
-		c.tocItems = append(c.tocItems, tocItem{title: "Servers", level: 1, linkID: c.pdf.AddLink()})
+		c.tocItems = append(c.tocItems, tocItem{title: c.t("Servers"), level: 1, linkID: c.pdf.AddLink()})
+	}
+
+	if len(doc.SecuritySchemes) > 0 {
+		c.tocItems = append(c.tocItems, tocItem{title: c.t("Authentication"), level: 1, linkID: c.pdf.AddLink()})
 	}
 
 	// Group paths by tags
-	tagPaths := c.groupPathsByTag(doc)
-	tags := make([]string, 0, len(tagPaths))
-	for tag := range tagPaths {
-		tags = append(tags, tag)
+	tagPaths := groupPathsByTag(doc)
+	tags := orderedTags(doc, tagPaths)
+
+	// With an appendix, every schema has a single entry that all tags link to, including webhooks without a tag
+	appendixLinks := make(map[string]int)
+	if c.appendix {
+		for _, name := range sortedComponentNames(doc.Components) {
+			appendixLinks[name] = c.pdf.AddLink()
+			c.componentLinks[":"+name] = appendixLinks[name]
+		}
 	}
-	sort.Strings(tags)
 
 	// Pre-create links for all tag+component combinations
 	for _, tag := range tags {
-		tagComponents := c.collectTagComponents(tagPaths[tag])
+		tagComponents := collectTagComponents(tagPaths[tag])
 		for _, compName := range tagComponents {
 			key := tag + ":" + compName
-			c.componentLinks[key] = c.pdf.AddLink()
+			if c.appendix {
+				c.componentLinks[key] = appendixLinks[compName]
+			} else {
+				c.componentLinks[key] = c.pdf.AddLink()
+			}
 		}
 	}
 
 	// Add Endpoints section
-	c.tocItems = append(c.tocItems, tocItem{title: "API Endpoints", level: 1, linkID: c.pdf.AddLink()})
+	c.tocItems = append(c.tocItems, tocItem{title: c.t("API Endpoints"), level: 1, linkID: c.pdf.AddLink()})
 
 	for _, tag := range tags {
 		c.tocItems = append(c.tocItems, tocItem{title: tag, level: 2, linkID: c.pdf.AddLink()})
@@ -106,94 +194,25 @@ func (c *PDFConverter) collectTOC(doc *domain.OpenAPIDocument) {
 			c.tocItems = append(c.tocItems, tocItem{title: title, level: 3, linkID: c.pdf.AddLink()})
 		}
 	}
-}
-
-// collectTagComponents gathers all unique component names used by endpoints in a tag.
-func (c *PDFConverter) collectTagComponents(endpoints []endpointRef) []string {
-	componentSet := make(map[string]struct{})
 
-	for _, ep := range endpoints {
-		// Check request body
-		if ep.operation.RequestBody != nil {
-			for _, media := range ep.operation.RequestBody.Content {
-				c.collectSchemaRefs(media.Schema, componentSet)
-			}
-		}
+	// Add Webhooks section
+	if len(doc.Webhooks) > 0 {
+		c.tocItems = append(c.tocItems, tocItem{title: c.t("Webhooks"), level: 1, linkID: c.pdf.AddLink()})
 
-		// Check responses
-		for _, resp := range ep.operation.Responses {
-			for _, media := range resp.Content {
-				c.collectSchemaRefs(media.Schema, componentSet)
-			}
-		}
-
-		// Check parameters
-		for _, param := range ep.operation.Parameters {
-			c.collectSchemaRefs(param.Schema, componentSet)
+		for _, ep := range webhookRefs(doc) {
+			title := fmt.Sprintf("%s %s", ep.method, ep.path)
+			c.tocItems = append(c.tocItems, tocItem{title: title, level: 3, linkID: c.pdf.AddLink()})
 		}
 	}
 
-	// Convert set to sorted slice
-	components := make([]string, 0, len(componentSet))
-	for name := range componentSet {
-		components = append(components, name)
-	}
-	sort.Strings(components)
-	return components
-}
-
-// collectSchemaRefs recursively collects component references from a schema.
-func (c *PDFConverter) collectSchemaRefs(schema domain.Schema, refs map[string]struct{}) {
-	if schema.Ref != "" {
-		refs[extractRefName(schema.Ref)] = struct{}{}
-	}
-
-	for _, prop := range schema.Properties {
-		c.collectSchemaRefs(prop, refs)
-	}
-
-	if schema.Items != nil {
-		c.collectSchemaRefs(*schema.Items, refs)
-	}
-}
-
-type endpointRef struct {
-	path      string
-	method    string
-	operation domain.Operation
-}
-
-func (c *PDFConverter) groupPathsByTag(doc *domain.OpenAPIDocument) map[string][]endpointRef {
-	result := make(map[string][]endpointRef)
-
-	for _, path := range doc.Paths {
-		for _, op := range path.Operations {
-			tags := op.Tags
-			if len(tags) == 0 {
-				tags = []string{"Default"}
-			}
+	// Add Schemas appendix
+	if len(appendixLinks) > 0 {
+		c.tocItems = append(c.tocItems, tocItem{title: c.t("Schemas"), level: 1, linkID: c.pdf.AddLink()})
 
-			for _, tag := range tags {
-				result[tag] = append(result[tag], endpointRef{
-					path:      path.Path,
-					method:    op.Method,
-					operation: op,
-				})
-			}
+		for _, name := range sortedComponentNames(doc.Components) {
+			c.tocItems = append(c.tocItems, tocItem{title: name, level: 2, linkID: appendixLinks[name]})
 		}
 	}
-
-	// Sort endpoints within each tag by path then method
-	for tag := range result {
-		sort.Slice(result[tag], func(i, j int) bool {
-			if result[tag][i].path == result[tag][j].path {
-				return result[tag][i].method < result[tag][j].method
-			}
-			return result[tag][i].path < result[tag][j].path
-		})
-	}
-
-	return result
 }
 
 func (c *PDFConverter) addTitlePage(doc *domain.OpenAPIDocument) {
@@ -236,10 +255,10 @@ func (c *PDFConverter) addTableOfContents() {
 	c.pdf.AddPage()
 
 	c.pdf.SetFont("Arial", "B", 20)
-	c.pdf.CellFormat(pdfPageWidth, 10, "Table of Contents", "", 1, "", false, 0, "")
+	c.pdf.CellFormat(pdfPageWidth, 10, c.t("Table of Contents"), "", 1, "", false, 0, "")
 	c.pdf.Ln(8)
 
-	for _, item := range c.tocItems {
+	for i, item := range c.tocItems {
 		indent := float64(item.level-1) * 8
 
 		switch item.level {
@@ -251,13 +270,14 @@ func (c *PDFConverter) addTableOfContents() {
 			c.pdf.SetFont("Arial", "", 9)
 		}
 
-		// Title with link
+		// Title and page number with link
 		c.pdf.SetX(pdfMarginLeft + indent)
 		title := item.title
 		if len(title) > 60 {
 			title = title[:57] + "..."
 		}
-		c.pdf.CellFormat(pdfPageWidth-indent, pdfLineHeight, title, "", 1, "", false, item.linkID, "")
+		c.pdf.CellFormat(pdfPageWidth-indent-15, pdfLineHeight, title, "", 0, "", false, item.linkID, "")
+		c.pdf.CellFormat(15, pdfLineHeight, tocPageAlias(i), "", 1, "R", false, item.linkID, "")
 	}
 }
 
@@ -265,15 +285,30 @@ func (c *PDFConverter) addContent(doc *domain.OpenAPIDocument) {
 	tocIndex := 0
 
 	// Overview section
+	c.chapter = c.t("Overview")
 	c.pdf.AddPage()
 	c.setLinkDest(tocIndex)
 	tocIndex++
 
-	c.addSectionHeader("Overview")
+	c.addSectionHeader(c.t("Overview"))
 
-	if doc.Description != "" {
+	if preamble := strings.TrimSpace(doc.Preamble); preamble != "" {
 		c.pdf.SetFont("Arial", "", 10)
-		c.pdf.MultiCell(pdfPageWidth, 5, stripHTML(doc.Description), "", "", false)
+		c.pdf.MultiCell(pdfPageWidth, 5, stripHTML(preamble), "", "", false)
+		c.pdf.Ln(4)
+	}
+
+	if entries := aboutEntries(doc); len(entries) > 0 {
+		c.pdf.SetFont("Arial", "", 10)
+		for _, entry := range entries {
+			c.pdf.MultiCell(pdfPageWidth, 5, entry.plain(), "", "", false)
+		}
+		c.pdf.Ln(4)
+	}
+
+	if description := withPlainExternalDocs(doc.Description, doc.ExternalDocs); description != "" {
+		c.pdf.SetFont("Arial", "", 10)
+		c.pdf.MultiCell(pdfPageWidth, 5, stripHTML(description), "", "", false)
 		c.pdf.Ln(4)
 	}
 
@@ -283,7 +318,7 @@ func (c *PDFConverter) addContent(doc *domain.OpenAPIDocument) {
 		c.setLinkDest(tocIndex)
 		tocIndex++
 
-		c.addSectionHeader("Servers")
+		c.addSectionHeader(c.t("Servers"))
 
 		for _, server := range doc.Servers {
 			c.pdf.SetFont("Arial", "B", 10)
@@ -302,24 +337,36 @@ func (c *PDFConverter) addContent(doc *domain.OpenAPIDocument) {
 		c.pdf.Ln(4)
 	}
 
+	// Authentication
+	if len(doc.SecuritySchemes) > 0 {
+		c.checkPageBreak(40)
+		c.setLinkDest(tocIndex)
+		tocIndex++
+
+		c.addSectionHeader(c.t("Authentication"))
+		c.addSecuritySchemes(doc)
+	}
+
 	// API Endpoints header
+	c.chapter = c.t("API Endpoints")
 	c.pdf.AddPage()
 	c.setLinkDest(tocIndex)
 	tocIndex++
 
-	c.addSectionHeader("API Endpoints")
+	c.addSectionHeader(c.t("API Endpoints"))
 	c.pdf.Ln(4)
 
 	// Group by tags
-	tagPaths := c.groupPathsByTag(doc)
-	tags := make([]string, 0, len(tagPaths))
-	for tag := range tagPaths {
-		tags = append(tags, tag)
-	}
-	sort.Strings(tags)
+	tagPaths := groupPathsByTag(doc)
+	tags := orderedTags(doc, tagPaths)
+
+	// Each tag is a chapter starting on its own page
+	for i, tag := range tags {
+		c.chapter = tag
+		if i > 0 {
+			c.pdf.AddPage()
+		}
 
-	for _, tag := range tags {
-		c.checkPageBreak(30)
 		c.setLinkDest(tocIndex)
 		tocIndex++
 
@@ -329,13 +376,24 @@ func (c *PDFConverter) addContent(doc *domain.OpenAPIDocument) {
 		c.pdf.CellFormat(pdfPageWidth, 8, tag, "", 1, "", true, 0, "")
 		c.pdf.Ln(4)
 
+		info := tagInfo(doc, tag)
+		if description := withPlainExternalDocs(info.Description, info.ExternalDocs); description != "" {
+			c.pdf.SetFont("Arial", "", 10)
+			c.pdf.MultiCell(pdfPageWidth, 5, stripHTML(description), "", "", false)
+			c.pdf.Ln(4)
+		}
+
 		// Set current tag context for link resolution
 		c.currentTag = tag
 
 		// Add components used by this tag's endpoints at the top
-		tagComponents := c.collectTagComponents(tagPaths[tag])
+		tagComponents := collectTagComponents(tagPaths[tag])
 		if len(tagComponents) > 0 {
-			c.addTagComponents(tag, tagComponents, doc.Components)
+			if c.appendix {
+				c.addTagComponentLinks(tag, tagComponents)
+			} else {
+				c.addTagComponents(tag, tagComponents, doc.Components)
+			}
 		}
 
 		for _, ep := range tagPaths[tag] {
@@ -348,12 +406,120 @@ func (c *PDFConverter) addContent(doc *domain.OpenAPIDocument) {
 
 		c.pdf.Ln(4)
 	}
+
+	// Webhooks
+	if len(doc.Webhooks) > 0 {
+		c.chapter = c.t("Webhooks")
+		c.pdf.AddPage()
+		c.setLinkDest(tocIndex)
+		tocIndex++
+
+		c.addSectionHeader(c.t("Webhooks"))
+		c.currentTag = ""
+
+		for _, ep := range webhookRefs(doc) {
+			c.checkPageBreak(50)
+			c.setLinkDest(tocIndex)
+			tocIndex++
+
+			c.addEndpoint(ep.path, ep.operation)
+		}
+	}
+
+	// Schemas appendix
+	if c.appendix && len(doc.Components) > 0 {
+		c.chapter = c.t("Schemas")
+		c.pdf.AddPage()
+		c.setLinkDest(tocIndex)
+		tocIndex++
+
+		c.addSectionHeader(c.t("Schemas"))
+		c.currentTag = ""
+
+		for _, name := range sortedComponentNames(doc.Components) {
+			c.checkPageBreak(30)
+			c.setLinkDest(tocIndex)
+			tocIndex++
+
+			c.addComponentSchema(name, flattenAllOf(doc.Components[name], doc.Components))
+		}
+	}
+
+	if epilogue := strings.TrimSpace(doc.Epilogue); epilogue != "" {
+		c.checkPageBreak(20)
+		c.pdf.SetFont("Arial", "", 10)
+		c.pdf.MultiCell(pdfPageWidth, 5, stripHTML(epilogue), "", "", false)
+	}
+}
+
+func (c *PDFConverter) addSecuritySchemes(doc *domain.OpenAPIDocument) {
+	for _, name := range sortedSecuritySchemes(doc) {
+		scheme := doc.SecuritySchemes[name]
+
+		c.checkPageBreak(20)
+		c.pdf.SetFont("Arial", "B", 11)
+		c.pdf.CellFormat(pdfPageWidth, 6, name, "", 1, "", false, 0, "")
+
+		c.pdf.SetFont("Arial", "", 9)
+		c.pdf.MultiCell(pdfPageWidth, 5, formatSecurityScheme(scheme), "", "", false)
+
+		if scheme.Description != "" {
+			c.pdf.SetTextColor(100, 100, 100)
+			c.pdf.MultiCell(pdfPageWidth, 4, stripHTML(scheme.Description), "", "", false)
+			c.pdf.SetTextColor(0, 0, 0)
+		}
+
+		for _, flow := range scheme.Flows {
+			c.pdf.Ln(1)
+			c.pdf.SetFont("Arial", "B", 9)
+			c.pdf.CellFormat(pdfPageWidth, 5, fmt.Sprintf("%s flow", formatOAuthFlow(flow.Type)), "", 1, "", false, 0, "")
+			c.pdf.SetFont("Arial", "", 8)
+
+			if flow.AuthorizationURL != "" {
+				c.pdf.CellFormat(pdfPageWidth, 4, "Authorization URL: "+flow.AuthorizationURL, "", 1, "", false, 0, "")
+			}
+
+			if flow.TokenURL != "" {
+				c.pdf.CellFormat(pdfPageWidth, 4, "Token URL: "+flow.TokenURL, "", 1, "", false, 0, "")
+			}
+
+			if flow.RefreshURL != "" {
+				c.pdf.CellFormat(pdfPageWidth, 4, "Refresh URL: "+flow.RefreshURL, "", 1, "", false, 0, "")
+			}
+
+			for _, scope := range sortedScopes(flow) {
+				c.pdf.MultiCell(pdfPageWidth, 4, fmt.Sprintf("  - %s: %s", scope, flow.Scopes[scope]), "", "", false)
+			}
+		}
+
+		c.pdf.Ln(4)
+	}
 }
 
+// setLinkDest points a table of contents entry at the current position, recording its page
+// and adding it to the document outline shown by PDF viewers.
 func (c *PDFConverter) setLinkDest(tocIndex int) {
-	if tocIndex < len(c.tocItems) {
-		c.pdf.SetLink(c.tocItems[tocIndex].linkID, -1, -1)
+	if tocIndex >= len(c.tocItems) {
+		return
 	}
+
+	item := &c.tocItems[tocIndex]
+	item.page = c.pdf.PageNo()
+	c.pdf.SetLink(item.linkID, -1, -1)
+
+	// Outline levels may only deepen one step at a time
+	level := item.level - 1
+	if tocIndex > 0 {
+		level = min(level, c.tocItems[tocIndex-1].outline+1)
+	}
+
+	item.outline = level
+	c.pdf.Bookmark(item.title, level, -1)
+}
+
+// t translates a fixed string, encoded for the core fonts of the document, which cover Western European languages.
+func (c *PDFConverter) t(text string) string {
+	return c.encode(c.locale.T(text))
 }
 
 func (c *PDFConverter) addSectionHeader(title string) {
@@ -386,10 +552,23 @@ func (c *PDFConverter) addEndpoint(pathStr string, op domain.Operation) {
 	methodWidth := float64(len(op.Method)*3) + 8
 	c.pdf.CellFormat(methodWidth, 7, op.Method, "", 0, "C", true, 0, "")
 
-	// Path
+	// Path, struck through and labelled when deprecated
 	c.pdf.SetTextColor(0, 0, 0)
-	c.pdf.SetFont("Arial", "B", 11)
-	c.pdf.CellFormat(pdfPageWidth-methodWidth, 7, " "+pathStr, "", 1, "", false, 0, "")
+
+	if op.Deprecated {
+		c.pdf.SetFont("Arial", "BS", 11)
+		pathWidth := c.pdf.GetStringWidth(" "+pathStr) + 2
+		c.pdf.CellFormat(pathWidth, 7, " "+pathStr, "", 0, "", false, 0, "")
+
+		c.pdf.SetFont("Arial", "B", 9)
+		c.pdf.SetTextColor(180, 0, 0)
+		c.pdf.CellFormat(pdfPageWidth-methodWidth-pathWidth, 7, strings.ToUpper(deprecatedLabel), "", 1, "", false, 0, "")
+		c.pdf.SetTextColor(0, 0, 0)
+	} else {
+		c.pdf.SetFont("Arial", "B", 11)
+		c.pdf.CellFormat(pdfPageWidth-methodWidth, 7, " "+pathStr, "", 1, "", false, 0, "")
+	}
+
 	c.pdf.Ln(2)
 
 	// Operation ID
@@ -400,6 +579,14 @@ func (c *PDFConverter) addEndpoint(pathStr string, op domain.Operation) {
 		c.pdf.SetTextColor(0, 0, 0)
 	}
 
+	// Authentication
+	if labels := securityLabels(op); len(labels) > 0 {
+		c.pdf.SetFont("Arial", "", 8)
+		c.pdf.SetTextColor(64, 50, 148)
+		c.pdf.MultiCell(pdfPageWidth, 4, fmt.Sprintf("Authentication: %s", strings.Join(labels, " or ")), "", "", false)
+		c.pdf.SetTextColor(0, 0, 0)
+	}
+
 	// Summary
 	if op.Summary != "" {
 		c.pdf.SetFont("Arial", "B", 10)
@@ -407,34 +594,64 @@ func (c *PDFConverter) addEndpoint(pathStr string, op domain.Operation) {
 	}
 
 	// Description
-	if op.Description != "" {
+	if op.Description != "" || op.ExternalDocs != nil {
 		c.pdf.SetFont("Arial", "", 9)
 		desc := stripHTML(op.Description)
 		if len(desc) > 500 {
 			desc = desc[:500] + "..."
 		}
-		c.pdf.MultiCell(pdfPageWidth, 4, desc, "", "", false)
+		c.pdf.MultiCell(pdfPageWidth, 4, withPlainExternalDocs(desc, op.ExternalDocs), "", "", false)
 	}
 	c.pdf.Ln(2)
 
 	// Parameters
 	if len(op.Parameters) > 0 {
-		c.addSubHeader("Parameters")
+		c.addSubHeader(c.t("Parameters"))
 		c.addParameterTable(op.Parameters)
 	}
 
 	// Request Body
 	if op.RequestBody != nil {
-		c.addSubHeader("Request Body")
+		c.addSubHeader(c.t("Request Body"))
 		c.addRequestBody(op.RequestBody)
 	}
 
 	// Responses
 	if len(op.Responses) > 0 {
-		c.addSubHeader("Responses")
+		c.addSubHeader(c.t("Responses"))
 		c.addResponseTable(op.Responses)
 	}
 
+	// Response headers
+	if headers := responseHeaders(op.Responses); len(headers) > 0 {
+		c.addSubHeader(c.t("Response Headers"))
+		c.addResponseHeaderTable(headers)
+	}
+
+	// Limits stamped by the gateway
+	if limits := operationLimits(op); len(limits) > 0 {
+		c.addSubHeader(c.t("Limits"))
+		c.addLimitTable(limits)
+	}
+
+	// Links to the operations that can follow
+	if related := relatedOperations(op, nil); len(related) > 0 {
+		c.addSubHeader(c.t("Related Operations"))
+		c.pdf.SetFont("Arial", "", 8)
+
+		for _, operation := range related {
+			c.pdf.MultiCell(pdfPageWidth, 4, fmt.Sprintf("%s -> %s", operation.StatusCode, formatRelatedOperation(operation)), "", "", false)
+		}
+
+		c.pdf.Ln(2)
+	}
+
+	// Callbacks
+	if callbacks := callbackRequests(op); len(callbacks) > 0 {
+		c.addSubHeader(c.t("Callbacks"))
+		c.addCallbacks(callbacks)
+	}
+
 	// Separator
 	c.pdf.Ln(2)
 	c.pdf.SetDrawColor(220, 220, 220)
@@ -456,7 +673,7 @@ func (c *PDFConverter) addParameterTable(params []domain.Parameter) {
 	c.pdf.SetFillColor(245, 245, 245)
 
 	colWidths := []float64{35, 20, 15, 60, 60}
-	headers := []string{"Name", "In", "Required", "Type", "Description"}
+	headers := []string{"Name", "In", c.t("Required"), "Type", "Description"}
 
 	for i, header := range headers {
 		c.pdf.CellFormat(colWidths[i], 6, header, "1", 0, "", true, 0, "")
@@ -473,12 +690,9 @@ func (c *PDFConverter) addParameterTable(params []domain.Parameter) {
 			required = "Yes"
 		}
 
-		schemaType := param.Schema.Type
-		if param.Schema.Format != "" {
-			schemaType = fmt.Sprintf("%s (%s)", schemaType, param.Schema.Format)
-		}
-		if param.Schema.Ref != "" {
-			schemaType = extractRefName(param.Schema.Ref)
+		schemaType := formatSchemaDetails(param.Schema)
+		if len(schemaType) > 40 {
+			schemaType = schemaType[:37] + "..."
 		}
 
 		desc := stripHTML(param.Description)
@@ -486,7 +700,7 @@ func (c *PDFConverter) addParameterTable(params []domain.Parameter) {
 			desc = desc[:77] + "..."
 		}
 
-		c.pdf.CellFormat(colWidths[0], 6, param.Name, "1", 0, "", false, 0, "")
+		c.pdf.CellFormat(colWidths[0], 6, param.Name+deprecatedSuffix(param.Deprecated), "1", 0, "", false, 0, "")
 		c.pdf.CellFormat(colWidths[1], 6, param.In, "1", 0, "", false, 0, "")
 		c.pdf.CellFormat(colWidths[2], 6, required, "1", 0, "C", false, 0, "")
 		c.pdf.CellFormat(colWidths[3], 6, schemaType, "1", 0, "", false, 0, "")
@@ -496,13 +710,79 @@ func (c *PDFConverter) addParameterTable(params []domain.Parameter) {
 	c.pdf.Ln(3)
 }
 
+func (c *PDFConverter) addResponseHeaderTable(headers []responseHeader) {
+	// Table header
+	c.pdf.SetFont("Arial", "B", 8)
+	c.pdf.SetFillColor(245, 245, 245)
+
+	colWidths := []float64{20, 50, 50, 70}
+	titles := []string{"Status", "Header", "Type", "Description"}
+
+	for i, title := range titles {
+		c.pdf.CellFormat(colWidths[i], 6, title, "1", 0, "", true, 0, "")
+	}
+	c.pdf.Ln(-1)
+
+	// Table rows
+	c.pdf.SetFont("Arial", "", 8)
+	for _, header := range headers {
+		c.checkPageBreak(10)
+
+		schemaType := formatSchemaDetails(header.Schema)
+		if len(schemaType) > 33 {
+			schemaType = schemaType[:30] + "..."
+		}
+
+		desc := stripHTML(header.Description)
+		if len(desc) > 45 {
+			desc = desc[:42] + "..."
+		}
+
+		c.pdf.CellFormat(colWidths[0], 6, header.StatusCode, "1", 0, "", false, 0, "")
+		c.pdf.CellFormat(colWidths[1], 6, header.Name+deprecatedSuffix(header.Deprecated), "1", 0, "", false, 0, "")
+		c.pdf.CellFormat(colWidths[2], 6, schemaType, "1", 0, "", false, 0, "")
+		c.pdf.CellFormat(colWidths[3], 6, desc, "1", 0, "", false, 0, "")
+		c.pdf.Ln(-1)
+	}
+	c.pdf.Ln(3)
+}
+
+func (c *PDFConverter) addLimitTable(limits []operationLimit) {
+	// Table header
+	c.pdf.SetFont("Arial", "B", 8)
+	c.pdf.SetFillColor(245, 245, 245)
+
+	colWidths := []float64{50, 140}
+
+	for i, title := range []string{"Limit", "Value"} {
+		c.pdf.CellFormat(colWidths[i], 6, title, "1", 0, "", true, 0, "")
+	}
+	c.pdf.Ln(-1)
+
+	// Table rows
+	c.pdf.SetFont("Arial", "", 8)
+	for _, limit := range limits {
+		c.checkPageBreak(10)
+
+		value := limit.Value
+		if len(value) > 90 {
+			value = value[:87] + "..."
+		}
+
+		c.pdf.CellFormat(colWidths[0], 6, c.t(limit.Label), "1", 0, "", false, 0, "")
+		c.pdf.CellFormat(colWidths[1], 6, value, "1", 0, "", false, 0, "")
+		c.pdf.Ln(-1)
+	}
+	c.pdf.Ln(3)
+}
+
 func (c *PDFConverter) addRequestBody(rb *domain.RequestBody) {
+	c.pdf.SetFont("Arial", "I", 9)
 	if rb.Required {
-		c.pdf.SetFont("Arial", "I", 9)
 		c.pdf.SetTextColor(180, 0, 0)
-		c.pdf.CellFormat(pdfPageWidth, 5, "Required", "", 1, "", false, 0, "")
-		c.pdf.SetTextColor(0, 0, 0)
 	}
+	c.pdf.CellFormat(pdfPageWidth, 5, c.t(requiredLabel(rb.Required)), "", 1, "", false, 0, "")
+	c.pdf.SetTextColor(0, 0, 0)
 
 	if rb.Description != "" {
 		c.pdf.SetFont("Arial", "", 9)
@@ -510,16 +790,52 @@ func (c *PDFConverter) addRequestBody(rb *domain.RequestBody) {
 	}
 
 	// Content types
-	for contentType, media := range rb.Content {
+	for _, contentType := range sortedContentTypes(rb.Content) {
 		c.pdf.SetFont("Arial", "B", 9)
 		c.pdf.CellFormat(pdfPageWidth, 5, fmt.Sprintf("Content-Type: %s", contentType), "", 1, "", false, 0, "")
 
 		// Schema info
-		c.addSchemaInfo(media.Schema, 0)
+		c.addSchemaInfo(rb.Content[contentType].Schema, 0)
 	}
 	c.pdf.Ln(2)
 }
 
+// addCallbacks lists the callback requests of an endpoint with their URL expression, payload and expected responses.
+func (c *PDFConverter) addCallbacks(callbacks []callbackRequest) {
+	for _, callback := range callbacks {
+		op := callback.Operation
+
+		c.checkPageBreak(15)
+		c.pdf.SetFont("Arial", "B", 9)
+		c.pdf.MultiCell(pdfPageWidth, 5, fmt.Sprintf("%s: %s %s", callback.Name, formatMethod(op.Method), callback.Expression), "", "", false)
+
+		c.pdf.SetFont("Arial", "", 9)
+
+		if op.Summary != "" {
+			c.pdf.MultiCell(pdfPageWidth, 4, stripHTML(op.Summary), "", "", false)
+		}
+
+		if op.Description != "" {
+			c.pdf.MultiCell(pdfPageWidth, 4, stripHTML(op.Description), "", "", false)
+		}
+
+		if op.RequestBody != nil {
+			for _, contentType := range sortedContentTypes(op.RequestBody.Content) {
+				c.pdf.SetFont("Arial", "", 9)
+				c.pdf.CellFormat(pdfPageWidth, 5, fmt.Sprintf("Payload: %s", contentType), "", 1, "", false, 0, "")
+				c.addSchemaInfo(op.RequestBody.Content[contentType].Schema, 1)
+			}
+		}
+
+		if len(op.Responses) > 0 {
+			c.pdf.SetFont("Arial", "", 9)
+			c.pdf.MultiCell(pdfPageWidth, 4, "Expected responses: "+callbackResponses(op), "", "", false)
+		}
+
+		c.pdf.Ln(2)
+	}
+}
+
 func (c *PDFConverter) addSchemaInfo(schema domain.Schema, indent int) {
 	c.pdf.SetFont("Arial", "", 8)
 	indentStr := strings.Repeat("  ", indent)
@@ -534,12 +850,7 @@ func (c *PDFConverter) addSchemaInfo(schema domain.Schema, indent int) {
 		return
 	}
 
-	schemaType := schema.Type
-	if schema.Format != "" {
-		schemaType = fmt.Sprintf("%s (%s)", schemaType, schema.Format)
-	}
-
-	if schemaType != "" {
+	if schemaType := formatSchemaDetails(schema); schemaType != "" {
 		c.pdf.CellFormat(pdfPageWidth, 4, fmt.Sprintf("%sType: %s", indentStr, schemaType), "", 1, "", false, 0, "")
 	}
 
@@ -555,10 +866,7 @@ func (c *PDFConverter) addSchemaInfo(schema domain.Schema, indent int) {
 	if len(schema.Properties) > 0 {
 		c.pdf.CellFormat(pdfPageWidth, 4, fmt.Sprintf("%sProperties:", indentStr), "", 1, "", false, 0, "")
 		for name, prop := range schema.Properties {
-			propType := prop.Type
-			if prop.Ref != "" {
-				propType = extractRefName(prop.Ref)
-			}
+			propType := formatPropertyDetails(prop, slices.Contains(schema.Required, name), c.locale)
 			c.pdf.CellFormat(pdfPageWidth, 4, fmt.Sprintf("%s  - %s: %s", indentStr, name, propType), "", 1, "", false, 0, "")
 		}
 	}
@@ -571,74 +879,74 @@ func (c *PDFConverter) addSchemaInfo(schema domain.Schema, indent int) {
 }
 
 func (c *PDFConverter) addResponseTable(responses []domain.Response) {
-	// Sort responses by status code
-	sort.Slice(responses, func(i, j int) bool {
-		return responses[i].StatusCode < responses[j].StatusCode
-	})
+	// Sort a copy of the responses by status code, leaving the document untouched for other converters
+	responses = sortedResponses(responses)
 
 	// Table header
 	c.pdf.SetFont("Arial", "B", 8)
 	c.pdf.SetFillColor(245, 245, 245)
 
-	colWidths := []float64{25, 95, 70}
-	headers := []string{"Status", "Description", "Schema"}
+	colWidths := []float64{20, 70, 50, 50}
+	headers := []string{"Status", "Description", "Content Type", "Schema"}
 
 	for i, header := range headers {
 		c.pdf.CellFormat(colWidths[i], 6, header, "1", 0, "", true, 0, "")
 	}
 	c.pdf.Ln(-1)
 
-	// Table rows
+	// Table rows, one per content type of each response
 	c.pdf.SetFont("Arial", "", 8)
 	for _, resp := range responses {
-		c.checkPageBreak(10)
-
 		desc := stripHTML(resp.Description)
-		if len(desc) > 55 {
-			desc = desc[:52] + "..."
-		}
-
-		// Get schema reference
-		schemaRef := ""
-		var schemaLinkID int
-		for _, media := range resp.Content {
-			if media.Schema.Ref != "" {
-				refName := extractRefName(media.Schema.Ref)
-				schemaRef = refName
-				key := c.currentTag + ":" + refName
-				schemaLinkID = c.componentLinks[key]
-
-				break
-			} else if media.Schema.Type != "" {
-				schemaRef = media.Schema.Type
-			}
+		if len(desc) > 40 {
+			desc = desc[:37] + "..."
 		}
 
-		// Color code status
-		switch {
-		case strings.HasPrefix(resp.StatusCode, "2"):
-			c.pdf.SetTextColor(0, 128, 0)
-		case strings.HasPrefix(resp.StatusCode, "4"):
-			c.pdf.SetTextColor(200, 100, 0)
-		case strings.HasPrefix(resp.StatusCode, "5"):
-			c.pdf.SetTextColor(180, 0, 0)
-		default:
-			c.pdf.SetTextColor(0, 0, 0)
+		contentTypes := sortedContentTypes(resp.Content)
+		if len(contentTypes) == 0 {
+			contentTypes = []string{""}
 		}
 
-		c.pdf.CellFormat(colWidths[0], 6, resp.StatusCode, "1", 0, "C", false, 0, "")
-		c.pdf.SetTextColor(0, 0, 0)
-		c.pdf.CellFormat(colWidths[1], 6, desc, "1", 0, "", false, 0, "")
+		for i, contentType := range contentTypes {
+			c.checkPageBreak(10)
 
-		// Schema with link
-		if schemaLinkID > 0 {
-			c.pdf.SetTextColor(0, 102, 204)
-			c.pdf.CellFormat(colWidths[2], 6, schemaRef, "1", 0, "", false, schemaLinkID, "")
+			status := ""
+			if i == 0 {
+				status = resp.StatusCode
+			} else {
+				desc = ""
+			}
+
+			// Color code status
+			switch {
+			case strings.HasPrefix(resp.StatusCode, "2"):
+				c.pdf.SetTextColor(0, 128, 0)
+			case strings.HasPrefix(resp.StatusCode, "4"):
+				c.pdf.SetTextColor(200, 100, 0)
+			case strings.HasPrefix(resp.StatusCode, "5"):
+				c.pdf.SetTextColor(180, 0, 0)
+			default:
+				c.pdf.SetTextColor(0, 0, 0)
+			}
+
+			c.pdf.CellFormat(colWidths[0], 6, status, "1", 0, "C", false, 0, "")
 			c.pdf.SetTextColor(0, 0, 0)
-		} else {
-			c.pdf.CellFormat(colWidths[2], 6, schemaRef, "1", 0, "", false, 0, "")
+			c.pdf.CellFormat(colWidths[1], 6, desc, "1", 0, "", false, 0, "")
+			c.pdf.CellFormat(colWidths[2], 6, contentType, "1", 0, "", false, 0, "")
+
+			// Schema with link
+			schema := resp.Content[contentType].Schema
+			if schema.Ref != "" {
+				refName := extractRefName(schema.Ref)
+				linkID := c.componentLinks[c.currentTag+":"+refName]
+				c.pdf.SetTextColor(0, 102, 204)
+				c.pdf.CellFormat(colWidths[3], 6, refName, "1", 0, "", false, linkID, "")
+				c.pdf.SetTextColor(0, 0, 0)
+			} else {
+				c.pdf.CellFormat(colWidths[3], 6, formatSchemaType(schema), "1", 0, "", false, 0, "")
+			}
+			c.pdf.Ln(-1)
 		}
-		c.pdf.Ln(-1)
 	}
 	c.pdf.Ln(3)
 }
@@ -688,27 +996,23 @@ func (c *PDFConverter) addComponentSchema(name string, schema domain.Schema) {
 	// Component name header
 	c.pdf.SetFont("Arial", "B", 11)
 	c.pdf.SetFillColor(248, 248, 248)
-	c.pdf.CellFormat(pdfPageWidth, 7, name, "1", 1, "", true, 0, "")
+	c.pdf.CellFormat(pdfPageWidth, 7, name+deprecatedSuffix(schema.Deprecated), "1", 1, "", true, 0, "")
 
 	// Type
-	if schema.Type != "" {
+	if typeStr := formatSchemaType(schema); typeStr != "" {
 		c.pdf.SetFont("Arial", "", 9)
-		typeStr := schema.Type
-		if schema.Format != "" {
-			typeStr = fmt.Sprintf("%s (%s)", schema.Type, schema.Format)
-		}
 		c.pdf.CellFormat(pdfPageWidth, 5, fmt.Sprintf("Type: %s", typeStr), "", 1, "", false, 0, "")
 	}
 
 	// Description
-	if schema.Description != "" {
+	if schema.Description != "" || schema.ExternalDocs != nil {
 		c.pdf.SetFont("Arial", "", 9)
 		c.pdf.SetTextColor(100, 100, 100)
 		desc := stripHTML(schema.Description)
 		if len(desc) > 200 {
 			desc = desc[:197] + "..."
 		}
-		c.pdf.MultiCell(pdfPageWidth, 4, desc, "", "", false)
+		c.pdf.MultiCell(pdfPageWidth, 4, withPlainExternalDocs(desc, schema.ExternalDocs), "", "", false)
 		c.pdf.SetTextColor(0, 0, 0)
 	}
 
@@ -741,18 +1045,19 @@ func (c *PDFConverter) addComponentSchema(name string, schema domain.Schema) {
 			prop := schema.Properties[propName]
 			c.checkPageBreak(8)
 
-			propType := prop.Type
+			propType := formatSchemaType(prop)
 			var propLinkID int
 			if prop.Ref != "" {
-				refName := extractRefName(prop.Ref)
-				propType = refName
-				key := c.currentTag + ":" + refName
+				key := c.currentTag + ":" + extractRefName(prop.Ref)
 				propLinkID = c.componentLinks[key]
-			} else if prop.Format != "" {
-				propType = fmt.Sprintf("%s (%s)", prop.Type, prop.Format)
 			}
 
+			// Constraints come first so allowed values survive truncation
 			propDesc := stripHTML(prop.Description)
+			if constraints := propertyConstraints(prop, slices.Contains(schema.Required, propName), c.locale); len(constraints) > 0 {
+				propDesc = strings.TrimSuffix(strings.Join(constraints, "; ")+". "+propDesc, ". ")
+			}
+
 			if len(propDesc) > 60 {
 				propDesc = propDesc[:57] + "..."
 			}
@@ -773,14 +1078,101 @@ func (c *PDFConverter) addComponentSchema(name string, schema domain.Schema) {
 		}
 	}
 
+	c.addComposition(schema)
+
 	c.pdf.Ln(6)
 }
 
+// addComposition lists the alternatives of a oneOf/anyOf schema and its discriminator mapping.
+func (c *PDFConverter) addComposition(schema domain.Schema) {
+	if label, variants := schemaVariants(schema); len(variants) > 0 {
+		c.pdf.Ln(2)
+		c.pdf.SetFont("Arial", "B", 9)
+		c.pdf.CellFormat(pdfPageWidth, 5, label, "", 1, "", false, 0, "")
+		c.pdf.SetFont("Arial", "", 8)
+
+		for _, variant := range variants {
+			c.addSchemaLink("  - ", variant)
+		}
+	}
+
+	if schema.Discriminator == nil {
+		return
+	}
+
+	c.pdf.Ln(2)
+	c.pdf.SetFont("Arial", "B", 9)
+	c.pdf.CellFormat(pdfPageWidth, 5, fmt.Sprintf("Discriminator: %s", schema.Discriminator.PropertyName), "", 1, "", false, 0, "")
+
+	if len(schema.Discriminator.Mapping) == 0 {
+		return
+	}
+
+	colWidths := []float64{60, 130}
+
+	c.pdf.SetFont("Arial", "B", 8)
+	c.pdf.SetFillColor(245, 245, 245)
+	c.pdf.CellFormat(colWidths[0], 5, "Value", "1", 0, "", true, 0, "")
+	c.pdf.CellFormat(colWidths[1], 5, "Schema", "1", 1, "", true, 0, "")
+
+	c.pdf.SetFont("Arial", "", 8)
+
+	for _, value := range sortedDiscriminatorValues(schema.Discriminator) {
+		c.checkPageBreak(8)
+
+		refName := extractRefName(schema.Discriminator.Mapping[value])
+		linkID := c.componentLinks[c.currentTag+":"+refName]
+
+		c.pdf.CellFormat(colWidths[0], 5, value, "1", 0, "", false, 0, "")
+
+		if linkID > 0 {
+			c.pdf.SetTextColor(0, 102, 204)
+		}
+
+		c.pdf.CellFormat(colWidths[1], 5, refName, "1", 1, "", false, linkID, "")
+		c.pdf.SetTextColor(0, 0, 0)
+	}
+}
+
+// addSchemaLink writes the type of a schema on its own line, linking to the component when it is a reference.
+func (c *PDFConverter) addSchemaLink(prefix string, schema domain.Schema) {
+	linkID := 0
+	if schema.Ref != "" {
+		linkID = c.componentLinks[c.currentTag+":"+extractRefName(schema.Ref)]
+	}
+
+	if linkID > 0 {
+		c.pdf.SetTextColor(0, 102, 204)
+	}
+
+	c.pdf.CellFormat(pdfPageWidth, 4, prefix+formatSchemaType(schema), "", 1, "", false, linkID, "")
+	c.pdf.SetTextColor(0, 0, 0)
+}
+
+// addTagComponentLinks lists the component schemas used by endpoints in a tag, linking to the appendix.
+func (c *PDFConverter) addTagComponentLinks(tag string, componentNames []string) {
+	c.pdf.SetFont("Arial", "B", 11)
+	c.pdf.SetTextColor(60, 60, 60)
+	c.pdf.CellFormat(pdfPageWidth, 6, c.t("Schemas Used"), "", 1, "", false, 0, "")
+	c.pdf.Ln(1)
+
+	c.pdf.SetFont("Arial", "", 9)
+	c.pdf.SetTextColor(0, 102, 204)
+
+	for _, name := range componentNames {
+		c.checkPageBreak(10)
+		c.pdf.CellFormat(pdfPageWidth, 5, "- "+name, "", 1, "", false, c.componentLinks[tag+":"+name], "")
+	}
+
+	c.pdf.SetTextColor(0, 0, 0)
+	c.pdf.Ln(4)
+}
+
 // addTagComponents renders the component schemas used by endpoints in a tag.
 func (c *PDFConverter) addTagComponents(tag string, componentNames []string, components map[string]domain.Schema) {
 	c.pdf.SetFont("Arial", "B", 11)
 	c.pdf.SetTextColor(60, 60, 60)
-	c.pdf.CellFormat(pdfPageWidth, 6, "Schemas Used", "", 1, "", false, 0, "")
+	c.pdf.CellFormat(pdfPageWidth, 6, c.t("Schemas Used"), "", 1, "", false, 0, "")
 	c.pdf.SetTextColor(0, 0, 0)
 	c.pdf.Ln(2)
 
@@ -798,7 +1190,7 @@ func (c *PDFConverter) addTagComponents(tag string, componentNames []string, com
 			c.pdf.SetLink(linkID, -1, -1)
 		}
 
-		c.addComponentSchema(name, schema)
+		c.addComponentSchema(name, flattenAllOf(schema, components))
 	}
 
 	// Separator after components
@@ -807,3 +1199,51 @@ func (c *PDFConverter) addTagComponents(tag string, componentNames []string, com
 	c.pdf.Line(pdfMarginLeft, c.pdf.GetY(), pdfMarginLeft+pdfPageWidth, c.pdf.GetY())
 	c.pdf.Ln(6)
 }
+
+// ConvertChangelog transforms a changelog between two specifications to PDF format.
+func (c *PDFConverter) ConvertChangelog(changelog *domain.Changelog, output io.Writer) error {
+	c.pdf = gofpdf.New("P", "mm", "A4", "")
+	c.pdf.SetMargins(pdfMarginLeft, pdfMarginTop, pdfMarginRight)
+	c.pdf.SetDrawColor(180, 180, 180)
+	c.pdf.AddPage()
+
+	c.addSectionHeader(ChangelogTitle(changelog))
+
+	c.pdf.SetFont("Arial", "", 10)
+	c.pdf.SetTextColor(100, 100, 100)
+	c.pdf.MultiCell(pdfPageWidth, 5, ChangelogSummary(changelog), "", "", false)
+	c.pdf.SetTextColor(0, 0, 0)
+	c.pdf.Ln(4)
+
+	for _, section := range changelogSections(changelog) {
+		c.checkPageBreak(30)
+		c.pdf.SetFont("Arial", "B", 14)
+		c.pdf.SetFillColor(240, 240, 240)
+		c.pdf.CellFormat(pdfPageWidth, 8, section.title, "", 1, "", true, 0, "")
+		c.pdf.Ln(2)
+
+		for _, group := range section.groups {
+			if group.title != "" {
+				c.checkPageBreak(15)
+				c.addSubHeader(group.title)
+			}
+
+			for _, item := range group.items {
+				c.pdf.SetFont("Arial", "", 9)
+
+				if item.breaking {
+					c.pdf.SetTextColor(180, 0, 0)
+				}
+
+				c.pdf.MultiCell(pdfPageWidth, 5, "- "+item.text, "", "", false)
+				c.pdf.SetTextColor(0, 0, 0)
+			}
+
+			c.pdf.Ln(2)
+		}
+
+		c.pdf.Ln(4)
+	}
+
+	return c.pdf.Output(output)
+}
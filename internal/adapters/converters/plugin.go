@@ -0,0 +1,165 @@
+package converters
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/GabrielNunesIT/openapi-converter/internal/domain"
+)
+
+// PluginPrefix starts the file names of converter plugins, followed by the name of their format,
+// e.g. openapi-converter-asciidoc or openapi-converter-asciidoc.py for the asciidoc format.
+const PluginPrefix = "openapi-converter-"
+
+// PluginProtocolVersion is the version of the request plugins read, raised on incompatible changes.
+const PluginProtocolVersion = 1
+
+// PluginRequest is the JSON document written to the standard input of a plugin. The plugin writes the converted
+// document to its standard output and exits with status 0, or writes an error message to its standard error and
+// exits with another status.
+type PluginRequest struct {
+	Version  int                     `json:"version"`  // PluginProtocolVersion
+	Format   string                  `json:"format"`   // Format the plugin is run for, the name of its file without prefix
+	Options  Options                 `json:"options"`  // Options given on the command line, for the plugin to honour or ignore
+	Document *domain.OpenAPIDocument `json:"document"` // Parsed specification, with the field names of domain.OpenAPIDocument
+}
+
+// PluginConverter converts documents by running an external executable, so that third parties can add output formats
+// without forking. The protocol is JSON over standard input and output, see PluginRequest.
+type PluginConverter struct {
+	format string
+	path   string
+	opts   Options
+}
+
+// NewPluginConverter creates a converter running the plugin executable at path for a format.
+func NewPluginConverter(format, path string, opts Options) *PluginConverter {
+	return &PluginConverter{format: format, path: path, opts: opts}
+}
+
+// Format returns the format name.
+func (c *PluginConverter) Format() string {
+	return c.format
+}
+
+// Convert runs the plugin with the document and copies its standard output to output.
+func (c *PluginConverter) Convert(doc *domain.OpenAPIDocument, output io.Writer) error {
+	request, err := json.Marshal(PluginRequest{
+		Version:  PluginProtocolVersion,
+		Format:   c.format,
+		Options:  c.opts,
+		Document: doc,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode plugin request: %w", err)
+	}
+
+	var stderr bytes.Buffer
+
+	cmd := exec.CommandContext(context.Background(), c.path) //nolint:gosec // plugins are executables the user installed
+	cmd.Stdin = bytes.NewReader(request)
+	cmd.Stdout = output
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if message := strings.TrimSpace(stderr.String()); message != "" {
+			return fmt.Errorf("plugin %s failed: %w: %s", c.path, err, message)
+		}
+
+		return fmt.Errorf("plugin %s failed: %w", c.path, err)
+	}
+
+	return nil
+}
+
+// LoadPlugins registers the executables of dir named after PluginPrefix as converters of the format following
+// the prefix, and returns the formats registered. Plugins named like an already registered format are not loaded
+// and reported in the error, along with the other plugins failing to load; only an unreadable directory returns
+// nil formats.
+func (r *Registry) LoadPlugins(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read plugin directory: %w", err)
+	}
+
+	formats := make([]string, 0, len(entries))
+
+	var errs []error
+
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, PluginPrefix) {
+			continue
+		}
+
+		path := filepath.Join(dir, name)
+
+		// Stat follows symbolic links, which package managers commonly install
+		info, err := os.Stat(path)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to load plugin %s: %w", path, err))
+
+			continue
+		}
+
+		if !isExecutable(name, info) {
+			continue
+		}
+
+		format := strings.TrimPrefix(strings.TrimSuffix(name, filepath.Ext(name)), PluginPrefix)
+		if format == "" {
+			continue
+		}
+
+		if r.registered(format) {
+			errs = append(errs, fmt.Errorf("plugin %s is not loaded: format %s is already registered", path, format))
+
+			continue
+		}
+
+		r.Register(format, func(opts Options) domain.Converter {
+			return NewPluginConverter(format, path, opts)
+		})
+
+		formats = append(formats, format)
+	}
+
+	return formats, errors.Join(errs...)
+}
+
+// isExecutable reports whether a file can be run, by its permissions or, on Windows, its extension.
+func isExecutable(name string, info os.FileInfo) bool {
+	if !info.Mode().IsRegular() {
+		return false
+	}
+
+	if runtime.GOOS == "windows" {
+		switch strings.ToLower(filepath.Ext(name)) {
+		case ".exe", ".bat", ".cmd", ".com":
+			return true
+		default:
+			return false
+		}
+	}
+
+	return info.Mode().Perm()&0o111 != 0
+}
+
+// registered reports whether a format name or alias is taken.
+func (r *Registry) registered(name string) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	_, taken := r.aliases[strings.ToLower(name)]
+
+	return taken
+}
@@ -0,0 +1,393 @@
+package converters
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/GabrielNunesIT/openapi-converter/internal/domain"
+)
+
+const (
+	postmanFormat     = "postman"
+	postmanSchemaURL  = "https://schema.getpostman.com/json/collection/v2.1.0/collection.json"
+	postmanBaseURLVar = "baseUrl"
+)
+
+var postmanPathParam = regexp.MustCompile(`\{([^}]+)\}`)
+
+// PostmanConverter converts OpenAPI documents to Postman Collection v2.1 JSON.
+type PostmanConverter struct{}
+
+// NewPostmanConverter creates a new Postman collection converter.
+func NewPostmanConverter() *PostmanConverter {
+	return &PostmanConverter{}
+}
+
+func init() { //nolint:gochecknoinits // converters self-register with the default registry
+	Register(postmanFormat, func(opts Options) domain.Converter {
+		return NewPostmanConverter()
+	})
+}
+
+// Format returns the output format name.
+func (c *PostmanConverter) Format() string {
+	return postmanFormat
+}
+
+// Postman collection types.
+type postmanCollection struct {
+	Info     postmanInfo       `json:"info"`
+	Item     []postmanItem     `json:"item"`
+	Variable []postmanVariable `json:"variable,omitempty"`
+}
+
+type postmanInfo struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Version     string `json:"version,omitempty"`
+	Schema      string `json:"schema"`
+}
+
+// postmanItem is either a folder (Item set) or a request (Request set).
+type postmanItem struct {
+	Name        string            `json:"name"`
+	Description string            `json:"description,omitempty"`
+	Item        []postmanItem     `json:"item,omitempty"`
+	Request     *postmanRequest   `json:"request,omitempty"`
+	Response    []postmanResponse `json:"response,omitempty"`
+}
+
+type postmanRequest struct {
+	Method      string            `json:"method"`
+	Header      []postmanVariable `json:"header"`
+	URL         postmanURL        `json:"url"`
+	Body        *postmanBody      `json:"body,omitempty"`
+	Auth        *postmanAuth      `json:"auth,omitempty"`
+	Description string            `json:"description,omitempty"`
+}
+
+type postmanURL struct {
+	Raw      string            `json:"raw"`
+	Host     []string          `json:"host"`
+	Path     []string          `json:"path,omitempty"`
+	Query    []postmanVariable `json:"query,omitempty"`
+	Variable []postmanVariable `json:"variable,omitempty"`
+}
+
+type postmanVariable struct {
+	Key         string `json:"key"`
+	Value       string `json:"value"`
+	Type        string `json:"type,omitempty"`
+	Description string `json:"description,omitempty"`
+	Disabled    bool   `json:"disabled,omitempty"`
+}
+
+type postmanBody struct {
+	Mode    string              `json:"mode"`
+	Raw     string              `json:"raw"`
+	Options *postmanBodyOptions `json:"options,omitempty"`
+}
+
+type postmanBodyOptions struct {
+	Raw postmanRawOptions `json:"raw"`
+}
+
+type postmanRawOptions struct {
+	Language string `json:"language"`
+}
+
+type postmanAuth struct {
+	Type   string            `json:"type"`
+	Bearer []postmanVariable `json:"bearer,omitempty"`
+	Basic  []postmanVariable `json:"basic,omitempty"`
+	APIKey []postmanVariable `json:"apikey,omitempty"`
+	OAuth2 []postmanVariable `json:"oauth2,omitempty"`
+}
+
+type postmanResponse struct {
+	Name            string            `json:"name"`
+	OriginalRequest *postmanRequest   `json:"originalRequest,omitempty"`
+	Status          string            `json:"status,omitempty"`
+	Code            int               `json:"code,omitempty"`
+	Header          []postmanVariable `json:"header"`
+	Body            string            `json:"body"`
+}
+
+// Convert transforms an OpenAPI document to a Postman collection.
+func (c *PostmanConverter) Convert(doc *domain.OpenAPIDocument, output io.Writer) error {
+	baseURL := ""
+	if len(doc.Servers) > 0 {
+		baseURL = strings.TrimRight(doc.Servers[0].URL, "/")
+	}
+
+	// The About block leads the description, as collections have no other place for it
+	description := withExternalDocs(doc.Description, doc.ExternalDocs)
+	if about := aboutMarkdown(doc); about != "" {
+		description = strings.TrimSpace(about + "\n\n" + description)
+	}
+
+	collection := postmanCollection{
+		Info: postmanInfo{
+			Name:        doc.Title,
+			Description: description,
+			Version:     doc.Version,
+			Schema:      postmanSchemaURL,
+		},
+		Item:     []postmanItem{},
+		Variable: []postmanVariable{{Key: postmanBaseURLVar, Value: baseURL, Type: "string"}},
+	}
+
+	variables := make(map[string]struct{})
+
+	// One folder per tag
+	tagPaths := groupPathsByTag(doc)
+	for _, tag := range orderedTags(doc, tagPaths) {
+		info := tagInfo(doc, tag)
+		folder := postmanItem{Name: tag, Description: withExternalDocs(info.Description, info.ExternalDocs)}
+
+		for _, ep := range tagPaths[tag] {
+			folder.Item = append(folder.Item, c.requestItem(doc, ep, variables))
+		}
+
+		collection.Item = append(collection.Item, folder)
+	}
+
+	// Placeholders used by authentication, to be filled in by the user
+	names := make([]string, 0, len(variables))
+	for name := range variables {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		collection.Variable = append(collection.Variable, postmanVariable{Key: name, Value: "", Type: "string"})
+	}
+
+	encoder := json.NewEncoder(output)
+	encoder.SetIndent("", "  ")
+
+	if err := encoder.Encode(collection); err != nil {
+		return fmt.Errorf("failed to encode postman collection: %w", err)
+	}
+
+	return nil
+}
+
+func (c *PostmanConverter) requestItem(doc *domain.OpenAPIDocument, ep endpointRef, variables map[string]struct{}) postmanItem {
+	op := ep.operation
+
+	name := op.Summary
+	if name == "" {
+		name = fmt.Sprintf("%s %s", formatMethod(op.Method), ep.path)
+	}
+
+	name += deprecatedSuffix(op.Deprecated)
+
+	request := &postmanRequest{
+		Method:      formatMethod(op.Method),
+		Header:      []postmanVariable{},
+		URL:         c.requestURL(ep.path, op.Parameters),
+		Description: withExternalDocs(op.Description, op.ExternalDocs),
+		Auth:        c.requestAuth(doc, op, variables),
+	}
+
+	for _, param := range op.Parameters {
+		if param.In == "header" {
+			request.Header = append(request.Header, postmanVariable{
+				Key:         param.Name,
+				Value:       parameterPlaceholder(param),
+				Description: param.Description,
+				Disabled:    !param.Required,
+			})
+		}
+	}
+
+	if op.RequestBody != nil {
+		if contentTypes := sortedContentTypes(op.RequestBody.Content); len(contentTypes) > 0 {
+			contentType := preferredContentType(contentTypes)
+
+			request.Header = append(request.Header, postmanVariable{Key: "Content-Type", Value: contentType})
+			request.Body = c.requestBody(contentType, op.RequestBody.Content[contentType], newSampler(doc.Components))
+		}
+	}
+
+	item := postmanItem{
+		Name:     name,
+		Request:  request,
+		Response: c.savedResponses(request, op.Responses),
+	}
+
+	return item
+}
+
+// requestURL builds the URL of a request, turning {param} path segments into Postman :param variables.
+func (c *PostmanConverter) requestURL(path string, params []domain.Parameter) postmanURL {
+	postmanPath := postmanPathParam.ReplaceAllString(path, ":$1")
+
+	url := postmanURL{
+		Host: []string{"{{" + postmanBaseURLVar + "}}"},
+	}
+
+	for _, segment := range strings.Split(strings.Trim(postmanPath, "/"), "/") {
+		if segment != "" {
+			url.Path = append(url.Path, segment)
+		}
+	}
+
+	query := []string{}
+
+	for _, param := range params {
+		switch param.In {
+		case "path":
+			url.Variable = append(url.Variable, postmanVariable{
+				Key:         param.Name,
+				Value:       parameterPlaceholder(param),
+				Description: param.Description,
+			})
+		case "query":
+			url.Query = append(url.Query, postmanVariable{
+				Key:         param.Name,
+				Value:       parameterPlaceholder(param),
+				Description: param.Description,
+				Disabled:    !param.Required,
+			})
+
+			if param.Required {
+				query = append(query, fmt.Sprintf("%s=%s", param.Name, parameterPlaceholder(param)))
+			}
+		}
+	}
+
+	url.Raw = "{{" + postmanBaseURLVar + "}}" + postmanPath
+	if len(query) > 0 {
+		url.Raw += "?" + strings.Join(query, "&")
+	}
+
+	return url
+}
+
+// requestBody uses the first example of the media type, falling back to one generated from the schema for JSON.
+func (c *PostmanConverter) requestBody(contentType string, media domain.MediaType, samples sampler) *postmanBody {
+	body := &postmanBody{Mode: "raw"}
+
+	if examples := samples.examples(contentType, media); len(examples) > 0 {
+		body.Raw = formatExampleValue(examples[0].value)
+	}
+
+	if strings.Contains(contentType, "json") {
+		body.Options = &postmanBodyOptions{Raw: postmanRawOptions{Language: "json"}}
+	} else if strings.Contains(contentType, "xml") {
+		body.Options = &postmanBodyOptions{Raw: postmanRawOptions{Language: "xml"}}
+	}
+
+	return body
+}
+
+// requestAuth maps the first security requirement of an operation to Postman auth settings.
+// Secrets are left as collection variables such as {{bearerToken}}.
+func (c *PostmanConverter) requestAuth(doc *domain.OpenAPIDocument, op domain.Operation, variables map[string]struct{}) *postmanAuth {
+	if len(op.Security) == 0 {
+		return nil
+	}
+
+	requirement := op.Security[0]
+	if len(requirement) == 0 {
+		return &postmanAuth{Type: "noauth"}
+	}
+
+	names := make([]string, 0, len(requirement))
+	for name := range requirement {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	scheme, exists := doc.SecuritySchemes[names[0]]
+	if !exists {
+		return nil
+	}
+
+	variable := func(name string) string {
+		variables[name] = struct{}{}
+
+		return "{{" + name + "}}"
+	}
+
+	switch {
+	case scheme.Type == "http" && strings.EqualFold(scheme.Scheme, "basic"):
+		return &postmanAuth{Type: "basic", Basic: []postmanVariable{
+			{Key: "username", Value: variable("username"), Type: "string"},
+			{Key: "password", Value: variable("password"), Type: "string"},
+		}}
+	case scheme.Type == "http":
+		return &postmanAuth{Type: "bearer", Bearer: []postmanVariable{
+			{Key: "token", Value: variable("bearerToken"), Type: "string"},
+		}}
+	case scheme.Type == "apiKey":
+		return &postmanAuth{Type: "apikey", APIKey: []postmanVariable{
+			{Key: "key", Value: scheme.Name, Type: "string"},
+			{Key: "value", Value: variable("apiKey"), Type: "string"},
+			{Key: "in", Value: scheme.In, Type: "string"},
+		}}
+	case scheme.Type == "oauth2" || scheme.Type == "openIdConnect":
+		return &postmanAuth{Type: "oauth2", OAuth2: []postmanVariable{
+			{Key: "accessToken", Value: variable("accessToken"), Type: "string"},
+			{Key: "addTokenTo", Value: "header", Type: "string"},
+		}}
+	default:
+		return nil
+	}
+}
+
+// savedResponses turns response examples into Postman saved responses.
+func (c *PostmanConverter) savedResponses(request *postmanRequest, responses []domain.Response) []postmanResponse {
+	saved := []postmanResponse{}
+
+	for _, resp := range sortedResponses(responses) {
+		code, _ := strconv.Atoi(resp.StatusCode)
+
+		for _, contentType := range sortedContentTypes(resp.Content) {
+			for _, example := range mediaExamples(resp.Content[contentType]) {
+				name := fmt.Sprintf("%s %s", resp.StatusCode, resp.Description)
+				if example.label != "" {
+					name = fmt.Sprintf("%s (%s)", name, example.label)
+				}
+
+				saved = append(saved, postmanResponse{
+					Name:            strings.TrimSpace(name),
+					OriginalRequest: request,
+					Status:          resp.Description,
+					Code:            code,
+					Header:          []postmanVariable{{Key: "Content-Type", Value: contentType}},
+					Body:            formatExampleValue(example.value),
+				})
+			}
+		}
+	}
+
+	return saved
+}
+
+// preferredContentType picks a JSON media type when available, otherwise the first one.
+func preferredContentType(contentTypes []string) string {
+	for _, contentType := range contentTypes {
+		if strings.Contains(contentType, "json") {
+			return contentType
+		}
+	}
+
+	return contentTypes[0]
+}
+
+// parameterPlaceholder returns the first example of a parameter or a <name> placeholder.
+func parameterPlaceholder(param domain.Parameter) string {
+	if len(param.Schema.Examples) > 0 {
+		return fmt.Sprintf("%v", param.Schema.Examples[0])
+	}
+
+	return fmt.Sprintf("<%s>", param.Name)
+}
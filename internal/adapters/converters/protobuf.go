@@ -0,0 +1,512 @@
+package converters
+
+import (
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/GabrielNunesIT/openapi-converter/internal/domain"
+)
+
+const protobufFormat = "protobuf"
+
+// Well-known Protocol Buffers types standing for what the scalar types cannot describe.
+const (
+	protoValue     = "google.protobuf.Value"     // Any JSON value
+	protoStruct    = "google.protobuf.Struct"    // Free-form JSON object
+	protoListValue = "google.protobuf.ListValue" // JSON array, for arrays of arrays
+	protoTimestamp = "google.protobuf.Timestamp" // Date and time
+	protoEmpty     = "google.protobuf.Empty"     // No response body
+)
+
+// protoImports are the files declaring the well-known types.
+var protoImports = map[string]string{ //nolint:gochecknoglobals // read-only lookup table
+	protoValue:     "google/protobuf/struct.proto",
+	protoStruct:    "google/protobuf/struct.proto",
+	protoListValue: "google/protobuf/struct.proto",
+	protoTimestamp: "google/protobuf/timestamp.proto",
+	protoEmpty:     "google/protobuf/empty.proto",
+}
+
+// protoScalars are the scalar types, which are neither messages nor enums.
+var protoScalars = []string{"string", "bytes", "int32", "int64", "float", "double", "bool"} //nolint:gochecknoglobals // read-only
+
+// ProtobufConverter converts OpenAPI documents to a proto3 file bootstrapping a gRPC migration: component schemas
+// become messages and enums, and operations the RPCs of a service named after the API. Integers map to int32,
+// or int64 with that format, numbers to double, or float, strings to string, or bytes for binary formats,
+// and date-times to google.protobuf.Timestamp. Webhooks and callbacks, which the API sends, are left out.
+type ProtobufConverter struct{}
+
+// NewProtobufConverter creates a new Protocol Buffers converter.
+func NewProtobufConverter() *ProtobufConverter {
+	return &ProtobufConverter{}
+}
+
+func init() { //nolint:gochecknoinits // converters self-register with the default registry
+	Register(protobufFormat, func(opts Options) domain.Converter {
+		return NewProtobufConverter()
+	}, "proto", "grpc")
+}
+
+// Format returns the output format name.
+func (c *ProtobufConverter) Format() string {
+	return protobufFormat
+}
+
+// Convert transforms an OpenAPI document to a proto3 file.
+func (c *ProtobufConverter) Convert(doc *domain.OpenAPIDocument, output io.Writer) error {
+	p := &protoFile{
+		components: doc.Components,
+		messages:   make(map[string]string),
+		enums:      make(map[string]struct{}),
+		imports:    make(map[string]struct{}),
+	}
+
+	// Every component schema, whether used by an operation or not
+	for _, name := range sortedComponentNames(doc.Components) {
+		p.typeOf(doc.Components[name], name)
+	}
+
+	rpcs := []string{}
+	names := make(map[string]int) // RPC names taken, with the number of operations wanting each
+
+	for _, path := range doc.Paths {
+		for _, op := range path.Operations {
+			rpcs = append(rpcs, p.rpc(path.Path, op, names))
+		}
+	}
+
+	var proto strings.Builder
+
+	fmt.Fprintf(&proto, "// %s, version %s\n", doc.Title, doc.Version)
+	proto.WriteString("// Protocol Buffers definitions generated from the OpenAPI specification.\n\n")
+	proto.WriteString("syntax = \"proto3\";\n\n")
+	fmt.Fprintf(&proto, "package %s;\n", protoPackage(doc))
+
+	if len(p.imports) > 0 {
+		proto.WriteString("\n")
+
+		for _, file := range slices.Sorted(maps.Keys(p.imports)) {
+			fmt.Fprintf(&proto, "import %q;\n", file)
+		}
+	}
+
+	if len(rpcs) > 0 {
+		proto.WriteString("\n")
+		writeLineComment(&proto, doc.Description, "")
+
+		serviceName := "API"
+		if doc.Title != "" {
+			serviceName = pascalCase(doc.Title)
+		}
+
+		proto.WriteString("service " + serviceName + "Service {\n" + strings.Join(rpcs, "\n") + "}\n")
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(p.messages)) {
+		proto.WriteString("\n" + p.messages[name])
+	}
+
+	if _, err := io.WriteString(output, proto.String()); err != nil {
+		return fmt.Errorf("failed to write Protocol Buffers definitions: %w", err)
+	}
+
+	return nil
+}
+
+// protoFile collects the message and enum definitions of a proto file while schemas are mapped to types.
+type protoFile struct {
+	components map[string]domain.Schema
+	messages   map[string]string   // Definition by message or enum name, empty while the fields of a message are mapped
+	enums      map[string]struct{} // Names of the enums among messages
+	imports    map[string]struct{} // Files declaring the well-known types used
+}
+
+// protoField is a field of a message, taken from a property, a parameter or a request body.
+type protoField struct {
+	Name        string // Name in the API, turned into a snake_case field name
+	Schema      domain.Schema
+	Type        string // Type already mapped from Schema, with Repeated, or empty to map it
+	Repeated    bool
+	Description string
+	Required    bool
+	Deprecated  bool
+}
+
+// rpc returns the RPC standing for an operation, named after its operationId, or its method and path without one.
+// Its request message holds the path and query parameters, and the request body in a "body" field; it returns
+// the message of the first successful JSON response, wrapped when it is not a message, or google.protobuf.Empty.
+func (p *protoFile) rpc(path string, op domain.Operation, names map[string]int) string {
+	name := pascalCase(op.OperationID)
+	if op.OperationID == "" {
+		name = pascalCase(strings.ToLower(op.Method) + " " + path)
+	}
+
+	if names[name]++; names[name] > 1 {
+		name = fmt.Sprintf("%s%d", name, names[name])
+	}
+
+	fields := []protoField{}
+
+	for _, param := range op.Parameters {
+		if param.In == "path" || param.In == "query" {
+			fields = append(fields, protoField{
+				Name:        param.Name,
+				Schema:      param.Schema,
+				Description: param.Description,
+				Required:    param.Required,
+				Deprecated:  param.Deprecated,
+			})
+		}
+	}
+
+	if body := op.RequestBody; body != nil && len(body.Content) > 0 {
+		media := body.Content[preferredContentType(sortedContentTypes(body.Content))]
+		fields = append(fields, protoField{Name: "body", Schema: media.Schema, Description: body.Description, Required: body.Required})
+	}
+
+	request := name + "Request"
+	p.defineMessage(request, "", fields)
+
+	response := ""
+
+	for _, resp := range sortedResponses(op.Responses) {
+		if strings.HasPrefix(resp.StatusCode, "2") && len(resp.Content) > 0 {
+			media := resp.Content[preferredContentType(sortedContentTypes(resp.Content))]
+			response = p.responseMessage(name+"Response", media.Schema)
+
+			break
+		}
+	}
+
+	if response == "" {
+		response = p.wellKnown(protoEmpty)
+	}
+
+	var rpc strings.Builder
+
+	description := strings.TrimSpace(strings.Join([]string{op.Summary, op.Description}, "\n\n"))
+	writeLineComment(&rpc, strings.TrimSpace(description+"\n\n"+formatMethod(op.Method)+" "+path), "  ")
+
+	if op.Deprecated {
+		fmt.Fprintf(&rpc, "  rpc %s(%s) returns (%s) {\n    option deprecated = true;\n  }\n", name, request, response)
+	} else {
+		fmt.Fprintf(&rpc, "  rpc %s(%s) returns (%s);\n", name, request, response)
+	}
+
+	return rpc.String()
+}
+
+// responseMessage returns the message returned by an RPC, wrapping a response body that is not a message
+// in a message named name, with an "items" field for arrays and a "value" field otherwise.
+func (p *protoFile) responseMessage(name string, schema domain.Schema) string {
+	protoType, repeated := p.typeOf(schema, name)
+	if !repeated && !slices.Contains(protoScalars, protoType) {
+		if _, enum := p.enums[protoType]; !enum {
+			return protoType
+		}
+	}
+
+	field := protoField{Name: "value", Type: protoType, Repeated: repeated, Required: true}
+	if repeated {
+		field.Name = "items"
+	}
+
+	p.defineMessage(name, "", []protoField{field})
+
+	return name
+}
+
+// typeOf returns the type of a field holding values of a schema and whether the field is repeated,
+// defining the messages and enums it needs. Inline objects and enums are named after name, such as
+// the message and property holding them, and component schemas after the component.
+func (p *protoFile) typeOf(schema domain.Schema, name string) (string, bool) {
+	if schema.Ref != "" {
+		refName := extractRefName(schema.Ref)
+		if component, ok := p.components[refName]; ok {
+			name = refName
+			schema = component
+		}
+	}
+
+	schema = flattenAllOf(schema, p.components)
+
+	if _, variants := schemaVariants(schema); len(variants) > 0 {
+		return p.oneofMessage(name, schema.Description, variants), false
+	}
+
+	switch {
+	case len(schema.Enum) > 0 && isStringEnum(schema.Enum):
+		return p.enumType(name, schema), false
+	case schema.Type == "array":
+		if schema.Items == nil {
+			return p.wellKnown(protoValue), true
+		}
+
+		// Repeated fields cannot nest, so arrays of arrays hold list values
+		if item, repeated := p.typeOf(*schema.Items, name+" item"); !repeated {
+			return item, true
+		}
+
+		return p.wellKnown(protoListValue), true
+	case len(schema.Properties) > 0:
+		return p.messageType(name, schema), false
+	case schema.Type == "object":
+		return p.wellKnown(protoStruct), false
+	case schema.Type == "string":
+		return p.stringType(schema.Format), false
+	case schema.Type == "integer":
+		if schema.Format == "int64" {
+			return "int64", false
+		}
+
+		return "int32", false
+	case schema.Type == "number":
+		if schema.Format == "float" {
+			return "float", false
+		}
+
+		return "double", false
+	case schema.Type == "boolean":
+		return "bool", false
+	default:
+		return p.wellKnown(protoValue), false
+	}
+}
+
+// stringType returns the type of a string with the given format.
+func (p *protoFile) stringType(format string) string {
+	switch format {
+	case "byte", "binary":
+		return "bytes"
+	case "date-time":
+		return p.wellKnown(protoTimestamp)
+	default:
+		return "string"
+	}
+}
+
+// messageType defines the message of a schema with properties.
+func (p *protoFile) messageType(name string, schema domain.Schema) string {
+	messageName := pascalCase(name)
+	if _, defined := p.messages[messageName]; defined {
+		return messageName
+	}
+
+	propNames := make([]string, 0, len(schema.Properties))
+	for propName := range schema.Properties {
+		propNames = append(propNames, propName)
+	}
+	sort.Strings(propNames)
+
+	fields := make([]protoField, 0, len(propNames))
+
+	for _, propName := range propNames {
+		prop := schema.Properties[propName]
+		fields = append(fields, protoField{
+			Name:        propName,
+			Schema:      prop,
+			Description: prop.Description,
+			Required:    slices.Contains(schema.Required, propName) && !prop.Nullable,
+			Deprecated:  prop.Deprecated,
+		})
+	}
+
+	p.defineMessage(messageName, schema.Description, fields)
+
+	return messageName
+}
+
+// defineMessage defines a message with fields numbered in order. Fields that are not required are optional,
+// so that an absent value can be told apart from its default.
+func (p *protoFile) defineMessage(name, description string, fields []protoField) {
+	if _, defined := p.messages[name]; defined {
+		return
+	}
+
+	p.messages[name] = "" // Reserved while the fields are mapped, for schemas referencing themselves
+
+	var def strings.Builder
+
+	writeLineComment(&def, description, "")
+	def.WriteString("message " + name + " {\n")
+
+	for i, field := range fields {
+		fieldName := protoFieldName(field.Name)
+
+		protoType, repeated := field.Type, field.Repeated
+		if protoType == "" {
+			protoType, repeated = p.typeOf(field.Schema, name+" "+field.Name)
+		}
+
+		label := ""
+		if repeated {
+			label = "repeated "
+		} else if !field.Required {
+			label = "optional "
+		}
+
+		options := []string{}
+		if protoJSONName(fieldName) != field.Name {
+			options = append(options, fmt.Sprintf("json_name = %q", field.Name))
+		}
+
+		if field.Deprecated {
+			options = append(options, "deprecated = true")
+		}
+
+		writeLineComment(&def, field.Description, "  ")
+		fmt.Fprintf(&def, "  %s%s %s = %d", label, protoType, fieldName, i+1)
+
+		if len(options) > 0 {
+			def.WriteString(" [" + strings.Join(options, ", ") + "]")
+		}
+
+		def.WriteString(";\n")
+	}
+
+	def.WriteString("}\n")
+	p.messages[name] = def.String()
+}
+
+// enumType defines the enum of a schema allowing a list of strings. Its values are prefixed with the enum name,
+// as enum values share the scope of the package, and follow an UNSPECIFIED zero value, which proto3 requires.
+func (p *protoFile) enumType(name string, schema domain.Schema) string {
+	enumName := pascalCase(name)
+	if _, defined := p.messages[enumName]; defined {
+		return enumName
+	}
+
+	prefix := strings.ToUpper(protoFieldName(enumName)) + "_"
+
+	var def strings.Builder
+
+	writeLineComment(&def, schema.Description, "")
+	def.WriteString("enum " + enumName + " {\n")
+	def.WriteString("  " + prefix + "UNSPECIFIED = 0;\n")
+
+	listed := map[string]struct{}{prefix + "UNSPECIFIED": {}}
+
+	for _, value := range schema.Enum {
+		valueName := prefix + strings.ToUpper(protoFieldName(fmt.Sprint(value)))
+		if _, ok := listed[valueName]; !ok {
+			listed[valueName] = struct{}{}
+			fmt.Fprintf(&def, "  %s = %d;\n", valueName, len(listed)-1)
+		}
+	}
+
+	def.WriteString("}\n")
+	p.messages[enumName] = def.String()
+	p.enums[enumName] = struct{}{}
+
+	return enumName
+}
+
+// oneofMessage defines a message holding one of the variants of a oneOf or anyOf schema in a "value" oneof.
+func (p *protoFile) oneofMessage(name, description string, variants []domain.Schema) string {
+	messageName := pascalCase(name)
+	if _, defined := p.messages[messageName]; defined {
+		return messageName
+	}
+
+	p.messages[messageName] = ""
+
+	var def strings.Builder
+
+	writeLineComment(&def, description, "")
+	def.WriteString("message " + messageName + " {\n  oneof value {\n")
+
+	taken := make(map[string]int)
+
+	for i, variant := range variants {
+		protoType, repeated := p.typeOf(variant, fmt.Sprintf("%s option %d", name, i+1))
+		if repeated {
+			protoType = p.wellKnown(protoListValue) // Oneof fields cannot be repeated
+		}
+
+		fieldName := protoFieldName(protoType[strings.LastIndex(protoType, ".")+1:])
+		if taken[fieldName]++; taken[fieldName] > 1 {
+			fieldName = fmt.Sprintf("%s_%d", fieldName, taken[fieldName])
+		}
+
+		fmt.Fprintf(&def, "    %s %s = %d;\n", protoType, fieldName, i+1)
+	}
+
+	def.WriteString("  }\n}\n")
+	p.messages[messageName] = def.String()
+
+	return messageName
+}
+
+// wellKnown returns a well-known type, importing the file declaring it.
+func (p *protoFile) wellKnown(protoType string) string {
+	p.imports[protoImports[protoType]] = struct{}{}
+
+	return protoType
+}
+
+// protoPackage returns the package of the proto file, named after the API and the major version,
+// e.g. "pet_store.v1" for Pet Store 1.2.0.
+func protoPackage(doc *domain.OpenAPIDocument) string {
+	pkg := strings.ToLower(strings.Trim(invalidIdentifierChars.ReplaceAllString(doc.Title, "_"), "_"))
+	if pkg == "" || isDigit(pkg[0]) {
+		pkg = "api" + pkg
+	}
+
+	major := strings.TrimLeft(doc.Version, "vV")
+	if end := strings.IndexFunc(major, func(r rune) bool { return !unicode.IsDigit(r) }); end >= 0 {
+		major = major[:end]
+	}
+
+	if major == "" {
+		return pkg
+	}
+
+	return pkg + ".v" + major
+}
+
+// protoFieldName turns a property or parameter name into a snake_case field name, e.g. "petId" into "pet_id".
+func protoFieldName(name string) string {
+	var snake strings.Builder
+
+	runes := []rune(name)
+	for i, r := range runes {
+		if unicode.IsUpper(r) && i > 0 && (unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1])) {
+			snake.WriteByte('_')
+		}
+
+		snake.WriteRune(unicode.ToLower(r))
+	}
+
+	fieldName := strings.Trim(invalidIdentifierChars.ReplaceAllString(snake.String(), "_"), "_")
+	if fieldName == "" || isDigit(fieldName[0]) {
+		return "field_" + fieldName
+	}
+
+	return fieldName
+}
+
+// protoJSONName returns the JSON name protoc gives a field, its name in lowerCamelCase, e.g. "petId" for "pet_id".
+func protoJSONName(fieldName string) string {
+	var jsonName strings.Builder
+
+	upper := false
+
+	for _, r := range fieldName {
+		switch {
+		case r == '_':
+			upper = true
+		case upper:
+			jsonName.WriteRune(unicode.ToUpper(r))
+			upper = false
+		default:
+			jsonName.WriteRune(r)
+		}
+	}
+
+	return jsonName.String()
+}
@@ -0,0 +1,138 @@
+package converters
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/GabrielNunesIT/openapi-converter/internal/domain"
+)
+
+// Options configures converters created through a Registry. Each converter reads the options relevant to it.
+type Options struct {
+	Tables         bool     // Confluence: render parameters and responses as tables
+	TemplateDir    string   // Markdown, Slate and HTML: directory of templates replacing the embedded defaults
+	Snippets       []string // Markdown, Slate, Confluence and Notion: languages of the sample requests, nil for curl only
+	Extensions     []string // Markdown, Slate, HTML, Confluence and Notion: vendor extensions to render, as "x-name" or "x-name=Label"
+	SchemaDepth    int      // Slate, Confluence and Notion: property levels of inline objects listed, 0 for DefaultSchemaDepth
+	SchemaAppendix bool     // Markdown, HTML, Confluence, Notion, PDF and Word: render component schemas once in an appendix
+	MethodColors   []string // Confluence: colours of the method lozenges as "method=colour", or "none" for plain text
+	Panels         []string // Confluence: sections set in panels among PanelSections, nil for all of them
+	StableAnchors  bool     // Markdown, HTML and Confluence: anchor operations at their operationId, see OperationAnchors
+	SchemaRefs     string   // Markdown and HTML: rendering of the schemas bodies refer to, among SchemaRefModes
+	GoClient       bool     // Go: generate a client with a method per operation besides the types
+	PlainLinks     bool     // Confluence: link server and external documentation URLs as text rather than smart-link cards
+	CollapseSize   int      // Confluence: collapse examples longer than this many bytes into expands, 0 for none
+	Directional    bool     // Markdown, Slate, HTML, Confluence and Notion: omit read-only request and write-only response fields
+	SchemaGraph    bool     // Markdown and Confluence: render a Mermaid graph of the schema references
+	Sequences      bool     // Markdown, HTML and Confluence: render Mermaid sequence diagrams of operation links and callbacks
+	Locale         Locale   // Documentation formats: translations of the section headings and other fixed strings, nil for English
+
+	// Values holds options of the format by name, see FormatOption, overriding the fields above they stand for
+	Values map[string]string
+}
+
+// Factory creates a converter with the given options.
+type Factory func(opts Options) domain.Converter
+
+// Registry maps output format names, and their aliases, to converter factories.
+type Registry struct {
+	mu        sync.RWMutex
+	factories map[string]errorFactory   // Canonical format name to factory
+	aliases   map[string]string         // Lower-case name or alias to canonical format name
+	options   map[string][]FormatOption // Canonical format name to the fields of its options struct
+}
+
+// errorFactory creates a converter with the given options, failing on invalid ones.
+type errorFactory func(opts Options) (domain.Converter, error)
+
+// NewRegistry creates an empty registry.
+func NewRegistry() *Registry {
+	return &Registry{
+		factories: make(map[string]errorFactory),
+		aliases:   make(map[string]string),
+		options:   make(map[string][]FormatOption),
+	}
+}
+
+// DefaultRegistry holds the built-in converters, which register themselves.
+var DefaultRegistry = NewRegistry() //nolint:gochecknoglobals // converters self-register at init time
+
+// Register adds a converter to the default registry. It panics if a name is already taken.
+func Register(format string, factory Factory, aliases ...string) {
+	DefaultRegistry.Register(format, factory, aliases...)
+}
+
+// Register makes a converter available under a format name and optional aliases, matched case-insensitively.
+// Like database/sql drivers, converters register at init time, so registering a taken name panics.
+// Converters registered so read no Options.Values, which Registry.New rejects unless the converter is a plugin.
+func (r *Registry) Register(format string, factory Factory, aliases ...string) {
+	if factory == nil {
+		panic("converters: Register factory is nil for format " + format)
+	}
+
+	r.register(format, nil, func(opts Options) (domain.Converter, error) {
+		converter := factory(opts)
+
+		// Plugins are handed all the values, which they check themselves
+		if _, plugin := converter.(*PluginConverter); !plugin && len(opts.Values) > 0 {
+			return nil, unknownOption(format, slices.Sorted(maps.Keys(opts.Values))[0], nil)
+		}
+
+		return converter, nil
+	}, aliases...)
+}
+
+// register adds a factory and the options of a format under its name and aliases.
+func (r *Registry) register(format string, options []FormatOption, factory errorFactory, aliases ...string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, name := range append([]string{format}, aliases...) {
+		if _, taken := r.aliases[strings.ToLower(name)]; taken {
+			panic("converters: Register called twice for format " + name)
+		}
+	}
+
+	r.factories[format] = factory
+	r.options[format] = options
+	for _, name := range append([]string{format}, aliases...) {
+		r.aliases[strings.ToLower(name)] = format
+	}
+}
+
+// Get returns a converter for the format, or one of its aliases, with default options.
+func (r *Registry) Get(format string) (domain.Converter, error) {
+	return r.New(format, Options{})
+}
+
+// New returns a converter for the format, or one of its aliases, configured with opts. The values of opts must
+// be options of the format, see RegisterFormat, except for plugins, which are handed them all.
+func (r *Registry) New(format string, opts Options) (domain.Converter, error) {
+	r.mu.RLock()
+	factory, exists := r.factories[r.aliases[strings.ToLower(format)]]
+	r.mu.RUnlock()
+
+	if !exists {
+		return nil, fmt.Errorf("unsupported format: %s (supported: %s)", format, strings.Join(r.Formats(), ", "))
+	}
+
+	return factory(opts)
+}
+
+// Formats returns the registered format names in alphabetical order, without aliases.
+func (r *Registry) Formats() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	formats := make([]string, 0, len(r.factories))
+	for format := range r.factories {
+		formats = append(formats, format)
+	}
+	sort.Strings(formats)
+
+	return formats
+}
@@ -0,0 +1,282 @@
+package converters
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/GabrielNunesIT/openapi-converter/internal/domain"
+)
+
+const (
+	// maxSampleDepth bounds the nesting of generated sample values.
+	maxSampleDepth = 5
+
+	// generatedExampleLabel labels the examples generated from a schema, to tell them from those of the document.
+	generatedExampleLabel = "generated"
+)
+
+// sampleStringHints are the sample values of string properties without a format, by a word in their name.
+// They are matched in order against the name in lower case without "_" and "-", so that "user_name" is a name.
+var sampleStringHints = []struct{ word, value string }{ //nolint:gochecknoglobals // read-only lookup table
+	{"email", "user@example.com"},
+	{"url", "https://example.com"},
+	{"uri", "https://example.com"},
+	{"website", "https://example.com"},
+	{"phone", "+1-555-0100"},
+	{"password", "********"},
+	{"username", "jdoe"},
+	{"firstname", "Jane"},
+	{"lastname", "Doe"},
+	{"name", "Jane Doe"},
+	{"country", "US"},
+	{"currency", "USD"},
+	{"language", "en"},
+	{"locale", "en-US"},
+	{"city", "Springfield"},
+	{"street", "1 Main Street"},
+	{"postalcode", "12345"},
+	{"zip", "12345"},
+	{"color", "#336699"},
+	{"colour", "#336699"},
+}
+
+// sampler derives sample values from schemas, resolving references against the document components.
+type sampler struct {
+	components map[string]domain.Schema
+	visiting   map[string]struct{} // Components being expanded, to stop at circular references
+}
+
+func newSampler(components map[string]domain.Schema) sampler {
+	return sampler{components: components, visiting: make(map[string]struct{})}
+}
+
+// examples returns the examples of a media type or, when it has none and is JSON, one generated from its schema.
+func (s sampler) examples(contentType string, media domain.MediaType) []exampleEntry {
+	if entries := mediaExamples(media); len(entries) > 0 || !strings.Contains(contentType, "json") {
+		return entries
+	}
+
+	value := s.value(media.Schema, 0)
+	if value == nil {
+		return nil
+	}
+
+	return []exampleEntry{{label: generatedExampleLabel, value: value}}
+}
+
+// value returns the example, default, const or first enum value of a schema, otherwise a value of its type
+// honouring its format and bounds. Objects and arrays are filled recursively.
+func (s sampler) value(schema domain.Schema, depth int) any {
+	return s.named("", schema, depth)
+}
+
+// named returns the sample value of a schema. The name of the property it describes, if any,
+// picks a realistic value for strings without a format, such as an address for "email".
+func (s sampler) named(name string, schema domain.Schema, depth int) any {
+	if schema.Ref != "" {
+		refName := extractRefName(schema.Ref)
+		resolved, exists := s.components[refName]
+
+		if _, cyclic := s.visiting[refName]; cyclic || !exists || depth > maxSampleDepth {
+			return nil
+		}
+
+		s.visiting[refName] = struct{}{}
+		defer delete(s.visiting, refName)
+
+		return s.named(name, resolved, depth)
+	}
+
+	switch {
+	case len(schema.Examples) > 0:
+		return schema.Examples[0]
+	case schema.Default != nil:
+		return schema.Default
+	case schema.Const != nil:
+		return schema.Const
+	case len(schema.Enum) > 0:
+		return schema.Enum[0]
+	case len(schema.AllOf) > 0:
+		return s.named(name, mergeAllOf(schema, s.components, s.visiting), depth)
+	case len(schema.OneOf) > 0:
+		return s.named(name, schema.OneOf[0], depth)
+	case len(schema.AnyOf) > 0:
+		return s.named(name, schema.AnyOf[0], depth)
+	}
+
+	// OpenAPI 3.1 type lists such as "string | null" sample their first type
+	schemaType, _, _ := strings.Cut(schema.Type, " | ")
+
+	switch {
+	case schemaType == "object" || (schemaType == "" && len(schema.Properties) > 0):
+		object := make(map[string]any, len(schema.Properties))
+		if depth < maxSampleDepth {
+			for propName, prop := range schema.Properties {
+				object[propName] = s.named(propName, prop, depth+1)
+			}
+		}
+
+		return object
+	case schemaType == "array":
+		if schema.Items == nil || depth >= maxSampleDepth {
+			return []any{}
+		}
+
+		return []any{s.named(name, *schema.Items, depth+1)}
+	case schemaType == "integer":
+		return int64(sampleNumber(schema, 1, 1))
+	case schemaType == "number":
+		return sampleNumber(schema, 1.5, 0.5)
+	case schemaType == "boolean":
+		return true
+	case schemaType == "string":
+		return sampleString(name, schema)
+	default:
+		return nil
+	}
+}
+
+// sampleNumber returns fallback moved within the bounds of a numeric schema, step inside exclusive bounds.
+func sampleNumber(schema domain.Schema, fallback, step float64) float64 {
+	value := fallback
+
+	if schema.Minimum != nil {
+		value = *schema.Minimum
+		if schema.ExclusiveMinimum {
+			value += step
+		}
+	}
+
+	if schema.Maximum != nil && (value > *schema.Maximum || (schema.ExclusiveMaximum && value == *schema.Maximum)) {
+		value = *schema.Maximum
+		if schema.ExclusiveMaximum {
+			value -= step
+		}
+	}
+
+	return value
+}
+
+// sampleString returns a string matching the format of a schema or, without one, suited to the property name,
+// padded or cut to the length bounds of the schema.
+func sampleString(name string, schema domain.Schema) string {
+	if value, ok := sampleFormattedString(schema.Format); ok {
+		return value
+	}
+
+	value := "string"
+
+	hint := strings.NewReplacer("_", "", "-", "").Replace(strings.ToLower(name))
+	for _, candidate := range sampleStringHints {
+		if strings.Contains(hint, candidate.word) {
+			value = candidate.value
+
+			break
+		}
+	}
+
+	if length := uint64(len(value)); length < schema.MinLength {
+		value += strings.Repeat("x", int(schema.MinLength-length)) //nolint:gosec // bounded by the schema
+	}
+
+	if schema.MaxLength != nil && uint64(len(value)) > *schema.MaxLength {
+		value = value[:*schema.MaxLength]
+	}
+
+	return value
+}
+
+// sampleFormattedString returns a value of a string format, if it is a known one.
+func sampleFormattedString(format string) (string, bool) {
+	switch format {
+	case "date":
+		return "2024-01-01", true
+	case "date-time":
+		return "2024-01-01T00:00:00Z", true
+	case "time":
+		return "12:00:00", true
+	case "duration":
+		return "P1D", true
+	case "email", "idn-email":
+		return "user@example.com", true
+	case "uuid":
+		return "3fa85f64-5717-4562-b3fc-2c963f66afa6", true
+	case "uri", "url", "iri":
+		return "https://example.com", true
+	case "uri-reference", "iri-reference":
+		return "/example", true
+	case "hostname", "idn-hostname":
+		return "example.com", true
+	case "ipv4":
+		return "192.0.2.1", true
+	case "ipv6":
+		return "2001:db8::1", true
+	case "byte":
+		return "ZXhhbXBsZQ==", true
+	case "binary":
+		return "@file", true
+	case "password":
+		return "********", true
+	default:
+		return "", false
+	}
+}
+
+// operationExample is a request or response example of an operation.
+type operationExample struct {
+	Title  string // "Request (<content type>)" or "Response <status> (<content type>)", then ": <label>" if labelled
+	Status string // Status code of the response, empty for request examples
+	Label  string // "<content type>", then ": <label>" if labelled, for listing under the request body or the response
+	Value  any
+}
+
+// operationExamples lists the request and response examples of an operation, by content type,
+// with one generated from the schema of JSON content without examples.
+func (s sampler) operationExamples(op domain.Operation) []operationExample {
+	examples := []operationExample{}
+
+	add := func(title, status, contentType string, media domain.MediaType) {
+		for _, entry := range s.examples(contentType, media) {
+			example := operationExample{Title: title, Status: status, Label: contentType, Value: entry.value}
+			if entry.label != "" {
+				example.Title = fmt.Sprintf("%s: %s", title, entry.label)
+				example.Label = fmt.Sprintf("%s: %s", contentType, entry.label)
+			}
+
+			examples = append(examples, example)
+		}
+	}
+
+	if op.RequestBody != nil {
+		for _, contentType := range sortedContentTypes(op.RequestBody.Content) {
+			add(fmt.Sprintf("Request (%s)", contentType), "", contentType, op.RequestBody.Content[contentType])
+		}
+	}
+
+	for _, resp := range sortedResponses(op.Responses) {
+		for _, contentType := range sortedContentTypes(resp.Content) {
+			add(fmt.Sprintf("Response %s (%s)", resp.StatusCode, contentType), resp.StatusCode, contentType, resp.Content[contentType])
+		}
+	}
+
+	return examples
+}
+
+// SampleValue returns the value generated examples show for a schema, resolving references against components.
+func SampleValue(schema domain.Schema, components map[string]domain.Schema) any {
+	return newSampler(components).value(schema, 0)
+}
+
+// MediaExample returns the example named name of a media type, the first example of the document when name is
+// empty or unknown, or else a value generated from its schema.
+func MediaExample(media domain.MediaType, name string, components map[string]domain.Schema) any {
+	if example, ok := media.Examples[name]; ok && example.Value != nil {
+		return example.Value
+	}
+
+	if entries := mediaExamples(media); len(entries) > 0 {
+		return entries[0].value
+	}
+
+	return SampleValue(media.Schema, components)
+}
@@ -0,0 +1,118 @@
+package converters
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/GabrielNunesIT/openapi-converter/internal/domain"
+)
+
+// mermaidLanguage is the language of the code blocks holding Mermaid diagrams, which renderers draw in their place.
+const mermaidLanguage = "mermaid"
+
+// sequenceDiagram returns a Mermaid sequence diagram of the flow an operation starts between the client and the
+// API: the request and its response, the operations the links of the response lead to, such as polling the
+// created resource, then the callbacks the API sends, such as a webhook notifying the end of the processing.
+// It is empty for an operation with neither links nor callbacks.
+func sequenceDiagram(doc *domain.OpenAPIDocument, path string, op domain.Operation) string {
+	related := relatedOperations(op, nil)
+	if len(related) == 0 && len(op.Callbacks) == 0 {
+		return ""
+	}
+
+	operations := make(map[string]endpointRef) // operationId and "METHOD /path" to the operation
+	for _, p := range doc.Paths {
+		for _, pathOp := range p.Operations {
+			ep := endpointRef{path: p.Path, method: pathOp.Method, operation: pathOp}
+			operations[formatMethod(pathOp.Method)+" "+p.Path] = ep
+
+			if pathOp.OperationID != "" {
+				operations[pathOp.OperationID] = ep
+			}
+		}
+	}
+
+	var diagram strings.Builder
+
+	diagram.WriteString("sequenceDiagram\n  participant Client\n  participant API\n")
+	writeSequenceMessage(&diagram, "Client->>API", formatMethod(op.Method)+" "+path)
+
+	if responses := sortedResponses(op.Responses); len(related) == 0 && len(responses) > 0 {
+		writeSequenceMessage(&diagram, "API-->>Client", responses[0].StatusCode)
+	}
+
+	status := ""
+
+	for _, link := range related {
+		if link.StatusCode != status {
+			status = link.StatusCode
+			writeSequenceMessage(&diagram, "API-->>Client", status)
+		}
+
+		// Targets given by operationId are shown as the request they send
+		target, ok := operations[link.Target]
+		message := link.Target
+
+		if ok {
+			message = formatMethod(target.method) + " " + target.path
+		}
+
+		if values := formatLinkValues(link); values != "" {
+			message += fmt.Sprintf(" (%s)", values)
+		}
+
+		writeSequenceMessage(&diagram, "Client->>API", message)
+
+		if responses := sortedResponses(target.operation.Responses); len(responses) > 0 {
+			writeSequenceMessage(&diagram, "API-->>Client", responses[0].StatusCode)
+		}
+	}
+
+	for _, callback := range op.Callbacks {
+		for _, callbackOp := range callback.Operations {
+			writeSequenceMessage(&diagram, "API->>Client",
+				fmt.Sprintf("%s: %s %s", callback.Name, formatMethod(callbackOp.Method), callback.Expression))
+
+			if responses := sortedResponses(callbackOp.Responses); len(responses) > 0 {
+				writeSequenceMessage(&diagram, "Client-->>API", responses[0].StatusCode)
+			}
+		}
+	}
+
+	return diagram.String()
+}
+
+// hasSequenceDiagrams reports whether an operation or webhook of a document has links or callbacks to diagram.
+func hasSequenceDiagrams(doc *domain.OpenAPIDocument) bool {
+	operations := []domain.Operation{}
+	for _, path := range doc.Paths {
+		operations = append(operations, path.Operations...)
+	}
+
+	for _, webhook := range doc.Webhooks {
+		operations = append(operations, webhook.Operations...)
+	}
+
+	for _, op := range operations {
+		if len(op.Callbacks) > 0 || len(relatedOperations(op, nil)) > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// operationSequence returns the sequence diagram of an endpoint when diagrams are enabled, empty otherwise.
+func operationSequence(enabled bool, doc *domain.OpenAPIDocument, ep endpointRef) string {
+	if !enabled {
+		return ""
+	}
+
+	return sequenceDiagram(doc, ep.path, ep.operation)
+}
+
+// writeSequenceMessage writes a message of a Mermaid sequence diagram, such as "Client->>API: GET /pets",
+// with the characters Mermaid reads as entity codes or statement separators written as entity codes.
+func writeSequenceMessage(diagram *strings.Builder, arrow, text string) {
+	fmt.Fprintf(diagram, "  %s: %s\n", arrow, strings.NewReplacer("#", "#35;", ";", "#59;").Replace(text))
+}
@@ -0,0 +1,352 @@
+package converters
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	texttemplate "text/template"
+
+	"github.com/GabrielNunesIT/openapi-converter/internal/domain"
+)
+
+const slateFormat = "slate"
+
+// SlateConverter converts OpenAPI documents to the Markdown of a Slate site: YAML front matter declaring
+// the language tabs, top-level headings per tag and the sample requests and responses as code blocks,
+// which Slate places in the right-hand column.
+type SlateConverter struct {
+	templateDir string   // Directory of templates replacing the embedded defaults
+	snippets    []string // Languages of the sample requests, nil for curl only
+	extensions  []string // Vendor extensions to render, as "x-name" or "x-name=Label"
+	depth       int      // Property levels of inline objects listed under a schema
+	directional bool     // Leave read-only properties out of request fields and write-only ones out of response fields
+	locale      Locale   // Translations of the fixed strings, nil for English
+}
+
+// SlateOptions configures a SlateConverter. The fields tagged option can also be set by name, e.g. with
+// --option slate.schema-depth=2, see RegisterFormat.
+type SlateOptions struct {
+	// TemplateDir is a directory of *.tmpl files, which may redefine the "heading", "tag", "operation" and "schema"
+	// templates.
+	TemplateDir string `option:"template-dir"`
+
+	// Snippets are the languages of the sample request of each endpoint, each language becoming a tab, nil for curl
+	// only. An empty list disables sample requests.
+	Snippets []string `option:"snippets"`
+
+	// Extensions are the vendor extensions of the API, its operations and schemas to render, each given as
+	// "x-name" or "x-name=Label".
+	Extensions []string `option:"extensions"`
+
+	// SchemaDepth lists the properties of inline objects below their property, down to that many levels.
+	// A depth below 1 keeps DefaultSchemaDepth.
+	SchemaDepth int `option:"schema-depth"`
+
+	// Directional leaves the read-only properties out of the request body field tables and the write-only
+	// properties out of the response field tables, as they are not sent that way.
+	Directional bool `option:"directional-fields"`
+
+	// Locale translates the section headings and other fixed strings, which the templates translate with the "t"
+	// function, e.g. {{t "Parameters"}}; nil for English.
+	Locale Locale
+}
+
+// NewSlateConverter creates a new Slate converter.
+func NewSlateConverter(opts SlateOptions) *SlateConverter {
+	c := &SlateConverter{
+		templateDir: opts.TemplateDir,
+		snippets:    opts.Snippets,
+		extensions:  opts.Extensions,
+		depth:       DefaultSchemaDepth,
+		directional: opts.Directional,
+		locale:      opts.Locale,
+	}
+
+	if opts.SchemaDepth > 0 {
+		c.depth = opts.SchemaDepth
+	}
+
+	return c
+}
+
+func init() { //nolint:gochecknoinits // converters self-register with the default registry
+	RegisterFormat(slateFormat, func(opts Options) SlateOptions {
+		return SlateOptions{
+			TemplateDir: opts.TemplateDir,
+			Snippets:    opts.Snippets,
+			Extensions:  opts.Extensions,
+			SchemaDepth: opts.SchemaDepth,
+			Directional: opts.Directional,
+			Locale:      opts.Locale,
+		}
+	}, func(opts SlateOptions) domain.Converter { return NewSlateConverter(opts) })
+}
+
+// Format returns the output format name.
+func (c *SlateConverter) Format() string {
+	return slateFormat
+}
+
+// slateOperationData is passed to the "operation" template of the Slate format.
+type slateOperationData struct {
+	operationData
+	Examples []slateExample // Sample payloads shown next to the endpoint
+}
+
+// slateExample is a sample payload, shown as a quoted title followed by a code block.
+type slateExample struct {
+	Title    string
+	Language string // Code block language, empty for plain text
+	Code     string
+}
+
+// slateProperty is a property of a schema passed to the "schema" template. Properties of inline objects
+// follow their parent, named by their path such as "owner.name", or "items[].name" below an array.
+type slateProperty struct {
+	Name     string
+	Schema   domain.Schema
+	Required bool // Required by the object holding it
+}
+
+// Convert transforms an OpenAPI document to Slate Markdown.
+func (c *SlateConverter) Convert(doc *domain.OpenAPIDocument, output io.Writer) error {
+	tmpl, err := loadTextTemplates(slateFormat, c.templateDir, slateFuncs(c.depth, c.locale))
+	if err != nil {
+		return err
+	}
+
+	generators, err := snippetGeneratorsFor(c.snippets)
+	if err != nil {
+		return err
+	}
+
+	extensions, err := extensionFieldsFor(c.extensions)
+	if err != nil {
+		return err
+	}
+
+	var md strings.Builder
+
+	w := &templateWriter{out: &md, tmpl: tmpl, locale: c.locale}
+
+	writeSlateFrontMatter(&md, doc.Title, generators)
+
+	if preamble := strings.TrimSpace(doc.Preamble); preamble != "" {
+		md.WriteString(preamble + "\n\n")
+	}
+
+	// Introduction
+	w.heading(1, c.locale.T("Introduction"))
+
+	if description := withExternalDocs(doc.Description, doc.ExternalDocs); description != "" {
+		md.WriteString(description + "\n\n")
+	}
+
+	md.WriteString(fmt.Sprintf("Version: %s\n\n", doc.Version))
+
+	for _, extension := range selectExtensions(extensions, doc.Extensions) {
+		md.WriteString(fmt.Sprintf("%s: %s\n\n", extension.Label, extension.Value))
+	}
+
+	if about := aboutMarkdown(doc); about != "" {
+		md.WriteString(about + "\n\n")
+	}
+
+	if len(doc.Servers) > 0 {
+		md.WriteString("Base URLs:\n\n")
+
+		for _, server := range doc.Servers {
+			if server.Description != "" {
+				md.WriteString(fmt.Sprintf("- `%s` - %s\n", server.URL, server.Description))
+			} else {
+				md.WriteString(fmt.Sprintf("- `%s`\n", server.URL))
+			}
+		}
+
+		md.WriteString("\n")
+	}
+
+	// Authentication
+	if len(doc.SecuritySchemes) > 0 {
+		w.heading(1, c.locale.T("Authentication"))
+		writeMarkdownSecuritySchemes(w, doc, 2)
+	}
+
+	// Each tag is a top-level section of the navigation, with its endpoints below
+	tagPaths := groupPathsByTag(doc)
+	for _, tag := range orderedTags(doc, tagPaths) {
+		w.tag(1, tagInfo(doc, tag), "")
+
+		for _, ep := range tagPaths[tag] {
+			snippets := requestSnippets(generators, doc, ep.path, ep.operation)
+
+			w.execute("operation", slateOperationData{
+				operationData: operationData{
+					Path:           ep.path,
+					Operation:      ep.operation,
+					Links:          relatedOperations(ep.operation, nil),
+					Snippets:       snippets,
+					Extensions:     selectExtensions(extensions, ep.operation.Extensions),
+					BodyFields:     bodyFieldsByContentType(ep.operation, doc.Components, c.depth, c.directional),
+					ResponseFields: responseFieldsByStatus(ep.operation, doc.Components, c.depth, c.directional),
+				},
+				Examples: slateExamples(doc, ep.operation, len(snippets) == 0),
+			})
+		}
+	}
+
+	// Webhooks
+	if len(doc.Webhooks) > 0 {
+		w.heading(1, c.locale.T("Webhooks"))
+
+		for _, ep := range webhookRefs(doc) {
+			w.execute("operation", slateOperationData{
+				operationData: operationData{
+					Path:           ep.path,
+					Operation:      ep.operation,
+					Links:          relatedOperations(ep.operation, nil),
+					Extensions:     selectExtensions(extensions, ep.operation.Extensions),
+					BodyFields:     bodyFieldsByContentType(ep.operation, doc.Components, c.depth, c.directional),
+					ResponseFields: responseFieldsByStatus(ep.operation, doc.Components, c.depth, c.directional),
+				},
+				Examples: slateExamples(doc, ep.operation, true),
+			})
+		}
+	}
+
+	// Schemas
+	if len(doc.Components) > 0 {
+		w.heading(1, c.locale.T("Schemas"))
+
+		names := make([]string, 0, len(doc.Components))
+		for name := range doc.Components {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			schema := doc.Components[name]
+
+			w.execute("schema", schemaData{
+				Name:       name,
+				Schema:     flattenAllOf(schema, doc.Components),
+				Extensions: selectExtensions(extensions, schema.Extensions),
+			})
+		}
+	}
+
+	if epilogue := strings.TrimSpace(doc.Epilogue); epilogue != "" {
+		md.WriteString(epilogue + "\n\n")
+	}
+
+	if w.err != nil {
+		return w.err
+	}
+
+	if _, err := io.WriteString(output, md.String()); err != nil {
+		return fmt.Errorf("failed to write slate markdown: %w", err)
+	}
+
+	return nil
+}
+
+// writeSlateFrontMatter writes the YAML front matter of a Slate page, with a language tab per snippet language.
+func writeSlateFrontMatter(md *strings.Builder, title string, generators []snippetGenerator) {
+	md.WriteString("---\n")
+	md.WriteString(fmt.Sprintf("title: %q\n", title))
+
+	if len(generators) > 0 {
+		// Slate shows the code blocks whose language matches the selected tab
+		md.WriteString("language_tabs:\n")
+
+		for _, generator := range generators {
+			md.WriteString(fmt.Sprintf("  - %s: %q\n", generator.language, generator.label))
+		}
+	}
+
+	md.WriteString("toc_footers: []\n")
+	md.WriteString("includes: []\n")
+	md.WriteString("search: true\n")
+	md.WriteString("code_clipboard: true\n")
+	md.WriteString("---\n\n")
+}
+
+// slateExamples returns a sample payload per response with content, using its first example or one derived from
+// its schema. With withRequest, the request body is included too, for endpoints without sample requests.
+func slateExamples(doc *domain.OpenAPIDocument, op domain.Operation, withRequest bool) []slateExample {
+	samples := newSampler(doc.Components)
+	examples := []slateExample{}
+
+	if withRequest && op.RequestBody != nil {
+		if example, ok := slateMediaExample(op.RequestBody.Content, samples); ok {
+			example.Title = "Example request body:"
+			examples = append(examples, example)
+		}
+	}
+
+	for _, resp := range sortedResponses(op.Responses) {
+		if example, ok := slateMediaExample(resp.Content, samples); ok {
+			example.Title = fmt.Sprintf("Example response (%s):", resp.StatusCode)
+			examples = append(examples, example)
+		}
+	}
+
+	return examples
+}
+
+// slateMediaExample renders a sample payload of the preferred content type, if it has one.
+// Only JSON content gets a payload derived from its schema.
+func slateMediaExample(content map[string]domain.MediaType, samples sampler) (slateExample, bool) {
+	contentTypes := sortedContentTypes(content)
+	if len(contentTypes) == 0 {
+		return slateExample{}, false
+	}
+
+	contentType := preferredContentType(contentTypes)
+	media := content[contentType]
+	isJSON := strings.Contains(contentType, "json")
+
+	var value any
+	if entries := mediaExamples(media); len(entries) > 0 {
+		value = entries[0].value
+	} else if isJSON {
+		value = samples.value(media.Schema, 0)
+	}
+
+	if value == nil {
+		return slateExample{}, false
+	}
+
+	example := slateExample{Code: formatExampleValue(value)}
+	if isJSON {
+		example.Language = "json"
+	}
+
+	return example, true
+}
+
+func slateFuncs(depth int, locale Locale) texttemplate.FuncMap {
+	funcs := markdownFuncs(locale)
+	funcs["properties"] = func(schema domain.Schema) []slateProperty {
+		return flattenSlateProperties(nestedProperties(schema, depth), "", nil)
+	}
+
+	return funcs
+}
+
+// flattenSlateProperties lists nested properties depth first, each named by its path below prefix.
+func flattenSlateProperties(properties []schemaProperty, prefix string, flat []slateProperty) []slateProperty {
+	for _, property := range properties {
+		name := prefix + property.Name
+		flat = append(flat, slateProperty{Name: name, Schema: property.Schema, Required: property.Required})
+
+		if property.Schema.Type == "array" {
+			name += "[]"
+		}
+
+		flat = flattenSlateProperties(property.Children, name+".", flat)
+	}
+
+	return flat
+}
@@ -0,0 +1,295 @@
+package converters
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/GabrielNunesIT/openapi-converter/internal/domain"
+)
+
+// defaultSnippetLanguage is rendered when a converter is not given snippet languages.
+const defaultSnippetLanguage = "curl"
+
+// codeSnippet is a sample request written in one language.
+type codeSnippet struct {
+	Label    string // Display name, such as "Python (requests)"
+	Language string // Code block language
+	Code     string
+}
+
+// snippetGenerator writes a sample request as code in one language.
+type snippetGenerator struct {
+	label    string
+	language string
+	generate func(req sampleRequest) string
+}
+
+// snippetGenerators maps the languages accepted by --snippets to their generator.
+var snippetGenerators = map[string]snippetGenerator{ //nolint:gochecknoglobals // read-only lookup table
+	"curl":       {label: "cURL", language: "bash", generate: curlSnippet},
+	"go":         {label: "Go (net/http)", language: "go", generate: goSnippet},
+	"python":     {label: "Python (requests)", language: "python", generate: pythonSnippet},
+	"javascript": {label: "JavaScript (fetch)", language: "javascript", generate: javascriptSnippet},
+}
+
+// SnippetLanguages returns the languages code snippets can be generated in, in alphabetical order.
+func SnippetLanguages() []string {
+	languages := make([]string, 0, len(snippetGenerators))
+	for language := range snippetGenerators {
+		languages = append(languages, language)
+	}
+	sort.Strings(languages)
+
+	return languages
+}
+
+// snippetGeneratorsFor looks up the generators of the given languages, in order.
+// A nil list selects curl; an empty one disables snippets.
+func snippetGeneratorsFor(languages []string) ([]snippetGenerator, error) {
+	if languages == nil {
+		languages = []string{defaultSnippetLanguage}
+	}
+
+	generators := make([]snippetGenerator, 0, len(languages))
+
+	for _, language := range languages {
+		generator, exists := snippetGenerators[strings.ToLower(strings.TrimSpace(language))]
+		if !exists {
+			return nil, fmt.Errorf("unsupported snippet language: %s (supported: %s)",
+				language, strings.Join(SnippetLanguages(), ", "))
+		}
+
+		generators = append(generators, generator)
+	}
+
+	return generators, nil
+}
+
+// requestSnippets writes a sample request to an operation with each generator.
+func requestSnippets(generators []snippetGenerator, doc *domain.OpenAPIDocument, path string, op domain.Operation) []codeSnippet {
+	if len(generators) == 0 {
+		return nil
+	}
+
+	req := buildSampleRequest(doc, path, op)
+	snippets := make([]codeSnippet, 0, len(generators))
+
+	for _, generator := range generators {
+		snippets = append(snippets, codeSnippet{
+			Label:    generator.label,
+			Language: generator.language,
+			Code:     generator.generate(req),
+		})
+	}
+
+	return snippets
+}
+
+func curlSnippet(req sampleRequest) string {
+	lines := []string{fmt.Sprintf("curl -X %s %s", req.Method, shellQuote(req.URL))}
+
+	if req.BasicAuth {
+		lines = append(lines, "-u "+shellQuote("<username>:<password>"))
+	}
+
+	for _, header := range req.Headers {
+		lines = append(lines, "-H "+shellQuote(header.Name+": "+header.Value))
+	}
+
+	if len(req.Cookies) > 0 {
+		lines = append(lines, "-b "+shellQuote(cookieHeader(req.Cookies)))
+	}
+
+	for _, field := range req.Form {
+		lines = append(lines, "-F "+shellQuote(field.Name+"="+field.Value))
+	}
+
+	if req.Body != "" {
+		lines = append(lines, "-d "+shellQuote(req.Body))
+	}
+
+	return strings.Join(lines, " \\\n  ")
+}
+
+func goSnippet(req sampleRequest) string {
+	var code strings.Builder
+
+	body := "nil"
+
+	switch {
+	case len(req.Form) > 0:
+		code.WriteString("var body bytes.Buffer\nform := multipart.NewWriter(&body)\n")
+
+		for _, field := range req.Form {
+			code.WriteString(fmt.Sprintf("_ = form.WriteField(%s, %s)\n", strconv.Quote(field.Name), strconv.Quote(field.Value)))
+		}
+
+		code.WriteString("_ = form.Close()\n\n")
+
+		body = "&body"
+	case req.Body != "":
+		literal := "`" + req.Body + "`"
+		if strings.Contains(req.Body, "`") {
+			literal = strconv.Quote(req.Body)
+		}
+
+		code.WriteString(fmt.Sprintf("body := strings.NewReader(%s)\n\n", literal))
+
+		body = "body"
+	}
+
+	code.WriteString(fmt.Sprintf("req, err := http.NewRequest(%s, %s, %s)\n", strconv.Quote(req.Method), strconv.Quote(req.URL), body))
+	code.WriteString("if err != nil {\n\tlog.Fatal(err)\n}\n")
+
+	setup := []string{}
+	for _, header := range req.Headers {
+		setup = append(setup, fmt.Sprintf("req.Header.Set(%s, %s)", strconv.Quote(header.Name), strconv.Quote(header.Value)))
+	}
+
+	if len(req.Form) > 0 {
+		setup = append(setup, "req.Header.Set(\"Content-Type\", form.FormDataContentType())")
+	}
+
+	if req.BasicAuth {
+		setup = append(setup, "req.SetBasicAuth(\"<username>\", \"<password>\")")
+	}
+
+	for _, cookie := range req.Cookies {
+		setup = append(setup, fmt.Sprintf("req.AddCookie(&http.Cookie{Name: %s, Value: %s})",
+			strconv.Quote(cookie.Name), strconv.Quote(cookie.Value)))
+	}
+
+	if len(setup) > 0 {
+		code.WriteString("\n" + strings.Join(setup, "\n") + "\n")
+	}
+
+	code.WriteString("\nresp, err := http.DefaultClient.Do(req)\nif err != nil {\n\tlog.Fatal(err)\n}\ndefer resp.Body.Close()")
+
+	return code.String()
+}
+
+func pythonSnippet(req sampleRequest) string {
+	var code strings.Builder
+
+	code.WriteString("import requests\n\nresponse = requests.request(\n")
+	code.WriteString(fmt.Sprintf("    %s,\n    %s,\n", quoteString(req.Method), quoteString(req.URL)))
+
+	if len(req.Headers) > 0 {
+		code.WriteString("    headers=" + pythonDict(req.Headers, func(v string) string { return quoteString(v) }) + ",\n")
+	}
+
+	if len(req.Cookies) > 0 {
+		code.WriteString("    cookies=" + pythonDict(req.Cookies, func(v string) string { return quoteString(v) }) + ",\n")
+	}
+
+	if req.BasicAuth {
+		code.WriteString("    auth=(\"<username>\", \"<password>\"),\n")
+	}
+
+	if len(req.Form) > 0 {
+		code.WriteString("    files=" + pythonDict(req.Form, func(v string) string { return "(None, " + quoteString(v) + ")" }) + ",\n")
+	}
+
+	if req.Body != "" {
+		data := quoteString(req.Body)
+		if req.jsonBody() && !strings.Contains(req.Body, `"""`) && !strings.Contains(req.Body, `\`) {
+			data = `"""` + req.Body + `"""`
+		}
+
+		code.WriteString(fmt.Sprintf("    data=%s,\n", data))
+	}
+
+	code.WriteString(")\nprint(response.status_code)")
+
+	return code.String()
+}
+
+// pythonDict writes fields as a Python dict literal, formatting each value with value.
+func pythonDict(fields []sampleField, value func(string) string) string {
+	entries := make([]string, 0, len(fields))
+	for _, field := range fields {
+		entries = append(entries, fmt.Sprintf("        %s: %s,\n", quoteString(field.Name), value(field.Value)))
+	}
+
+	return "{\n" + strings.Join(entries, "") + "    }"
+}
+
+func javascriptSnippet(req sampleRequest) string {
+	var code strings.Builder
+
+	if len(req.Form) > 0 {
+		code.WriteString("const form = new FormData();\n")
+
+		for _, field := range req.Form {
+			code.WriteString(fmt.Sprintf("form.append(%s, %s);\n", quoteString(field.Name), quoteString(field.Value)))
+		}
+
+		code.WriteString("\n")
+	}
+
+	code.WriteString(fmt.Sprintf("const response = await fetch(%s, {\n", quoteString(req.URL)))
+	code.WriteString(fmt.Sprintf("  method: %s,\n", quoteString(req.Method)))
+
+	headers := make([]string, 0, len(req.Headers)+2)
+	for _, header := range req.Headers {
+		headers = append(headers, fmt.Sprintf("    %s: %s,\n", quoteString(header.Name), quoteString(header.Value)))
+	}
+
+	if req.BasicAuth {
+		headers = append(headers, "    \"Authorization\": \"Basic \" + btoa(\"<username>:<password>\"),\n")
+	}
+
+	if len(req.Cookies) > 0 {
+		headers = append(headers, fmt.Sprintf("    \"Cookie\": %s,\n", quoteString(cookieHeader(req.Cookies))))
+	}
+
+	if len(headers) > 0 {
+		code.WriteString("  headers: {\n" + strings.Join(headers, "") + "  },\n")
+	}
+
+	switch {
+	case len(req.Form) > 0:
+		code.WriteString("  body: form,\n")
+	case req.jsonBody():
+		code.WriteString(fmt.Sprintf("  body: JSON.stringify(%s),\n", strings.ReplaceAll(req.Body, "\n", "\n  ")))
+	case req.Body != "":
+		code.WriteString(fmt.Sprintf("  body: %s,\n", quoteString(req.Body)))
+	}
+
+	code.WriteString("});\nconsole.log(response.status);")
+
+	return code.String()
+}
+
+// cookieHeader joins cookies into the value of a Cookie header.
+func cookieHeader(cookies []sampleField) string {
+	pairs := make([]string, 0, len(cookies))
+	for _, cookie := range cookies {
+		pairs = append(pairs, cookie.Name+"="+cookie.Value)
+	}
+
+	return strings.Join(pairs, "; ")
+}
+
+// quoteString writes a double-quoted string literal valid in JavaScript and Python.
+func quoteString(value string) string {
+	var buf bytes.Buffer
+
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+
+	if err := encoder.Encode(value); err != nil {
+		return strconv.Quote(value)
+	}
+
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+// shellQuote wraps a value in single quotes for POSIX shells.
+func shellQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
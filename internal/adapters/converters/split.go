@@ -0,0 +1,190 @@
+package converters
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/GabrielNunesIT/openapi-converter/internal/domain"
+)
+
+const indexPartName = "index"
+
+var slugInvalidChars = regexp.MustCompile(`[^a-z0-9]+`)
+
+// DocumentPart is a self-contained slice of an OpenAPI document rendered as its own output.
+type DocumentPart struct {
+	Name     string // Tag name, empty for the index
+	Slug     string // File-name friendly identifier, unique within a split
+	Document *domain.OpenAPIDocument
+}
+
+// SplitByTag splits a document into an index part followed by one part per tag.
+// The index keeps the API overview (description, servers, authentication and webhooks) and lists the tag parts;
+// every tag part contains only the endpoints of that tag.
+func SplitByTag(doc *domain.OpenAPIDocument) []DocumentPart {
+	tagPaths := groupPathsByTag(doc)
+	tags := orderedTags(doc, tagPaths)
+
+	index := &domain.OpenAPIDocument{
+		Title:           doc.Title,
+		Version:         doc.Version,
+		Description:     indexDescription(doc.Description, tags, tagPaths),
+		ExternalDocs:    doc.ExternalDocs,
+		Contact:         doc.Contact,
+		License:         doc.License,
+		TermsOfService:  doc.TermsOfService,
+		Servers:         doc.Servers,
+		Tags:            doc.Tags,
+		Webhooks:        doc.Webhooks,
+		Components:      doc.Components,
+		SecuritySchemes: doc.SecuritySchemes,
+		Extensions:      doc.Extensions,
+		Preamble:        doc.Preamble,
+		Epilogue:        doc.Epilogue,
+	}
+
+	parts := []DocumentPart{{Slug: indexPartName, Document: index}}
+	used := map[string]struct{}{indexPartName: {}}
+
+	for _, tag := range tags {
+		parts = append(parts, DocumentPart{
+			Name: tag,
+			Slug: uniqueSlug(tag, used),
+			Document: &domain.OpenAPIDocument{
+				Title:      fmt.Sprintf("%s - %s", doc.Title, tag),
+				Version:    doc.Version,
+				License:    doc.License, // Repeated on every page, as a license must accompany each copy
+				Tags:       []domain.Tag{tagInfo(doc, tag)},
+				Paths:      tagDocumentPaths(tag, tagPaths[tag]),
+				Components: doc.Components,
+			},
+		})
+	}
+
+	return parts
+}
+
+// indexDescription appends the list of tag parts to the API description.
+func indexDescription(description string, tags []string, tagPaths map[string][]endpointRef) string {
+	var text strings.Builder
+
+	if description = strings.TrimSpace(description); description != "" {
+		text.WriteString(description + "\n\n")
+	}
+
+	text.WriteString("Endpoints are documented separately for each tag:\n")
+
+	for _, tag := range tags {
+		noun := "endpoints"
+		if len(tagPaths[tag]) == 1 {
+			noun = "endpoint"
+		}
+
+		text.WriteString(fmt.Sprintf("- %s (%d %s)\n", tag, len(tagPaths[tag]), noun))
+	}
+
+	return strings.TrimSpace(text.String())
+}
+
+// tagDocumentPaths rebuilds the paths of a tag, restricting every operation to that tag
+// so that operations shared between tags are not repeated under other headings.
+func tagDocumentPaths(tag string, endpoints []endpointRef) []domain.Path {
+	paths := []domain.Path{}
+	positions := make(map[string]int)
+
+	for _, ep := range endpoints {
+		op := ep.operation
+		op.Tags = []string{tag}
+
+		pos, exists := positions[ep.path]
+		if !exists {
+			pos = len(paths)
+			positions[ep.path] = pos
+			paths = append(paths, domain.Path{Path: ep.path})
+		}
+
+		paths[pos].Operations = append(paths[pos].Operations, op)
+	}
+
+	return paths
+}
+
+// uniqueSlug turns a name into a lowercase identifier usable as a file name or anchor,
+// adding a numeric suffix when it is already taken.
+func uniqueSlug(name string, used map[string]struct{}) string {
+	base := strings.Trim(slugInvalidChars.ReplaceAllString(strings.ToLower(name), "-"), "-")
+	if base == "" {
+		base = "tag"
+	}
+
+	slug := base
+	for i := 2; ; i++ {
+		if _, taken := used[slug]; !taken {
+			break
+		}
+
+		slug = fmt.Sprintf("%s-%d", base, i)
+	}
+
+	used[slug] = struct{}{}
+
+	return slug
+}
+
+// TagSplitter renders one output file per tag plus an index with any single-document converter.
+type TagSplitter struct {
+	converter domain.Converter
+	extension string
+}
+
+// NewTagSplitter creates a splitter that writes files with the given extension using the converter.
+func NewTagSplitter(converter domain.Converter, extension string) *TagSplitter {
+	return &TagSplitter{
+		converter: converter,
+		extension: strings.TrimPrefix(extension, "."),
+	}
+}
+
+// Format returns the output format name of the wrapped converter.
+func (s *TagSplitter) Format() string {
+	return s.converter.Format()
+}
+
+// MultiConvert writes the index and tag documents into outputDir, creating it if needed.
+func (s *TagSplitter) MultiConvert(doc *domain.OpenAPIDocument, outputDir string) ([]string, error) {
+	if err := os.MkdirAll(outputDir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	parts := SplitByTag(doc)
+	files := make([]string, 0, len(parts))
+
+	for _, part := range parts {
+		path := filepath.Join(outputDir, part.Slug+"."+s.extension)
+
+		if err := s.writePart(part, path); err != nil {
+			return files, err
+		}
+
+		files = append(files, path)
+	}
+
+	return files, nil
+}
+
+func (s *TagSplitter) writePart(part DocumentPart, path string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create output file: %w", err)
+	}
+	defer file.Close()
+
+	if err := s.converter.Convert(part.Document, file); err != nil {
+		return fmt.Errorf("failed to convert %s: %w", part.Slug, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,1050 @@
+package converters
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"strings"
+
+	"github.com/GabrielNunesIT/openapi-converter/internal/domain"
+)
+
+const storageFormat = "confluence-storage"
+
+// storageCodeLanguages maps code block languages to the names the Confluence code macro knows them by.
+// Other languages are passed as they are.
+var storageCodeLanguages = map[string]string{ //nolint:gochecknoglobals // read-only lookup table
+	"javascript": "js",
+	"json":       "js",
+	"python":     "py",
+}
+
+// StorageConverter converts OpenAPI documents to the Confluence storage format, the XHTML with macros that
+// Confluence Server and Data Center accept through their REST API, laid out like the ADF output.
+type StorageConverter struct {
+	tables      bool     // Render parameters and responses as tables instead of bullet lists
+	snippets    []string // Languages of the sample requests, nil for curl only
+	extensions  []string // Vendor extensions to render, as "x-name" or "x-name=Label"
+	depth       int      // Property levels of inline objects listed under a schema
+	appendix    bool     // Render component schemas once in an appendix rather than under every tag
+	stable      bool     // Anchor operations at their operationId, see documentTOC
+	directional bool     // Leave read-only properties out of request fields and write-only ones out of response fields
+	locale      Locale   // Translations of the fixed strings, nil for English
+	headings    headingLevels
+}
+
+// StorageOptions configures a StorageConverter. The fields tagged option can also be set by name, e.g. with
+// --option confluence-storage.tables=true, see RegisterFormat.
+type StorageOptions struct {
+	// Tables renders parameters and responses as tables instead of bullet lists.
+	Tables bool `option:"tables"`
+
+	// Snippets are the languages of the sample request of each endpoint, such as "curl" or "python", nil for curl
+	// only. An empty list disables sample requests.
+	Snippets []string `option:"snippets"`
+
+	// Extensions are the vendor extensions of the API, its operations and schemas to render, each given as
+	// "x-name" or "x-name=Label".
+	Extensions []string `option:"extensions"`
+
+	// SchemaDepth lists the properties of inline objects nested below their property, down to that many levels.
+	// A depth below 1 keeps DefaultSchemaDepth.
+	SchemaDepth int `option:"schema-depth"`
+
+	// SchemaAppendix renders every component schema once in a "Schemas" appendix, the "Schemas Used" section of
+	// each tag linking to it.
+	SchemaAppendix bool `option:"schema-appendix"`
+
+	// StableAnchors anchors the first occurrence of each operation with an operationId at "op-" followed by the
+	// operationId, e.g. "op-listPets", whatever else the document holds, so that other documents can link to it.
+	StableAnchors bool `option:"stable-anchors"`
+
+	// Directional leaves the read-only properties out of the request body field tables and the write-only
+	// properties out of the response field tables, as they are not sent that way.
+	Directional bool `option:"directional-fields"`
+
+	// HeadingOffset adds levels to every heading of the page, e.g. 1 to start at h2 when the page is embedded under
+	// a heading of its own.
+	HeadingOffset int `option:"heading-offset"`
+
+	// MaxHeadingLevel raises the headings deeper than this level to it, 0 for h6.
+	MaxHeadingLevel int `option:"max-heading-level"`
+
+	// Locale translates the section headings and other fixed strings of the page, nil for English.
+	Locale Locale
+}
+
+// NewStorageConverter creates a new Confluence storage format converter.
+func NewStorageConverter(opts StorageOptions) *StorageConverter {
+	c := &StorageConverter{
+		tables:      opts.Tables,
+		snippets:    opts.Snippets,
+		extensions:  opts.Extensions,
+		depth:       DefaultSchemaDepth,
+		appendix:    opts.SchemaAppendix,
+		stable:      opts.StableAnchors,
+		directional: opts.Directional,
+		locale:      opts.Locale,
+		headings:    headingLevels{offset: opts.HeadingOffset, max: opts.MaxHeadingLevel},
+	}
+
+	if opts.SchemaDepth > 0 {
+		c.depth = opts.SchemaDepth
+	}
+
+	return c
+}
+
+func init() { //nolint:gochecknoinits // converters self-register with the default registry
+	RegisterFormat(storageFormat, func(opts Options) StorageOptions {
+		return StorageOptions{
+			Tables:         opts.Tables,
+			Snippets:       opts.Snippets,
+			Extensions:     opts.Extensions,
+			SchemaDepth:    opts.SchemaDepth,
+			SchemaAppendix: opts.SchemaAppendix,
+			StableAnchors:  opts.StableAnchors,
+			Directional:    opts.Directional,
+			Locale:         opts.Locale,
+		}
+	}, func(opts StorageOptions) domain.Converter { return NewStorageConverter(opts) }, "storage")
+}
+
+// Format returns the output format name.
+func (c *StorageConverter) Format() string {
+	return storageFormat
+}
+
+// Convert transforms an OpenAPI document to Confluence storage format XHTML.
+func (c *StorageConverter) Convert(doc *domain.OpenAPIDocument, output io.Writer) error {
+	generators, err := snippetGeneratorsFor(c.snippets)
+	if err != nil {
+		return err
+	}
+
+	extensions, err := extensionFieldsFor(c.extensions)
+	if err != nil {
+		return err
+	}
+
+	samples := newSampler(doc.Components)
+	blocks := []string{}
+
+	// Title
+	blocks = append(blocks, c.heading(doc.Title, 1))
+	blocks = append(blocks, c.paragraph(fmt.Sprintf("Version: %s", doc.Version)))
+	blocks = append(blocks, c.extensionBlocks(selectExtensions(extensions, doc.Extensions))...)
+
+	if preamble := strings.TrimSpace(doc.Preamble); preamble != "" {
+		blocks = append(blocks, c.richText(preamble))
+	}
+
+	// Table of contents
+	toc := newDocumentTOC(doc, c.stable)
+	if c.appendix {
+		toc.addSchemaAppendix(doc)
+	}
+
+	toc.localize(c.locale)
+
+	if len(toc.Entries) > 0 {
+		blocks = append(blocks, c.heading(c.locale.T("Contents"), 2), c.tocList(toc.Entries))
+	}
+
+	// About
+	if about := aboutMarkdown(doc); about != "" {
+		blocks = append(blocks, c.heading(c.locale.T("About"), 2), c.richText(about))
+	}
+
+	// Description
+	if description := withExternalDocs(doc.Description, doc.ExternalDocs); description != "" {
+		blocks = append(blocks, c.heading(c.locale.T("Description"), 2), c.richText(description))
+	}
+
+	// Servers
+	if len(doc.Servers) > 0 {
+		blocks = append(blocks, c.heading(c.locale.T("Servers"), 2), c.serverList(doc.Servers))
+	}
+
+	// Authentication
+	if len(doc.SecuritySchemes) > 0 {
+		blocks = append(blocks, c.heading(c.locale.T("Authentication"), 2))
+		blocks = append(blocks, c.securitySchemeBlocks(doc)...)
+	}
+
+	// Endpoints grouped by tags
+	if len(doc.Paths) > 0 {
+		blocks = append(blocks, c.heading(c.locale.T("API Endpoints"), 2))
+
+		tagPaths := groupPathsByTag(doc)
+
+		for _, tag := range orderedTags(doc, tagPaths) {
+			blocks = append(blocks, c.anchoredHeading(tag, 3, toc.tagAnchor(tag)))
+
+			info := tagInfo(doc, tag)
+			if description := withExternalDocs(info.Description, info.ExternalDocs); description != "" {
+				blocks = append(blocks, c.richText(description))
+			}
+
+			// Add components used by this tag's endpoints
+			tagComponents := collectTagComponents(tagPaths[tag])
+			if len(tagComponents) > 0 {
+				if c.appendix {
+					blocks = append(blocks, c.heading(c.locale.T("Schemas Used"), 4), c.tocList(toc.schemaLinks(tagComponents)))
+				} else {
+					blocks = append(blocks, c.tagComponentBlocks(tagComponents, doc.Components, extensions)...)
+				}
+			}
+
+			// Add endpoints
+			for _, ep := range tagPaths[tag] {
+				snippets := requestSnippets(generators, doc, ep.path, ep.operation)
+				blocks = append(blocks, c.anchorParagraph(toc.endpointAnchor(tag, ep)))
+				blocks = append(blocks, c.operationBlock(ep.path, ep.operation, snippets, samples.operationExamples(ep.operation),
+					bodyFieldsByContentType(ep.operation, doc.Components, c.depth, c.directional),
+					responseFieldsByStatus(ep.operation, doc.Components, c.depth, c.directional),
+					selectExtensions(extensions, ep.operation.Extensions), relatedOperations(ep.operation, toc)))
+			}
+		}
+	}
+
+	// Webhooks
+	if len(doc.Webhooks) > 0 {
+		blocks = append(blocks, c.anchoredHeading(c.locale.T("Webhooks"), 2, webhooksAnchor))
+
+		for _, ep := range webhookRefs(doc) {
+			blocks = append(blocks, c.anchorParagraph(toc.endpointAnchor("", ep)))
+			blocks = append(blocks, c.operationBlock(ep.path, ep.operation, nil, samples.operationExamples(ep.operation),
+				bodyFieldsByContentType(ep.operation, doc.Components, c.depth, c.directional),
+				responseFieldsByStatus(ep.operation, doc.Components, c.depth, c.directional),
+				selectExtensions(extensions, ep.operation.Extensions), relatedOperations(ep.operation, toc)))
+		}
+	}
+
+	// Schemas appendix
+	if c.appendix && len(doc.Components) > 0 {
+		blocks = append(blocks, c.anchoredHeading(c.locale.T("Schemas"), 2, schemasAnchor))
+
+		for _, name := range sortedComponentNames(doc.Components) {
+			schema := doc.Components[name]
+			blocks = append(blocks, c.componentSchemaBlocks(name, toc.schemaAnchor(name),
+				flattenAllOf(schema, doc.Components), selectExtensions(extensions, schema.Extensions))...)
+		}
+	}
+
+	if epilogue := strings.TrimSpace(doc.Epilogue); epilogue != "" {
+		blocks = append(blocks, c.richText(epilogue))
+	}
+
+	if _, err := io.WriteString(output, strings.Join(blocks, "\n")+"\n"); err != nil {
+		return fmt.Errorf("failed to write Confluence storage format: %w", err)
+	}
+
+	return nil
+}
+
+// tagComponentBlocks renders the component schemas used in a tag.
+func (c *StorageConverter) tagComponentBlocks(componentNames []string, components map[string]domain.Schema,
+	extensions []extensionField,
+) []string {
+	blocks := []string{c.heading(c.locale.T("Schemas Used"), 4)}
+
+	for _, name := range componentNames {
+		schema, exists := components[name]
+		if !exists {
+			continue
+		}
+
+		blocks = append(blocks, c.componentSchemaBlocks(name, "", flattenAllOf(schema, components),
+			selectExtensions(extensions, schema.Extensions))...)
+	}
+
+	return blocks
+}
+
+// componentSchemaBlocks renders a single component schema, anchored when anchor is not empty.
+func (c *StorageConverter) componentSchemaBlocks(name, anchor string, schema domain.Schema, extensions []extensionValue) []string {
+	title := c.bold(name)
+	if anchor != "" {
+		title = c.anchor(anchor) + title
+	}
+
+	if schema.Deprecated {
+		title += " " + c.deprecatedStatus()
+	}
+
+	blocks := []string{"<p>" + title + "</p>"}
+
+	// Type info
+	if typeStr := formatSchemaType(schema); typeStr != "" {
+		blocks = append(blocks, c.paragraph(fmt.Sprintf("Type: %s", typeStr)))
+	}
+
+	// Description
+	if description := withExternalDocs(schema.Description, schema.ExternalDocs); description != "" {
+		blocks = append(blocks, c.richText(description))
+	}
+
+	blocks = append(blocks, c.extensionBlocks(extensions)...)
+
+	// Properties as bullet list, with those of inline objects nested below them
+	if properties := nestedProperties(schema, c.depth); len(properties) > 0 {
+		blocks = append(blocks, c.propertyList(properties))
+	}
+
+	// Alternatives of oneOf/anyOf schemas
+	if label, variants := schemaVariants(schema); len(variants) > 0 {
+		blocks = append(blocks, c.paragraph(label), c.variantList(variants))
+	}
+
+	if schema.Discriminator != nil {
+		blocks = append(blocks, c.discriminatorBlocks(schema.Discriminator)...)
+	}
+
+	return blocks
+}
+
+func (c *StorageConverter) propertyList(properties []schemaProperty) string {
+	items := make([]string, 0, len(properties))
+
+	for _, property := range properties {
+		item := c.code(property.Name) + c.text(fmt.Sprintf(" (%s)", formatPropertyDetails(property.Schema, property.Required, c.locale)))
+		if len(property.Children) > 0 {
+			item += c.propertyList(property.Children)
+		}
+
+		items = append(items, item)
+	}
+
+	return c.list(items)
+}
+
+func (c *StorageConverter) variantList(variants []domain.Schema) string {
+	items := make([]string, 0, len(variants))
+
+	for _, variant := range variants {
+		item := c.code(formatSchemaType(variant))
+		if variant.Ref == "" && variant.Description != "" {
+			item += c.text(": ") + inlineMarkdownHTML(variant.Description)
+		}
+
+		items = append(items, item)
+	}
+
+	return c.list(items)
+}
+
+// discriminatorBlocks describes the discriminator property and its value to schema mapping.
+func (c *StorageConverter) discriminatorBlocks(discriminator *domain.Discriminator) []string {
+	blocks := []string{"<p>" + c.text("Discriminator: ") + c.code(discriminator.PropertyName) + "</p>"}
+
+	if len(discriminator.Mapping) == 0 {
+		return blocks
+	}
+
+	rows := []string{c.tableRow("th", c.text("Value"), c.text("Schema"))}
+
+	for _, value := range sortedDiscriminatorValues(discriminator) {
+		rows = append(rows, c.tableRow("td", c.code(value), c.text(extractRefName(discriminator.Mapping[value]))))
+	}
+
+	return append(blocks, c.table(rows))
+}
+
+func (c *StorageConverter) serverList(servers []domain.Server) string {
+	items := make([]string, 0, len(servers))
+
+	for _, server := range servers {
+		text := server.URL
+		if server.Description != "" {
+			text = fmt.Sprintf("%s - %s", server.URL, server.Description)
+		}
+
+		items = append(items, c.text(text))
+	}
+
+	return c.list(items)
+}
+
+// extensionBlocks renders vendor extensions as "Label: value" paragraphs with a bold label.
+func (c *StorageConverter) extensionBlocks(extensions []extensionValue) []string {
+	blocks := make([]string, 0, len(extensions))
+
+	for _, extension := range extensions {
+		blocks = append(blocks, "<p>"+c.bold(extension.Label+":")+c.text(" "+extension.Value)+"</p>")
+	}
+
+	return blocks
+}
+
+// operationBlock renders an endpoint as an expand macro titled "METHOD /path — summary",
+// so that large APIs stay readable on Confluence. Snippets are shown one after the other as example requests.
+func (c *StorageConverter) operationBlock(pathStr string, operation domain.Operation, snippets []codeSnippet,
+	examples []operationExample, fields map[string][]bodyField, responses []responseFields, extensions []extensionValue,
+	related []relatedOperation,
+) string {
+	details := []string{}
+
+	// Deprecation status
+	if operation.Deprecated {
+		details = append(details, "<p>"+c.deprecatedStatus()+"</p>")
+	}
+
+	// Description
+	if description := withExternalDocs(operation.Description, operation.ExternalDocs); description != "" {
+		details = append(details, c.richText(description))
+	}
+
+	// Required authentication as status macros
+	if len(operation.Security) > 0 {
+		details = append(details, c.securityStatuses(operation))
+	}
+
+	details = append(details, c.extensionBlocks(extensions)...)
+
+	// Parameters
+	if len(operation.Parameters) > 0 {
+		details = append(details, c.heading(c.locale.T("Parameters"), 6))
+
+		if c.tables {
+			details = append(details, c.parameterTable(operation.Parameters))
+		} else {
+			details = append(details, c.parameterList(operation.Parameters))
+		}
+	}
+
+	// Request body
+	if operation.RequestBody != nil {
+		details = append(details, c.heading(c.locale.T("Request Body"), 6))
+		details = append(details, c.requestBodyBlocks(*operation.RequestBody, fields)...)
+	}
+
+	// Responses
+	if len(operation.Responses) > 0 {
+		details = append(details, c.heading(c.locale.T("Responses"), 6))
+
+		if c.tables {
+			details = append(details, c.responseTable(operation.Responses))
+		} else {
+			details = append(details, c.responseList(operation.Responses))
+		}
+	}
+
+	// Flattened response fields, by status code and content type
+	if len(responses) > 0 {
+		details = append(details, c.heading(c.locale.T("Response Fields"), 6))
+		details = append(details, c.responseFieldBlocks(responses)...)
+	}
+
+	// Response headers
+	if headers := responseHeaders(operation.Responses); len(headers) > 0 {
+		details = append(details, c.heading(c.locale.T("Response Headers"), 6))
+
+		if c.tables {
+			details = append(details, c.responseHeaderTable(headers))
+		} else {
+			details = append(details, c.responseHeaderList(headers))
+		}
+	}
+
+	// Limits stamped by the gateway
+	if limits := operationLimits(operation); len(limits) > 0 {
+		details = append(details, c.heading(c.locale.T("Limits"), 6), c.limitTable(limits))
+	}
+
+	// Links to the operations that can follow
+	if len(related) > 0 {
+		details = append(details, c.heading(c.locale.T("Related Operations"), 6), c.relatedOperationList(related))
+	}
+
+	// Callbacks
+	if callbacks := callbackRequests(operation); len(callbacks) > 0 {
+		details = append(details, c.heading(c.locale.T("Callbacks"), 6))
+		details = append(details, c.callbackBlocks(callbacks)...)
+	}
+
+	// Sample requests
+	if len(snippets) > 0 {
+		details = append(details, c.heading(c.locale.T("Example Request"), 6))
+
+		for _, snippet := range snippets {
+			details = append(details, "<p>"+c.bold(snippet.Label)+"</p>", c.codeBlock(snippet.Code, snippet.Language))
+		}
+	}
+
+	// Request and response examples, in an expand of their own since they can be long
+	if examples := c.exampleBlocks(examples); len(examples) > 0 {
+		details = append(details, c.expand("Examples", examples))
+	}
+
+	if len(details) == 0 {
+		details = append(details, c.paragraph("No further details."))
+	}
+
+	return c.expand(endpointTitle(pathStr, operation), details)
+}
+
+// securitySchemeBlocks describes every security scheme of the document.
+func (c *StorageConverter) securitySchemeBlocks(doc *domain.OpenAPIDocument) []string {
+	blocks := []string{}
+
+	for _, name := range sortedSecuritySchemes(doc) {
+		scheme := doc.SecuritySchemes[name]
+
+		blocks = append(blocks, "<p>"+c.bold(name)+"</p>")
+		blocks = append(blocks, c.paragraph(fmt.Sprintf("Type: %s", formatSecurityScheme(scheme))))
+
+		if scheme.Description != "" {
+			blocks = append(blocks, c.richText(scheme.Description))
+		}
+
+		for _, flow := range scheme.Flows {
+			blocks = append(blocks, c.paragraph(fmt.Sprintf("%s flow", formatOAuthFlow(flow.Type))), c.oauthFlowList(flow))
+		}
+	}
+
+	return blocks
+}
+
+// oauthFlowList lists the endpoints and scopes of an OAuth 2.0 flow.
+func (c *StorageConverter) oauthFlowList(flow domain.OAuthFlow) string {
+	items := []string{}
+
+	urls := []struct{ label, url string }{
+		{"Authorization URL", flow.AuthorizationURL},
+		{"Token URL", flow.TokenURL},
+		{"Refresh URL", flow.RefreshURL},
+	}
+
+	for _, u := range urls {
+		if u.url != "" {
+			items = append(items, c.text(fmt.Sprintf("%s: %s", u.label, u.url)))
+		}
+	}
+
+	for _, scope := range sortedScopes(flow) {
+		items = append(items, c.code(scope)+c.text(": "+flow.Scopes[scope]))
+	}
+
+	return c.list(items)
+}
+
+// securityStatuses renders one status macro per alternative security requirement of an operation.
+func (c *StorageConverter) securityStatuses(operation domain.Operation) string {
+	content := c.bold("Auth: ")
+
+	for i, requirement := range operation.Security {
+		if i > 0 {
+			content += c.text(" or ")
+		}
+
+		colour := "Purple"
+		if len(requirement) == 0 {
+			colour = "Grey"
+		}
+
+		content += c.status(formatSecurityRequirement(requirement), colour)
+	}
+
+	return "<p>" + content + "</p>"
+}
+
+// exampleBlocks renders the request and response examples of an operation as labelled code blocks.
+func (c *StorageConverter) exampleBlocks(examples []operationExample) []string {
+	blocks := []string{}
+
+	for _, example := range examples {
+		blocks = append(blocks, "<p>"+c.bold(example.Title)+"</p>", c.codeBlock(formatExampleValue(example.Value), "json"))
+	}
+
+	return blocks
+}
+
+func (c *StorageConverter) parameterList(params []domain.Parameter) string {
+	items := make([]string, 0, len(params))
+
+	for _, param := range params {
+		required := ""
+		if param.Required {
+			required = " (" + c.locale.T("required") + ")"
+		}
+
+		location := param.In
+		if details := formatSchemaDetails(param.Schema); details != "" {
+			location += ", " + details
+		}
+
+		item := c.code(param.Name) + c.text(fmt.Sprintf(" (%s): ", location)) + inlineMarkdownHTML(param.Description) + c.text(required)
+		if param.Deprecated {
+			item += " " + c.deprecatedStatus()
+		}
+
+		items = append(items, item)
+	}
+
+	return c.list(items)
+}
+
+func (c *StorageConverter) parameterTable(params []domain.Parameter) string {
+	rows := []string{
+		c.tableRow("th", c.text("Name"), c.text("In"), c.text("Type"), c.text(c.locale.T("Required")), c.text("Description")),
+	}
+
+	for _, param := range params {
+		required := "No"
+		if param.Required {
+			required = "Yes"
+		}
+
+		name := c.code(param.Name)
+		if param.Deprecated {
+			name += " " + c.deprecatedStatus()
+		}
+
+		rows = append(rows, c.tableRow("td",
+			name,
+			c.text(param.In),
+			c.text(formatSchemaDetails(param.Schema)),
+			c.text(required),
+			inlineMarkdownHTML(param.Description),
+		))
+	}
+
+	return c.table(rows)
+}
+
+func (c *StorageConverter) responseList(responses []domain.Response) string {
+	items := make([]string, 0, len(responses))
+
+	for _, resp := range responses {
+		item := c.code(resp.StatusCode) + c.text(": ") + inlineMarkdownHTML(resp.Description)
+		if summaries := contentSummaries(resp.Content); len(summaries) > 0 {
+			item += c.text(" \u2014 ") + c.mediaText(summaries)
+		}
+
+		items = append(items, item)
+	}
+
+	return c.list(items)
+}
+
+func (c *StorageConverter) responseTable(responses []domain.Response) string {
+	rows := []string{c.tableRow("th", c.text("Status"), c.text("Description"), c.text("Content"))}
+
+	for _, resp := range sortedResponses(responses) {
+		rows = append(rows, c.tableRow("td",
+			c.code(resp.StatusCode),
+			inlineMarkdownHTML(resp.Description),
+			c.mediaText(contentSummaries(resp.Content)),
+		))
+	}
+
+	return c.table(rows)
+}
+
+func (c *StorageConverter) responseHeaderList(headers []responseHeader) string {
+	items := make([]string, 0, len(headers))
+
+	for _, header := range headers {
+		text := fmt.Sprintf(" (%s", header.StatusCode)
+		if details := formatSchemaDetails(header.Schema); details != "" {
+			text += ", " + details
+		}
+
+		item := c.code(header.Name) + c.text(text+"): ") + inlineMarkdownHTML(header.Description)
+		if header.Deprecated {
+			item += " " + c.deprecatedStatus()
+		}
+
+		items = append(items, item)
+	}
+
+	return c.list(items)
+}
+
+func (c *StorageConverter) responseHeaderTable(headers []responseHeader) string {
+	rows := []string{c.tableRow("th", c.text("Status"), c.text("Header"), c.text("Type"), c.text("Description"))}
+
+	for _, header := range headers {
+		name := c.code(header.Name)
+		if header.Deprecated {
+			name += " " + c.deprecatedStatus()
+		}
+
+		rows = append(rows, c.tableRow("td",
+			c.code(header.StatusCode),
+			name,
+			c.text(formatSchemaDetails(header.Schema)),
+			inlineMarkdownHTML(header.Description),
+		))
+	}
+
+	return c.table(rows)
+}
+
+func (c *StorageConverter) limitTable(limits []operationLimit) string {
+	rows := []string{c.tableRow("th", c.text("Limit"), c.text("Value"))}
+
+	for _, limit := range limits {
+		rows = append(rows, c.tableRow("td", c.text(c.locale.T(limit.Label)), c.text(limit.Value)))
+	}
+
+	return c.table(rows)
+}
+
+// requestBodyBlocks describes a request body: whether it is required, then each content type with its schema,
+// followed by a table of the flattened fields of each content type that has any.
+func (c *StorageConverter) requestBodyBlocks(body domain.RequestBody, fields map[string][]bodyField) []string {
+	blocks := []string{"<p>" + c.bold(c.locale.T(requiredLabel(body.Required))) + "</p>"}
+
+	if body.Description != "" {
+		blocks = append(blocks, c.richText(body.Description))
+	}
+
+	summaries := contentSummaries(body.Content)
+	if len(summaries) == 0 {
+		return blocks
+	}
+
+	if c.tables {
+		rows := []string{c.tableRow("th", c.text("Content Type"), c.text("Schema"))}
+		for _, summary := range summaries {
+			schema := ""
+			if summary.Schema != "" {
+				schema = c.code(summary.Schema)
+			}
+
+			rows = append(rows, c.tableRow("td", c.code(summary.ContentType), schema))
+		}
+
+		blocks = append(blocks, c.table(rows))
+	} else {
+		items := make([]string, 0, len(summaries))
+		for _, summary := range summaries {
+			items = append(items, c.mediaText([]mediaSummary{summary}))
+		}
+
+		blocks = append(blocks, c.list(items))
+	}
+
+	for _, summary := range summaries {
+		if flat, ok := fields[summary.ContentType]; ok {
+			blocks = append(blocks, "<p>"+c.bold("Fields: ")+c.code(summary.ContentType)+"</p>", c.bodyFieldTable(flat))
+		}
+	}
+
+	return blocks
+}
+
+// bodyFieldTable lists request body fields with their type, whether they are required, description and constraints.
+func (c *StorageConverter) bodyFieldTable(fields []bodyField) string {
+	rows := []string{
+		c.tableRow("th", c.text("Field"), c.text("Type"), c.text(c.locale.T("Required")), c.text("Description"), c.text("Constraints")),
+	}
+
+	for _, field := range fields {
+		required := "No"
+		if field.Required {
+			required = "Yes"
+		}
+
+		rows = append(rows, c.tableRow("td",
+			c.code(field.Path),
+			c.text(formatSchemaType(field.Schema)),
+			c.text(required),
+			inlineMarkdownHTML(field.Schema.Description),
+			c.text(joinConstraints("", schemaConstraints(field.Schema))),
+		))
+	}
+
+	return c.table(rows)
+}
+
+// responseFieldBlocks labels the flattened fields of each response with its status code, content type and schema,
+// each followed by a table of the fields with their type, format, nullability, whether they are required and description.
+func (c *StorageConverter) responseFieldBlocks(responses []responseFields) []string {
+	blocks := make([]string, 0, 2*len(responses))
+
+	for _, resp := range responses {
+		label := c.bold(resp.StatusCode+" ") + c.code(resp.ContentType)
+		if resp.Schema != "" {
+			label += c.text(": ") + c.code(resp.Schema)
+		}
+
+		rows := []string{
+			c.tableRow("th", c.text("Field"), c.text("Type"), c.text("Format"), c.text("Nullable"), c.text(c.locale.T("Required")),
+				c.text("Description")),
+		}
+
+		for _, field := range resp.Fields {
+			format := ""
+			if f := fieldFormat(field.Schema); f != "" {
+				format = c.code(f)
+			}
+
+			rows = append(rows, c.tableRow("td",
+				c.code(field.Path),
+				c.text(fieldType(field.Schema)),
+				format,
+				c.text(yesNo(field.Schema.Nullable)),
+				c.text(yesNo(field.Required)),
+				inlineMarkdownHTML(field.Schema.Description),
+			))
+		}
+
+		blocks = append(blocks, "<p>"+label+"</p>", c.table(rows))
+	}
+
+	return blocks
+}
+
+// relatedOperationList lists the operations following the responses, linked to their endpoint when it has an anchor.
+func (c *StorageConverter) relatedOperationList(related []relatedOperation) string {
+	items := make([]string, 0, len(related))
+
+	for _, operation := range related {
+		target := c.code(operation.Target)
+		if operation.Anchor != "" {
+			target = c.link(operation.Anchor, target)
+		}
+
+		item := c.text(operation.StatusCode+" \u2192 ") + target
+
+		if values := formatLinkValues(operation); values != "" {
+			item += c.text(" (" + values + ")")
+		}
+
+		if operation.Description != "" {
+			item += c.text(" - ") + inlineMarkdownHTML(operation.Description)
+		}
+
+		items = append(items, item)
+	}
+
+	return c.list(items)
+}
+
+// callbackBlocks describes each callback request by its URL expression, payload and expected responses.
+func (c *StorageConverter) callbackBlocks(callbacks []callbackRequest) []string {
+	blocks := []string{}
+
+	for _, callback := range callbacks {
+		op := callback.Operation
+
+		blocks = append(blocks, "<p>"+c.bold(callback.Name)+c.text(": ")+c.code(formatMethod(op.Method)+" "+callback.Expression)+"</p>")
+
+		if op.Summary != "" {
+			blocks = append(blocks, c.paragraph(op.Summary))
+		}
+
+		if op.Description != "" {
+			blocks = append(blocks, c.richText(op.Description))
+		}
+
+		if op.RequestBody != nil {
+			if summaries := contentSummaries(op.RequestBody.Content); len(summaries) > 0 {
+				blocks = append(blocks, "<p>"+c.text("Payload: ")+c.mediaText(summaries)+"</p>")
+			}
+		}
+
+		if len(op.Responses) > 0 {
+			blocks = append(blocks, c.paragraph("Expected responses: "+callbackResponses(op)))
+		}
+	}
+
+	return blocks
+}
+
+// mediaText renders media types as inline code, each followed by its schema: "application/json: Pet".
+func (c *StorageConverter) mediaText(summaries []mediaSummary) string {
+	var b strings.Builder
+
+	for i, summary := range summaries {
+		if i > 0 {
+			b.WriteString(c.text(", "))
+		}
+
+		b.WriteString(c.code(summary.ContentType))
+
+		if summary.Schema != "" {
+			b.WriteString(c.text(": ") + c.code(summary.Schema))
+		}
+	}
+
+	return b.String()
+}
+
+// tocList renders table of contents entries as nested bullet lists of links to their anchors.
+func (c *StorageConverter) tocList(entries []tocEntry) string {
+	items := make([]string, 0, len(entries))
+
+	for _, entry := range entries {
+		item := c.link(entry.Anchor, c.text(entry.Title))
+		if len(entry.Children) > 0 {
+			item += c.tocList(entry.Children)
+		}
+
+		items = append(items, item)
+	}
+
+	return c.list(items)
+}
+
+// XHTML elements and Confluence macros. Inline helpers return markup, escaping the text they are given.
+
+func (c *StorageConverter) heading(text string, level int) string {
+	level = c.headings.level(level)
+
+	return fmt.Sprintf("<h%d>%s</h%d>", level, c.text(text), level)
+}
+
+// anchoredHeading is a heading the table of contents links to, through an anchor macro before its text.
+func (c *StorageConverter) anchoredHeading(text string, level int, anchor string) string {
+	level = c.headings.level(level)
+
+	return fmt.Sprintf("<h%d>%s%s</h%d>", level, c.anchor(anchor), c.text(text), level)
+}
+
+// anchorParagraph holds the anchor of an endpoint, placed before its expand so that links land on its title.
+func (c *StorageConverter) anchorParagraph(anchor string) string {
+	return "<p>" + c.anchor(anchor) + "</p>"
+}
+
+// richText renders a CommonMark description as XHTML blocks.
+func (c *StorageConverter) richText(text string) string {
+	return strings.TrimSuffix(markdownHTML(text), "\n")
+}
+
+func (c *StorageConverter) paragraph(text string) string {
+	return "<p>" + c.text(text) + "</p>"
+}
+
+func (c *StorageConverter) text(text string) string {
+	return html.EscapeString(text)
+}
+
+func (c *StorageConverter) bold(text string) string {
+	return "<strong>" + c.text(text) + "</strong>"
+}
+
+func (c *StorageConverter) code(text string) string {
+	return "<code>" + c.text(text) + "</code>"
+}
+
+// list renders items, given as markup, as a bullet list.
+func (c *StorageConverter) list(items []string) string {
+	var b strings.Builder
+
+	b.WriteString("<ul>")
+
+	for _, item := range items {
+		b.WriteString("<li>" + item + "</li>")
+	}
+
+	b.WriteString("</ul>")
+
+	return b.String()
+}
+
+func (c *StorageConverter) table(rows []string) string {
+	return "<table><tbody>" + strings.Join(rows, "") + "</tbody></table>"
+}
+
+// tableRow builds a row of cells, given as markup, of the given element ("th" or "td").
+func (c *StorageConverter) tableRow(cell string, cells ...string) string {
+	var b strings.Builder
+
+	b.WriteString("<tr>")
+
+	for _, content := range cells {
+		fmt.Fprintf(&b, "<%s>%s</%s>", cell, content, cell)
+	}
+
+	b.WriteString("</tr>")
+
+	return b.String()
+}
+
+// link links content, given as markup, to an anchor of the page.
+func (c *StorageConverter) link(anchor, content string) string {
+	return fmt.Sprintf(`<ac:link ac:anchor="%s"><ac:link-body>%s</ac:link-body></ac:link>`, c.text(anchor), content)
+}
+
+// macro renders a Confluence structured macro with its parameters, in order, followed by its body.
+func (c *StorageConverter) macro(name string, params [][2]string, body string) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, `<ac:structured-macro ac:name="%s">`, name)
+
+	for _, param := range params {
+		fmt.Fprintf(&b, `<ac:parameter ac:name="%s">%s</ac:parameter>`, param[0], c.text(param[1]))
+	}
+
+	b.WriteString(body + "</ac:structured-macro>")
+
+	return b.String()
+}
+
+// anchor is the anchor macro, which links to the anchor name jump to.
+func (c *StorageConverter) anchor(name string) string {
+	return c.macro("anchor", [][2]string{{"", name}}, "")
+}
+
+// status is the status macro, a coloured lozenge: Grey, Red, Yellow, Green, Blue or Purple.
+func (c *StorageConverter) status(title, colour string) string {
+	return c.macro("status", [][2]string{{"colour", colour}, {"title", title}}, "")
+}
+
+func (c *StorageConverter) deprecatedStatus() string {
+	return c.status(deprecatedLabel, "Red")
+}
+
+// expand is the expand macro, a collapsible section holding blocks, which may hold expands in turn.
+func (c *StorageConverter) expand(title string, blocks []string) string {
+	return c.macro("expand", [][2]string{{"title", title}},
+		"<ac:rich-text-body>"+strings.Join(blocks, "")+"</ac:rich-text-body>")
+}
+
+// codeBlock is the code macro. Its body is kept as character data, split wherever it holds "]]>".
+func (c *StorageConverter) codeBlock(code, language string) string {
+	if name, ok := storageCodeLanguages[language]; ok {
+		language = name
+	}
+
+	body := "<![CDATA[" + strings.ReplaceAll(code, "]]>", "]]]]><![CDATA[>") + "]]>"
+
+	return c.macro("code", [][2]string{{"language", language}}, "<ac:plain-text-body>"+body+"</ac:plain-text-body>")
+}
+
+// ConvertChangelog transforms a changelog between two specifications to Confluence storage format XHTML.
+func (c *StorageConverter) ConvertChangelog(changelog *domain.Changelog, output io.Writer) error {
+	blocks := []string{c.heading(ChangelogTitle(changelog), 1), c.paragraph(ChangelogSummary(changelog))}
+
+	for _, section := range changelogSections(changelog) {
+		blocks = append(blocks, c.heading(section.title, 2))
+
+		for _, group := range section.groups {
+			if group.title != "" {
+				blocks = append(blocks, c.heading(group.title, 3))
+			}
+
+			items := make([]string, 0, len(group.items))
+			for _, item := range group.items {
+				content := c.text(item.text)
+				if item.breaking && section.title != breakingSectionTitle {
+					content += " " + c.status("breaking", "Red")
+				}
+
+				items = append(items, content)
+			}
+
+			blocks = append(blocks, c.list(items))
+		}
+	}
+
+	if _, err := io.WriteString(output, strings.Join(blocks, "\n")+"\n"); err != nil {
+		return fmt.Errorf("failed to write Confluence storage format: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,261 @@
+package converters
+
+import (
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	"io"
+	"path/filepath"
+	"strings"
+	texttemplate "text/template"
+
+	"github.com/GabrielNunesIT/openapi-converter/internal/domain"
+)
+
+// defaultTemplates holds the built-in layout of the template-driven converters, one directory per format.
+// Each format defines the "heading", "tag", "operation" and "schema" templates.
+//
+//go:embed templates
+var defaultTemplates embed.FS
+
+// headingData is passed to the "heading" template.
+type headingData struct {
+	Level  int
+	Text   string
+	Anchor string // ID the table of contents links to, empty for headings it does not list
+}
+
+// tagData is passed to the "tag" template, which renders the heading of a tag section followed by its description.
+type tagData struct {
+	Heading headingData
+	Tag     domain.Tag
+}
+
+// operationData is passed to the "operation" template.
+type operationData struct {
+	Path       string
+	Operation  domain.Operation
+	Anchor     string                 // ID the table of contents links to
+	Links      []relatedOperation     // Operations that can follow its responses
+	Snippets   []codeSnippet          // Sample requests, empty for webhooks and formats without request examples
+	Extensions []extensionValue       // Vendor extensions chosen for rendering
+	BodyFields map[string][]bodyField // Flattened request body fields by content type, without those lacking fields
+	// Flattened response fields by status code and content type, without those lacking fields
+	ResponseFields []responseFields
+	// Component schema name to the anchor of its appendix entry, for linking the schemas of the bodies; nil for none
+	SchemaAnchors map[string]string
+	// Mermaid sequence diagram of the links and callbacks of the operation, empty for none
+	Sequence string
+}
+
+// Renderings of the component schemas that request and response bodies refer to.
+const (
+	SchemaRefsInline    = "inline"    // The fields of request bodies and responses are listed where they are used
+	SchemaRefsReference = "reference" // Schema names link to their entry in the schemas appendix
+	SchemaRefsBoth      = "both"      // The fields of bodies are listed and schema names link to the appendix
+)
+
+// SchemaRefModes returns the supported renderings of the schemas that bodies refer to.
+func SchemaRefModes() []string {
+	return []string{SchemaRefsInline, SchemaRefsReference, SchemaRefsBoth}
+}
+
+// schemaRefRendering is a rendering of the schemas that bodies refer to, among SchemaRefModes.
+type schemaRefRendering struct {
+	fields bool // List the fields of request bodies and responses
+	links  bool // Link schema names to the schemas appendix, which the document must then hold
+}
+
+func newSchemaRefRendering(mode string) (schemaRefRendering, error) {
+	switch mode {
+	case SchemaRefsInline, "":
+		return schemaRefRendering{fields: true}, nil
+	case SchemaRefsReference:
+		return schemaRefRendering{links: true}, nil
+	case SchemaRefsBoth:
+		return schemaRefRendering{fields: true, links: true}, nil
+	default:
+		return schemaRefRendering{}, fmt.Errorf("unsupported schema reference rendering: %s (supported: %s)",
+			mode, strings.Join(SchemaRefModes(), ", "))
+	}
+}
+
+// bodyFields returns the flattened request body fields of an operation, nil when they are not listed.
+func (r schemaRefRendering) bodyFields(op domain.Operation, components map[string]domain.Schema,
+	directional bool,
+) map[string][]bodyField {
+	if !r.fields {
+		return nil
+	}
+
+	return bodyFieldsByContentType(op, components, DefaultSchemaDepth, directional)
+}
+
+// responseFields returns the flattened response fields of an operation, nil when they are not listed.
+func (r schemaRefRendering) responseFields(op domain.Operation, components map[string]domain.Schema,
+	directional bool,
+) []responseFields {
+	if !r.fields {
+		return nil
+	}
+
+	return responseFieldsByStatus(op, components, DefaultSchemaDepth, directional)
+}
+
+// anchors returns the anchors of the appendix entries that schema names link to, nil when they are not linked.
+func (r schemaRefRendering) anchors(toc *documentTOC) map[string]string {
+	if !r.links {
+		return nil
+	}
+
+	return toc.schemas
+}
+
+// schemaData is passed to the "schema" template with the allOf members already merged.
+type schemaData struct {
+	Name       string
+	Schema     domain.Schema
+	Anchor     string           // ID of the schema heading in the schemas appendix, empty elsewhere
+	Extensions []extensionValue // Vendor extensions chosen for rendering
+}
+
+// templateExecutor is satisfied by both text/template and html/template templates.
+type templateExecutor interface {
+	ExecuteTemplate(wr io.Writer, name string, data any) error
+}
+
+// templateWriter renders templates into a document, keeping the first error so that
+// rendering code does not need to check every call.
+type templateWriter struct {
+	out    *strings.Builder
+	tmpl   templateExecutor
+	locale Locale // Translations of the headings the writer adds itself
+	err    error
+}
+
+func (w *templateWriter) execute(name string, data any) {
+	if w.err != nil {
+		return
+	}
+
+	if err := w.tmpl.ExecuteTemplate(w.out, name, data); err != nil {
+		w.err = fmt.Errorf("failed to render %s template: %w", name, err)
+	}
+}
+
+func (w *templateWriter) heading(level int, text string) {
+	w.execute("heading", headingData{Level: level, Text: text})
+}
+
+func (w *templateWriter) anchoredHeading(level int, text, anchor string) {
+	w.execute("heading", headingData{Level: level, Text: text, Anchor: anchor})
+}
+
+func (w *templateWriter) tag(level int, tag domain.Tag, anchor string) {
+	w.execute("tag", tagData{Heading: headingData{Level: level, Text: tag.Name, Anchor: anchor}, Tag: tag})
+}
+
+// schemaAppendix renders every component schema once under an anchored "Schemas" heading.
+func (w *templateWriter) schemaAppendix(doc *domain.OpenAPIDocument, toc *documentTOC, extensions []extensionField) {
+	if len(doc.Components) == 0 {
+		return
+	}
+
+	w.anchoredHeading(2, w.locale.T("Schemas"), schemasAnchor)
+
+	for _, name := range sortedComponentNames(doc.Components) {
+		schema := doc.Components[name]
+
+		w.execute("schema", schemaData{
+			Name:       name,
+			Schema:     flattenAllOf(schema, doc.Components),
+			Anchor:     toc.schemaAnchor(name),
+			Extensions: selectExtensions(extensions, schema.Extensions),
+		})
+	}
+}
+
+// templateFuncs returns the helpers available to every template, t translating fixed strings with locale.
+func templateFuncs(locale Locale) map[string]any {
+	return map[string]any{
+		"heading": func(level int, text string) headingData {
+			return headingData{Level: level, Text: text}
+		},
+		"t":              locale.T,
+		"method":         formatMethod,
+		"lower":          strings.ToLower,
+		"trim":           strings.TrimSpace,
+		"join":           strings.Join,
+		"repeat":         strings.Repeat,
+		"schemaType":     formatSchemaType,
+		"fieldType":      fieldType,
+		"fieldFormat":    fieldFormat,
+		"schemaDetails":  formatSchemaDetails,
+		"securityLabels": securityLabels,
+		"callbacks":      callbackRequests,
+		"linkValues":     linkValues,
+		"limits":         operationLimits,
+		"responses":      sortedResponses,
+		"headers":        responseHeaders,
+		"content":        contentSummaries,
+		"refName":        extractRefName,
+		"constraints": func(schema domain.Schema) string {
+			return joinConstraints("", schemaConstraints(schema))
+		},
+		"propertyDetails": func(schema domain.Schema, required bool) string {
+			return formatPropertyDetails(schema, required, locale)
+		},
+		"outline": func(schema domain.Schema) string {
+			return schemaOutline(schema, 0)
+		},
+		"variantLabel": func(schema domain.Schema) string {
+			label, _ := schemaVariants(schema)
+
+			return label
+		},
+		"variants": func(schema domain.Schema) []domain.Schema {
+			_, variants := schemaVariants(schema)
+
+			return variants
+		},
+	}
+}
+
+// loadTextTemplates parses the embedded templates of a format, then the *.tmpl files of dir, if any,
+// so that templates defined there replace the defaults of the same name.
+func loadTextTemplates(format, dir string, funcs texttemplate.FuncMap) (*texttemplate.Template, error) {
+	tmpl, err := texttemplate.New(format).Funcs(funcs).ParseFS(defaultTemplates, "templates/"+format+"/*.tmpl")
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse default %s templates: %w", format, err)
+	}
+
+	if dir == "" {
+		return tmpl, nil
+	}
+
+	tmpl, err = tmpl.ParseGlob(filepath.Join(dir, "*.tmpl"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse templates from %s: %w", dir, err)
+	}
+
+	return tmpl, nil
+}
+
+// loadHTMLTemplates is the html/template counterpart of loadTextTemplates, escaping every value for HTML output.
+func loadHTMLTemplates(format, dir string, funcs htmltemplate.FuncMap) (*htmltemplate.Template, error) {
+	tmpl, err := htmltemplate.New(format).Funcs(funcs).ParseFS(defaultTemplates, "templates/"+format+"/*.tmpl")
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse default %s templates: %w", format, err)
+	}
+
+	if dir == "" {
+		return tmpl, nil
+	}
+
+	tmpl, err = tmpl.ParseGlob(filepath.Join(dir, "*.tmpl"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse templates from %s: %w", dir, err)
+	}
+
+	return tmpl, nil
+}
@@ -0,0 +1,257 @@
+package converters
+
+import (
+	"strings"
+
+	"github.com/GabrielNunesIT/openapi-converter/internal/domain"
+)
+
+const (
+	// webhooksAnchor is the anchor of the webhooks section.
+	webhooksAnchor = "webhooks"
+
+	// schemasAnchor is the anchor of the schemas appendix.
+	schemasAnchor = "schemas"
+
+	// schemaGraphAnchor is the anchor of the schema graph section.
+	schemaGraphAnchor = "schema-graph"
+)
+
+// tocEntry is a heading listed in the table of contents, with the headings nested below it.
+type tocEntry struct {
+	Title    string
+	Anchor   string
+	Children []tocEntry
+}
+
+// documentTOC lists the tag and endpoint headings of a document in the order they are rendered, each with an anchor ID.
+// Anchors are derived from names rather than positions so that links stay valid as the API grows: "tag-pets" for
+// a tag, "op-listpets" for an endpoint, or "op-get-pets-petid" for one without operationId. An endpoint listed
+// under several tags gets a numeric suffix after its first occurrence.
+//
+// With stable anchors, the first occurrence of an operation with an operationId is anchored at "op-" followed by
+// the operationId as is, e.g. "op-listPets". Those anchors are reserved before any other is assigned, so that they
+// depend on nothing but the operationId and can be linked to from outside the document.
+type documentTOC struct {
+	Entries    []tocEntry
+	tags       map[string]string   // Tag name to anchor
+	endpoints  map[string]string   // endpointKey to anchor
+	operations map[string]string   // operationId and "METHOD /path" to the anchor of their first occurrence
+	schemas    map[string]string   // Component schema name to the anchor of its appendix entry
+	stable     map[string]string   // operationId to its reserved anchor, with stable anchors
+	used       map[string]struct{} // Anchors already assigned
+}
+
+// newDocumentTOC assigns the anchors of the tags and endpoints of a document, then of its webhooks.
+// With stable, operations with an operationId get their reserved anchor first.
+func newDocumentTOC(doc *domain.OpenAPIDocument, stable bool) *documentTOC {
+	toc := &documentTOC{
+		tags:       make(map[string]string),
+		endpoints:  make(map[string]string),
+		operations: make(map[string]string),
+		schemas:    make(map[string]string),
+		stable:     make(map[string]string),
+		used:       map[string]struct{}{webhooksAnchor: {}, schemasAnchor: {}, schemaGraphAnchor: {}},
+	}
+
+	tagPaths := groupPathsByTag(doc)
+
+	if stable {
+		toc.reserveStableAnchors(doc, tagPaths)
+	}
+	for _, tag := range orderedTags(doc, tagPaths) {
+		entry := tocEntry{Title: tag, Anchor: uniqueSlug("tag-"+tag, toc.used)}
+		toc.tags[tag] = entry.Anchor
+
+		for _, ep := range tagPaths[tag] {
+			entry.Children = append(entry.Children, toc.addEndpoint(tag, ep))
+		}
+
+		toc.Entries = append(toc.Entries, entry)
+	}
+
+	if webhooks := webhookRefs(doc); len(webhooks) > 0 {
+		entry := tocEntry{Title: "Webhooks", Anchor: webhooksAnchor}
+
+		for _, ep := range webhooks {
+			entry.Children = append(entry.Children, toc.addEndpoint("", ep))
+		}
+
+		toc.Entries = append(toc.Entries, entry)
+	}
+
+	return toc
+}
+
+func (t *documentTOC) addEndpoint(tag string, ep endpointRef) tocEntry {
+	name := ep.operation.OperationID
+	if name == "" {
+		name = ep.method + " " + ep.path
+	}
+
+	entry := tocEntry{Title: endpointTitle(ep.path, ep.operation)}
+
+	// The reserved anchor goes to the first occurrence only, the operations map then holding it
+	if anchor, ok := t.stable[ep.operation.OperationID]; ok && t.operations[ep.operation.OperationID] == "" {
+		entry.Anchor = anchor
+	} else {
+		entry.Anchor = uniqueSlug("op-"+name, t.used)
+	}
+
+	t.endpoints[endpointKey(tag, ep)] = entry.Anchor
+
+	for _, key := range []string{ep.operation.OperationID, formatMethod(ep.method) + " " + ep.path} {
+		if _, exists := t.operations[key]; key != "" && !exists {
+			t.operations[key] = entry.Anchor
+		}
+	}
+
+	return entry
+}
+
+// reserveStableAnchors reserves the anchors of the operations with an operationId, in the order they are rendered.
+// An operationId whose anchor is already taken, which only whitespace can cause, keeps a derived anchor.
+func (t *documentTOC) reserveStableAnchors(doc *domain.OpenAPIDocument, tagPaths map[string][]endpointRef) {
+	reserve := func(endpoints []endpointRef) {
+		for _, ep := range endpoints {
+			id := ep.operation.OperationID
+			if _, reserved := t.stable[id]; id == "" || reserved {
+				continue
+			}
+
+			anchor := stableAnchor(id)
+			if _, taken := t.used[anchor]; !taken {
+				t.stable[id] = anchor
+				t.used[anchor] = struct{}{}
+			}
+		}
+	}
+
+	for _, tag := range orderedTags(doc, tagPaths) {
+		reserve(tagPaths[tag])
+	}
+
+	reserve(webhookRefs(doc))
+}
+
+// stableAnchor returns the anchor reserved for an operationId: "op-" followed by the operationId,
+// with any whitespace, which IDs cannot hold, replaced by "-".
+func stableAnchor(operationID string) string {
+	return "op-" + strings.Join(strings.Fields(operationID), "-")
+}
+
+// localize translates the titles of the sections the table of contents lists besides the tags: webhooks, schemas
+// and schema graph.
+func (t *documentTOC) localize(locale Locale) {
+	for i, entry := range t.Entries {
+		if entry.Anchor == webhooksAnchor || entry.Anchor == schemasAnchor || entry.Anchor == schemaGraphAnchor {
+			t.Entries[i].Title = locale.T(entry.Title)
+		}
+	}
+}
+
+// addSchemaAppendix lists the component schemas of a document in an appendix after its endpoints and webhooks,
+// each with an anchor such as "schema-pet".
+func (t *documentTOC) addSchemaAppendix(doc *domain.OpenAPIDocument) {
+	if len(doc.Components) == 0 {
+		return
+	}
+
+	entry := tocEntry{Title: "Schemas", Anchor: schemasAnchor}
+
+	for _, name := range sortedComponentNames(doc.Components) {
+		t.schemas[name] = uniqueSlug("schema-"+name, t.used)
+		entry.Children = append(entry.Children, tocEntry{Title: name, Anchor: t.schemas[name]})
+	}
+
+	t.Entries = append(t.Entries, entry)
+}
+
+// addSchemaGraph lists the graph of the component schemas of a document after its schemas appendix.
+func (t *documentTOC) addSchemaGraph(doc *domain.OpenAPIDocument) {
+	if len(doc.Components) == 0 {
+		return
+	}
+
+	t.Entries = append(t.Entries, tocEntry{Title: "Schema Graph", Anchor: schemaGraphAnchor})
+}
+
+// tagAnchor returns the anchor of a tag heading.
+func (t *documentTOC) tagAnchor(tag string) string {
+	return t.tags[tag]
+}
+
+// endpointAnchor returns the anchor of an endpoint listed under a tag, or of a webhook when tag is empty.
+func (t *documentTOC) endpointAnchor(tag string, ep endpointRef) string {
+	return t.endpoints[endpointKey(tag, ep)]
+}
+
+// operationAnchor returns the anchor of an operation given by operationId or as "METHOD /path",
+// empty when it is not in the document or there is no table of contents.
+func (t *documentTOC) operationAnchor(operation string) string {
+	if t == nil {
+		return ""
+	}
+
+	return t.operations[operation]
+}
+
+// schemaAnchor returns the anchor of a schema in the appendix, empty when there is no appendix.
+func (t *documentTOC) schemaAnchor(name string) string {
+	return t.schemas[name]
+}
+
+// schemaLinks returns entries linking to the appendix entries of the given schemas.
+func (t *documentTOC) schemaLinks(names []string) []tocEntry {
+	entries := make([]tocEntry, 0, len(names))
+	for _, name := range names {
+		if anchor := t.schemaAnchor(name); anchor != "" {
+			entries = append(entries, tocEntry{Title: name, Anchor: anchor})
+		}
+	}
+
+	return entries
+}
+
+// endpointKey identifies an endpoint within a tag; a path and method pair is unique within a document.
+func endpointKey(tag string, ep endpointRef) string {
+	return tag + "\x00" + ep.method + " " + ep.path
+}
+
+// OperationAnchor locates the heading of an operation in a converted document.
+type OperationAnchor struct {
+	Method string `json:"method"`
+	Path   string `json:"path"` // Webhook name for webhooks
+	Anchor string `json:"anchor"`
+	URL    string `json:"url,omitempty"` // Link to the anchor on a published page
+}
+
+// OperationAnchors returns the anchors given to the operations and webhooks of a document by the Markdown, HTML
+// and Confluence converters, keyed by operationId or, for operations without one, by "METHOD /path".
+// An operation listed under several tags is anchored at its first occurrence.
+func OperationAnchors(doc *domain.OpenAPIDocument, stable bool) map[string]OperationAnchor {
+	toc := newDocumentTOC(doc, stable)
+	anchors := make(map[string]OperationAnchor)
+
+	add := func(endpoints []endpointRef) {
+		for _, ep := range endpoints {
+			key := ep.operation.OperationID
+			if key == "" {
+				key = formatMethod(ep.method) + " " + ep.path
+			}
+
+			if _, exists := anchors[key]; !exists {
+				anchors[key] = OperationAnchor{Method: formatMethod(ep.method), Path: ep.path, Anchor: toc.operationAnchor(key)}
+			}
+		}
+	}
+
+	tagPaths := groupPathsByTag(doc)
+	for _, tag := range orderedTags(doc, tagPaths) {
+		add(tagPaths[tag])
+	}
+
+	add(webhookRefs(doc))
+
+	return anchors
+}
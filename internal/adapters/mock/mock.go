@@ -0,0 +1,446 @@
+// Package mock serves the documented responses of a specification over HTTP, so that clients can be developed
+// against its contract before the API exists.
+package mock
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"mime"
+	"net/http"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/GabrielNunesIT/openapi-converter/internal/adapters/converters"
+	"github.com/GabrielNunesIT/openapi-converter/internal/domain"
+)
+
+// paramPattern matches the parameters of a path template segment, e.g. "{petId}".
+var paramPattern = regexp.MustCompile(`\{([^}]+)\}`)
+
+// Server answers the requests to the operations of a specification with their example responses.
+//
+// The response is the first success response the operation documents, or the one of the status asked for with a
+// "Prefer: code=<status>" header, in the first of its media types the Accept header allows. Its body is the example
+// named by a "Prefer: example=<name>" header, else the first example of the media type, else a value generated from
+// its schema. Requests missing required parameters or bodies, or whose values do not match their schema, are
+// answered with 400 Bad Request unless validation is disabled.
+type Server struct {
+	doc      *domain.OpenAPIDocument
+	routes   []route
+	patterns sync.Map // Compiled schema patterns by expression, nil for those Go does not support
+	validate bool
+	logger   func(method, path string, status int)
+}
+
+// route is a path of the specification under one of the base paths it is served under, compiled once.
+type route struct {
+	path     domain.Path
+	segments []segment
+	literals int // Number of literal segments, the route with the most winning when several match
+}
+
+// segment is a segment of a path template: a literal, or a pattern capturing the values of its parameters.
+type segment struct {
+	literal string
+	pattern *regexp.Regexp // nil for literal segments
+	params  []string       // Names of the parameters captured by pattern, in order
+}
+
+// Option configures a Server.
+type Option func(*Server)
+
+// WithValidation sets whether requests are checked against the specification before being answered.
+// It is on by default.
+func WithValidation(validate bool) Option {
+	return func(s *Server) {
+		s.validate = validate
+	}
+}
+
+// WithLogger sets a function called with the method, path and response status of every request.
+func WithLogger(logger func(method, path string, status int)) Option {
+	return func(s *Server) {
+		s.logger = logger
+	}
+}
+
+// NewServer creates a Server mocking the operations of doc.
+func NewServer(doc *domain.OpenAPIDocument, opts ...Option) *Server {
+	s := &Server{doc: doc, validate: true}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	for _, base := range s.basePaths() {
+		for _, path := range doc.Paths {
+			s.routes = append(s.routes, compileRoute(base+path.Path, path))
+		}
+	}
+
+	return s
+}
+
+// problem is the body of the errors the server answers with itself.
+type problem struct {
+	Error   string   `json:"error"`
+	Details []string `json:"details,omitempty"`
+}
+
+// ServeHTTP answers a request with the example response of the operation it calls.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	status := s.serve(w, r)
+
+	if s.logger != nil {
+		s.logger(r.Method, r.URL.Path, status)
+	}
+}
+
+// serve answers a request and returns the status of the response.
+func (s *Server) serve(w http.ResponseWriter, r *http.Request) int {
+	// Browser clients are served from another origin than the mock
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.Header().Set("Access-Control-Expose-Headers", "*")
+
+	if r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != "" {
+		w.Header().Set("Access-Control-Allow-Methods", "*")
+		w.Header().Set("Access-Control-Allow-Headers", "*")
+		w.WriteHeader(http.StatusNoContent)
+
+		return http.StatusNoContent
+	}
+
+	path, params, found := s.findPath(r.URL.Path)
+	if !found {
+		return writeProblem(w, http.StatusNotFound, fmt.Sprintf("no path of the specification matches %s", r.URL.Path))
+	}
+
+	index := slices.IndexFunc(path.Operations, func(op domain.Operation) bool {
+		return strings.EqualFold(op.Method, r.Method)
+	})
+	if index < 0 {
+		methods := make([]string, 0, len(path.Operations))
+		for _, op := range path.Operations {
+			methods = append(methods, strings.ToUpper(op.Method))
+		}
+
+		w.Header().Set("Allow", strings.Join(methods, ", "))
+
+		return writeProblem(w, http.StatusMethodNotAllowed, fmt.Sprintf("%s does not support %s", path.Path, r.Method))
+	}
+
+	op := path.Operations[index]
+
+	if s.validate {
+		if details := s.validateRequest(r, op, params); len(details) > 0 {
+			return writeProblem(w, http.StatusBadRequest, "the request does not match the specification", details...)
+		}
+	}
+
+	return s.respond(w, r, op)
+}
+
+// findPath returns the path of the specification a request path calls with the values of its parameters.
+// Templates match the whole request path, after the base path of one of the servers of the specification if any;
+// the template with the most literal segments wins.
+func (s *Server) findPath(requestPath string) (domain.Path, map[string]string, bool) {
+	var (
+		found  *route
+		params map[string]string
+	)
+
+	for i := range s.routes {
+		candidate := &s.routes[i]
+		if found != nil && candidate.literals <= found.literals {
+			continue
+		}
+
+		if values, ok := candidate.match(requestPath); ok {
+			found, params = candidate, values
+		}
+	}
+
+	if found == nil {
+		return domain.Path{}, nil, false
+	}
+
+	return found.path, params, true
+}
+
+// basePaths returns the paths the operations are served under: the root and the paths of the server URLs, e.g.
+// "/v1" for https://api.example.com/v1, whose variables match any segment as path parameters do.
+func (s *Server) basePaths() []string {
+	bases := []string{""}
+
+	for _, server := range s.doc.Servers {
+		base := server.URL
+		if _, rest, ok := strings.Cut(base, "://"); ok {
+			base = "/"
+			if _, path, ok := strings.Cut(rest, "/"); ok {
+				base += path
+			}
+		}
+
+		if base = strings.TrimRight(base, "/"); base != "" && !slices.Contains(bases, base) {
+			bases = append(bases, base)
+		}
+	}
+
+	return bases
+}
+
+// compileRoute compiles a path template, e.g. "/pets/{petId}", into the route of path.
+func compileRoute(template string, path domain.Path) route {
+	r := route{path: path}
+
+	for part := range strings.SplitSeq(strings.Trim(template, "/"), "/") {
+		if !strings.Contains(part, "{") {
+			r.segments = append(r.segments, segment{literal: part})
+			r.literals++
+
+			continue
+		}
+
+		literalParts := paramPattern.Split(part, -1)
+		for j, literal := range literalParts {
+			literalParts[j] = regexp.QuoteMeta(literal)
+		}
+
+		compiled := segment{pattern: regexp.MustCompile("^" + strings.Join(literalParts, "([^/]+)") + "$")}
+		for _, name := range paramPattern.FindAllStringSubmatch(part, -1) {
+			compiled.params = append(compiled.params, name[1])
+		}
+
+		r.segments = append(r.segments, compiled)
+	}
+
+	return r
+}
+
+// match reports whether a route matches a request path, with the values of its parameters.
+func (r *route) match(path string) (map[string]string, bool) {
+	pathSegments := strings.Split(strings.Trim(path, "/"), "/")
+	if len(pathSegments) != len(r.segments) {
+		return nil, false
+	}
+
+	params := make(map[string]string)
+
+	for i, segment := range r.segments {
+		actual := pathSegments[i]
+
+		if segment.pattern == nil {
+			if segment.literal != actual {
+				return nil, false
+			}
+
+			continue
+		}
+
+		values := segment.pattern.FindStringSubmatch(actual)
+		if values == nil {
+			return nil, false
+		}
+
+		for j, name := range segment.params {
+			params[name] = values[j+1]
+		}
+	}
+
+	return params, true
+}
+
+// respond writes the example response of an operation.
+func (s *Server) respond(w http.ResponseWriter, r *http.Request, op domain.Operation) int {
+	preferences := preferences(r.Header.Get("Prefer"))
+
+	resp, status, ok := selectResponse(op.Responses, preferences["code"])
+	if !ok {
+		if preferences["code"] != "" {
+			return writeProblem(w, http.StatusBadRequest,
+				fmt.Sprintf("%s %s documents no response with status %s", strings.ToUpper(op.Method), r.URL.Path, preferences["code"]))
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+
+		return http.StatusNoContent
+	}
+
+	if len(resp.Content) == 0 {
+		s.writeHeaders(w, resp)
+		w.WriteHeader(status)
+
+		return status
+	}
+
+	contentType, ok := negotiate(resp.Content, r.Header.Get("Accept"))
+	if !ok {
+		return writeProblem(w, http.StatusNotAcceptable,
+			fmt.Sprintf("the response is documented as %s only", strings.Join(slices.Sorted(maps.Keys(resp.Content)), ", ")))
+	}
+
+	body, err := encode(contentType, converters.MediaExample(resp.Content[contentType], preferences["example"], s.doc.Components))
+	if err != nil {
+		return writeProblem(w, http.StatusInternalServerError, fmt.Sprintf("failed to encode the example: %v", err))
+	}
+
+	// Media type ranges such as "image/*" cannot be sent as they are
+	if strings.Contains(contentType, "*") {
+		contentType = "application/octet-stream"
+	}
+
+	s.writeHeaders(w, resp)
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(status)
+	_, _ = w.Write(body)
+
+	return status
+}
+
+// writeHeaders sets the headers documented for a response to sample values.
+func (s *Server) writeHeaders(w http.ResponseWriter, resp domain.Response) {
+	for _, header := range resp.Headers {
+		if value := converters.SampleValue(header.Schema, s.doc.Components); value != nil {
+			w.Header().Set(header.Name, fmt.Sprint(value))
+		}
+	}
+}
+
+// selectResponse returns the response documenting the status asked for, or without one the first success response,
+// else the default response, with the status to send it with.
+func selectResponse(responses []domain.Response, code string) (domain.Response, int, bool) {
+	sorted := slices.Clone(responses)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].StatusCode < sorted[j].StatusCode
+	})
+
+	if code != "" {
+		status, err := strconv.Atoi(code)
+		if err != nil || len(code) != 3 {
+			return domain.Response{}, 0, false
+		}
+
+		for _, candidate := range []string{code, code[:1] + "XX", "default"} {
+			for _, resp := range sorted {
+				if strings.EqualFold(resp.StatusCode, candidate) {
+					return resp, status, true
+				}
+			}
+		}
+
+		return domain.Response{}, 0, false
+	}
+
+	for _, resp := range sorted {
+		if strings.HasPrefix(resp.StatusCode, "2") {
+			return resp, responseStatus(resp.StatusCode), true
+		}
+	}
+
+	for _, resp := range sorted {
+		if resp.StatusCode == "default" {
+			return resp, http.StatusOK, true
+		}
+	}
+
+	return domain.Response{}, 0, false
+}
+
+// responseStatus returns the status to send a response documented for a status code or range such as "2XX" with.
+func responseStatus(code string) int {
+	if status, err := strconv.Atoi(code); err == nil {
+		return status
+	}
+
+	status, err := strconv.Atoi(strings.NewReplacer("X", "0", "x", "0").Replace(code))
+	if err != nil {
+		return http.StatusOK
+	}
+
+	return status
+}
+
+// preferences parses the parameters of a Prefer header, e.g. "code=404, example=missing".
+func preferences(header string) map[string]string {
+	values := make(map[string]string)
+
+	for _, preference := range strings.FieldsFunc(header, func(r rune) bool { return r == ',' || r == ';' }) {
+		name, value, _ := strings.Cut(strings.TrimSpace(preference), "=")
+		values[strings.ToLower(name)] = strings.Trim(value, `"`)
+	}
+
+	return values
+}
+
+// negotiate returns the first media type of content, preferring JSON, that the Accept header allows.
+func negotiate(content map[string]domain.MediaType, accept string) (string, bool) {
+	contentTypes := slices.Sorted(maps.Keys(content))
+	sort.SliceStable(contentTypes, func(i, j int) bool {
+		return isJSON(contentTypes[i]) && !isJSON(contentTypes[j])
+	})
+
+	if strings.TrimSpace(accept) == "" {
+		return contentTypes[0], true
+	}
+
+	for _, contentType := range contentTypes {
+		for accepted := range strings.SplitSeq(accept, ",") {
+			if mediaTypeMatches(strings.TrimSpace(accepted), contentType) {
+				return contentType, true
+			}
+		}
+	}
+
+	return "", false
+}
+
+// mediaTypeMatches reports whether a media type or range, e.g. "application/*", includes a media type or range.
+func mediaTypeMatches(pattern, contentType string) bool {
+	pattern, _, _ = strings.Cut(pattern, ";")
+	contentType, _, _ = strings.Cut(contentType, ";")
+
+	patternType, patternSubtype, _ := strings.Cut(strings.ToLower(strings.TrimSpace(pattern)), "/")
+	actualType, actualSubtype, _ := strings.Cut(strings.ToLower(strings.TrimSpace(contentType)), "/")
+
+	return (patternType == "*" || actualType == "*" || patternType == actualType) &&
+		(patternSubtype == "*" || actualSubtype == "*" || patternSubtype == actualSubtype)
+}
+
+// isJSON reports whether a media type carries JSON, such as application/json or application/problem+json.
+func isJSON(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		mediaType = contentType
+	}
+
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
+
+// encode returns the body of an example sent as a media type: strings of other media types than JSON as they are,
+// anything else as JSON.
+func encode(contentType string, value any) ([]byte, error) {
+	if text, ok := value.(string); ok && !isJSON(contentType) {
+		return []byte(text), nil
+	}
+
+	body, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode example: %w", err)
+	}
+
+	return append(body, '\n'), nil
+}
+
+// writeProblem writes an error of the mock server itself as JSON and returns its status.
+func writeProblem(w http.ResponseWriter, status int, message string, details ...string) int {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(problem{Error: message, Details: details})
+
+	return status
+}
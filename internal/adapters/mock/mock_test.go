@@ -0,0 +1,170 @@
+package mock_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/GabrielNunesIT/openapi-converter/internal/adapters/mock"
+	"github.com/GabrielNunesIT/openapi-converter/internal/domain"
+)
+
+func ptr[T any](value T) *T {
+	return &value
+}
+
+// jsonResponse returns a response of status with a JSON example.
+func jsonResponse(status string, example any) domain.Response {
+	return domain.Response{StatusCode: status, Description: status, Content: map[string]domain.MediaType{
+		"application/json": {Example: example},
+	}}
+}
+
+// petsDoc returns a document served under /v1 and under a version variable.
+func petsDoc() *domain.OpenAPIDocument {
+	petSchema := domain.Schema{
+		Type:     "object",
+		Required: []string{"name"},
+		Properties: map[string]domain.Schema{
+			"name": {Type: "string", MinLength: 1, MaxLength: ptr[uint64](10), Pattern: "^[a-z]+$"},
+			"age":  {Type: "number", Minimum: ptr(0.0), Maximum: ptr(30.0), ExclusiveMaximum: true},
+		},
+	}
+
+	return &domain.OpenAPIDocument{
+		Servers: []domain.Server{
+			{URL: "https://api.example.com/v1"},
+			{URL: "https://{region}.example.com/{version}/"},
+		},
+		Paths: []domain.Path{
+			{Path: "/pets", Operations: []domain.Operation{
+				{
+					Method: "get",
+					Parameters: []domain.Parameter{
+						{Name: "limit", In: "query", Schema: domain.Schema{Type: "integer", Minimum: ptr(1.0), Maximum: ptr(100.0)}},
+						{Name: "tags", In: "query", Schema: domain.Schema{
+							Type: "array", MaxItems: ptr[uint64](2), Items: &domain.Schema{Type: "string", MaxLength: ptr[uint64](5)},
+						}},
+					},
+					Responses: []domain.Response{jsonResponse("200", []any{"all"})},
+				},
+				{
+					Method:      "post",
+					RequestBody: &domain.RequestBody{Required: true, Content: map[string]domain.MediaType{"application/json": {Schema: petSchema}}},
+					Responses:   []domain.Response{jsonResponse("201", "created")},
+				},
+			}},
+			{Path: "/pets/{petId}", Operations: []domain.Operation{{
+				Method:     "get",
+				Parameters: []domain.Parameter{{Name: "petId", In: "path", Required: true, Schema: domain.Schema{Type: "integer"}}},
+				Responses:  []domain.Response{jsonResponse("200", "pet"), jsonResponse("404", "missing")},
+			}}},
+			{Path: "/pets/mine", Operations: []domain.Operation{{
+				Method:    "get",
+				Responses: []domain.Response{jsonResponse("200", "mine")},
+			}}},
+			{Path: "/files/{name}.{ext}", Operations: []domain.Operation{{
+				Method: "get",
+				Parameters: []domain.Parameter{
+					{Name: "name", In: "path", Required: true, Schema: domain.Schema{Type: "string", MinLength: 3}},
+					{Name: "ext", In: "path", Required: true, Schema: domain.Schema{Type: "string", Enum: []any{"pdf", "txt"}}},
+				},
+				Responses: []domain.Response{jsonResponse("200", "file")},
+			}}},
+		},
+	}
+}
+
+func TestServer(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		target     string
+		header     http.Header
+		body       string
+		opts       []mock.Option
+		wantStatus int
+		wantBody   string // Substring of the body
+	}{
+		{name: "path", method: "GET", target: "/pets", wantStatus: 200, wantBody: `"all"`},
+		{name: "server base path", method: "GET", target: "/v1/pets", wantStatus: 200, wantBody: `"all"`},
+		{name: "server base path variable", method: "GET", target: "/v2/pets/7", wantStatus: 200, wantBody: `"pet"`},
+		{name: "path under another base", method: "GET", target: "/anything/v1/pets", wantStatus: 404},
+		{name: "trailing segments", method: "GET", target: "/v1/pets/7/owner", wantStatus: 404},
+		{name: "literal over template", method: "GET", target: "/v1/pets/mine", wantStatus: 200, wantBody: `"mine"`},
+		{name: "path parameter", method: "GET", target: "/pets/12", wantStatus: 200, wantBody: `"pet"`},
+		{
+			name: "path parameter type", method: "GET", target: "/pets/rex", wantStatus: 400,
+			wantBody: `path parameter "petId" must be of type integer`,
+		},
+		{name: "parameters of a segment", method: "GET", target: "/files/report.pdf", wantStatus: 200, wantBody: `"file"`},
+		{name: "parameter enum", method: "GET", target: "/files/report.doc", wantStatus: 400, wantBody: "must be one of pdf, txt"},
+		{name: "parameter length", method: "GET", target: "/files/ab.txt", wantStatus: 400, wantBody: "at least 3 characters"},
+		{name: "method", method: "DELETE", target: "/pets", wantStatus: 405, wantBody: "does not support DELETE"},
+		{name: "minimum", method: "GET", target: "/pets?limit=0", wantStatus: 400, wantBody: `"limit" must be >= 1`},
+		{name: "maximum", method: "GET", target: "/pets?limit=101", wantStatus: 400, wantBody: `"limit" must be <= 100`},
+		{name: "within bounds", method: "GET", target: "/pets?limit=100", wantStatus: 200},
+		{name: "max items", method: "GET", target: "/pets?tags=a,b,c", wantStatus: 400, wantBody: "at most 2 items"},
+		{name: "item length", method: "GET", target: "/pets?tags=a&tags=abcdef", wantStatus: 400, wantBody: "at most 5 characters"},
+		{
+			name: "validation disabled", method: "GET", target: "/pets?limit=0", opts: []mock.Option{mock.WithValidation(false)},
+			wantStatus: 200,
+		},
+		{name: "body", method: "POST", target: "/pets", body: `{"name":"rex","age":3}`, wantStatus: 201},
+		{name: "missing body", method: "POST", target: "/pets", wantStatus: 400, wantBody: "missing required request body"},
+		{name: "required property", method: "POST", target: "/pets", body: `{"age":3}`, wantStatus: 400, wantBody: "missing required property"},
+		{name: "min length", method: "POST", target: "/pets", body: `{"name":""}`, wantStatus: 400, wantBody: "at least 1 characters"},
+		{
+			name: "max length", method: "POST", target: "/pets", body: `{"name":"abcdefghijk"}`, wantStatus: 400,
+			wantBody: "at most 10 characters",
+		},
+		{name: "pattern", method: "POST", target: "/pets", body: `{"name":"Rex"}`, wantStatus: 400, wantBody: "must match the pattern"},
+		{
+			name: "exclusive maximum", method: "POST", target: "/pets", body: `{"name":"rex","age":30}`, wantStatus: 400,
+			wantBody: `body.age must be < 30`,
+		},
+		{
+			name: "preferred status", method: "GET", target: "/pets/1", header: http.Header{"Prefer": {"code=404"}},
+			wantStatus: 404, wantBody: `"missing"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := mock.NewServer(petsDoc(), tt.opts...)
+
+			request := httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body))
+			if tt.body != "" {
+				request.Header.Set("Content-Type", "application/json")
+			}
+
+			for name, values := range tt.header {
+				request.Header[name] = values
+			}
+
+			recorder := httptest.NewRecorder()
+			server.ServeHTTP(recorder, request)
+
+			if recorder.Code != tt.wantStatus {
+				t.Errorf("got status %d, want %d: %s", recorder.Code, tt.wantStatus, recorder.Body)
+			}
+
+			body := recorder.Body.String()
+
+			// Errors of the server itself are JSON, escaping the comparisons of their details
+			var problem struct {
+				Error   string   `json:"error"`
+				Details []string `json:"details"`
+			}
+			if err := json.Unmarshal(recorder.Body.Bytes(), &problem); err == nil && problem.Error != "" {
+				body = strings.Join(append([]string{problem.Error}, problem.Details...), "\n")
+			}
+
+			if !strings.Contains(body, tt.wantBody) {
+				t.Errorf("got body %s, want it to contain %s", body, tt.wantBody)
+			}
+		})
+	}
+}
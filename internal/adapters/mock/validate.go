@@ -0,0 +1,358 @@
+package mock
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"mime"
+	"net/http"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/GabrielNunesIT/openapi-converter/internal/domain"
+)
+
+const (
+	// maxBodySize bounds the request bodies read for validation.
+	maxBodySize = 10 << 20
+
+	// maxValidationDepth bounds the nesting of the values checked against a schema, stopping at circular schemas.
+	maxValidationDepth = 32
+)
+
+// validateRequest checks the parameters and body of a request against an operation and describes its mismatches.
+func (s *Server) validateRequest(r *http.Request, op domain.Operation, pathParams map[string]string) []string {
+	var details []string
+
+	query := r.URL.Query()
+
+	for _, param := range op.Parameters {
+		var values []string
+
+		switch param.In {
+		case "path":
+			if value, ok := pathParams[param.Name]; ok {
+				values = []string{value}
+			}
+		case "query":
+			values = query[param.Name]
+		case "header":
+			values = r.Header.Values(param.Name)
+		case "cookie":
+			if cookie, err := r.Cookie(param.Name); err == nil {
+				values = []string{cookie.Value}
+			}
+		}
+
+		label := fmt.Sprintf("%s parameter %q", param.In, param.Name)
+
+		if len(values) == 0 {
+			if param.Required {
+				details = append(details, fmt.Sprintf("missing required %s", label))
+			}
+
+			continue
+		}
+
+		details = append(details, s.checkParameter(label, param.Schema, values)...)
+	}
+
+	if op.RequestBody != nil {
+		details = append(details, s.checkBody(r, *op.RequestBody)...)
+	}
+
+	return details
+}
+
+// checkParameter checks the values of a parameter, several for arrays, against its schema.
+func (s *Server) checkParameter(label string, schema domain.Schema, values []string) []string {
+	schema = s.resolve(schema)
+
+	if schema.Type == "array" {
+		// Arrays are sent as repeated parameters or as a comma-separated list
+		if len(values) == 1 {
+			values = strings.Split(values[0], ",")
+		}
+
+		items := domain.Schema{}
+		if schema.Items != nil {
+			items = *schema.Items
+		}
+
+		list := make([]any, 0, len(values))
+		for _, value := range values {
+			list = append(list, value)
+		}
+
+		details := s.checkBounds(label, schema, list)
+
+		for _, value := range values {
+			details = append(details, s.checkParameter(label, items, []string{value})...)
+		}
+
+		return details
+	}
+
+	return s.checkValue(label, schema, parameterValue(schema, values[0]), 0)
+}
+
+// parameterValue converts the text of a parameter to the type of its schema, leaving it as text when it does not
+// parse, so that the schema check reports it.
+func parameterValue(schema domain.Schema, text string) any {
+	for schemaType := range strings.SplitSeq(schema.Type, " | ") {
+		switch schemaType {
+		case "integer", "number":
+			if number, err := strconv.ParseFloat(text, 64); err == nil {
+				return number
+			}
+		case "boolean":
+			if boolean, err := strconv.ParseBool(text); err == nil {
+				return boolean
+			}
+		}
+	}
+
+	return text
+}
+
+// checkBody checks the body of a request against the request body of an operation. Only JSON bodies are checked
+// against their schema.
+func (s *Server) checkBody(r *http.Request, requestBody domain.RequestBody) []string {
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxBodySize))
+	if err != nil {
+		return []string{fmt.Sprintf("failed to read the request body: %v", err)}
+	}
+
+	if len(body) == 0 {
+		if requestBody.Required {
+			return []string{"missing required request body"}
+		}
+
+		return nil
+	}
+
+	if len(requestBody.Content) == 0 {
+		return nil
+	}
+
+	contentType := r.Header.Get("Content-Type")
+	if mediaType, _, err := mime.ParseMediaType(contentType); err == nil {
+		contentType = mediaType
+	}
+
+	documented := ""
+
+	for _, candidate := range slices.Sorted(maps.Keys(requestBody.Content)) {
+		if contentType != "" && mediaTypeMatches(candidate, contentType) {
+			documented = candidate
+
+			break
+		}
+	}
+
+	if documented == "" {
+		return []string{fmt.Sprintf("request body of type %q is not one of %s", contentType,
+			strings.Join(slices.Sorted(maps.Keys(requestBody.Content)), ", "))}
+	}
+
+	if !isJSON(contentType) {
+		return nil
+	}
+
+	var value any
+	if err := json.Unmarshal(body, &value); err != nil {
+		return []string{fmt.Sprintf("request body is not valid JSON: %v", err)}
+	}
+
+	return s.checkValue("body", requestBody.Content[documented].Schema, value, 0)
+}
+
+// checkValue checks a decoded JSON value against a schema: its type, enumeration, bounds, length, pattern, required
+// properties and the properties and items it holds. Of the composed schemas, only allOf is checked.
+func (s *Server) checkValue(label string, schema domain.Schema, value any, depth int) []string {
+	if depth > maxValidationDepth {
+		return nil
+	}
+
+	schema = s.resolve(schema)
+
+	if value == nil {
+		if schema.Nullable || schema.Type == "" || slices.Contains(strings.Split(schema.Type, " | "), "null") {
+			return nil
+		}
+
+		return []string{fmt.Sprintf("%s must not be null", label)}
+	}
+
+	var details []string
+
+	for _, part := range schema.AllOf {
+		details = append(details, s.checkValue(label, part, value, depth+1)...)
+	}
+
+	if schema.Type != "" && !hasType(schema.Type, value) {
+		return append(details, fmt.Sprintf("%s must be of type %s", label, schema.Type))
+	}
+
+	if len(schema.Enum) > 0 && !slices.ContainsFunc(schema.Enum, func(allowed any) bool {
+		return fmt.Sprint(allowed) == fmt.Sprint(value)
+	}) {
+		details = append(details, fmt.Sprintf("%s must be one of %s", label, enumList(schema.Enum)))
+	}
+
+	details = append(details, s.checkBounds(label, schema, value)...)
+
+	switch typed := value.(type) {
+	case map[string]any:
+		for _, name := range schema.Required {
+			if _, ok := typed[name]; !ok {
+				details = append(details, fmt.Sprintf("%s is missing required property %q", label, name))
+			}
+		}
+
+		for _, name := range slices.Sorted(maps.Keys(schema.Properties)) {
+			if property, ok := typed[name]; ok {
+				details = append(details, s.checkValue(fmt.Sprintf("%s.%s", label, name), schema.Properties[name], property, depth+1)...)
+			}
+		}
+	case []any:
+		if schema.Items != nil {
+			for i, item := range typed {
+				details = append(details, s.checkValue(fmt.Sprintf("%s[%d]", label, i), *schema.Items, item, depth+1)...)
+			}
+		}
+	}
+
+	return details
+}
+
+// checkBounds checks a decoded JSON value of the type of a schema against the bounds of its numbers, the length and
+// pattern of its strings and the number of its items.
+func (s *Server) checkBounds(label string, schema domain.Schema, value any) []string {
+	var details []string
+
+	switch typed := value.(type) {
+	case float64:
+		if minimum := schema.Minimum; minimum != nil && (typed < *minimum || schema.ExclusiveMinimum && typed == *minimum) {
+			details = append(details, fmt.Sprintf("%s must be %s %v", label, bound(">", schema.ExclusiveMinimum), *minimum))
+		}
+
+		if maximum := schema.Maximum; maximum != nil && (typed > *maximum || schema.ExclusiveMaximum && typed == *maximum) {
+			details = append(details, fmt.Sprintf("%s must be %s %v", label, bound("<", schema.ExclusiveMaximum), *maximum))
+		}
+	case string:
+		length := uint64(utf8.RuneCountInString(typed))
+
+		if length < schema.MinLength {
+			details = append(details, fmt.Sprintf("%s must be at least %d characters long", label, schema.MinLength))
+		}
+
+		if schema.MaxLength != nil && length > *schema.MaxLength {
+			details = append(details, fmt.Sprintf("%s must be at most %d characters long", label, *schema.MaxLength))
+		}
+
+		if pattern := s.pattern(schema.Pattern); pattern != nil && !pattern.MatchString(typed) {
+			details = append(details, fmt.Sprintf("%s must match the pattern %s", label, schema.Pattern))
+		}
+	case []any:
+		if count := uint64(len(typed)); count < schema.MinItems {
+			details = append(details, fmt.Sprintf("%s must have at least %d items", label, schema.MinItems))
+		} else if schema.MaxItems != nil && count > *schema.MaxItems {
+			details = append(details, fmt.Sprintf("%s must have at most %d items", label, *schema.MaxItems))
+		}
+	}
+
+	return details
+}
+
+// pattern returns the compiled pattern of a schema, compiling it once, or nil when the schema has none or Go does not
+// support it, such as patterns with lookarounds, which are then not checked.
+func (s *Server) pattern(expr string) *regexp.Regexp {
+	if expr == "" {
+		return nil
+	}
+
+	if compiled, ok := s.patterns.Load(expr); ok {
+		return compiled.(*regexp.Regexp) //nolint:forcetypeassert // only *regexp.Regexp values are stored
+	}
+
+	compiled, err := regexp.Compile(expr)
+	if err != nil {
+		compiled = nil
+	}
+
+	s.patterns.Store(expr, compiled)
+
+	return compiled
+}
+
+// bound returns the comparison a bound allows values on one side of, e.g. ">=" for an inclusive minimum.
+func bound(comparison string, exclusive bool) string {
+	if exclusive {
+		return comparison
+	}
+
+	return comparison + "="
+}
+
+// hasType reports whether a decoded JSON value is of one of the types of a schema, e.g. "string | integer".
+func hasType(schemaType string, value any) bool {
+	for candidate := range strings.SplitSeq(schemaType, " | ") {
+		switch typed := value.(type) {
+		case map[string]any:
+			if candidate == "object" {
+				return true
+			}
+		case []any:
+			if candidate == "array" {
+				return true
+			}
+		case string:
+			if candidate == "string" {
+				return true
+			}
+		case bool:
+			if candidate == "boolean" {
+				return true
+			}
+		case float64:
+			if candidate == "number" || candidate == "integer" && typed == float64(int64(typed)) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// resolve returns the component a schema references, or the schema itself.
+func (s *Server) resolve(schema domain.Schema) domain.Schema {
+	for range maxValidationDepth {
+		if schema.Ref == "" {
+			break
+		}
+
+		resolved, ok := s.doc.Components[schema.Ref[strings.LastIndex(schema.Ref, "/")+1:]]
+		if !ok {
+			return domain.Schema{}
+		}
+
+		schema = resolved
+	}
+
+	return schema
+}
+
+// enumList formats the allowed values of a schema, e.g. "available, pending".
+func enumList(values []any) string {
+	parts := make([]string, 0, len(values))
+	for _, value := range values {
+		parts = append(parts, fmt.Sprint(value))
+	}
+
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,248 @@
+// Package notifiers sends summaries of the conversions and publications to chat systems.
+package notifiers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/GabrielNunesIT/openapi-converter/internal/adapters/converters"
+	"github.com/GabrielNunesIT/openapi-converter/internal/domain"
+)
+
+// Webhook providers, which expect different payloads.
+const (
+	ProviderSlack = "slack"
+	ProviderTeams = "teams"
+)
+
+const (
+	webhookTimeout = 30 * time.Second
+
+	// maxBreakingChanges bounds the breaking changes listed in a notification, the rest being counted.
+	maxBreakingChanges = 10
+
+	// adaptiveCardType is the attachment content type of the Adaptive Cards Teams webhooks and workflows accept.
+	adaptiveCardType = "application/vnd.microsoft.card.adaptive"
+)
+
+// WebhookConfig holds the settings of a chat webhook.
+type WebhookConfig struct {
+	URL      string // Incoming webhook URL, which holds its own credentials
+	Provider string // ProviderSlack or ProviderTeams, detected from URL when empty
+}
+
+// WebhookNotifier posts notifications to a Slack incoming webhook, as Block Kit messages,
+// or to a Microsoft Teams incoming webhook or workflow, as Adaptive Cards.
+type WebhookNotifier struct {
+	cfg    WebhookConfig
+	client *http.Client
+}
+
+// NewWebhookNotifier creates a new webhook notifier, detecting the provider from the host of the URL when not set.
+func NewWebhookNotifier(cfg WebhookConfig) (*WebhookNotifier, error) {
+	location, err := url.Parse(cfg.URL)
+	if err != nil || (location.Scheme != "https" && location.Scheme != "http") || location.Host == "" {
+		// The URL holds the credentials of the webhook and is left out of the error
+		return nil, errors.New("invalid webhook URL: expected an https:// URL")
+	}
+
+	switch cfg.Provider = strings.ToLower(cfg.Provider); cfg.Provider {
+	case ProviderSlack, ProviderTeams:
+	case "":
+		if cfg.Provider = DetectProvider(location.Host); cfg.Provider == "" {
+			return nil, fmt.Errorf("cannot tell the provider of the webhook at %s, set it to %s or %s",
+				location.Host, ProviderSlack, ProviderTeams)
+		}
+	default:
+		return nil, fmt.Errorf("unknown webhook provider %q, expected %s or %s", cfg.Provider, ProviderSlack, ProviderTeams)
+	}
+
+	return &WebhookNotifier{cfg: cfg, client: &http.Client{Timeout: webhookTimeout}}, nil
+}
+
+// DetectProvider returns the provider of the webhooks served by a host, empty when it is not known.
+func DetectProvider(host string) string {
+	host = strings.ToLower(host)
+
+	switch {
+	case strings.HasSuffix(host, "slack.com"):
+		return ProviderSlack
+	case strings.HasSuffix(host, "office.com"), strings.HasSuffix(host, "logic.azure.com"):
+		// Office 365 connectors, and the Power Automate workflows replacing them
+		return ProviderTeams
+	default:
+		return ""
+	}
+}
+
+// Notify posts a notification to the webhook.
+func (n *WebhookNotifier) Notify(ctx context.Context, notification domain.Notification) error {
+	var payload any = slackMessage(notification)
+	if n.cfg.Provider == ProviderTeams {
+		payload = teamsMessage(notification)
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode notification: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.cfg.URL, bytes.NewReader(body))
+	if err != nil {
+		return errors.New("failed to create request")
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+
+		return fmt.Errorf("failed to post notification: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+
+		return fmt.Errorf("failed to post notification: status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	return nil
+}
+
+// heading returns the first line of a notification, e.g. "Pet Store 1.1.0 documentation updated".
+func heading(notification domain.Notification) string {
+	return fmt.Sprintf("%s %s documentation updated", notification.Title, notification.Version)
+}
+
+// changesTitle returns the heading of the changes of a notification, e.g. "Changes since 1.0.0".
+func changesTitle(changelog *domain.Changelog) string {
+	return "Changes since " + changelog.FromVersion
+}
+
+// breakingChanges returns the lines of the breaking changes of a changelog, at most maxBreakingChanges of them
+// followed by a count of the others.
+func breakingChanges(changelog *domain.Changelog) []string {
+	var lines []string
+
+	for _, change := range changelog.Changes {
+		switch {
+		case !change.Breaking:
+		case change.Detail == "":
+			// Endpoints and schemas added or removed as a whole
+			lines = append(lines, fmt.Sprintf("%s %s", change.Target, change.Kind))
+		default:
+			lines = append(lines, change.Target+": "+change.Detail)
+		}
+	}
+
+	if len(lines) > maxBreakingChanges {
+		lines = append(lines[:maxBreakingChanges], fmt.Sprintf("and %d more", len(lines)-maxBreakingChanges))
+	}
+
+	return lines
+}
+
+// isURL reports whether the link of a notification is a web page rather than the path of a file.
+func isURL(link string) bool {
+	return strings.HasPrefix(link, "https://") || strings.HasPrefix(link, "http://")
+}
+
+// slackMessage returns the Block Kit message of a notification, with its heading as the text shown in
+// notifications and clients without blocks.
+func slackMessage(notification domain.Notification) map[string]any {
+	fields := []map[string]any{
+		slackText("*Version*\n" + slackEscape(notification.Version)),
+		slackText("*Endpoints*\n" + strconv.Itoa(notification.Endpoints)),
+	}
+
+	if notification.Link != "" && !isURL(notification.Link) {
+		fields = append(fields, slackText("*Output*\n"+slackEscape(notification.Link)))
+	}
+
+	blocks := []map[string]any{
+		{"type": "header", "text": map[string]any{"type": "plain_text", "text": heading(notification)}},
+		{"type": "section", "fields": fields},
+	}
+
+	if changelog := notification.Changes; changelog != nil {
+		text := "*" + slackEscape(changesTitle(changelog)) + "*\n" + slackEscape(converters.ChangelogSummary(changelog))
+
+		for _, line := range breakingChanges(changelog) {
+			text += "\n• " + slackEscape(line)
+		}
+
+		blocks = append(blocks, map[string]any{"type": "section", "text": slackText(text)})
+	}
+
+	if isURL(notification.Link) {
+		blocks = append(blocks, map[string]any{"type": "section", "text": slackText("<" + notification.Link + "|View the documentation>")})
+	}
+
+	return map[string]any{"text": heading(notification), "blocks": blocks}
+}
+
+func slackText(text string) map[string]any {
+	return map[string]any{"type": "mrkdwn", "text": text}
+}
+
+// slackEscape escapes the characters Slack reads as the markup of links and mentions.
+func slackEscape(text string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(text)
+}
+
+// teamsMessage returns the Adaptive Card message of a notification.
+func teamsMessage(notification domain.Notification) map[string]any {
+	facts := []map[string]any{
+		{"title": "Version", "value": notification.Version},
+		{"title": "Endpoints", "value": strconv.Itoa(notification.Endpoints)},
+	}
+
+	if notification.Link != "" && !isURL(notification.Link) {
+		facts = append(facts, map[string]any{"title": "Output", "value": notification.Link})
+	}
+
+	body := []map[string]any{
+		{"type": "TextBlock", "text": heading(notification), "size": "Large", "weight": "Bolder", "wrap": true},
+		{"type": "FactSet", "facts": facts},
+	}
+
+	if changelog := notification.Changes; changelog != nil {
+		body = append(body,
+			map[string]any{"type": "TextBlock", "text": changesTitle(changelog), "weight": "Bolder", "wrap": true},
+			map[string]any{"type": "TextBlock", "text": converters.ChangelogSummary(changelog), "wrap": true})
+
+		if lines := breakingChanges(changelog); len(lines) > 0 {
+			body = append(body, map[string]any{"type": "TextBlock", "text": "- " + strings.Join(lines, "\n- "), "wrap": true})
+		}
+	}
+
+	card := map[string]any{
+		"$schema": "http://adaptivecards.io/schemas/adaptive-card.json",
+		"type":    "AdaptiveCard",
+		"version": "1.4",
+		"body":    body,
+	}
+
+	if isURL(notification.Link) {
+		card["actions"] = []map[string]any{{"type": "Action.OpenUrl", "title": "View the documentation", "url": notification.Link}}
+	}
+
+	return map[string]any{
+		"type":        "message",
+		"attachments": []map[string]any{{"contentType": adaptiveCardType, "content": card}},
+	}
+}
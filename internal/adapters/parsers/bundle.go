@@ -0,0 +1,313 @@
+package parsers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// SplitRootFile is the name of the root document written by Split.
+const SplitRootFile = "openapi.yaml"
+
+// componentRefPattern matches the references to the components of the same document, capturing the collection and the name.
+var componentRefPattern = regexp.MustCompile(`^#/components/([^/]+)/([^/]+)$`)
+
+// topLevelOrder is the conventional order of the top-level fields of a specification.
+var topLevelOrder = []string{"openapi", "info", "servers", "security", "tags", "externalDocs", "paths", "components"}
+
+// BundledSpec is a specification in which every reference is local, as a tree of JSON values.
+type BundledSpec struct {
+	Tree      map[string]any
+	PathOrder []string // Paths in the order of the source document, which the tree does not keep
+}
+
+// Bundle reads the OpenAPI 3.0 specification at path with every document it references through $ref
+// moved into its components.
+func Bundle(path string) (*BundledSpec, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read specification: %w", err)
+	}
+
+	// The loader reads 3.1 and 2.0 documents through conversions that would change them
+	if parser, err := Detect(data); err != nil {
+		return nil, err
+	} else if _, ok := parser.(*OpenAPIParser); !ok || isOpenAPI31(data) {
+		return nil, errors.New("only OpenAPI 3.0 specifications can be bundled and split")
+	}
+
+	loader := NewRefResolver().newLoader()
+
+	spec, err := loader.LoadFromDataWithPath(data, specLocation(path))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse OpenAPI file: %w", err)
+	}
+
+	internalizeRefs(spec)
+
+	encoded, err := json.Marshal(spec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode bundled specification: %w", err)
+	}
+
+	var tree map[string]any
+	if err := json.Unmarshal(encoded, &tree); err != nil {
+		return nil, fmt.Errorf("failed to encode bundled specification: %w", err)
+	}
+
+	return &BundledSpec{Tree: tree, PathOrder: documentKeys(data, "paths")}, nil
+}
+
+// Document returns the tree with its top-level fields and paths in order, for EncodeDocument.
+func (s *BundledSpec) Document() any {
+	root := orderMap(s.Tree, topLevelOrder)
+
+	if paths, ok := s.Tree["paths"].(map[string]any); ok {
+		root.values["paths"] = orderMap(paths, s.PathOrder)
+	}
+
+	return root
+}
+
+// Split breaks a bundled specification into a root document, named SplitRootFile, referencing one file
+// per path under paths/ and one file per component under components/<collection>/, by relative file path.
+// References between the parts are rewritten relative to the file they appear in.
+func (s *BundledSpec) Split() map[string]any {
+	root := &BundledSpec{Tree: make(map[string]any, len(s.Tree)), PathOrder: s.PathOrder}
+	for key, value := range s.Tree {
+		root.Tree[key] = value
+	}
+
+	files := make(map[string]any)
+
+	components, _ := s.Tree["components"].(map[string]any)
+	if len(components) > 0 {
+		rootComponents := make(map[string]any, len(components))
+
+		for _, collection := range slices.Sorted(maps.Keys(components)) {
+			items, ok := components[collection].(map[string]any)
+			if !ok {
+				rootComponents[collection] = components[collection]
+
+				continue
+			}
+
+			refs := make(map[string]any, len(items))
+
+			for _, name := range slices.Sorted(maps.Keys(items)) {
+				file := path.Join("components", collection, name+".yaml")
+				files[file] = relativeRefs(items[name], path.Dir(file))
+				refs[name] = map[string]any{"$ref": file}
+			}
+
+			rootComponents[collection] = refs
+		}
+
+		root.Tree["components"] = rootComponents
+	}
+
+	paths, _ := s.Tree["paths"].(map[string]any)
+	if len(paths) > 0 {
+		rootPaths := make(map[string]any, len(paths))
+		used := make(map[string]struct{})
+
+		for _, name := range orderMap(paths, s.PathOrder).keys {
+			file := uniqueFile(used, "paths", pathFileName(name))
+			files[file] = relativeRefs(paths[name], "paths")
+			rootPaths[name] = map[string]any{"$ref": file}
+		}
+
+		root.Tree["paths"] = rootPaths
+	}
+
+	files[SplitRootFile] = root.Document()
+
+	return files
+}
+
+// EncodeDocument encodes a tree of JSON values as indented JSON, or as YAML when asYAML is set.
+func EncodeDocument(tree any, asYAML bool) ([]byte, error) {
+	if !asYAML {
+		data, err := json.MarshalIndent(tree, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode JSON: %w", err)
+		}
+
+		return append(data, '\n'), nil
+	}
+
+	var buf bytes.Buffer
+
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2) //nolint:mnd // conventional indentation of specifications
+
+	if err := encoder.Encode(tree); err != nil {
+		return nil, fmt.Errorf("failed to encode YAML: %w", err)
+	}
+
+	if err := encoder.Close(); err != nil {
+		return nil, fmt.Errorf("failed to encode YAML: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// orderedMap is a JSON object encoded with its keys in a given order rather than sorted.
+type orderedMap struct {
+	keys   []string
+	values map[string]any
+}
+
+// orderMap returns the object with the keys listed in order first, in that order, followed by the others sorted.
+func orderMap(values map[string]any, order []string) *orderedMap {
+	keys := make([]string, 0, len(values))
+
+	for _, key := range order {
+		if _, ok := values[key]; ok && !slices.Contains(keys, key) {
+			keys = append(keys, key)
+		}
+	}
+
+	for _, key := range slices.Sorted(maps.Keys(values)) {
+		if !slices.Contains(keys, key) {
+			keys = append(keys, key)
+		}
+	}
+
+	copied := make(map[string]any, len(values))
+	for key, value := range values {
+		copied[key] = value
+	}
+
+	return &orderedMap{keys: keys, values: copied}
+}
+
+// MarshalJSON implements json.Marshaler.
+func (m *orderedMap) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+
+	buf.WriteByte('{')
+
+	for i, key := range m.keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+
+		encodedKey, err := json.Marshal(key)
+		if err != nil {
+			return nil, err //nolint:wrapcheck // reported by the outer Marshal call
+		}
+
+		value, err := json.Marshal(m.values[key])
+		if err != nil {
+			return nil, err //nolint:wrapcheck // reported by the outer Marshal call
+		}
+
+		buf.Write(encodedKey)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+
+	buf.WriteByte('}')
+
+	return buf.Bytes(), nil
+}
+
+// MarshalYAML implements yaml.Marshaler.
+func (m *orderedMap) MarshalYAML() (any, error) {
+	node := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+
+	for _, key := range m.keys {
+		value := &yaml.Node{}
+		if err := value.Encode(m.values[key]); err != nil {
+			return nil, err //nolint:wrapcheck // reported by the outer Encode call
+		}
+
+		node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, value)
+	}
+
+	return node, nil
+}
+
+// relativeRefs returns a copy of a tree with the references to components rewritten as paths to their files,
+// relative to the directory dir of the file holding the tree.
+func relativeRefs(node any, dir string) any {
+	switch value := node.(type) {
+	case map[string]any:
+		rewritten := make(map[string]any, len(value))
+
+		for key, child := range value {
+			if ref, ok := child.(string); ok && key == "$ref" {
+				rewritten[key] = relativeRef(ref, dir)
+
+				continue
+			}
+
+			rewritten[key] = relativeRefs(child, dir)
+		}
+
+		return rewritten
+	case []any:
+		rewritten := make([]any, 0, len(value))
+		for _, child := range value {
+			rewritten = append(rewritten, relativeRefs(child, dir))
+		}
+
+		return rewritten
+	default:
+		return node
+	}
+}
+
+// relativeRef rewrites a reference to a component as the path to its file from dir, leaving other references alone.
+func relativeRef(ref, dir string) string {
+	match := componentRefPattern.FindStringSubmatch(ref)
+	if match == nil {
+		return ref
+	}
+
+	target := path.Join("components", match[1], match[2]+".yaml")
+
+	if dir == path.Dir(target) {
+		return "./" + path.Base(target)
+	}
+
+	// Walk up from dir to the root of the split documents, then down to the target
+	return strings.Repeat("../", strings.Count(dir, "/")+1) + target
+}
+
+// pathFileName derives the file name of a path, e.g. "/pets/{petId}" becomes "pets_petId.yaml".
+func pathFileName(name string) string {
+	base := strings.NewReplacer("{", "", "}", "").Replace(strings.Trim(name, "/"))
+	base = strings.ReplaceAll(base, "/", "_")
+
+	if base == "" {
+		base = "root"
+	}
+
+	return base + ".yaml"
+}
+
+// uniqueFile returns dir/name, adding a suffix when an earlier file took that name.
+func uniqueFile(used map[string]struct{}, dir, name string) string {
+	base := strings.TrimSuffix(name, ".yaml")
+
+	for i := 2; ; i++ {
+		if _, taken := used[name]; !taken {
+			used[name] = struct{}{}
+
+			return path.Join(dir, name)
+		}
+
+		name = fmt.Sprintf("%s_%d.yaml", base, i)
+	}
+}
@@ -0,0 +1,68 @@
+package parsers
+
+import "gopkg.in/yaml.v3"
+
+// SourceMap finds the lines of the nodes of a JSON or YAML specification.
+type SourceMap struct {
+	root *yaml.Node
+}
+
+// NewSourceMap indexes the specification data. Malformed data yields a map that knows no lines.
+func NewSourceMap(data []byte) *SourceMap {
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil || len(root.Content) == 0 {
+		return &SourceMap{}
+	}
+
+	return &SourceMap{root: root.Content[0]}
+}
+
+// Line returns the line of the node reached by following pointer from the document root. When the node does
+// not exist, such as a default added by the parser, the line of its closest existing parent is returned.
+// Components of Swagger 2.0 documents are looked up under their original locations ("definitions").
+// It returns 0 when nothing is known.
+func (m *SourceMap) Line(pointer []string) int {
+	if m.root == nil {
+		return 0
+	}
+
+	if len(pointer) >= 2 && pointer[0] == "components" && pointer[1] == "schemas" && !hasKey(m.root, "components") {
+		pointer = append([]string{"definitions"}, pointer[2:]...)
+	}
+
+	node := m.root
+	line := node.Line
+
+	for _, key := range pointer {
+		keyNode, value := lookup(node, key)
+		if keyNode == nil {
+			break
+		}
+
+		// Report the line holding the key rather than the first entry of its value
+		node, line = value, keyNode.Line
+	}
+
+	return line
+}
+
+func hasKey(node *yaml.Node, key string) bool {
+	keyNode, _ := lookup(node, key)
+
+	return keyNode != nil
+}
+
+// lookup returns the key and value nodes of key in a mapping node, or nil when it is missing.
+func lookup(node *yaml.Node, key string) (*yaml.Node, *yaml.Node) {
+	if node.Kind != yaml.MappingNode {
+		return nil, nil
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i], node.Content[i+1]
+		}
+	}
+
+	return nil, nil
+}
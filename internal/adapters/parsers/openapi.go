@@ -0,0 +1,554 @@
+package parsers
+
+import (
+	"fmt"
+	"maps"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/GabrielNunesIT/openapi-converter/internal/domain"
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// OpenAPIParser parses OpenAPI 3.x specifications.
+type OpenAPIParser struct {
+	visiting map[*openapi3.Schema]struct{} // Schemas on the current conversion path, used to break cycles
+	security openapi3.SecurityRequirements // Document-wide requirements inherited by operations
+	resolver *RefResolver                  // Reference resolver of the last parsed document
+}
+
+// NewOpenAPIParser creates a new OpenAPI 3.x parser.
+func NewOpenAPIParser() *OpenAPIParser {
+	return &OpenAPIParser{}
+}
+
+// Parse maps an OpenAPI 3.x document into the domain model, resolving references relative to path.
+func (p *OpenAPIParser) Parse(data []byte, path string) (*domain.OpenAPIDocument, error) {
+	// Normalization re-encodes the document, so the path order is read beforehand
+	pathOrder := documentKeys(data, "paths")
+
+	if isOpenAPI31(data) {
+		normalized, err := normalizeOpenAPI31(data)
+		if err != nil {
+			return nil, err
+		}
+
+		data = normalized
+	}
+
+	p.resolver = NewRefResolver()
+	loader := p.resolver.newLoader()
+	location := specLocation(path)
+
+	spec, err := loader.LoadFromDataWithPath(data, location)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse OpenAPI file: %w", err)
+	}
+
+	internalizeRefs(spec)
+
+	doc := p.convertSpec(spec, pathOrder)
+
+	doc.Webhooks, err = p.convertWebhooks(loader, spec, location)
+	if err != nil {
+		return nil, err
+	}
+
+	return doc, nil
+}
+
+// Sources returns the referenced documents read while parsing the last specification.
+func (p *OpenAPIParser) Sources() []string {
+	if p.resolver == nil {
+		return nil
+	}
+
+	return p.resolver.Locations()
+}
+
+// specLocation returns the URL used to resolve relative references of a specification.
+// Without a path, references are resolved against the working directory.
+func specLocation(path string) *url.URL {
+	if path == "" {
+		if wd, err := os.Getwd(); err == nil {
+			return &url.URL{Path: filepath.ToSlash(wd) + "/"}
+		}
+
+		path = "."
+	}
+
+	if absPath, err := filepath.Abs(path); err == nil {
+		path = absPath
+	}
+
+	return &url.URL{Path: filepath.ToSlash(path)}
+}
+
+// convertSpec maps a loaded specification into the domain model, keeping paths in the given order.
+func (p *OpenAPIParser) convertSpec(spec *openapi3.T, pathOrder []string) *domain.OpenAPIDocument {
+	p.visiting = make(map[*openapi3.Schema]struct{})
+	p.security = spec.Security
+
+	doc := &domain.OpenAPIDocument{
+		Title:          spec.Info.Title,
+		Version:        spec.Info.Version,
+		Description:    spec.Info.Description,
+		ExternalDocs:   convertExternalDocs(spec.ExternalDocs),
+		TermsOfService: spec.Info.TermsOfService,
+		Components:     make(map[string]domain.Schema),
+		Extensions:     vendorExtensions(spec.Info.Extensions),
+	}
+
+	if contact := spec.Info.Contact; contact != nil {
+		doc.Contact = &domain.Contact{Name: contact.Name, Email: contact.Email, URL: contact.URL}
+	}
+
+	if license := spec.Info.License; license != nil {
+		doc.License = &domain.License{Name: license.Name, URL: license.URL}
+	}
+
+	// Convert servers
+	for _, server := range spec.Servers {
+		doc.Servers = append(doc.Servers, domain.Server{
+			URL:         server.URL,
+			Description: server.Description,
+		})
+	}
+
+	// Convert tags in the order they are declared
+	for _, tag := range spec.Tags {
+		if tag == nil {
+			continue
+		}
+
+		doc.Tags = append(doc.Tags, domain.Tag{
+			Name:         tag.Name,
+			Description:  tag.Description,
+			ExternalDocs: convertExternalDocs(tag.ExternalDocs),
+		})
+	}
+
+	// Convert paths in the order they are written
+	for _, pathStr := range orderKeys(slices.Collect(maps.Keys(spec.Paths.Map())), pathOrder) {
+		path := domain.Path{Path: pathStr}
+
+		path.Operations = p.convertOperations(spec.Paths.Value(pathStr))
+		doc.Paths = append(doc.Paths, path)
+	}
+
+	// Convert components/schemas
+	if spec.Components != nil && spec.Components.Schemas != nil {
+		for name, schemaRef := range spec.Components.Schemas {
+			doc.Components[name] = p.convertSchema(schemaRef)
+		}
+	}
+
+	// Convert components/securitySchemes
+	if spec.Components != nil && len(spec.Components.SecuritySchemes) > 0 {
+		doc.SecuritySchemes = make(map[string]domain.SecurityScheme)
+
+		for name, schemeRef := range spec.Components.SecuritySchemes {
+			if schemeRef == nil || schemeRef.Value == nil {
+				continue
+			}
+
+			doc.SecuritySchemes[name] = convertSecurityScheme(schemeRef.Value)
+		}
+	}
+
+	return doc
+}
+
+func (p *OpenAPIParser) convertOperations(pathItem *openapi3.PathItem) []domain.Operation {
+	var operations []domain.Operation
+
+	methods := []struct {
+		name string
+		op   *openapi3.Operation
+	}{
+		{"GET", pathItem.Get},
+		{"POST", pathItem.Post},
+		{"PUT", pathItem.Put},
+		{"PATCH", pathItem.Patch},
+		{"DELETE", pathItem.Delete},
+		{"HEAD", pathItem.Head},
+		{"OPTIONS", pathItem.Options},
+	}
+
+	for _, method := range methods {
+		op := method.op
+		if op == nil {
+			continue
+		}
+
+		operation := domain.Operation{
+			Method:       method.name,
+			Summary:      op.Summary,
+			Description:  op.Description,
+			OperationID:  op.OperationID,
+			Deprecated:   op.Deprecated,
+			ExternalDocs: convertExternalDocs(op.ExternalDocs),
+			Tags:         op.Tags,
+			Extensions:   vendorExtensions(op.Extensions),
+		}
+
+		// Operations without their own requirements inherit the document-wide ones
+		security := p.security
+		if op.Security != nil {
+			security = *op.Security
+		}
+
+		for _, requirement := range security {
+			req := make(domain.SecurityRequirement, len(requirement))
+			for name, scopes := range requirement {
+				req[name] = scopes
+			}
+
+			operation.Security = append(operation.Security, req)
+		}
+
+		// Convert parameters
+		for _, param := range op.Parameters {
+			if param.Value == nil {
+				continue
+			}
+
+			operation.Parameters = append(operation.Parameters, domain.Parameter{
+				Name:        param.Value.Name,
+				In:          param.Value.In,
+				Description: param.Value.Description,
+				Required:    param.Value.Required,
+				Deprecated:  param.Value.Deprecated,
+				Schema:      p.convertSchema(param.Value.Schema),
+			})
+		}
+
+		// Convert responses
+		if op.Responses != nil {
+			for statusCode, response := range op.Responses.Map() {
+				if response.Value == nil {
+					continue
+				}
+
+				resp := domain.Response{
+					StatusCode: statusCode,
+				}
+
+				if response.Value.Description != nil {
+					resp.Description = *response.Value.Description
+				}
+
+				resp.Content = p.convertContent(response.Value.Content)
+				resp.Headers = p.convertHeaders(response.Value.Headers)
+				resp.Links = convertLinks(response.Value.Links)
+				operation.Responses = append(operation.Responses, resp)
+			}
+		}
+
+		// Convert request body
+		if op.RequestBody != nil && op.RequestBody.Value != nil {
+			operation.RequestBody = &domain.RequestBody{
+				Description: op.RequestBody.Value.Description,
+				Required:    op.RequestBody.Value.Required,
+				Content:     p.convertContent(op.RequestBody.Value.Content),
+			}
+		}
+
+		operation.Callbacks = p.convertCallbacks(op.Callbacks)
+		operations = append(operations, operation)
+	}
+
+	return operations
+}
+
+// convertCallbacks maps the callbacks of an operation, sorted by name and then by URL expression.
+func (p *OpenAPIParser) convertCallbacks(callbacks openapi3.Callbacks) []domain.Callback {
+	var result []domain.Callback
+
+	names := slices.Sorted(maps.Keys(callbacks))
+
+	for _, name := range names {
+		ref := callbacks[name]
+		if ref == nil || ref.Value == nil {
+			continue
+		}
+
+		items := ref.Value.Map()
+		expressions := slices.Sorted(maps.Keys(items))
+
+		for _, expression := range expressions {
+			result = append(result, domain.Callback{
+				Name:       name,
+				Expression: expression,
+				Operations: p.convertOperations(items[expression]),
+			})
+		}
+	}
+
+	return result
+}
+
+// convertHeaders maps the headers of a response, sorted by name.
+func (p *OpenAPIParser) convertHeaders(headers openapi3.Headers) []domain.Header {
+	var result []domain.Header
+
+	names := slices.Sorted(maps.Keys(headers))
+
+	for _, name := range names {
+		ref := headers[name]
+		if ref == nil || ref.Value == nil {
+			continue
+		}
+
+		result = append(result, domain.Header{
+			Name:        name,
+			Description: ref.Value.Description,
+			Required:    ref.Value.Required,
+			Deprecated:  ref.Value.Deprecated,
+			Schema:      p.convertSchema(ref.Value.Schema),
+		})
+	}
+
+	return result
+}
+
+// convertLinks maps the links of a response, sorted by name.
+func convertLinks(links openapi3.Links) []domain.Link {
+	var result []domain.Link
+
+	names := slices.Sorted(maps.Keys(links))
+
+	for _, name := range names {
+		ref := links[name]
+		if ref == nil || ref.Value == nil {
+			continue
+		}
+
+		result = append(result, domain.Link{
+			Name:         name,
+			Description:  ref.Value.Description,
+			OperationID:  ref.Value.OperationID,
+			OperationRef: ref.Value.OperationRef,
+			Parameters:   ref.Value.Parameters,
+			RequestBody:  ref.Value.RequestBody,
+		})
+	}
+
+	return result
+}
+
+func convertSecurityScheme(scheme *openapi3.SecurityScheme) domain.SecurityScheme {
+	result := domain.SecurityScheme{
+		Type:             scheme.Type,
+		Description:      scheme.Description,
+		Name:             scheme.Name,
+		In:               scheme.In,
+		Scheme:           scheme.Scheme,
+		BearerFormat:     scheme.BearerFormat,
+		OpenIDConnectURL: scheme.OpenIdConnectUrl,
+	}
+
+	if scheme.Flows == nil {
+		return result
+	}
+
+	flows := []struct {
+		flowType string
+		flow     *openapi3.OAuthFlow
+	}{
+		{"implicit", scheme.Flows.Implicit},
+		{"password", scheme.Flows.Password},
+		{"clientCredentials", scheme.Flows.ClientCredentials},
+		{"authorizationCode", scheme.Flows.AuthorizationCode},
+	}
+
+	for _, f := range flows {
+		if f.flow == nil {
+			continue
+		}
+
+		result.Flows = append(result.Flows, domain.OAuthFlow{
+			Type:             f.flowType,
+			AuthorizationURL: f.flow.AuthorizationURL,
+			TokenURL:         f.flow.TokenURL,
+			RefreshURL:       f.flow.RefreshURL,
+			Scopes:           f.flow.Scopes,
+		})
+	}
+
+	return result
+}
+
+func (p *OpenAPIParser) convertContent(content openapi3.Content) map[string]domain.MediaType {
+	result := make(map[string]domain.MediaType)
+
+	for mediaType, item := range content {
+		media := domain.MediaType{
+			Schema:  p.convertSchema(item.Schema),
+			Example: item.Example,
+		}
+
+		for name, exampleRef := range item.Examples {
+			if exampleRef == nil || exampleRef.Value == nil {
+				continue
+			}
+
+			if media.Examples == nil {
+				media.Examples = make(map[string]domain.Example)
+			}
+
+			media.Examples[name] = domain.Example{
+				Summary:     exampleRef.Value.Summary,
+				Description: exampleRef.Value.Description,
+				Value:       exampleRef.Value.Value,
+			}
+		}
+
+		result[mediaType] = media
+	}
+
+	return result
+}
+
+func (p *OpenAPIParser) convertSchema(ref *openapi3.SchemaRef) domain.Schema {
+	if ref == nil {
+		return domain.Schema{}
+	}
+
+	schema := domain.Schema{
+		Ref: ref.Ref,
+	}
+
+	// Self-referencing schemas keep only their reference once the cycle is detected
+	if _, cyclic := p.visiting[ref.Value]; cyclic && ref.Ref != "" {
+		return schema
+	}
+
+	if ref.Value != nil {
+		p.visiting[ref.Value] = struct{}{}
+		defer delete(p.visiting, ref.Value)
+
+		// OpenAPI 3.1 expresses nullability as a "null" member of a type array
+		var types []string
+		for _, t := range ref.Value.Type.Slice() {
+			if t == openapi3.TypeNull {
+				schema.Nullable = true
+
+				continue
+			}
+
+			types = append(types, t)
+		}
+
+		schema.Type = strings.Join(types, " | ")
+		schema.Format = ref.Value.Format
+		schema.Description = ref.Value.Description
+		schema.Nullable = schema.Nullable || ref.Value.Nullable
+		schema.ReadOnly = ref.Value.ReadOnly
+		schema.WriteOnly = ref.Value.WriteOnly
+		schema.Deprecated = ref.Value.Deprecated
+		schema.ExternalDocs = convertExternalDocs(ref.Value.ExternalDocs)
+		schema.Const = ref.Value.Extensions["const"]
+		schema.Enum = ref.Value.Enum
+		schema.Default = ref.Value.Default
+		schema.Minimum = ref.Value.Min
+		schema.Maximum = ref.Value.Max
+		schema.ExclusiveMinimum = ref.Value.ExclusiveMin
+		schema.ExclusiveMaximum = ref.Value.ExclusiveMax
+		schema.MinLength = ref.Value.MinLength
+		schema.MaxLength = ref.Value.MaxLength
+		schema.Pattern = ref.Value.Pattern
+		schema.MinItems = ref.Value.MinItems
+		schema.MaxItems = ref.Value.MaxItems
+		schema.UniqueItems = ref.Value.UniqueItems
+		schema.Extensions = vendorExtensions(ref.Value.Extensions)
+
+		if ref.Value.Example != nil {
+			schema.Examples = append(schema.Examples, ref.Value.Example)
+		}
+
+		if examples, ok := ref.Value.Extensions["examples"].([]any); ok {
+			schema.Examples = append(schema.Examples, examples...)
+		}
+
+		// Convert properties
+		if len(ref.Value.Properties) > 0 {
+			schema.Properties = make(map[string]domain.Schema)
+
+			for name, prop := range ref.Value.Properties {
+				schema.Properties[name] = p.convertSchema(prop)
+			}
+		}
+
+		schema.Required = ref.Value.Required
+
+		// Convert items for arrays
+		if ref.Value.Items != nil {
+			itemSchema := p.convertSchema(ref.Value.Items)
+			schema.Items = &itemSchema
+		}
+
+		// Convert composition keywords
+		schema.AllOf = p.convertSchemas(ref.Value.AllOf)
+		schema.AnyOf = p.convertSchemas(ref.Value.AnyOf)
+		schema.OneOf = p.convertSchemas(ref.Value.OneOf)
+
+		if d := ref.Value.Discriminator; d != nil {
+			schema.Discriminator = &domain.Discriminator{
+				PropertyName: d.PropertyName,
+				Mapping:      make(map[string]string, len(d.Mapping)),
+			}
+
+			for value, mapped := range d.Mapping {
+				schema.Discriminator.Mapping[value] = mapped
+			}
+		}
+	}
+
+	return schema
+}
+
+func (p *OpenAPIParser) convertSchemas(refs openapi3.SchemaRefs) []domain.Schema {
+	if len(refs) == 0 {
+		return nil
+	}
+
+	schemas := make([]domain.Schema, 0, len(refs))
+	for _, ref := range refs {
+		schemas = append(schemas, p.convertSchema(ref))
+	}
+
+	return schemas
+}
+
+// convertExternalDocs maps a link to external documentation, nil when there is none.
+func convertExternalDocs(docs *openapi3.ExternalDocs) *domain.ExternalDocs {
+	if docs == nil || docs.URL == "" {
+		return nil
+	}
+
+	return &domain.ExternalDocs{URL: docs.URL, Description: docs.Description}
+}
+
+// vendorExtensions returns the x- fields of an object. The loader also keeps the keywords it does not know,
+// such as the OpenAPI 3.1 "const" and "examples", among the extensions, so those are left out.
+func vendorExtensions(extensions map[string]any) map[string]any {
+	var result map[string]any
+
+	for name, value := range extensions {
+		if !strings.HasPrefix(name, "x-") {
+			continue
+		}
+
+		if result == nil {
+			result = make(map[string]any)
+		}
+
+		result[name] = value
+	}
+
+	return result
+}
@@ -0,0 +1,112 @@
+package parsers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/GabrielNunesIT/openapi-converter/internal/domain"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/oasdiff/yaml"
+)
+
+// isOpenAPI31 reports whether the raw specification declares an OpenAPI 3.1 version.
+func isOpenAPI31(data []byte) bool {
+	var version specVersion
+	if err := yaml.Unmarshal(data, &version); err != nil {
+		return false
+	}
+
+	return strings.HasPrefix(version.OpenAPI, "3.1")
+}
+
+// normalizeOpenAPI31 rewrites JSON Schema 2020-12 keywords that the OpenAPI 3.0 loader cannot read.
+// Numeric exclusiveMinimum/exclusiveMaximum values become a minimum/maximum plus the boolean flag.
+func normalizeOpenAPI31(data []byte) ([]byte, error) {
+	var root any
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return nil, fmt.Errorf("failed to read OpenAPI 3.1 document: %w", err)
+	}
+
+	normalizeNode(root)
+
+	normalized, err := json.Marshal(root)
+	if err != nil {
+		return nil, fmt.Errorf("failed to normalize OpenAPI 3.1 document: %w", err)
+	}
+
+	return normalized, nil
+}
+
+func normalizeNode(node any) {
+	switch value := node.(type) {
+	case map[string]any:
+		for keyword, bound := range map[string]string{"exclusiveMinimum": "minimum", "exclusiveMaximum": "maximum"} {
+			if limit, ok := value[keyword].(float64); ok {
+				value[bound] = limit
+				value[keyword] = true
+			}
+		}
+
+		for _, child := range value {
+			normalizeNode(child)
+		}
+	case []any:
+		for _, child := range value {
+			normalizeNode(child)
+		}
+	}
+}
+
+// convertWebhooks maps the top-level webhooks object, which the loader keeps as a raw extension.
+// Webhook path items are resolved against the components of the enclosing document.
+func (p *OpenAPIParser) convertWebhooks(loader *openapi3.Loader, spec *openapi3.T, location *url.URL) ([]domain.Webhook, error) {
+	raw, ok := spec.Extensions["webhooks"]
+	if !ok {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read webhooks: %w", err)
+	}
+
+	var items map[string]*openapi3.PathItem
+	if err := json.Unmarshal(data, &items); err != nil {
+		return nil, fmt.Errorf("failed to parse webhooks: %w", err)
+	}
+
+	// Resolve references through a document that shares the spec components
+	holder := &openapi3.T{
+		OpenAPI:    spec.OpenAPI,
+		Info:       spec.Info,
+		Components: spec.Components,
+		Paths:      openapi3.NewPaths(),
+	}
+
+	for name, item := range items {
+		holder.Paths.Set("/"+name, item)
+	}
+
+	if err := loader.ResolveRefsIn(holder, location); err != nil {
+		return nil, fmt.Errorf("failed to resolve webhook references: %w", err)
+	}
+
+	names := make([]string, 0, len(items))
+	for name := range items {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	webhooks := make([]domain.Webhook, 0, len(names))
+	for _, name := range names {
+		webhooks = append(webhooks, domain.Webhook{
+			Name:       name,
+			Operations: p.convertOperations(items[name]),
+		})
+	}
+
+	return webhooks, nil
+}
@@ -0,0 +1,71 @@
+package parsers
+
+import (
+	"sort"
+
+	"gopkg.in/yaml.v3"
+)
+
+// documentKeys returns the keys of a top-level mapping of a JSON or YAML document, such as "paths",
+// in the order they are written. Decoding into Go maps loses that order.
+// A missing or malformed section yields no keys.
+func documentKeys(data []byte, section string) []string {
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil || len(root.Content) == 0 {
+		return nil
+	}
+
+	document := root.Content[0]
+	if document.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	for i := 0; i+1 < len(document.Content); i += 2 {
+		if document.Content[i].Value != section {
+			continue
+		}
+
+		mapping := document.Content[i+1]
+		if mapping.Kind != yaml.MappingNode {
+			return nil
+		}
+
+		keys := make([]string, 0, len(mapping.Content)/2)
+		for j := 0; j+1 < len(mapping.Content); j += 2 {
+			keys = append(keys, mapping.Content[j].Value)
+		}
+
+		return keys
+	}
+
+	return nil
+}
+
+// orderKeys sorts keys by their position in order. Keys missing from order follow, alphabetically.
+func orderKeys(keys, order []string) []string {
+	position := make(map[string]int, len(order))
+	for i, key := range order {
+		if _, seen := position[key]; !seen {
+			position[key] = i
+		}
+	}
+
+	sorted := make([]string, len(keys))
+	copy(sorted, keys)
+
+	sort.Slice(sorted, func(i, j int) bool {
+		pi, iKnown := position[sorted[i]]
+		pj, jKnown := position[sorted[j]]
+
+		switch {
+		case iKnown && jKnown:
+			return pi < pj
+		case iKnown != jKnown:
+			return iKnown
+		default:
+			return sorted[i] < sorted[j]
+		}
+	})
+
+	return sorted
+}
@@ -0,0 +1,111 @@
+// Package parsers provides implementations for reading API specifications into the domain model.
+package parsers
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/GabrielNunesIT/openapi-converter/internal/domain"
+	"github.com/oasdiff/yaml"
+)
+
+// specVersion holds the top-level version markers of an OpenAPI or Swagger document.
+type specVersion struct {
+	Swagger string `json:"swagger"`
+	OpenAPI string `json:"openapi"`
+}
+
+// sourceTracker is implemented by parsers that record the documents read while resolving references.
+type sourceTracker interface {
+	Sources() []string
+}
+
+// ParseFile reads the specification at path and parses it with the parser matching its version.
+func ParseFile(path string) (*domain.OpenAPIDocument, error) {
+	doc, _, err := ParseFileWithSources(path)
+
+	return doc, err
+}
+
+// ParseFileWithSources parses the specification at path like ParseFile and also returns
+// the locations it was assembled from: the file itself followed by every referenced document.
+func ParseFileWithSources(path string) (*domain.OpenAPIDocument, []string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read specification: %w", err)
+	}
+
+	return parseWithSources(data, path)
+}
+
+// ParseReader reads a JSON or YAML specification from r, such as standard input.
+// Relative references are resolved against the working directory.
+func ParseReader(r io.Reader) (*domain.OpenAPIDocument, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read specification: %w", err)
+	}
+
+	doc, _, err := parseWithSources(data, "")
+
+	return doc, err
+}
+
+func parseWithSources(data []byte, path string) (*domain.OpenAPIDocument, []string, error) {
+	parser, err := Detect(data)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	doc, err := parser.Parse(data, path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	sources := []string{}
+	if path != "" {
+		sources = append(sources, path)
+	}
+
+	if tracker, ok := parser.(sourceTracker); ok {
+		sources = append(sources, tracker.Sources()...)
+	}
+
+	return doc, sources, nil
+}
+
+// IsSpecification reports whether JSON or YAML data has the openapi or swagger root key of a specification, of any
+// version, as opposed to another document such as a package.json. Data that does not parse is reported as one, so
+// that reading it reports the error.
+func IsSpecification(data []byte) bool {
+	var version specVersion
+	if err := yaml.Unmarshal(data, &version); err != nil {
+		return true
+	}
+
+	return version.Swagger != "" || version.OpenAPI != ""
+}
+
+// Detect returns the parser able to read the given JSON or YAML specification.
+func Detect(data []byte) (domain.Parser, error) {
+	var version specVersion
+	if err := yaml.Unmarshal(data, &version); err != nil {
+		return nil, fmt.Errorf("failed to read specification version: %w", err)
+	}
+
+	switch {
+	case strings.HasPrefix(version.Swagger, "2."):
+		return NewSwaggerParser(), nil
+	case strings.HasPrefix(version.OpenAPI, "3."):
+		return NewOpenAPIParser(), nil
+	case version.Swagger != "":
+		return nil, fmt.Errorf("unsupported swagger version: %s", version.Swagger)
+	case version.OpenAPI != "":
+		return nil, fmt.Errorf("unsupported openapi version: %s", version.OpenAPI)
+	default:
+		return nil, errors.New("document is neither an OpenAPI 3.x nor a Swagger 2.0 specification")
+	}
+}
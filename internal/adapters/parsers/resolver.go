@@ -0,0 +1,234 @@
+package parsers
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/url"
+	"path"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+const refFetchTimeout = 30 * time.Second
+
+// RefResolver reads the documents referenced through $ref from local files and HTTP(S) URLs.
+// Every location is fetched at most once per resolver and recorded for later inspection.
+type RefResolver struct {
+	read      openapi3.ReadFromURIFunc
+	mu        sync.Mutex
+	cache     map[string][]byte
+	locations []string
+}
+
+// NewRefResolver creates a new reference resolver.
+func NewRefResolver() *RefResolver {
+	client := &http.Client{Timeout: refFetchTimeout}
+
+	return &RefResolver{
+		read:  openapi3.ReadFromURIs(openapi3.ReadFromHTTP(client), openapi3.ReadFromFile),
+		cache: make(map[string][]byte),
+	}
+}
+
+// ReadFromURI implements openapi3.ReadFromURIFunc with caching.
+func (r *RefResolver) ReadFromURI(loader *openapi3.Loader, location *url.URL) ([]byte, error) {
+	key := location.String()
+
+	r.mu.Lock()
+	data, ok := r.cache[key]
+	r.mu.Unlock()
+
+	if ok {
+		return data, nil
+	}
+
+	data, err := r.read(loader, location)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read referenced document %s: %w", key, err)
+	}
+
+	r.mu.Lock()
+	r.cache[key] = data
+	r.locations = append(r.locations, key)
+	r.mu.Unlock()
+
+	return data, nil
+}
+
+// Locations returns the referenced documents read so far, in the order they were fetched.
+func (r *RefResolver) Locations() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	locations := make([]string, len(r.locations))
+	copy(locations, r.locations)
+
+	return locations
+}
+
+// newLoader returns an OpenAPI loader that follows external references through the resolver.
+func (r *RefResolver) newLoader() *openapi3.Loader {
+	loader := openapi3.NewLoader()
+	loader.IsExternalRefsAllowed = true
+	loader.ReadFromURIFunc = r.ReadFromURI
+
+	return loader
+}
+
+// internalizeRefs moves components defined in other documents into the root document,
+// so that every $ref of the merged document points at a local component.
+func internalizeRefs(spec *openapi3.T) {
+	spec.InternalizeRefs(context.Background(), shortRefNames(rootComponentFiles(spec)))
+}
+
+// shortRefNames names internalized components after the last segment of their reference,
+// e.g. "models.yaml#/components/schemas/Pet" becomes "Pet", adding a suffix on collisions.
+// References to a file a root component is defined in take the name of that component.
+func shortRefNames(rootFiles map[string]map[string]string) openapi3.RefNameResolver {
+	assigned := make(map[string]string)          // Reference location to component name
+	used := make(map[string]map[string]struct{}) // Component names taken per collection
+
+	return func(doc *openapi3.T, ref openapi3.ComponentRef) string {
+		if name, found := openapi3.ReferencesComponentInRootDocument(doc, ref); found {
+			return path.Base(name)
+		}
+
+		if refPath := ref.RefPath(); refPath != nil && refPath.Fragment == "" {
+			if name, found := rootFiles[ref.CollectionName()][refPath.String()]; found {
+				return name
+			}
+		}
+
+		key := ref.RefString()
+		if refPath := ref.RefPath(); refPath != nil {
+			// Nested references may carry the fragment marker twice ("#%23/Name")
+			location := *refPath
+			location.Fragment = ""
+			key = location.String() + "#" + strings.TrimLeft(refPath.Fragment, "#")
+		}
+
+		if name, ok := assigned[key]; ok {
+			return name
+		}
+
+		collection := ref.CollectionName()
+		if used[collection] == nil {
+			used[collection] = rootComponentNames(doc, collection)
+		}
+
+		base := refBaseName(ref.RefString())
+		name := base
+
+		for i := 2; ; i++ {
+			if _, taken := used[collection][name]; !taken {
+				break
+			}
+
+			name = fmt.Sprintf("%s_%d", base, i)
+		}
+
+		assigned[key] = name
+		used[collection][name] = struct{}{}
+
+		return name
+	}
+}
+
+// refBaseName derives a component name from a reference, preferring the JSON pointer over the file name.
+func refBaseName(ref string) string {
+	location, fragment, _ := strings.Cut(ref, "#")
+
+	name := path.Base(strings.TrimRight(fragment, "/"))
+	if fragment == "" || name == "/" || name == "." {
+		name = path.Base(location)
+		name = strings.TrimSuffix(name, path.Ext(name))
+	}
+
+	return openapi3.InvalidIdentifierCharRegExp.ReplaceAllString(name, "_")
+}
+
+// rootComponentFiles maps the files that components of the root document are wholly defined in,
+// as in "Pet: {$ref: schemas/Pet.yaml}", to the names of those components, per collection.
+// They are read before internalization, which rewrites the references of the root components.
+func rootComponentFiles(doc *openapi3.T) map[string]map[string]string {
+	files := make(map[string]map[string]string)
+	if doc.Components == nil {
+		return files
+	}
+
+	addComponentFiles(files, "schemas", doc.Components.Schemas)
+	addComponentFiles(files, "parameters", doc.Components.Parameters)
+	addComponentFiles(files, "headers", doc.Components.Headers)
+	addComponentFiles(files, "requestBodies", doc.Components.RequestBodies)
+	addComponentFiles(files, "responses", doc.Components.Responses)
+	addComponentFiles(files, "securitySchemes", doc.Components.SecuritySchemes)
+	addComponentFiles(files, "examples", doc.Components.Examples)
+	addComponentFiles(files, "links", doc.Components.Links)
+	addComponentFiles(files, "callbacks", doc.Components.Callbacks)
+
+	return files
+}
+
+func addComponentFiles[V openapi3.ComponentRef](files map[string]map[string]string, collection string, components map[string]V) {
+	var missing V
+
+	for name, component := range components {
+		if any(component) == any(missing) {
+			continue
+		}
+
+		refPath := component.RefPath()
+		if refPath == nil || refPath.Fragment != "" || strings.HasPrefix(component.RefString(), "#") {
+			continue
+		}
+
+		if files[collection] == nil {
+			files[collection] = make(map[string]string)
+		}
+
+		files[collection][refPath.String()] = name
+	}
+}
+
+// rootComponentNames returns the names already declared in a components collection of the root document.
+func rootComponentNames(doc *openapi3.T, collection string) map[string]struct{} {
+	names := make(map[string]struct{})
+	if doc.Components == nil {
+		return names
+	}
+
+	var keys []string
+
+	switch collection {
+	case "schemas":
+		keys = slices.Collect(maps.Keys(doc.Components.Schemas))
+	case "parameters":
+		keys = slices.Collect(maps.Keys(doc.Components.Parameters))
+	case "headers":
+		keys = slices.Collect(maps.Keys(doc.Components.Headers))
+	case "requestBodies":
+		keys = slices.Collect(maps.Keys(doc.Components.RequestBodies))
+	case "responses":
+		keys = slices.Collect(maps.Keys(doc.Components.Responses))
+	case "securitySchemes":
+		keys = slices.Collect(maps.Keys(doc.Components.SecuritySchemes))
+	case "examples":
+		keys = slices.Collect(maps.Keys(doc.Components.Examples))
+	case "links":
+		keys = slices.Collect(maps.Keys(doc.Components.Links))
+	case "callbacks":
+		keys = slices.Collect(maps.Keys(doc.Components.Callbacks))
+	}
+
+	for _, key := range keys {
+		names[key] = struct{}{}
+	}
+
+	return names
+}
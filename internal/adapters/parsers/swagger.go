@@ -0,0 +1,53 @@
+package parsers
+
+import (
+	"fmt"
+
+	"github.com/GabrielNunesIT/openapi-converter/internal/domain"
+	"github.com/getkin/kin-openapi/openapi2"
+	"github.com/getkin/kin-openapi/openapi2conv"
+	"github.com/oasdiff/yaml"
+)
+
+// SwaggerParser parses Swagger/OpenAPI 2.0 specifications by upgrading them to OpenAPI 3.
+// Host, basePath and schemes become servers, definitions become component schemas.
+type SwaggerParser struct {
+	openapi  *OpenAPIParser
+	resolver *RefResolver // Reference resolver of the last parsed document
+}
+
+// NewSwaggerParser creates a new Swagger 2.0 parser.
+func NewSwaggerParser() *SwaggerParser {
+	return &SwaggerParser{
+		openapi: NewOpenAPIParser(),
+	}
+}
+
+// Parse maps a Swagger 2.0 document into the domain model, resolving references relative to path.
+func (p *SwaggerParser) Parse(data []byte, path string) (*domain.OpenAPIDocument, error) {
+	var spec openapi2.T
+	if err := yaml.Unmarshal(data, &spec); err != nil {
+		return nil, fmt.Errorf("failed to parse Swagger file: %w", err)
+	}
+
+	p.resolver = NewRefResolver()
+	loader := p.resolver.newLoader()
+
+	converted, err := openapi2conv.ToV3WithLoader(&spec, loader, specLocation(path))
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert Swagger file to OpenAPI 3: %w", err)
+	}
+
+	internalizeRefs(converted)
+
+	return p.openapi.convertSpec(converted, documentKeys(data, "paths")), nil
+}
+
+// Sources returns the referenced documents read while parsing the last specification.
+func (p *SwaggerParser) Sources() []string {
+	if p.resolver == nil {
+		return nil
+	}
+
+	return p.resolver.Locations()
+}
@@ -0,0 +1,454 @@
+// Package publishers provides implementations for publishing generated documents to external systems.
+package publishers
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	confluenceContentPath    = "/rest/api/content"
+	confluenceRepresentation = "atlas_doc_format"
+	confluenceTimeout        = 30 * time.Second
+
+	// confluenceHashProperty is the page property holding the hash of the content last published.
+	confluenceHashProperty = "openapi-converter-hash"
+	// confluencePartProperty is the page property holding the operations a child page documents, see Child.
+	confluencePartProperty = "openapi-converter-part"
+)
+
+// ConfluenceConfig holds the connection settings for a Confluence Cloud site.
+type ConfluenceConfig struct {
+	BaseURL  string // Site URL including the wiki context, e.g. https://example.atlassian.net/wiki
+	SpaceKey string
+	ParentID string // Optional ancestor page ID for newly created pages
+	Email    string
+	APIToken string
+
+	// RenderDiagram, when set, renders the Mermaid code blocks of the pages as PNG images attached to them
+	RenderDiagram DiagramRenderer
+
+	// Incremental stores the hash of the content of the pages in a page property, Unchanged comparing it
+	Incremental bool
+}
+
+// ConfluencePublisher creates or updates Confluence Cloud pages from ADF documents.
+type ConfluencePublisher struct {
+	cfg    ConfluenceConfig
+	client *http.Client
+	parent *confluenceParent // Page the pages are nested under, for the publishers returned by Child
+}
+
+// NewConfluencePublisher creates a new Confluence publisher.
+func NewConfluencePublisher(cfg ConfluenceConfig) *ConfluencePublisher {
+	cfg.BaseURL = strings.TrimRight(cfg.BaseURL, "/")
+
+	return &ConfluencePublisher{
+		cfg:    cfg,
+		client: &http.Client{Timeout: confluenceTimeout},
+	}
+}
+
+type confluenceBody struct {
+	AtlasDocFormat confluenceValue `json:"atlas_doc_format"`
+}
+
+type confluenceValue struct {
+	Value          string `json:"value"`
+	Representation string `json:"representation"`
+}
+
+type confluenceSpace struct {
+	Key string `json:"key"`
+}
+
+type confluenceAncestor struct {
+	ID string `json:"id"`
+}
+
+type confluenceVersion struct {
+	Number int `json:"number"`
+}
+
+type confluenceLinks struct {
+	Base  string `json:"base"`
+	WebUI string `json:"webui"`
+}
+
+type confluenceMetadata struct {
+	Properties map[string]confluenceProperty `json:"properties,omitempty"`
+}
+
+type confluenceProperty struct {
+	Key     string                  `json:"key"`
+	Value   confluencePropertyValue `json:"value"`
+	Version *confluenceVersion      `json:"version,omitempty"`
+}
+
+// confluencePropertyValue is the value of the page properties, holding either a content hash or operations.
+type confluencePropertyValue struct {
+	SHA256     string   `json:"sha256,omitempty"`
+	Operations []string `json:"operations,omitempty"`
+}
+
+type confluencePage struct {
+	ID        string               `json:"id,omitempty"`
+	Type      string               `json:"type"`
+	Title     string               `json:"title"`
+	Space     *confluenceSpace     `json:"space,omitempty"`
+	Ancestors []confluenceAncestor `json:"ancestors,omitempty"`
+	Version   *confluenceVersion   `json:"version,omitempty"`
+	Body      *confluenceBody      `json:"body,omitempty"`
+	Metadata  *confluenceMetadata  `json:"metadata,omitempty"`
+	Links     *confluenceLinks     `json:"_links,omitempty"`
+}
+
+// property returns the page property with the given key, nil when the page has none or it was not expanded.
+func (page confluencePage) property(key string) *confluenceProperty {
+	if page.Metadata == nil {
+		return nil
+	}
+
+	if property, ok := page.Metadata.Properties[key]; ok {
+		return &property
+	}
+
+	return nil
+}
+
+type confluenceSearchResult struct {
+	Results []confluencePage `json:"results"`
+}
+
+// Publish creates the page if no page with the same title exists in the space, otherwise it updates it.
+// With a diagram renderer, the Mermaid code blocks of the page are replaced by their images, attached to the page
+// before it is updated, or once it is created as attachments need the page. The publishers returned by Child also
+// rename the child page of a renamed tag, see Documents, and move the page updated under their parent page.
+func (p *ConfluencePublisher) Publish(ctx context.Context, title string, content []byte) (string, error) {
+	if err := p.validate(); err != nil {
+		return "", err
+	}
+
+	existing, err := p.findPage(ctx, title)
+	if err == nil && existing == nil && p.parent != nil {
+		existing, err = p.findRenamed(ctx, title)
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	// The hash is that of the content converted, before diagrams are replaced by images
+	hash := contentHash(content)
+
+	if existing != nil && p.cfg.RenderDiagram != nil {
+		withImages, replaced, err := p.attachDiagrams(ctx, existing.ID, content)
+		if err != nil {
+			return "", err
+		}
+
+		if replaced {
+			content = withImages
+		}
+	}
+
+	page := confluencePage{
+		Type:  "page",
+		Title: title,
+		Space: &confluenceSpace{Key: p.cfg.SpaceKey},
+		Body: &confluenceBody{
+			AtlasDocFormat: confluenceValue{
+				Value:          string(content),
+				Representation: confluenceRepresentation,
+			},
+		},
+	}
+
+	var result confluencePage
+
+	if existing == nil {
+		if p.cfg.ParentID != "" {
+			page.Ancestors = []confluenceAncestor{{ID: p.cfg.ParentID}}
+		}
+
+		err = p.do(ctx, http.MethodPost, confluenceContentPath, page, &result)
+	} else {
+		page.ID = existing.ID
+		page.Version = &confluenceVersion{Number: existing.Version.Number + 1}
+
+		if p.parent != nil {
+			page.Ancestors = []confluenceAncestor{{ID: p.parent.id}}
+		}
+
+		err = p.do(ctx, http.MethodPut, confluenceContentPath+"/"+url.PathEscape(existing.ID), page, &result)
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	// Attachments need the page, so a page just created is updated again once they are uploaded
+	if existing == nil && p.cfg.RenderDiagram != nil && result.Version != nil {
+		withImages, replaced, err := p.attachDiagrams(ctx, result.ID, content)
+		if err != nil {
+			return "", err
+		}
+
+		if replaced {
+			page.ID = result.ID
+			page.Ancestors = nil
+			page.Version = &confluenceVersion{Number: result.Version.Number + 1}
+			page.Body.AtlasDocFormat.Value = string(withImages)
+
+			if err := p.do(ctx, http.MethodPut, confluenceContentPath+"/"+url.PathEscape(result.ID), page, &result); err != nil {
+				return "", err
+			}
+		}
+	}
+
+	if err := p.storeProperties(ctx, result.ID, existing, title, hash); err != nil {
+		return "", err
+	}
+
+	return p.pageURL(result), nil
+}
+
+// storeProperties stores the hash of the content published to a page with incremental publishing, and the
+// operations it documents for the publishers returned by Child, unless the page already holds them.
+func (p *ConfluencePublisher) storeProperties(ctx context.Context, pageID string, existing *confluencePage, title, hash string) error {
+	properties := map[string]confluencePropertyValue{}
+
+	if p.cfg.Incremental {
+		properties[confluenceHashProperty] = confluencePropertyValue{SHA256: hash}
+	}
+
+	if p.parent != nil {
+		p.parent.published[title] = true
+		properties[confluencePartProperty] = confluencePropertyValue{Operations: p.parent.operations[title]}
+	}
+
+	for _, key := range slices.Sorted(maps.Keys(properties)) {
+		var previous *confluenceProperty
+		if existing != nil {
+			previous = existing.property(key)
+		}
+
+		if previous != nil && previous.Value.SHA256 == properties[key].SHA256 &&
+			slices.Equal(previous.Value.Operations, properties[key].Operations) {
+			continue
+		}
+
+		if err := p.storeProperty(ctx, pageID, previous, key, properties[key]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Unchanged reports whether the page with the given title was last published with the same content, by the hash
+// stored with incremental publishing, and its URL. Without incremental publishing every page is reported changed.
+func (p *ConfluencePublisher) Unchanged(ctx context.Context, title string, content []byte) (bool, string, error) {
+	if !p.cfg.Incremental {
+		return false, "", nil
+	}
+
+	if err := p.validate(); err != nil {
+		return false, "", err
+	}
+
+	existing, err := p.findPage(ctx, title)
+	if err != nil || existing == nil {
+		return false, "", err
+	}
+
+	// A page published before it was nested is not unchanged, as it still has to be moved under the parent page
+	property := existing.property(confluenceHashProperty)
+	if property == nil || property.Value.SHA256 != contentHash(content) ||
+		p.parent != nil && existing.property(confluencePartProperty) == nil {
+		return false, "", nil
+	}
+
+	if p.parent != nil {
+		p.parent.published[title] = true
+	}
+
+	return true, p.pageURL(*existing), nil
+}
+
+// storeProperty stores a page property, replacing previous if any.
+func (p *ConfluencePublisher) storeProperty(ctx context.Context, pageID string, previous *confluenceProperty,
+	key string, value confluencePropertyValue,
+) error {
+	property := confluenceProperty{Key: key, Value: value}
+	path := confluenceContentPath + "/" + url.PathEscape(pageID) + "/property"
+
+	if previous == nil || previous.Version == nil {
+		return p.do(ctx, http.MethodPost, path, property, nil)
+	}
+
+	property.Version = &confluenceVersion{Number: previous.Version.Number + 1}
+
+	return p.do(ctx, http.MethodPut, path+"/"+url.PathEscape(key), property, nil)
+}
+
+// contentHash returns the hex-encoded SHA-256 hash of the content of a page.
+func contentHash(content []byte) string {
+	sum := sha256.Sum256(content)
+
+	return hex.EncodeToString(sum[:])
+}
+
+// Fetch returns the ADF content of the page with the given title in the space and its URL, or nil content
+// when no page has that title.
+func (p *ConfluencePublisher) Fetch(ctx context.Context, title string) ([]byte, string, error) {
+	if err := p.validate(); err != nil {
+		return nil, "", err
+	}
+
+	existing, err := p.findPage(ctx, title)
+	if err != nil || existing == nil {
+		return nil, "", err
+	}
+
+	var page confluencePage
+
+	path := confluenceContentPath + "/" + url.PathEscape(existing.ID) + "?expand=body." + confluenceRepresentation
+	if err := p.do(ctx, http.MethodGet, path, nil, &page); err != nil {
+		return nil, "", err
+	}
+
+	if page.Body == nil {
+		return nil, "", fmt.Errorf("confluence page %q returned no %s body", title, confluenceRepresentation)
+	}
+
+	return []byte(page.Body.AtlasDocFormat.Value), p.pageURL(page), nil
+}
+
+func (p *ConfluencePublisher) validate() error {
+	var missing []string
+
+	if p.cfg.BaseURL == "" {
+		missing = append(missing, "base URL")
+	}
+
+	if p.cfg.SpaceKey == "" {
+		missing = append(missing, "space key")
+	}
+
+	if p.cfg.Email == "" {
+		missing = append(missing, "email")
+	}
+
+	if p.cfg.APIToken == "" {
+		missing = append(missing, "API token")
+	}
+
+	if len(missing) > 0 {
+		return fmt.Errorf("confluence configuration incomplete: missing %s", strings.Join(missing, ", "))
+	}
+
+	return nil
+}
+
+// findPage looks up a page by title in the configured space, returning nil when none exists.
+func (p *ConfluencePublisher) findPage(ctx context.Context, title string) (*confluencePage, error) {
+	query := url.Values{}
+	query.Set("spaceKey", p.cfg.SpaceKey)
+	query.Set("title", title)
+	query.Set("type", "page")
+	query.Set("expand", confluencePageExpansion)
+
+	var result confluenceSearchResult
+	if err := p.do(ctx, http.MethodGet, confluenceContentPath+"?"+query.Encode(), nil, &result); err != nil {
+		return nil, err
+	}
+
+	if len(result.Results) == 0 {
+		return nil, nil //nolint:nilnil // a missing page is not an error
+	}
+
+	page := result.Results[0]
+	if page.Version == nil {
+		return nil, errors.New("confluence page lookup returned no version information")
+	}
+
+	return &page, nil
+}
+
+func (p *ConfluencePublisher) do(ctx context.Context, method, path string, body, result any) error {
+	var reader io.Reader
+
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to encode confluence request: %w", err)
+		}
+
+		reader = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, p.cfg.BaseURL+path, reader)
+	if err != nil {
+		return fmt.Errorf("failed to create confluence request: %w", err)
+	}
+
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	return p.send(req, result)
+}
+
+// send authenticates and sends a request, decoding the JSON response into result unless it is nil.
+func (p *ConfluencePublisher) send(req *http.Request, result any) error {
+	req.SetBasicAuth(p.cfg.Email, p.cfg.APIToken)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("confluence request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+
+		return fmt.Errorf("confluence %s %s returned %s: %s", req.Method, req.URL.RequestURI(), resp.Status, strings.TrimSpace(string(msg)))
+	}
+
+	if result == nil {
+		return nil
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return fmt.Errorf("failed to decode confluence response: %w", err)
+	}
+
+	return nil
+}
+
+func (p *ConfluencePublisher) pageURL(page confluencePage) string {
+	if page.Links == nil || page.Links.WebUI == "" {
+		return fmt.Sprintf("%s/pages/viewpage.action?pageId=%s", p.cfg.BaseURL, page.ID)
+	}
+
+	base := page.Links.Base
+	if base == "" {
+		base = p.cfg.BaseURL
+	}
+
+	return base + page.Links.WebUI
+}
@@ -0,0 +1,234 @@
+package publishers
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// confluenceDiagramLanguage is the language of the ADF code blocks holding the Mermaid diagrams rendered as images.
+const confluenceDiagramLanguage = "mermaid"
+
+// DiagramRenderer renders the source of a Mermaid diagram as a PNG image.
+type DiagramRenderer func(ctx context.Context, source string) ([]byte, error)
+
+// NewMermaidCLIRenderer renders diagrams with a command taking the options of the Mermaid CLI, mmdc: the input
+// file after -i and the output file after -o. The command may hold arguments, e.g. "npx -y @mermaid-js/mermaid-cli".
+func NewMermaidCLIRenderer(command string) DiagramRenderer {
+	return func(ctx context.Context, source string) ([]byte, error) {
+		args := strings.Fields(command)
+		if len(args) == 0 {
+			return nil, errors.New("no diagram renderer command")
+		}
+
+		dir, err := os.MkdirTemp("", "openapi-converter-diagram-*")
+		if err != nil {
+			return nil, fmt.Errorf("failed to create diagram directory: %w", err)
+		}
+		defer os.RemoveAll(dir)
+
+		input, output := filepath.Join(dir, "diagram.mmd"), filepath.Join(dir, "diagram.png")
+		if err := os.WriteFile(input, []byte(source), 0o600); err != nil {
+			return nil, fmt.Errorf("failed to write diagram: %w", err)
+		}
+
+		var stderr bytes.Buffer
+
+		//nolint:gosec // the renderer is a command the user configured
+		cmd := exec.CommandContext(ctx, args[0], append(args[1:], "-i", input, "-o", output)...)
+		cmd.Stderr = &stderr
+
+		if err := cmd.Run(); err != nil {
+			if message := strings.TrimSpace(stderr.String()); message != "" {
+				return nil, fmt.Errorf("diagram renderer %s failed: %w: %s", args[0], err, message)
+			}
+
+			return nil, fmt.Errorf("diagram renderer %s failed: %w", args[0], err)
+		}
+
+		image, err := os.ReadFile(output)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read rendered diagram: %w", err)
+		}
+
+		return image, nil
+	}
+}
+
+// confluenceAttachments is the response of an attachment lookup or upload.
+type confluenceAttachments struct {
+	Results []struct {
+		Extensions struct {
+			FileID string `json:"fileId"`
+		} `json:"extensions"`
+	} `json:"results"`
+}
+
+// attachDiagrams renders the Mermaid code blocks of an ADF document as PNG images attached to a page, and returns
+// the document with each block replaced by its image. Attachments are named after a hash of the diagram source, so
+// that the diagrams already attached are neither rendered nor uploaded again. It reports false when the document
+// holds no diagram.
+func (p *ConfluencePublisher) attachDiagrams(ctx context.Context, pageID string, content []byte) ([]byte, bool, error) {
+	var document map[string]any
+	if err := json.Unmarshal(content, &document); err != nil {
+		return nil, false, fmt.Errorf("failed to decode ADF document: %w", err)
+	}
+
+	files := make(map[string]string) // Attachment name to media file ID
+	replaced := false
+
+	var walk func(node map[string]any) error
+
+	walk = func(node map[string]any) error {
+		children, _ := node["content"].([]any)
+
+		for i, child := range children {
+			childNode, ok := child.(map[string]any)
+			if !ok {
+				continue
+			}
+
+			source, ok := diagramSource(childNode)
+			if !ok {
+				if err := walk(childNode); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			sum := sha256.Sum256([]byte(source))
+			name := "diagram-" + hex.EncodeToString(sum[:8]) + ".png"
+
+			if _, done := files[name]; !done {
+				fileID, err := p.attachDiagram(ctx, pageID, name, source)
+				if err != nil {
+					return err
+				}
+
+				files[name] = fileID
+			}
+
+			children[i] = map[string]any{
+				"type":  "mediaSingle",
+				"attrs": map[string]any{"layout": "center"},
+				"content": []any{map[string]any{
+					"type":  "media",
+					"attrs": map[string]any{"type": "file", "id": files[name], "collection": "contentId-" + pageID},
+				}},
+			}
+			replaced = true
+		}
+
+		return nil
+	}
+
+	if err := walk(document); err != nil || !replaced {
+		return nil, false, err
+	}
+
+	updated, err := json.Marshal(document)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to encode ADF document: %w", err)
+	}
+
+	return updated, true, nil
+}
+
+// diagramSource returns the text of an ADF code block holding a Mermaid diagram.
+func diagramSource(node map[string]any) (string, bool) {
+	attrs, _ := node["attrs"].(map[string]any)
+	if node["type"] != "codeBlock" || attrs["language"] != confluenceDiagramLanguage {
+		return "", false
+	}
+
+	var source strings.Builder
+
+	children, _ := node["content"].([]any)
+	for _, child := range children {
+		if text, ok := child.(map[string]any)["text"].(string); ok {
+			source.WriteString(text)
+		}
+	}
+
+	return source.String(), true
+}
+
+// attachDiagram returns the media file ID of the attachment of a page holding the image of a diagram, rendering
+// and uploading it unless the page already has it.
+func (p *ConfluencePublisher) attachDiagram(ctx context.Context, pageID, name, source string) (string, error) {
+	var existing confluenceAttachments
+
+	path := confluenceContentPath + "/" + url.PathEscape(pageID) + "/child/attachment?filename=" + url.QueryEscape(name)
+	if err := p.do(ctx, http.MethodGet, path, nil, &existing); err != nil {
+		return "", err
+	}
+
+	if len(existing.Results) > 0 && existing.Results[0].Extensions.FileID != "" {
+		return existing.Results[0].Extensions.FileID, nil
+	}
+
+	image, err := p.cfg.RenderDiagram(ctx, source)
+	if err != nil {
+		return "", err
+	}
+
+	return p.uploadAttachment(ctx, pageID, name, image)
+}
+
+// uploadAttachment attaches a PNG image to a page, replacing the attachment of the same name, and returns its
+// media file ID, which ADF media nodes refer to.
+func (p *ConfluencePublisher) uploadAttachment(ctx context.Context, pageID, name string, image []byte) (string, error) {
+	var body bytes.Buffer
+
+	form := multipart.NewWriter(&body)
+
+	part, err := form.CreateFormFile("file", name)
+	if err == nil {
+		_, err = part.Write(image)
+	}
+
+	if err == nil {
+		err = form.WriteField("minorEdit", "true")
+	}
+
+	if err == nil {
+		err = form.Close()
+	}
+
+	if err != nil {
+		return "", fmt.Errorf("failed to encode attachment %s: %w", name, err)
+	}
+
+	path := confluenceContentPath + "/" + url.PathEscape(pageID) + "/child/attachment"
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, p.cfg.BaseURL+path, &body)
+	if err != nil {
+		return "", fmt.Errorf("failed to create confluence request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", form.FormDataContentType())
+	req.Header.Set("X-Atlassian-Token", "no-check")
+
+	var result confluenceAttachments
+	if err := p.send(req, &result); err != nil {
+		return "", err
+	}
+
+	if len(result.Results) == 0 || result.Results[0].Extensions.FileID == "" {
+		return "", fmt.Errorf("confluence attachment %s returned no file ID", name)
+	}
+
+	return result.Results[0].Extensions.FileID, nil
+}
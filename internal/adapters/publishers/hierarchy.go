@@ -0,0 +1,156 @@
+package publishers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+)
+
+// confluenceChildLimit is the number of child pages listed per request.
+const confluenceChildLimit = 100
+
+// confluencePageExpansion expands the version and the properties of the pages looked up.
+const confluencePageExpansion = "version,metadata.properties." + confluenceHashProperty +
+	",metadata.properties." + confluencePartProperty
+
+// confluenceParent is the page the pages of a child publisher are nested under.
+type confluenceParent struct {
+	id         string
+	operations map[string][]string // Titles of the child pages to publish to the operations they document
+	published  map[string]bool     // Titles of the child pages published or found unchanged
+}
+
+// Child returns a publisher of pages nested under the page with the given title, which must exist, such as the
+// pages of the tags of a document under its index page. Child pages keep the operations they document, set with
+// Documents, in a page property, so that a renamed tag renames its page rather than publishing another.
+func (p *ConfluencePublisher) Child(ctx context.Context, title string) (*ConfluencePublisher, error) {
+	if err := p.validate(); err != nil {
+		return nil, err
+	}
+
+	parent, err := p.findPage(ctx, title)
+	if err != nil {
+		return nil, err
+	}
+
+	if parent == nil {
+		return nil, fmt.Errorf("confluence parent page %q not found", title)
+	}
+
+	child := *p
+	child.cfg.ParentID = parent.ID
+	child.parent = &confluenceParent{id: parent.ID, operations: make(map[string][]string), published: make(map[string]bool)}
+
+	return &child, nil
+}
+
+// Documents sets the operations the child page with the given title documents, such as "listPets" or
+// "GET /pets". It is called for every child page before any is published: a child page no page has the title of
+// yet replaces the page left from a previous publication documenting most of the same operations, unless that page
+// keeps the title of another child page.
+func (p *ConfluencePublisher) Documents(title string, operations []string) {
+	if p.parent != nil {
+		p.parent.operations[title] = operations
+	}
+}
+
+// Prune returns the titles of the child pages left from previous publications, such as those of the tags removed
+// from a document, deleting them unless dryRun is set. Only the pages published by a child publisher are
+// considered, so that the pages added under the parent page by hand are kept.
+func (p *ConfluencePublisher) Prune(ctx context.Context, dryRun bool) ([]string, error) {
+	if p.parent == nil {
+		return nil, nil
+	}
+
+	children, err := p.children(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var stale []string
+
+	for _, child := range children {
+		if child.property(confluencePartProperty) == nil || p.parent.published[child.Title] {
+			continue
+		}
+
+		if !dryRun {
+			if err := p.do(ctx, http.MethodDelete, confluenceContentPath+"/"+url.PathEscape(child.ID), nil, nil); err != nil {
+				return stale, err
+			}
+		}
+
+		stale = append(stale, child.Title)
+	}
+
+	return stale, nil
+}
+
+// findRenamed looks up the child page left from a previous publication sharing the most operations with the child
+// page with the given title, returning nil when none shares any. Pages keeping the title of a page to publish are
+// left to that page.
+func (p *ConfluencePublisher) findRenamed(ctx context.Context, title string) (*confluencePage, error) {
+	operations := p.parent.operations[title]
+	if len(operations) == 0 {
+		return nil, nil //nolint:nilnil // a missing page is not an error
+	}
+
+	children, err := p.children(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		renamed *confluencePage
+		best    int
+	)
+
+	for _, child := range children {
+		property := child.property(confluencePartProperty)
+		if _, expected := p.parent.operations[child.Title]; property == nil || expected || p.parent.published[child.Title] {
+			continue
+		}
+
+		shared := 0
+
+		for _, operation := range property.Value.Operations {
+			if slices.Contains(operations, operation) {
+				shared++
+			}
+		}
+
+		if shared > best {
+			renamed, best = &child, shared
+		}
+	}
+
+	return renamed, nil
+}
+
+// children lists the child pages of the parent page.
+func (p *ConfluencePublisher) children(ctx context.Context) ([]confluencePage, error) {
+	var children []confluencePage
+
+	for start := 0; ; start += confluenceChildLimit {
+		query := url.Values{}
+		query.Set("expand", confluencePageExpansion)
+		query.Set("start", strconv.Itoa(start))
+		query.Set("limit", strconv.Itoa(confluenceChildLimit))
+
+		var result confluenceSearchResult
+
+		path := confluenceContentPath + "/" + url.PathEscape(p.parent.id) + "/child/page?" + query.Encode()
+		if err := p.do(ctx, http.MethodGet, path, nil, &result); err != nil {
+			return nil, err
+		}
+
+		children = append(children, result.Results...)
+
+		if len(result.Results) < confluenceChildLimit {
+			return children, nil
+		}
+	}
+}
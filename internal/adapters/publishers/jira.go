@@ -0,0 +1,307 @@
+package publishers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	jiraIssuePath     = "/rest/api/3/issue"
+	jiraIssueLinkPath = "/rest/api/3/issueLink"
+	jiraSearchPath    = "/rest/api/3/search/jql"
+	jiraTimeout       = 30 * time.Second
+
+	// jiraSearchLimit bounds the issues whose summary is compared with that of a new issue.
+	jiraSearchLimit = 50
+)
+
+// JiraConfig holds the connection settings of a Jira Cloud site and the fields of the issues filed there.
+type JiraConfig struct {
+	BaseURL    string // Site URL, e.g. https://example.atlassian.net
+	ProjectKey string
+	IssueType  string   // Type of the created issues, e.g. Task
+	Labels     []string // Labels of the created issues
+	LinkTo     string   // Key of an issue, such as an epic, every filed issue is linked to; empty for none
+	LinkType   string   // Name of the link type, e.g. Relates
+	Email      string
+	APIToken   string
+}
+
+// JiraIssue is an issue to file: a summary identifying it, paragraphs and a bullet list describing it, and a link
+// to the documentation of its subject.
+type JiraIssue struct {
+	Summary   string
+	Text      []string // Paragraphs
+	Items     []string // Bullet list following the paragraphs
+	DocsURL   string   // Documentation of the subject, empty for none
+	DocsLabel string   // Text of the documentation link
+}
+
+// JiraTracker files issues in a Jira Cloud project through its REST API, once: an issue whose summary matches
+// an open or closed issue of the project is not filed again.
+type JiraTracker struct {
+	cfg    JiraConfig
+	client *http.Client
+}
+
+// NewJiraTracker creates a new Jira tracker.
+func NewJiraTracker(cfg JiraConfig) *JiraTracker {
+	cfg.BaseURL = strings.TrimRight(cfg.BaseURL, "/")
+
+	return &JiraTracker{
+		cfg:    cfg,
+		client: &http.Client{Timeout: jiraTimeout},
+	}
+}
+
+type jiraIssueFields struct {
+	Project     jiraKey     `json:"project"`
+	IssueType   jiraName    `json:"issuetype"`
+	Summary     string      `json:"summary"`
+	Description adfDocument `json:"description"`
+	Labels      []string    `json:"labels,omitempty"`
+}
+
+type jiraKey struct {
+	Key string `json:"key"`
+}
+
+type jiraName struct {
+	Name string `json:"name"`
+}
+
+type jiraIssueRequest struct {
+	Fields jiraIssueFields `json:"fields"`
+}
+
+type jiraFoundIssue struct {
+	Key    string `json:"key"`
+	Fields struct {
+		Summary string `json:"summary"`
+	} `json:"fields"`
+}
+
+type jiraSearchRequest struct {
+	JQL        string   `json:"jql"`
+	Fields     []string `json:"fields"`
+	MaxResults int      `json:"maxResults"`
+}
+
+type jiraSearchResult struct {
+	Issues []jiraFoundIssue `json:"issues"`
+}
+
+type jiraIssueLink struct {
+	Type         jiraName `json:"type"`
+	InwardIssue  jiraKey  `json:"inwardIssue"`
+	OutwardIssue jiraKey  `json:"outwardIssue"`
+}
+
+// adfDocument and adfNode are the subset of the Atlassian Document Format that issue descriptions use.
+type adfDocument struct {
+	Type    string    `json:"type"`
+	Version int       `json:"version"`
+	Content []adfNode `json:"content"`
+}
+
+type adfNode struct {
+	Type    string    `json:"type"`
+	Text    string    `json:"text,omitempty"`
+	Marks   []adfMark `json:"marks,omitempty"`
+	Content []adfNode `json:"content,omitempty"`
+}
+
+type adfMark struct {
+	Type  string         `json:"type"`
+	Attrs map[string]any `json:"attrs,omitempty"`
+}
+
+// File creates an issue in the project unless one with the same summary exists, links it to the LinkTo issue when
+// set, and returns its key and whether it was created.
+func (t *JiraTracker) File(ctx context.Context, issue JiraIssue) (string, bool, error) {
+	if err := t.validate(); err != nil {
+		return "", false, err
+	}
+
+	key, err := t.findIssue(ctx, issue.Summary)
+	if err != nil {
+		return "", false, err
+	}
+
+	created := key == ""
+
+	if created {
+		request := jiraIssueRequest{Fields: jiraIssueFields{
+			Project:     jiraKey{Key: t.cfg.ProjectKey},
+			IssueType:   jiraName{Name: t.cfg.IssueType},
+			Summary:     issue.Summary,
+			Description: issueDescription(issue),
+			Labels:      t.cfg.Labels,
+		}}
+
+		var result jiraKey
+		if err := t.do(ctx, http.MethodPost, jiraIssuePath, request, &result); err != nil {
+			return "", false, err
+		}
+
+		key = result.Key
+	}
+
+	// Jira keeps a single link of a type between two issues, so that existing issues are linked again harmlessly
+	if t.cfg.LinkTo != "" {
+		link := jiraIssueLink{Type: jiraName{Name: t.cfg.LinkType}, InwardIssue: jiraKey{Key: key}, OutwardIssue: jiraKey{Key: t.cfg.LinkTo}}
+		if err := t.do(ctx, http.MethodPost, jiraIssueLinkPath, link, nil); err != nil {
+			return key, created, fmt.Errorf("failed to link %s to %s: %w", key, t.cfg.LinkTo, err)
+		}
+	}
+
+	return key, created, nil
+}
+
+// IssueURL returns the URL of an issue.
+func (t *JiraTracker) IssueURL(key string) string {
+	return t.cfg.BaseURL + "/browse/" + url.PathEscape(key)
+}
+
+func (t *JiraTracker) validate() error {
+	var missing []string
+
+	if t.cfg.BaseURL == "" {
+		missing = append(missing, "base URL")
+	}
+
+	if t.cfg.ProjectKey == "" {
+		missing = append(missing, "project key")
+	}
+
+	if t.cfg.Email == "" {
+		missing = append(missing, "email")
+	}
+
+	if t.cfg.APIToken == "" {
+		missing = append(missing, "API token")
+	}
+
+	if len(missing) > 0 {
+		return fmt.Errorf("jira configuration incomplete: missing %s", strings.Join(missing, ", "))
+	}
+
+	return nil
+}
+
+// findIssue returns the key of the issue of the project with exactly the given summary, empty when there is none.
+// The text search of Jira matches words rather than whole summaries, so that the issues it finds are compared again.
+func (t *JiraTracker) findIssue(ctx context.Context, summary string) (string, error) {
+	request := jiraSearchRequest{
+		JQL:        fmt.Sprintf("project = %s AND summary ~ %s ORDER BY created ASC", jqlString(t.cfg.ProjectKey), jqlString(jqlString(summary))),
+		Fields:     []string{"summary"},
+		MaxResults: jiraSearchLimit,
+	}
+
+	var result jiraSearchResult
+	if err := t.do(ctx, http.MethodPost, jiraSearchPath, request, &result); err != nil {
+		return "", err
+	}
+
+	for _, found := range result.Issues {
+		if found.Fields.Summary == summary {
+			return found.Key, nil
+		}
+	}
+
+	return "", nil
+}
+
+// jqlString quotes a value as a JQL string. Text searches quote the phrase twice, once for the phrase itself.
+func jqlString(value string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(value) + `"`
+}
+
+// issueDescription returns the ADF description of an issue.
+func issueDescription(issue JiraIssue) adfDocument {
+	doc := adfDocument{Type: "doc", Version: 1, Content: []adfNode{}}
+
+	for _, text := range issue.Text {
+		doc.Content = append(doc.Content, adfNode{Type: "paragraph", Content: []adfNode{{Type: "text", Text: text}}})
+	}
+
+	if len(issue.Items) > 0 {
+		list := adfNode{Type: "bulletList"}
+		for _, item := range issue.Items {
+			list.Content = append(list.Content, adfNode{Type: "listItem", Content: []adfNode{
+				{Type: "paragraph", Content: []adfNode{{Type: "text", Text: item}}},
+			}})
+		}
+
+		doc.Content = append(doc.Content, list)
+	}
+
+	if issue.DocsURL != "" {
+		label := issue.DocsLabel
+		if label == "" {
+			label = issue.DocsURL
+		}
+
+		doc.Content = append(doc.Content, adfNode{Type: "paragraph", Content: []adfNode{{
+			Type:  "text",
+			Text:  label,
+			Marks: []adfMark{{Type: "link", Attrs: map[string]any{"href": issue.DocsURL}}},
+		}}})
+	}
+
+	return doc
+}
+
+func (t *JiraTracker) do(ctx context.Context, method, path string, body, result any) error {
+	var reader io.Reader
+
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to encode jira request: %w", err)
+		}
+
+		reader = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, t.cfg.BaseURL+path, reader)
+	if err != nil {
+		return fmt.Errorf("failed to create jira request: %w", err)
+	}
+
+	req.SetBasicAuth(t.cfg.Email, t.cfg.APIToken)
+	req.Header.Set("Accept", "application/json")
+
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("jira request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+
+		return fmt.Errorf("jira %s %s returned %s: %s", method, path, resp.Status, strings.TrimSpace(string(msg)))
+	}
+
+	if result == nil {
+		return nil
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return fmt.Errorf("failed to decode jira response: %w", err)
+	}
+
+	return nil
+}
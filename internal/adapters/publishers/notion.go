@@ -0,0 +1,279 @@
+package publishers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	notionBaseURL    = "https://api.notion.com/v1"
+	notionAPIVersion = "2022-06-28"
+	notionTimeout    = 30 * time.Second
+
+	// notionMaxBlocks is the number of blocks the Notion API accepts per request.
+	notionMaxBlocks = 100
+)
+
+// NotionConfig holds the connection settings for a Notion workspace.
+type NotionConfig struct {
+	BaseURL  string // API URL, defaults to https://api.notion.com/v1
+	ParentID string // ID of the page the documentation pages are created under
+	Token    string // Internal integration token with access to the parent page
+}
+
+// NotionPublisher creates or updates Notion pages from the block JSON of the Notion converter.
+type NotionPublisher struct {
+	cfg    NotionConfig
+	client *http.Client
+}
+
+// NewNotionPublisher creates a new Notion publisher.
+func NewNotionPublisher(cfg NotionConfig) *NotionPublisher {
+	if cfg.BaseURL == "" {
+		cfg.BaseURL = notionBaseURL
+	}
+
+	cfg.BaseURL = strings.TrimRight(cfg.BaseURL, "/")
+
+	return &NotionPublisher{
+		cfg:    cfg,
+		client: &http.Client{Timeout: notionTimeout},
+	}
+}
+
+type notionBlocks struct {
+	Children []json.RawMessage `json:"children"`
+}
+
+type notionParent struct {
+	Type   string `json:"type,omitempty"`
+	PageID string `json:"page_id,omitempty"`
+}
+
+type notionPage struct {
+	ID         string           `json:"id,omitempty"`
+	URL        string           `json:"url,omitempty"`
+	Parent     notionParent     `json:"parent"`
+	Properties notionProperties `json:"properties"`
+}
+
+type notionProperties struct {
+	Title notionTitle `json:"title"`
+}
+
+type notionTitle struct {
+	Title []notionRichText `json:"title"`
+}
+
+type notionRichText struct {
+	Text      *notionText `json:"text,omitempty"`
+	PlainText string      `json:"plain_text,omitempty"` // Set in responses only
+}
+
+type notionText struct {
+	Content string `json:"content"`
+}
+
+type notionBlockRef struct {
+	ID string `json:"id"`
+}
+
+type notionList[T any] struct {
+	Results    []T    `json:"results"`
+	HasMore    bool   `json:"has_more"`
+	NextCursor string `json:"next_cursor"`
+}
+
+// Publish creates the page under the parent page if no child page has the same title, otherwise it replaces its content.
+func (p *NotionPublisher) Publish(ctx context.Context, title string, content []byte) (string, error) {
+	if err := p.validate(); err != nil {
+		return "", err
+	}
+
+	var blocks notionBlocks
+	if err := json.Unmarshal(content, &blocks); err != nil {
+		return "", fmt.Errorf("invalid notion content: %w", err)
+	}
+
+	page, err := p.findPage(ctx, title)
+	if err != nil {
+		return "", err
+	}
+
+	if page == nil {
+		page, err = p.createPage(ctx, title)
+	} else {
+		err = p.clearPage(ctx, page.ID)
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	// Large documents are appended in several requests
+	for start := 0; start < len(blocks.Children); start += notionMaxBlocks {
+		batch := notionBlocks{Children: blocks.Children[start:min(start+notionMaxBlocks, len(blocks.Children))]}
+
+		if err := p.do(ctx, http.MethodPatch, "/blocks/"+url.PathEscape(page.ID)+"/children", batch, nil); err != nil {
+			return "", err
+		}
+	}
+
+	return page.URL, nil
+}
+
+func (p *NotionPublisher) validate() error {
+	var missing []string
+
+	if p.cfg.ParentID == "" {
+		missing = append(missing, "parent page ID")
+	}
+
+	if p.cfg.Token == "" {
+		missing = append(missing, "token")
+	}
+
+	if len(missing) > 0 {
+		return fmt.Errorf("notion configuration incomplete: missing %s", strings.Join(missing, ", "))
+	}
+
+	return nil
+}
+
+func (p *NotionPublisher) createPage(ctx context.Context, title string) (*notionPage, error) {
+	page := notionPage{
+		Parent:     notionParent{PageID: p.cfg.ParentID},
+		Properties: notionProperties{Title: notionTitle{Title: []notionRichText{{Text: &notionText{Content: title}}}}},
+	}
+
+	var result notionPage
+	if err := p.do(ctx, http.MethodPost, "/pages", page, &result); err != nil {
+		return nil, err
+	}
+
+	if result.ID == "" {
+		return nil, errors.New("notion page creation returned no page ID")
+	}
+
+	return &result, nil
+}
+
+// findPage looks up a page by title among the children of the parent page, returning nil when none exists.
+func (p *NotionPublisher) findPage(ctx context.Context, title string) (*notionPage, error) {
+	query := map[string]any{
+		"query":  title,
+		"filter": map[string]string{"property": "object", "value": "page"},
+	}
+
+	for {
+		var result notionList[notionPage]
+		if err := p.do(ctx, http.MethodPost, "/search", query, &result); err != nil {
+			return nil, err
+		}
+
+		for _, page := range result.Results {
+			if sameNotionID(page.Parent.PageID, p.cfg.ParentID) && page.title() == title {
+				return &page, nil
+			}
+		}
+
+		if !result.HasMore {
+			return nil, nil //nolint:nilnil // a missing page is not an error
+		}
+
+		query["start_cursor"] = result.NextCursor
+	}
+}
+
+// clearPage deletes the blocks of a page so that its content can be written again.
+func (p *NotionPublisher) clearPage(ctx context.Context, pageID string) error {
+	path := "/blocks/" + url.PathEscape(pageID) + "/children?page_size=100"
+
+	// Deleted blocks disappear from the listing, so the first page is read until it is empty
+	for {
+		var result notionList[notionBlockRef]
+		if err := p.do(ctx, http.MethodGet, path, nil, &result); err != nil {
+			return err
+		}
+
+		if len(result.Results) == 0 {
+			return nil
+		}
+
+		for _, block := range result.Results {
+			if err := p.do(ctx, http.MethodDelete, "/blocks/"+url.PathEscape(block.ID), nil, nil); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (page notionPage) title() string {
+	var title strings.Builder
+	for _, text := range page.Properties.Title.Title {
+		title.WriteString(text.PlainText)
+	}
+
+	return title.String()
+}
+
+// sameNotionID compares page IDs, which the API writes with dashes but users often copy without.
+func sameNotionID(a, b string) bool {
+	return a != "" && strings.ReplaceAll(a, "-", "") == strings.ReplaceAll(b, "-", "")
+}
+
+func (p *NotionPublisher) do(ctx context.Context, method, path string, body, result any) error {
+	var reader io.Reader
+
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to encode notion request: %w", err)
+		}
+
+		reader = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, p.cfg.BaseURL+path, reader)
+	if err != nil {
+		return fmt.Errorf("failed to create notion request: %w", err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+p.cfg.Token)
+	req.Header.Set("Notion-Version", notionAPIVersion)
+	req.Header.Set("Accept", "application/json")
+
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("notion request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+
+		return fmt.Errorf("notion %s %s returned %s: %s", method, path, resp.Status, strings.TrimSpace(string(msg)))
+	}
+
+	if result == nil {
+		return nil
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return fmt.Errorf("failed to decode notion response: %w", err)
+	}
+
+	return nil
+}
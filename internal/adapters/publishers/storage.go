@@ -0,0 +1,294 @@
+package publishers
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Storage schemes of the bucket URLs.
+const (
+	StorageS3  = "s3"
+	StorageGCS = "gs"
+)
+
+const (
+	storageTimeout       = 5 * time.Minute
+	storageDefaultRegion = "us-east-1"
+	storageGCSEndpoint   = "https://storage.googleapis.com"
+
+	// s3Algorithm is the signing algorithm of Signature Version 4, which S3 and S3-compatible stores accept.
+	s3Algorithm = "AWS4-HMAC-SHA256"
+)
+
+// storageContentTypes are the media types of the generated files that the system may not know, by extension.
+var storageContentTypes = map[string]string{ //nolint:gochecknoglobals // read-only lookup table
+	".md":      "text/markdown; charset=utf-8",
+	".mdx":     "text/markdown; charset=utf-8",
+	".graphql": "application/graphql; charset=utf-8",
+	".proto":   "text/plain; charset=utf-8",
+	".go":      "text/plain; charset=utf-8",
+	".mmd":     "text/plain; charset=utf-8",
+	".dot":     "text/vnd.graphviz; charset=utf-8",
+	".json":    "application/json",
+	".docx":    "application/vnd.openxmlformats-officedocument.wordprocessingml.document",
+}
+
+// StorageConfig holds the settings of an S3 or Google Cloud Storage bucket.
+type StorageConfig struct {
+	URL          string // Bucket and key prefix, e.g. s3://docs-bucket/api/v1 or gs://docs-bucket/api
+	CacheControl string // Cache-Control header stored with the objects, e.g. "public, max-age=300"
+	Endpoint     string // S3-compatible endpoint, such as MinIO or R2, addressed with path-style URLs (S3)
+	Region       string // Region of the bucket, defaults to us-east-1 (S3)
+
+	AccessKeyID     string // S3
+	SecretAccessKey string // S3
+	SessionToken    string // Temporary credentials only (S3)
+	Token           string // OAuth 2.0 access token (Google Cloud Storage)
+}
+
+// StoragePublisher uploads documents as objects of an S3 or Google Cloud Storage bucket, under a key prefix.
+// S3 requests are signed with Signature Version 4; Google Cloud Storage is reached through its XML API with
+// an OAuth 2.0 access token.
+type StoragePublisher struct {
+	cfg    StorageConfig
+	scheme string
+	bucket string
+	prefix string // Key prefix, ending with "/" unless empty
+	client *http.Client
+}
+
+// NewStoragePublisher creates a new storage publisher for the bucket URL of the configuration.
+func NewStoragePublisher(cfg StorageConfig) (*StoragePublisher, error) {
+	location, err := url.Parse(cfg.URL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid bucket URL %q: %w", cfg.URL, err)
+	}
+
+	if location.Scheme != StorageS3 && location.Scheme != StorageGCS {
+		return nil, fmt.Errorf("invalid bucket URL %q: expected s3://<bucket>/<prefix> or gs://<bucket>/<prefix>", cfg.URL)
+	}
+
+	if location.Host == "" {
+		return nil, fmt.Errorf("invalid bucket URL %q: missing bucket", cfg.URL)
+	}
+
+	if cfg.Region == "" {
+		cfg.Region = storageDefaultRegion
+	}
+
+	cfg.Endpoint = strings.TrimRight(cfg.Endpoint, "/")
+
+	if endpoint, err := url.Parse(cfg.Endpoint); cfg.Endpoint != "" && (err != nil || endpoint.Host == "") {
+		return nil, fmt.Errorf("invalid endpoint %q: expected a URL such as https://minio.example.com", cfg.Endpoint)
+	}
+
+	prefix := strings.Trim(location.Path, "/")
+	if prefix != "" {
+		prefix += "/"
+	}
+
+	return &StoragePublisher{
+		cfg:    cfg,
+		scheme: location.Scheme,
+		bucket: location.Host,
+		prefix: prefix,
+		client: &http.Client{Timeout: storageTimeout},
+	}, nil
+}
+
+// Key returns the object key of a file name, its path relative to the uploaded directory, under the prefix.
+func (p *StoragePublisher) Key(name string) string {
+	return p.prefix + strings.TrimLeft(path.Clean("/"+name), "/")
+}
+
+// Publish uploads content as the object named by title, a path under the key prefix, with the media type of its
+// extension, and returns the URL of the object.
+func (p *StoragePublisher) Publish(ctx context.Context, title string, content []byte) (string, error) {
+	if err := p.validate(); err != nil {
+		return "", err
+	}
+
+	key := p.Key(title)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, p.objectURL(key), bytes.NewReader(content))
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", StorageContentType(key))
+
+	if p.cfg.CacheControl != "" {
+		req.Header.Set("Cache-Control", p.cfg.CacheControl)
+	}
+
+	if p.scheme == StorageGCS {
+		req.Header.Set("Authorization", "Bearer "+p.cfg.Token)
+	} else {
+		p.sign(req, content)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to upload %s: %w", key, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+
+		return "", fmt.Errorf("failed to upload %s: status %d: %s", key, resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	return fmt.Sprintf("%s://%s/%s", p.scheme, p.bucket, key), nil
+}
+
+// StorageContentType returns the media type objects are stored with, from the extension of their key.
+func StorageContentType(key string) string {
+	extension := strings.ToLower(path.Ext(key))
+
+	if contentType, ok := storageContentTypes[extension]; ok {
+		return contentType
+	}
+
+	if contentType := mime.TypeByExtension(extension); contentType != "" {
+		return contentType
+	}
+
+	return "application/octet-stream"
+}
+
+func (p *StoragePublisher) validate() error {
+	if p.scheme == StorageGCS {
+		if p.cfg.Token == "" {
+			return errors.New("an OAuth 2.0 access token is required to upload to Google Cloud Storage")
+		}
+
+		return nil
+	}
+
+	if p.cfg.AccessKeyID == "" || p.cfg.SecretAccessKey == "" {
+		return errors.New("an access key ID and secret access key are required to upload to S3")
+	}
+
+	return nil
+}
+
+// objectURL returns the URL of an object: path-style on Google Cloud Storage and custom S3 endpoints,
+// virtual-hosted on AWS.
+func (p *StoragePublisher) objectURL(key string) string {
+	escaped := s3Escape(key)
+
+	switch {
+	case p.scheme == StorageGCS:
+		endpoint := p.cfg.Endpoint
+		if endpoint == "" {
+			endpoint = storageGCSEndpoint
+		}
+
+		return endpoint + "/" + s3Escape(p.bucket) + "/" + escaped
+	case p.cfg.Endpoint != "":
+		return p.cfg.Endpoint + "/" + s3Escape(p.bucket) + "/" + escaped
+	default:
+		return fmt.Sprintf("https://%s.s3.%s.amazonaws.com/%s", p.bucket, p.cfg.Region, escaped)
+	}
+}
+
+// sign adds the Signature Version 4 authorization of an S3 request sending payload.
+func (p *StoragePublisher) sign(req *http.Request, payload []byte) {
+	now := time.Now().UTC()
+	timestamp := now.Format("20060102T150405Z")
+	date := timestamp[:8]
+
+	payloadHash := sha256.Sum256(payload)
+
+	req.Header.Set("X-Amz-Date", timestamp)
+	req.Header.Set("X-Amz-Content-Sha256", hex.EncodeToString(payloadHash[:]))
+
+	if p.cfg.SessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", p.cfg.SessionToken)
+	}
+
+	// Host and the x-amz-* headers are signed, which S3 requires, along with the headers stored as metadata
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		lower := strings.ToLower(name)
+		if strings.HasPrefix(lower, "x-amz-") || lower == "content-type" || lower == "cache-control" {
+			headers[lower] = strings.TrimSpace(strings.Join(values, ","))
+		}
+	}
+
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.RawQuery,
+		canonicalHeaders.String(),
+		signedHeaders,
+		hex.EncodeToString(payloadHash[:]),
+	}, "\n")
+
+	scope := date + "/" + p.cfg.Region + "/s3/aws4_request"
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := strings.Join([]string{s3Algorithm, timestamp, scope, hex.EncodeToString(requestHash[:])}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+p.cfg.SecretAccessKey), date)
+	for _, part := range []string{p.cfg.Region, "s3", "aws4_request"} {
+		key = hmacSHA256(key, part)
+	}
+
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s3Algorithm, p.cfg.AccessKeyID, scope, signedHeaders, signature))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+
+	return mac.Sum(nil)
+}
+
+// s3Escape escapes an object key as Signature Version 4 expects, every byte but the unreserved characters of
+// RFC 3986 and the "/" separating its segments.
+func s3Escape(key string) string {
+	var escaped strings.Builder
+
+	for i := range len(key) {
+		b := key[i]
+
+		switch {
+		case 'A' <= b && b <= 'Z', 'a' <= b && b <= 'z', '0' <= b && b <= '9', strings.IndexByte("-_.~/", b) >= 0:
+			escaped.WriteByte(b)
+		default:
+			fmt.Fprintf(&escaped, "%%%02X", b)
+		}
+	}
+
+	return escaped.String()
+}
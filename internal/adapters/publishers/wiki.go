@@ -0,0 +1,315 @@
+package publishers
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Wiki providers, which differ in the name of the sidebar page and in the URL of the pages.
+const (
+	WikiGitHub = "github"
+	WikiGitLab = "gitlab"
+)
+
+const (
+	// wikiAuthorName and wikiAuthorEmail sign the commits of the pages when neither the configuration nor git set
+	// an identity, as is usual on CI runners.
+	wikiAuthorName  = "openapi-converter"
+	wikiAuthorEmail = "openapi-converter@users.noreply.github.com"
+
+	// wikiPageInvalidChars are the characters that cannot appear in the name of a wiki page file.
+	wikiPageInvalidChars = `/\:*?"<>|#%`
+)
+
+// WikiConfig holds the settings of a GitHub or GitLab wiki.
+type WikiConfig struct {
+	URL         string // Git URL of the wiki repository, e.g. https://github.com/owner/repo.wiki.git
+	Provider    string // WikiGitHub or WikiGitLab, detected from URL when empty
+	Token       string // Personal access token allowed to push to the wiki, empty for the credentials of git itself
+	Sidebar     bool   // Replace the sidebar of the wiki with links to the published pages
+	AuthorName  string // Name the commit is signed with, defaults to the identity of git, else openapi-converter
+	AuthorEmail string
+}
+
+// WikiPublisher publishes Markdown pages to the git repository of a GitHub or GitLab wiki.
+// Pages are written into a clone of the repository as they are published and pushed together by Push,
+// with a sidebar listing them when enabled; Close removes the clone.
+type WikiPublisher struct {
+	cfg   WikiConfig
+	dir   string       // Clone of the wiki repository, empty until the first page is published
+	pages []wikiPageID // Pages published, in order, for the sidebar
+}
+
+// wikiPageID names a published page.
+type wikiPageID struct {
+	title string
+	name  string // Name of the page file without extension, which is the page path of the wiki
+}
+
+// NewWikiPublisher creates a new wiki publisher.
+func NewWikiPublisher(cfg WikiConfig) *WikiPublisher {
+	if cfg.Provider == "" {
+		cfg.Provider = WikiGitHub
+		if strings.Contains(strings.ToLower(cfg.URL), "gitlab") {
+			cfg.Provider = WikiGitLab
+		}
+	}
+
+	return &WikiPublisher{cfg: cfg}
+}
+
+// Publish writes a Markdown page into the clone of the wiki, replacing the page of the same title,
+// and returns the URL the page will have once pushed.
+func (p *WikiPublisher) Publish(ctx context.Context, title string, content []byte) (string, error) {
+	if err := p.clone(ctx); err != nil {
+		return "", err
+	}
+
+	name := WikiPageName(title)
+
+	if err := os.WriteFile(filepath.Join(p.dir, name+".md"), content, 0o600); err != nil {
+		return "", fmt.Errorf("failed to write page %q: %w", title, err)
+	}
+
+	p.pages = append(p.pages, wikiPageID{title: title, name: name})
+
+	return p.pageURL(name), nil
+}
+
+// Push commits the published pages, and the sidebar when enabled, with message and pushes them to the wiki.
+// It reports false when the wiki already held the same pages and nothing was pushed.
+func (p *WikiPublisher) Push(ctx context.Context, message string) (bool, error) {
+	if p.dir == "" {
+		return false, nil
+	}
+
+	if p.cfg.Sidebar {
+		if err := os.WriteFile(filepath.Join(p.dir, p.sidebarName()+".md"), p.sidebar(), 0o600); err != nil {
+			return false, fmt.Errorf("failed to write sidebar: %w", err)
+		}
+	}
+
+	if _, err := p.git(ctx, "add", "--all"); err != nil {
+		return false, err
+	}
+
+	status, err := p.git(ctx, "status", "--porcelain")
+	if err != nil {
+		return false, err
+	}
+
+	if len(bytes.TrimSpace(status)) == 0 {
+		return false, nil
+	}
+
+	if _, err := p.run(ctx, p.identity(ctx), "commit", "--quiet", "--message", message); err != nil {
+		return false, err
+	}
+
+	if _, err := p.git(ctx, "push", "--quiet", "origin", "HEAD"); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// Close removes the clone of the wiki.
+func (p *WikiPublisher) Close() error {
+	if p.dir == "" {
+		return nil
+	}
+
+	if err := os.RemoveAll(p.dir); err != nil {
+		return fmt.Errorf("failed to remove wiki clone: %w", err)
+	}
+
+	p.dir = ""
+
+	return nil
+}
+
+// WikiPageName returns the name of the page file of a title, without extension: the title with spaces and the
+// characters file names cannot hold replaced by hyphens, as the wikis name pages created in their editor.
+func WikiPageName(title string) string {
+	return strings.Map(func(r rune) rune {
+		if r == ' ' || strings.ContainsRune(wikiPageInvalidChars, r) {
+			return '-'
+		}
+
+		return r
+	}, strings.TrimSpace(title))
+}
+
+// clone makes a shallow clone of the wiki repository into a temporary directory, once.
+func (p *WikiPublisher) clone(ctx context.Context) error {
+	if p.dir != "" {
+		return nil
+	}
+
+	if p.cfg.URL == "" {
+		return errors.New("wiki URL is required")
+	}
+
+	dir, err := os.MkdirTemp("", "openapi-converter-wiki-")
+	if err != nil {
+		return fmt.Errorf("failed to create wiki clone directory: %w", err)
+	}
+
+	p.dir = dir
+
+	if _, err := p.git(ctx, "clone", "--quiet", "--depth", "1", p.cfg.URL, "."); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// git runs a git command in the clone of the wiki, authenticated with the token when set, and returns its output.
+func (p *WikiPublisher) git(ctx context.Context, args ...string) ([]byte, error) {
+	return p.run(ctx, nil, args...)
+}
+
+// run runs a git command like git, adding env to its environment.
+func (p *WikiPublisher) run(ctx context.Context, env []string, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = p.dir
+	cmd.Env = append(append(append(os.Environ(), "GIT_TERMINAL_PROMPT=0"), p.authorization()...), env...)
+
+	var stdout, stderr bytes.Buffer
+
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("git %s failed: %w: %s", args[0], err, p.redact(strings.TrimSpace(stderr.String())))
+	}
+
+	return stdout.Bytes(), nil
+}
+
+// authorization returns the environment passing the token to git as an HTTP header, empty without a token.
+// A header rather than credentials in the URL keeps the token out of the remote saved in the clone, and the
+// environment rather than a -c argument keeps it out of the process list. The entry is added after those of
+// GIT_CONFIG_COUNT already set.
+func (p *WikiPublisher) authorization() []string {
+	if p.cfg.Token == "" {
+		return nil
+	}
+
+	count, err := strconv.Atoi(os.Getenv("GIT_CONFIG_COUNT"))
+	if err != nil || count < 0 {
+		count = 0
+	}
+
+	return []string{
+		"GIT_CONFIG_COUNT=" + strconv.Itoa(count+1),
+		fmt.Sprintf("GIT_CONFIG_KEY_%d=http.extraHeader", count),
+		fmt.Sprintf("GIT_CONFIG_VALUE_%d=Authorization: Basic %s", count, p.credentials()),
+	}
+}
+
+// credentials returns the token and the user name the provider expects with it, base64 encoded.
+func (p *WikiPublisher) credentials() string {
+	user := "x-access-token"
+	if p.cfg.Provider == WikiGitLab {
+		user = "oauth2"
+	}
+
+	return base64.StdEncoding.EncodeToString([]byte(user + ":" + p.cfg.Token))
+}
+
+// identity returns the environment setting the author of the commit: the configured one, else that of git, else
+// wikiAuthorName and wikiAuthorEmail.
+func (p *WikiPublisher) identity(ctx context.Context) []string {
+	name, email := p.cfg.AuthorName, p.cfg.AuthorEmail
+
+	// The variables git reads, which the returned ones would otherwise override
+	if name == "" {
+		name = os.Getenv("GIT_AUTHOR_NAME")
+	}
+
+	if email == "" {
+		email = os.Getenv("GIT_AUTHOR_EMAIL")
+	}
+
+	if name == "" {
+		if configured, err := p.git(ctx, "config", "user.name"); err == nil {
+			name = strings.TrimSpace(string(configured))
+		}
+	}
+
+	if email == "" {
+		if configured, err := p.git(ctx, "config", "user.email"); err == nil {
+			email = strings.TrimSpace(string(configured))
+		}
+	}
+
+	if name == "" {
+		name = wikiAuthorName
+	}
+
+	if email == "" {
+		email = wikiAuthorEmail
+	}
+
+	return []string{
+		"GIT_AUTHOR_NAME=" + name, "GIT_AUTHOR_EMAIL=" + email,
+		"GIT_COMMITTER_NAME=" + name, "GIT_COMMITTER_EMAIL=" + email,
+	}
+}
+
+// sidebarName returns the name of the sidebar page of the provider.
+func (p *WikiPublisher) sidebarName() string {
+	if p.cfg.Provider == WikiGitLab {
+		return "_sidebar"
+	}
+
+	return "_Sidebar"
+}
+
+// sidebar returns the Markdown of a sidebar linking to the published pages, in order.
+func (p *WikiPublisher) sidebar() []byte {
+	var sidebar strings.Builder
+
+	for _, page := range p.pages {
+		fmt.Fprintf(&sidebar, "- [%s](%s)\n", page.title, page.name)
+	}
+
+	return []byte(sidebar.String())
+}
+
+// pageURL returns the URL of a page of the wiki: the web URL of the repository the wiki belongs to,
+// followed by /wiki/<name> on GitHub and /-/wikis/<name> on GitLab. Pages of wikis not served over HTTP, such as
+// local repositories, are given by name instead.
+func (p *WikiPublisher) pageURL(name string) string {
+	repository, err := url.Parse(strings.TrimSuffix(strings.TrimSuffix(p.cfg.URL, ".git"), ".wiki"))
+	if err != nil || (repository.Scheme != "https" && repository.Scheme != "http") {
+		return name
+	}
+
+	repository.User = nil
+
+	if p.cfg.Provider == WikiGitLab {
+		return repository.JoinPath("-", "wikis", name).String()
+	}
+
+	return repository.JoinPath("wiki", name).String()
+}
+
+// redact hides the token, and the header carrying it, in the output of git.
+func (p *WikiPublisher) redact(output string) string {
+	if p.cfg.Token == "" {
+		return output
+	}
+
+	return strings.NewReplacer(p.credentials(), "***", p.cfg.Token, "***").Replace(output)
+}
@@ -0,0 +1,70 @@
+package reporters
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/GabrielNunesIT/openapi-converter/internal/domain"
+)
+
+const jsonFormat = "json"
+
+// JSONReporter writes a report as a JSON document for scripts.
+type JSONReporter struct{}
+
+// NewJSONReporter creates a new JSON reporter.
+func NewJSONReporter() *JSONReporter {
+	return &JSONReporter{}
+}
+
+// Format returns the report format name.
+func (r *JSONReporter) Format() string {
+	return jsonFormat
+}
+
+type jsonReport struct {
+	File     string      `json:"file"`
+	Errors   int         `json:"errors"`
+	Warnings int         `json:"warnings"`
+	Infos    int         `json:"infos"`
+	Issues   []jsonIssue `json:"issues"`
+}
+
+type jsonIssue struct {
+	Rule     string `json:"rule,omitempty"`
+	Severity string `json:"severity"`
+	Message  string `json:"message"`
+	Location string `json:"location"`
+	Line     int    `json:"line,omitempty"`
+}
+
+// Report writes the issues of a report to output.
+func (r *JSONReporter) Report(report *domain.Report, output io.Writer) error {
+	doc := jsonReport{
+		File:     reportFile(report),
+		Errors:   report.Count(domain.SeverityError),
+		Warnings: report.Count(domain.SeverityWarning),
+		Infos:    report.Count(domain.SeverityInfo),
+		Issues:   make([]jsonIssue, 0, len(report.Issues)),
+	}
+
+	for _, issue := range report.Issues {
+		doc.Issues = append(doc.Issues, jsonIssue{
+			Rule:     issue.Rule,
+			Severity: string(issue.Severity),
+			Message:  issue.Message,
+			Location: issue.Location(),
+			Line:     issue.Line,
+		})
+	}
+
+	encoder := json.NewEncoder(output)
+	encoder.SetIndent("", "  ")
+
+	if err := encoder.Encode(doc); err != nil {
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,32 @@
+// Package reporters provides writers of issue reports for people and for CI tooling.
+package reporters
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/GabrielNunesIT/openapi-converter/internal/domain"
+)
+
+// New returns the reporter of a format: text, json or sarif.
+func New(format string) (domain.Reporter, error) {
+	switch strings.ToLower(format) {
+	case textFormat:
+		return NewTextReporter(), nil
+	case jsonFormat:
+		return NewJSONReporter(), nil
+	case sarifFormat:
+		return NewSARIFReporter(), nil
+	default:
+		return nil, fmt.Errorf("unsupported report format: %s (supported: text, json, sarif)", format)
+	}
+}
+
+// reportFile returns the name of the specification of a report for display.
+func reportFile(report *domain.Report) string {
+	if report.File == "" {
+		return "<stdin>"
+	}
+
+	return report.File
+}
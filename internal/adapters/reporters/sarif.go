@@ -0,0 +1,154 @@
+package reporters
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/GabrielNunesIT/openapi-converter/internal/domain"
+)
+
+const (
+	sarifFormat  = "sarif"
+	sarifVersion = "2.1.0"
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	toolName     = "openapi-converter"
+	toolURI      = "https://github.com/GabrielNunesIT/openapi-converter"
+)
+
+// SARIFReporter writes a report in the Static Analysis Results Interchange Format, which code hosts
+// such as GitHub display as code scanning alerts.
+type SARIFReporter struct{}
+
+// NewSARIFReporter creates a new SARIF reporter.
+func NewSARIFReporter() *SARIFReporter {
+	return &SARIFReporter{}
+}
+
+// Format returns the report format name.
+func (r *SARIFReporter) Format() string {
+	return sarifFormat
+}
+
+// SARIF document structures, limited to the properties this reporter writes.
+type (
+	sarifLog struct {
+		Schema  string     `json:"$schema"`
+		Version string     `json:"version"`
+		Runs    []sarifRun `json:"runs"`
+	}
+
+	sarifRun struct {
+		Tool    sarifTool     `json:"tool"`
+		Results []sarifResult `json:"results"`
+	}
+
+	sarifTool struct {
+		Driver sarifDriver `json:"driver"`
+	}
+
+	sarifDriver struct {
+		Name           string      `json:"name"`
+		InformationURI string      `json:"informationUri"`
+		Rules          []sarifRule `json:"rules,omitempty"`
+	}
+
+	sarifRule struct {
+		ID               string       `json:"id"`
+		ShortDescription sarifMessage `json:"shortDescription"`
+	}
+
+	sarifResult struct {
+		RuleID    string          `json:"ruleId,omitempty"`
+		Level     string          `json:"level"`
+		Message   sarifMessage    `json:"message"`
+		Locations []sarifLocation `json:"locations"`
+	}
+
+	sarifMessage struct {
+		Text string `json:"text"`
+	}
+
+	sarifLocation struct {
+		PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+		LogicalLocations []sarifLogical        `json:"logicalLocations,omitempty"`
+	}
+
+	sarifPhysicalLocation struct {
+		ArtifactLocation sarifArtifact `json:"artifactLocation"`
+		Region           *sarifRegion  `json:"region,omitempty"`
+	}
+
+	sarifArtifact struct {
+		URI string `json:"uri"`
+	}
+
+	sarifRegion struct {
+		StartLine int `json:"startLine"`
+	}
+
+	sarifLogical struct {
+		FullyQualifiedName string `json:"fullyQualifiedName"`
+	}
+)
+
+// Report writes the issues of a report to output.
+func (r *SARIFReporter) Report(report *domain.Report, output io.Writer) error {
+	run := sarifRun{
+		Tool:    sarifTool{Driver: sarifDriver{Name: toolName, InformationURI: toolURI}},
+		Results: make([]sarifResult, 0, len(report.Issues)),
+	}
+
+	names := make([]string, 0, len(report.Rules))
+	for name := range report.Rules {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, sarifRule{
+			ID:               name,
+			ShortDescription: sarifMessage{Text: report.Rules[name]},
+		})
+	}
+
+	for _, issue := range report.Issues {
+		location := sarifLocation{
+			PhysicalLocation: sarifPhysicalLocation{ArtifactLocation: sarifArtifact{URI: reportFile(report)}},
+			LogicalLocations: []sarifLogical{{FullyQualifiedName: issue.Location()}},
+		}
+
+		if issue.Line > 0 {
+			location.PhysicalLocation.Region = &sarifRegion{StartLine: issue.Line}
+		}
+
+		run.Results = append(run.Results, sarifResult{
+			RuleID:    issue.Rule,
+			Level:     sarifLevel(issue.Severity),
+			Message:   sarifMessage{Text: issue.Message},
+			Locations: []sarifLocation{location},
+		})
+	}
+
+	encoder := json.NewEncoder(output)
+	encoder.SetIndent("", "  ")
+
+	if err := encoder.Encode(sarifLog{Schema: sarifSchema, Version: sarifVersion, Runs: []sarifRun{run}}); err != nil {
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+
+	return nil
+}
+
+// sarifLevel maps a severity to a SARIF result level.
+func sarifLevel(severity domain.Severity) string {
+	switch severity {
+	case domain.SeverityError:
+		return "error"
+	case domain.SeverityWarning:
+		return "warning"
+	default:
+		return "note"
+	}
+}
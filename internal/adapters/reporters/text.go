@@ -0,0 +1,53 @@
+package reporters
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/GabrielNunesIT/openapi-converter/internal/domain"
+)
+
+const textFormat = "text"
+
+// TextReporter writes one line per issue, "file:line: severity: message [rule] (location)", like compilers do.
+type TextReporter struct{}
+
+// NewTextReporter creates a new text reporter.
+func NewTextReporter() *TextReporter {
+	return &TextReporter{}
+}
+
+// Format returns the report format name.
+func (r *TextReporter) Format() string {
+	return textFormat
+}
+
+// Report writes the issues of a report to output.
+func (r *TextReporter) Report(report *domain.Report, output io.Writer) error {
+	var text strings.Builder
+
+	for _, issue := range report.Issues {
+		text.WriteString(FormatIssue(reportFile(report), issue) + "\n")
+	}
+
+	if _, err := io.WriteString(output, text.String()); err != nil {
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+
+	return nil
+}
+
+// FormatIssue renders an issue found in file as a single line.
+func FormatIssue(file string, issue domain.Issue) string {
+	if issue.Line > 0 {
+		file = fmt.Sprintf("%s:%d", file, issue.Line)
+	}
+
+	message := issue.Message
+	if issue.Rule != "" {
+		message += " [" + issue.Rule + "]"
+	}
+
+	return fmt.Sprintf("%s: %s: %s (%s)", file, issue.Severity, message, issue.Location())
+}
@@ -0,0 +1,251 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+
+	"github.com/GabrielNunesIT/openapi-converter/internal/adapters/parsers"
+	"github.com/spf13/cobra"
+)
+
+// batchFlags holds the flags of the batch command.
+type batchFlags struct {
+	outputDir string
+	patterns  []string
+	jobs      int
+}
+
+// batchResult is the outcome of converting one specification of a batch.
+type batchResult struct {
+	input  string
+	output string
+	err    error
+}
+
+func (c *CLI) newBatchCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "batch <dir>",
+		Short: "Convert every specification of a directory",
+		Long: "Converts every specification found under a directory in parallel, writing each output to the same " +
+			"relative path under the output directory with the extension of the format, e.g. specs/billing/api.yaml " +
+			"becomes docs/billing/api.md. Reports the specifications that failed and exits with an error when any did.",
+		Args:        cobra.ExactArgs(1),
+		RunE:        c.runBatch,
+		Annotations: configSectionAnnotation(""),
+	}
+
+	cmd.Flags().StringVar(&c.batch.outputDir, "out", "", "Directory to write the converted documents into (required)")
+	cmd.Flags().StringSliceVar(&c.batch.patterns, "pattern", []string{"*.yaml", "*.yml", "*.json"},
+		"Globs matched against file names to find the specifications")
+	cmd.Flags().IntVarP(&c.batch.jobs, "jobs", "j", runtime.NumCPU(), "Number of specifications to convert at the same time")
+	cmd.Flags().StringVarP(&c.format, "format", "f", "pdf", formatUsage())
+	c.addConversionFlags(cmd, &c.conversionFlags)
+	cmd.Flags().IntVar(&c.maxDocSize, "max-doc-size", 0, maxDocSizeUsage)
+	cmd.Flags().BoolVar(&c.strict, "strict", false, strictUsage)
+
+	_ = cmd.MarkFlagRequired("out")
+
+	return cmd
+}
+
+func (c *CLI) runBatch(_ *cobra.Command, args []string) error {
+	if c.batch.jobs < 1 {
+		return fmt.Errorf("--jobs must be at least 1, got %d", c.batch.jobs)
+	}
+
+	// Fail on an unknown format before converting anything
+	converter, err := c.getConverter(c.format, c.conversionFlags)
+	if err != nil {
+		return err
+	}
+
+	format := converter.Format()
+
+	specs, err := c.findSpecs(args[0])
+	if err != nil {
+		return err
+	}
+
+	if len(specs) == 0 {
+		return fmt.Errorf("no specification under %s matches %s", args[0], strings.Join(c.batch.patterns, ", "))
+	}
+
+	c.log.Infof("Converting %d specification(s) to %s format...", len(specs), format)
+
+	results := make([]batchResult, len(specs))
+	indexes := make(chan int)
+
+	var wg sync.WaitGroup
+	for range min(c.batch.jobs, len(specs)) {
+		wg.Go(func() {
+			for i := range indexes {
+				results[i] = c.convertBatchSpec(args[0], specs[i], format)
+			}
+		})
+	}
+
+	for i := range specs {
+		indexes <- i
+	}
+
+	close(indexes)
+	wg.Wait()
+
+	failed := 0
+
+	for _, result := range results {
+		if result.err != nil {
+			failed++
+
+			c.log.Errorf("Failed: %s: %v", result.input, result.err)
+
+			continue
+		}
+
+		c.log.Infof("Successfully created: %s", result.output)
+	}
+
+	c.log.Infof("Converted %d of %d specification(s), %d failed", len(results)-failed, len(results), failed)
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d specification(s) failed to convert", failed, len(results))
+	}
+
+	return nil
+}
+
+// findSpecs returns the files under dir whose name matches one of the patterns, relative to dir and sorted.
+// The output directory is skipped when it lies within dir, so that a previous batch is not converted again, and so
+// are the files that are not specifications, such as CI configurations or a package.json.
+func (c *CLI) findSpecs(dir string) ([]string, error) {
+	for _, pattern := range c.batch.patterns {
+		if _, err := filepath.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid pattern %q: %w", pattern, err)
+		}
+	}
+
+	outputDir, err := filepath.Abs(c.batch.outputDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve path: %w", err)
+	}
+
+	var specs []string
+
+	err = filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if entry.IsDir() {
+			if absPath, err := filepath.Abs(path); err == nil && absPath == outputDir {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		if !matchesPattern(c.batch.patterns, entry.Name()) {
+			return nil
+		}
+
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+
+		if data, err := os.ReadFile(path); err == nil && !parsers.IsSpecification(data) {
+			c.log.Infof("Skipped: %s is not an OpenAPI or Swagger specification", path)
+
+			return nil
+		}
+
+		specs = append(specs, rel)
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to search %s: %w", dir, err)
+	}
+
+	return specs, nil
+}
+
+// matchesPattern reports whether name matches one of the patterns, which are known to be valid.
+func matchesPattern(patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := filepath.Match(pattern, name); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// convertBatchSpec converts the specification at rel under dir into the same relative path under the output directory.
+// Each call creates its own converter, as converters keep state while rendering a document.
+func (c *CLI) convertBatchSpec(dir, rel, format string) batchResult {
+	input := filepath.Join(dir, rel)
+	output := filepath.Join(c.batch.outputDir, strings.TrimSuffix(rel, filepath.Ext(rel))+"."+formatExtension(format))
+	result := batchResult{input: input, output: output}
+
+	doc, sources, err := c.loadOpenAPI(input)
+	if err != nil {
+		result.err = fmt.Errorf("failed to load OpenAPI specification: %w", err)
+
+		return result
+	}
+
+	if c.strict {
+		if err := c.checkSpec(doc, sources, input); err != nil {
+			result.err = err
+
+			return result
+		}
+	}
+
+	doc, err = prepareDocument(doc, withServer(c.transforms, c.server), c.redaction, c.hideDeprecated, c.selection,
+		c.groupBy, c.tagOrder, c.operationOrder)
+	if err != nil {
+		result.err = err
+
+		return result
+	}
+
+	converter, err := c.getConverter(format, c.conversionFlags)
+	if err != nil {
+		result.err = err
+
+		return result
+	}
+
+	if err := os.MkdirAll(filepath.Dir(output), 0o755); err != nil {
+		result.err = fmt.Errorf("failed to create output directory: %w", err)
+
+		return result
+	}
+
+	file, err := os.Create(output)
+	if err != nil {
+		result.err = fmt.Errorf("failed to create output file: %w", err)
+
+		return result
+	}
+
+	if err := converter.Convert(doc, file); err != nil {
+		result.err = errors.Join(fmt.Errorf("conversion failed: %w", err), file.Close())
+
+		return result
+	}
+
+	if err := file.Close(); err != nil {
+		result.err = fmt.Errorf("failed to write output file: %w", err)
+	}
+
+	return result
+}
@@ -0,0 +1,108 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/GabrielNunesIT/openapi-converter/internal/adapters/parsers"
+	"github.com/spf13/cobra"
+)
+
+// bundleFlags holds the flags of the bundle and split commands.
+type bundleFlags struct {
+	outputFile string
+	outputDir  string
+}
+
+func (c *CLI) newBundleCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "bundle <spec>",
+		Short: "Inline every external reference of a specification into one file",
+		Long: "Reads an OpenAPI 3.0 specification spread over several files or URLs and writes it as a single document, " +
+			"with every externally referenced schema, parameter, response and other component moved into its components " +
+			"and referenced locally. The output is JSON when its file name ends in .json and YAML otherwise.",
+		Args: cobra.ExactArgs(1),
+		RunE: c.runBundle,
+	}
+
+	cmd.Flags().StringVarP(&c.bundle.outputFile, "output", "o", "", "Path for the bundled specification (default: standard output)")
+
+	return cmd
+}
+
+func (c *CLI) newSplitCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "split <spec>",
+		Short: "Break a specification into one file per path and component",
+		Long: "Reads an OpenAPI 3.0 specification and writes it to a directory as a root " + parsers.SplitRootFile +
+			" referencing one file per path under paths/ and one file per component under components/<collection>/, " +
+			"e.g. components/schemas/Pet.yaml. External references are bundled first, so the split files only " +
+			"reference each other.",
+		Args: cobra.ExactArgs(1),
+		RunE: c.runSplitSpec,
+	}
+
+	cmd.Flags().StringVar(&c.bundle.outputDir, "out", "", "Directory to write the split specification into (required)")
+
+	_ = cmd.MarkFlagRequired("out")
+
+	return cmd
+}
+
+func (c *CLI) runBundle(_ *cobra.Command, args []string) error {
+	bundled, err := parsers.Bundle(args[0])
+	if err != nil {
+		return fmt.Errorf("failed to bundle specification: %w", err)
+	}
+
+	data, err := parsers.EncodeDocument(bundled.Document(), !strings.EqualFold(filepath.Ext(c.bundle.outputFile), ".json"))
+	if err != nil {
+		return err
+	}
+
+	output, err := c.createOutput(c.bundle.outputFile)
+	if err != nil {
+		return err
+	}
+	defer output.Close()
+
+	if _, err := output.Write(data); err != nil {
+		return fmt.Errorf("failed to write bundled specification: %w", err)
+	}
+
+	c.log.Infof("Successfully created: %s", stdioName(c.bundle.outputFile, "standard output"))
+
+	return nil
+}
+
+func (c *CLI) runSplitSpec(_ *cobra.Command, args []string) error {
+	bundled, err := parsers.Bundle(args[0])
+	if err != nil {
+		return fmt.Errorf("failed to bundle specification: %w", err)
+	}
+
+	files := bundled.Split()
+
+	for name, tree := range files {
+		data, err := parsers.EncodeDocument(tree, true)
+		if err != nil {
+			return err
+		}
+
+		path := filepath.Join(c.bundle.outputDir, filepath.FromSlash(name))
+
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return fmt.Errorf("failed to create output directory: %w", err)
+		}
+
+		if err := os.WriteFile(path, data, 0o644); err != nil { //nolint:gosec // specifications are not secret
+			return fmt.Errorf("failed to write %s: %w", path, err)
+		}
+	}
+
+	c.log.Infof("Successfully split %s into %d files under: %s", args[0], len(files), c.bundle.outputDir)
+
+	return nil
+}
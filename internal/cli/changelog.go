@@ -0,0 +1,93 @@
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+
+	"github.com/GabrielNunesIT/openapi-converter/internal/adapters/converters"
+	"github.com/GabrielNunesIT/openapi-converter/internal/adapters/publishers"
+	"github.com/GabrielNunesIT/openapi-converter/internal/usecases/diff"
+	"github.com/spf13/cobra"
+)
+
+// changelogFlags holds the flags of the publish changelog command.
+type changelogFlags struct {
+	baseURL  string
+	spaceKey string
+	parentID string
+	title    string
+}
+
+func (c *CLI) newPublishChangelogCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "changelog <old-spec> <new-spec>",
+		Short: "Publish the changelog between two versions of an OpenAPI specification as a Confluence Cloud page",
+		Long: "Compares two versions of an OpenAPI specification and publishes the changes as a Confluence Cloud release page: " +
+			"the breaking changes in a panel, then tables of the added, removed and changed endpoints and schemas " +
+			"with a lozenge telling breaking changes apart. The page is updated when a page with the same title already " +
+			"exists in the space.\n\n" +
+			"Credentials and the site URL are read as for the publish command. " +
+			"Either specification may be \"-\" to read it from standard input.",
+		Args:        cobra.ExactArgs(2),
+		RunE:        c.runPublishChangelog,
+		Annotations: configSectionAnnotation("publish"),
+	}
+
+	cmd.Flags().StringVar(&c.changelog.baseURL, "base-url", os.Getenv(envConfluenceBaseURL),
+		"Confluence site URL, e.g. https://example.atlassian.net/wiki")
+	cmd.Flags().StringVar(&c.changelog.spaceKey, "space", "", "Key of the Confluence space to publish into (required)")
+	cmd.Flags().StringVar(&c.changelog.parentID, "parent", "", "ID of the parent page for a newly created page")
+	cmd.Flags().StringVar(&c.changelog.title, "title", "",
+		"Page title (defaults to \"<API title>: changes from <old version> to <new version>\")")
+
+	_ = cmd.MarkFlagRequired("space")
+
+	return cmd
+}
+
+func (c *CLI) runPublishChangelog(cmd *cobra.Command, args []string) error {
+	from, _, err := c.loadOpenAPI(args[0])
+	if err != nil {
+		return fmt.Errorf("failed to load old specification: %w", err)
+	}
+
+	to, _, err := c.loadOpenAPI(args[1])
+	if err != nil {
+		return fmt.Errorf("failed to load new specification: %w", err)
+	}
+
+	c.log.Infof("Comparing %s v%s with v%s", to.Title, from.Version, to.Version)
+
+	changelog := diff.Compare(from, to)
+
+	var content bytes.Buffer
+
+	if err := converters.NewADFConverter(converters.ADFOptions{}).ConvertChangelog(changelog, &content); err != nil {
+		return fmt.Errorf("conversion failed: %w", err)
+	}
+
+	title := c.changelog.title
+	if title == "" {
+		title = converters.ChangelogTitle(changelog)
+	}
+
+	publisher := publishers.NewConfluencePublisher(publishers.ConfluenceConfig{
+		BaseURL:  c.changelog.baseURL,
+		SpaceKey: c.changelog.spaceKey,
+		ParentID: c.changelog.parentID,
+		Email:    credentialEnv(c.credentials.EmailEnv, envConfluenceEmail),
+		APIToken: credentialEnv(c.credentials.TokenEnv, envConfluenceAPIToken),
+	})
+
+	c.log.Infof("Publishing %d change(s) as page %q to space %s...", len(changelog.Changes), title, c.changelog.spaceKey)
+
+	pageURL, err := publisher.Publish(cmd.Context(), title, content.Bytes())
+	if err != nil {
+		return fmt.Errorf("publishing failed: %w", err)
+	}
+
+	c.log.Infof("Successfully published: %s", pageURL)
+
+	return nil
+}
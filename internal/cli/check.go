@@ -0,0 +1,274 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+
+	"github.com/GabrielNunesIT/openapi-converter/internal/domain"
+)
+
+// checkContextLines is the number of unchanged lines shown around the changed ones of a drift report.
+const checkContextLines = 3
+
+// noNewlineMarker follows the last line of a file that does not end with a newline, as in the diffs of diff and git.
+// Carried by the line, it also tells that line apart from the same text ended by a newline.
+const noNewlineMarker = "\n\\ No newline at end of file"
+
+// maxDiffCells bounds the table compared lines are matched in; larger changes are reported as one replaced block.
+const maxDiffCells = 4_000_000
+
+// checkFormat converts a specification with one converter to a temporary directory and compares every file written
+// with the one of the same name next to path, or in the directory at path with --split. The differences are printed
+// as a unified diff and fail the check, so that CI can detect documentation that no longer matches its specification.
+func (c *CLI) checkFormat(doc *domain.OpenAPIDocument, converter domain.Converter, path string) error {
+	if isStdio(path) {
+		return errors.New("--check compares the conversion with existing files and requires an output path")
+	}
+
+	tmp, err := os.MkdirTemp("", "openapi-converter-check-")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary directory: %w", err)
+	}
+	defer os.RemoveAll(tmp)
+
+	if err := c.writeFormat(doc, converter, filepath.Join(tmp, filepath.Base(path))); err != nil {
+		return err
+	}
+
+	var report strings.Builder
+
+	drifted := 0
+
+	err = filepath.WalkDir(tmp, func(file string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+
+		rel, err := filepath.Rel(tmp, file)
+		if err != nil {
+			return fmt.Errorf("failed to locate %s: %w", file, err)
+		}
+
+		existing := filepath.Join(filepath.Dir(path), rel)
+
+		want, err := os.ReadFile(file)
+		if err != nil {
+			return fmt.Errorf("failed to read conversion: %w", err)
+		}
+
+		got, err := os.ReadFile(existing)
+		if errors.Is(err, os.ErrNotExist) || errors.Is(err, syscall.ENOTDIR) {
+			drifted++
+			fmt.Fprintf(&report, "%s is missing\n", existing)
+
+			return nil
+		} else if err != nil {
+			return fmt.Errorf("failed to read output file: %w", err)
+		}
+
+		if diff := lineDiff(existing, existing+" (expected)", got, want); diff != "" {
+			drifted++
+			report.WriteString(diff)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to compare with %s: %w", path, err)
+	}
+
+	if drifted > 0 {
+		fmt.Fprint(c.rootCmd.OutOrStdout(), report.String())
+
+		return fmt.Errorf("%s is out of date: %d file(s) differ from the conversion", path, drifted)
+	}
+
+	c.log.Infof("Up to date: %s", path)
+
+	return nil
+}
+
+// canonicalJSON indents a JSON document, so that documents differing only in layout compare equal and their
+// differences show line by line.
+func canonicalJSON(data []byte) ([]byte, error) {
+	var value any
+	if err := json.Unmarshal(data, &value); err != nil {
+		return nil, fmt.Errorf("failed to decode document: %w", err)
+	}
+
+	indented, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode document: %w", err)
+	}
+
+	return append(indented, '\n'), nil
+}
+
+// diffLine is a line of a diff: unchanged (' '), removed ('-') or added ('+').
+type diffLine struct {
+	op   byte
+	text string
+}
+
+// lineDiff returns the unified diff turning oldText into newText, or "" when they are equal.
+func lineDiff(oldName, newName string, oldText, newText []byte) string {
+	if bytes.Equal(oldText, newText) {
+		return ""
+	}
+
+	script := diffLines(splitLines(oldText), splitLines(newText))
+
+	// Lines of each side before every line of the script, to number the hunks
+	oldLines, newLines := make([]int, len(script)+1), make([]int, len(script)+1)
+
+	for i, line := range script {
+		oldLines[i+1], newLines[i+1] = oldLines[i], newLines[i]
+		if line.op != '+' {
+			oldLines[i+1]++
+		}
+
+		if line.op != '-' {
+			newLines[i+1]++
+		}
+	}
+
+	var out strings.Builder
+
+	fmt.Fprintf(&out, "--- %s\n+++ %s\n", oldName, newName)
+
+	for i := 0; i < len(script); {
+		if script[i].op == ' ' {
+			i++
+
+			continue
+		}
+
+		// Changes separated by fewer unchanged lines than their contexts share a hunk
+		end := i + 1
+		for j := end; j < len(script) && j-end < 2*checkContextLines; j++ {
+			if script[j].op != ' ' {
+				end = j + 1
+			}
+		}
+
+		start, stop := max(i-checkContextLines, 0), min(end+checkContextLines, len(script))
+
+		fmt.Fprintf(&out, "@@ -%d,%d +%d,%d @@\n", oldLines[start]+1, oldLines[stop]-oldLines[start],
+			newLines[start]+1, newLines[stop]-newLines[start])
+
+		for _, line := range script[start:stop] {
+			out.WriteByte(line.op)
+			out.WriteString(line.text)
+			out.WriteByte('\n')
+		}
+
+		i = stop
+	}
+
+	return out.String()
+}
+
+// splitLines returns the lines of text, the last one followed by noNewlineMarker when text does not end with a newline.
+func splitLines(text []byte) []string {
+	if len(text) == 0 {
+		return nil
+	}
+
+	content, terminated := strings.CutSuffix(string(text), "\n")
+
+	lines := strings.Split(content, "\n")
+	if !terminated {
+		lines[len(lines)-1] += noNewlineMarker
+	}
+
+	return lines
+}
+
+// diffLines returns the edit script turning the lines a into b, keeping the longest common subsequence of the lines
+// between their common prefix and suffix.
+func diffLines(a, b []string) []diffLine {
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	script := make([]diffLine, 0, len(a)+len(b))
+	for _, line := range a[:prefix] {
+		script = append(script, diffLine{' ', line})
+	}
+
+	script = append(script, diffMiddle(a[prefix:len(a)-suffix], b[prefix:len(b)-suffix])...)
+
+	for _, line := range a[len(a)-suffix:] {
+		script = append(script, diffLine{' ', line})
+	}
+
+	return script
+}
+
+func diffMiddle(a, b []string) []diffLine {
+	script := make([]diffLine, 0, len(a)+len(b))
+
+	if len(a)*len(b) > maxDiffCells {
+		for _, line := range a {
+			script = append(script, diffLine{'-', line})
+		}
+
+		for _, line := range b {
+			script = append(script, diffLine{'+', line})
+		}
+
+		return script
+	}
+
+	// lcs[i*width+j] is the length of the longest common subsequence of a[i:] and b[j:]
+	width := len(b) + 1
+	lcs := make([]int32, (len(a)+1)*width)
+
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i*width+j] = lcs[(i+1)*width+j+1] + 1
+			} else {
+				lcs[i*width+j] = max(lcs[(i+1)*width+j], lcs[i*width+j+1])
+			}
+		}
+	}
+
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			script = append(script, diffLine{' ', a[i]})
+			i, j = i+1, j+1
+		case lcs[(i+1)*width+j] >= lcs[i*width+j+1]:
+			script = append(script, diffLine{'-', a[i]})
+			i++
+		default:
+			script = append(script, diffLine{'+', b[j]})
+			j++
+		}
+	}
+
+	for _, line := range a[i:] {
+		script = append(script, diffLine{'-', line})
+	}
+
+	for _, line := range b[j:] {
+		script = append(script, diffLine{'+', line})
+	}
+
+	return script
+}
@@ -0,0 +1,66 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLineDiff(t *testing.T) {
+	numbers := "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n12\n"
+
+	tests := []struct {
+		name     string
+		old, new string
+		want     string
+	}{
+		{
+			name: "equal",
+			old:  numbers,
+			new:  numbers,
+		},
+		{
+			name: "missing newline at end of file",
+			old:  "a\nb",
+			new:  "a\nb\n",
+			want: "@@ -1,2 +1,2 @@\n a\n-b\n\\ No newline at end of file\n+b\n",
+		},
+		{
+			name: "added newline at end of file",
+			old:  "a\nb\n",
+			new:  "a\nb",
+			want: "@@ -1,2 +1,2 @@\n a\n-b\n+b\n\\ No newline at end of file\n",
+		},
+		{
+			name: "unchanged last line without newline",
+			old:  "a\nb\nc",
+			new:  "x\nb\nc",
+			want: "@@ -1,3 +1,3 @@\n-a\n+x\n b\n c\n\\ No newline at end of file\n",
+		},
+		{
+			name: "merged hunks",
+			old:  numbers,
+			new:  strings.Replace(strings.Replace(numbers, "\n2\n", "\ntwo\n", 1), "\n7\n", "\nseven\n", 1),
+			want: "@@ -1,10 +1,10 @@\n 1\n-2\n+two\n 3\n 4\n 5\n 6\n-7\n+seven\n 8\n 9\n 10\n",
+		},
+		{
+			name: "split hunks",
+			old:  numbers,
+			new:  strings.Replace(strings.Replace(numbers, "\n2\n", "\ntwo\n", 1), "\n10\n", "\nten\n", 1),
+			want: "@@ -1,5 +1,5 @@\n 1\n-2\n+two\n 3\n 4\n 5\n" +
+				"@@ -7,6 +7,6 @@\n 7\n 8\n 9\n-10\n+ten\n 11\n 12\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want := tt.want
+			if want != "" {
+				want = "--- old\n+++ new\n" + want
+			}
+
+			if got := lineDiff("old", "new", []byte(tt.old), []byte(tt.new)); got != want {
+				t.Errorf("got\n%s\nwant\n%s", got, want)
+			}
+		})
+	}
+}
@@ -2,25 +2,220 @@
 package cli
 
 import (
+	"bytes"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 
 	"github.com/GabrielNunesIT/go-libs/logger"
+	"github.com/GabrielNunesIT/openapi-converter/internal/adapters/captures"
 	"github.com/GabrielNunesIT/openapi-converter/internal/adapters/converters"
+	"github.com/GabrielNunesIT/openapi-converter/internal/adapters/parsers"
+	"github.com/GabrielNunesIT/openapi-converter/internal/config"
 	"github.com/GabrielNunesIT/openapi-converter/internal/domain"
-	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/GabrielNunesIT/openapi-converter/internal/usecases/filter"
+	"github.com/GabrielNunesIT/openapi-converter/internal/usecases/transform"
 	"github.com/spf13/cobra"
 )
 
 // CLI holds the command-line interface configuration.
 type CLI struct {
-	log        logger.ILogger
-	rootCmd    *cobra.Command
-	inputFile  string
-	outputFile string
-	format     string
+	conversionFlags
+
+	log           logger.ILogger
+	rootCmd       *cobra.Command
+	inputFile     string
+	outputFile    string
+	format        string
+	diffFormat    string // Format of the diff command, which defaults to markdown rather than pdf
+	enforceSemver bool
+	split         bool
+	formatOptions []string
+	maxDocSize    int
+	anchorsFile   string
+	strict        bool
+	check         bool
+	harFile       string
+	publish       publishFlags
+	notion        notionFlags
+	wiki          wikiFlags
+	storage       storageFlags
+	jira          jiraFlags
+	lint          lintFlags
+	stats         statsFlags
+	changelog     changelogFlags
+	serve         serveFlags
+	mock          mockFlags
+	batch         batchFlags
+	merge         mergeFlags
+	bundle        bundleFlags
+	configFile    string
+	pluginDir     string
+	transforms    transform.Chain
+	title         string
+	metadata      metadataFlags
+	notifications notifyFlags
+	credentials   config.Credentials
+}
+
+// conversionFlags holds the flags choosing the content of a specification to document and how to render it,
+// shared by the commands converting one.
+type conversionFlags struct {
+	tables         bool
+	hideDeprecated bool
+	server         string
+	selection      filter.Selection
+	redaction      filter.Redaction
+	groupBy        string
+	tagOrder       string
+	operationOrder string
+	templateDir    string
+	snippets       []string
+	extensions     []string
+	schemaDepth    int
+	schemaAppendix bool
+	directional    bool
+	schemaGraph    bool
+	sequences      bool
+	methodColors   []string
+	panels         []string
+	stableAnchors  bool
+	schemaRefs     string
+	goClient       bool
+	plainLinks     bool
+	collapseSize   int
+	locale         string
+	stringsFile    string
+}
+
+// extensionsUsage describes the extensions flag of the commands converting a specification.
+const extensionsUsage = "Vendor extensions of the API, operations and schemas to render, as x-name or x-name=Label, " +
+	"e.g. x-rate-limit=\"Rate limit\" (markdown, slate, html, confluence, confluence-storage and notion formats)"
+
+// schemaDepthUsage describes the schema-depth flag of the commands converting a specification.
+const schemaDepthUsage = "Levels of properties of inline objects listed under a schema " +
+	"(slate, confluence, confluence-storage and notion formats)"
+
+// schemaAppendixUsage describes the schema-appendix flag of the commands converting a specification.
+const schemaAppendixUsage = "Render every component schema once in a Schemas appendix that the tags link to, " +
+	"instead of repeating the schemas under every tag using them " +
+	"(markdown, html, confluence, confluence-storage, notion, pdf and docx formats)"
+
+// directionalFieldsUsage describes the directional-fields flag of the commands converting a specification.
+const directionalFieldsUsage = "Omit the readOnly properties from the request field tables and the writeOnly ones from the response " +
+	"field tables, as clients do not send the former and servers do not return the latter " +
+	"(markdown, slate, html, confluence, confluence-storage and notion formats)"
+
+// sequenceDiagramsUsage describes the sequence-diagrams flag of the commands converting a specification.
+const sequenceDiagramsUsage = "Render a Sequence section under the operations with links or callbacks, holding a Mermaid " +
+	"sequence diagram of the requests following them and of the callbacks received; HTML pages then load Mermaid " +
+	"from a CDN to draw them (markdown, hugo, docusaurus, mkdocs, html and confluence formats)"
+
+// schemaGraphUsage describes the schema-graph flag of the commands converting a specification.
+const schemaGraphUsage = "Render a Schema Graph section holding a Mermaid flowchart of the references between component schemas " +
+	"and from the operations, as the mermaid format writes it (markdown, hugo, docusaurus, mkdocs and confluence formats)"
+
+// methodColorsUsage describes the method-colors flag of the commands converting a specification.
+const methodColorsUsage = "Colours of the method lozenges heading the endpoints as method=colour, e.g. get=green,patch=purple, " +
+	"among neutral, purple, blue, red, yellow and green, or none for plain-text methods (confluence format)"
+
+// stableAnchorsUsage describes the stable-anchors flag of the commands converting a specification.
+const stableAnchorsUsage = "Anchor each operation at \"op-\" followed by its operationId, e.g. op-listPets, " +
+	"whatever else the document holds (markdown, html, confluence and confluence-storage formats)"
+
+// goClientUsage describes the go-client flag of the commands converting a specification.
+const goClientUsage = "Generate a client with a method per operation besides the types (golang format)"
+
+// plainLinksUsage describes the plain-links flag of the commands converting a specification.
+const plainLinksUsage = "Link server and external documentation URLs as text instead of smart-link cards (confluence format)"
+
+// formatOptionUsage describes the option flag of the commands converting a specification.
+const formatOptionUsage = "Option of a single output format as format.name=value, e.g. confluence.tables=true or " +
+	"markdown.schema-refs=both, overriding for that format the flag of the same name; repeatable, lists comma-separated"
+
+// collapseExamplesUsage describes the collapse-examples flag of the commands converting a specification.
+const collapseExamplesUsage = "Collapse the request and response examples longer than this many bytes into expands, " +
+	"0 to show them all under the request body and their status code (confluence format)"
+
+// stringsFileUsage describes the strings-file flag of the commands converting a specification.
+const stringsFileUsage = "YAML or JSON file mapping the English section headings and other fixed strings, e.g. Parameters, " +
+	"to their translation, replacing those of --locale (documentation formats)"
+
+// maxDocSizeUsage describes the max-doc-size flag of the commands writing files.
+const maxDocSizeUsage = "Split documents larger than this many bytes into continuation documents, e.g. api-2.json, " +
+	"each linked from the one before, 0 for no limit (confluence format written to files)"
+
+// anchorsFileUsage describes the anchors-file flag of the commands writing a single document.
+const anchorsFileUsage = "Write a JSON map of the operation anchors of the document, by operationId or \"METHOD /path\", " +
+	"to this file for deep links (markdown, html, confluence and confluence-storage formats)"
+
+// harUsage describes the har flag of the commands converting a specification.
+const harUsage = "HAR capture whose recorded request and response bodies are added as examples of the operations they call"
+
+// strictUsage describes the strict flag of the commands converting a specification.
+const strictUsage = "Validate the specification first and fail on structural errors, see the validate command"
+
+// checkUsage describes the check flag of the commands writing documentation files.
+const checkUsage = "Compare the conversion with the existing output files instead of writing them, " +
+	"failing with a diff when they differ"
+
+// serverUsage describes the server flag of the commands converting a specification.
+const serverUsage = "Server used by the request snippets, collections and mock, instead of the first one: its index from 0, " +
+	"its description or its URL, a URL the specification does not list being added first"
+
+// titleUsage describes the title flag of the commands converting a specification.
+const titleUsage = "Document title (defaults to the API title)"
+
+// configUsage describes the config flag of the root command.
+const configUsage = "Path to a YAML or JSON configuration setting the flags not given on the command line, " +
+	"with the same names, and a publish section for the publish commands (default: " + config.DefaultFile + " when present)"
+
+// envPluginDir is the environment variable holding the default plugin directory.
+const envPluginDir = "OPENAPI_CONVERTER_PLUGIN_DIR"
+
+// pluginDirUsage describes the plugin-dir flag of the root command.
+const pluginDirUsage = "Directory of converter plugins, executables named " + converters.PluginPrefix + "<format> " +
+	"reading a JSON request on standard input and writing the document to standard output, available as --format <format> " +
+	"(default: " + envPluginDir + ")"
+
+// stdioPath is the input or output path standing for standard input or output.
+const stdioPath = "-"
+
+// formatExtensions maps converter formats to the file extension used for split output.
+var formatExtensions = map[string]string{
+	"pdf":                "pdf",
+	"graphql":            "graphql",
+	"protobuf":           "proto",
+	"golang":             "go",
+	"gotest":             "contract_test.go",
+	"docx":               "docx",
+	"confluence":         "json",
+	"confluence-storage": "xml",
+	"markdown":           "md",
+	"docusaurus":         "mdx",
+	"hugo":               "md",
+	"mkdocs":             "md",
+	"slate":              "md",
+	"html":               "html",
+	"postman":            "postman_collection.json",
+	"insomnia":           "insomnia.json",
+	"mermaid":            "mmd",
+	"dot":                "dot",
+	"notion":             "json",
+}
+
+// formatExtension returns the file extension of a format, the format name itself for plugins.
+func formatExtension(format string) string {
+	if extension, ok := formatExtensions[format]; ok {
+		return extension
+	}
+
+	return format
 }
 
 // New creates a new CLI instance.
@@ -31,23 +226,48 @@ func New(log logger.ILogger) *CLI {
 
 	cli.rootCmd = &cobra.Command{
 		Use:   "openapi-converter",
-		Short: "Convert OpenAPI specifications to PDF or Word documents",
-		Long:  "A CLI tool that converts OpenAPI 3.x specifications to various document formats including PDF and Word (DOCX).",
-		RunE:  cli.run,
+		Short: "Convert OpenAPI specifications to PDF, Word, Confluence, Markdown or HTML documents",
+		Long: "A CLI tool that converts OpenAPI 3.x and Swagger 2.0 specifications to various document formats " +
+			"including PDF, Word (DOCX), Confluence (ADF), Markdown and HTML, or to a Postman collection.",
+		RunE:              cli.run,
+		PersistentPreRunE: cli.preRun,
+		Annotations:       configSectionAnnotation(""),
 	}
 
 	cli.setupFlags()
+	cli.rootCmd.AddCommand(cli.newPublishCmd())
+	cli.rootCmd.AddCommand(cli.newWatchCmd())
+	cli.rootCmd.AddCommand(cli.newDiffCmd())
+	cli.rootCmd.AddCommand(cli.newValidateCmd())
+	cli.rootCmd.AddCommand(cli.newLintCmd())
+	cli.rootCmd.AddCommand(cli.newStatsCmd())
+	cli.rootCmd.AddCommand(cli.newServeCmd())
+	cli.rootCmd.AddCommand(cli.newMockCmd())
+	cli.rootCmd.AddCommand(cli.newBatchCmd())
+	cli.rootCmd.AddCommand(cli.newMergeCmd())
+	cli.rootCmd.AddCommand(cli.newBundleCmd())
+	cli.rootCmd.AddCommand(cli.newSplitCmd())
 
 	return cli
 }
 
 func (c *CLI) setupFlags() {
-	c.rootCmd.Flags().StringVarP(&c.inputFile, "input", "i", "", "Path to the OpenAPI specification file (required)")
-	c.rootCmd.Flags().StringVarP(&c.outputFile, "output", "o", "", "Path for the output file (required)")
-	c.rootCmd.Flags().StringVarP(&c.format, "format", "f", "pdf", "Output format: pdf, docx")
-
-	_ = c.rootCmd.MarkFlagRequired("input")
-	_ = c.rootCmd.MarkFlagRequired("output")
+	c.rootCmd.PersistentFlags().StringVar(&c.configFile, "config", "", configUsage)
+	c.rootCmd.PersistentFlags().StringVar(&c.pluginDir, "plugin-dir", os.Getenv(envPluginDir), pluginDirUsage)
+	c.rootCmd.Flags().StringVarP(&c.inputFile, "input", "i", "", "Path to the OpenAPI specification file (default: standard input)")
+	c.rootCmd.Flags().StringVarP(&c.outputFile, "output", "o", "", "Path for the output file (default: standard output)")
+	c.rootCmd.Flags().StringVarP(&c.format, "format", "f", "pdf", formatListUsage())
+	c.rootCmd.Flags().StringVar(&c.title, "title", "", titleUsage)
+	addMetadataFlags(c.rootCmd, &c.metadata)
+	c.addConversionFlags(c.rootCmd, &c.conversionFlags)
+	c.rootCmd.Flags().BoolVar(&c.split, "split", false,
+		"Write one document per tag plus an index into the output directory instead of a single file")
+	c.rootCmd.Flags().IntVar(&c.maxDocSize, "max-doc-size", 0, maxDocSizeUsage)
+	c.rootCmd.Flags().StringVar(&c.anchorsFile, "anchors-file", "", anchorsFileUsage)
+	c.rootCmd.Flags().BoolVar(&c.strict, "strict", false, strictUsage)
+	c.rootCmd.Flags().BoolVar(&c.check, "check", false, checkUsage)
+	c.rootCmd.Flags().StringVar(&c.harFile, "har", "", harUsage)
+	addNotifyFlags(c.rootCmd, &c.notifications)
 }
 
 // Execute runs the CLI.
@@ -55,224 +275,617 @@ func (c *CLI) Execute() error {
 	return c.rootCmd.Execute()
 }
 
-func (c *CLI) run(_ *cobra.Command, _ []string) error {
-	c.log.Infof("Loading OpenAPI specification from: %s", c.inputFile)
+func (c *CLI) run(cmd *cobra.Command, _ []string) error {
+	notifier, err := c.notifier(c.notifications)
+	if err != nil {
+		return err
+	}
+
+	doc, _, err := c.convert()
+	if err != nil || c.check {
+		return err
+	}
+
+	link := c.outputFile
+	if isStdio(link) {
+		link = ""
+	}
+
+	c.notify(cmd.Context(), notifier, c.notifications, doc, doc.Title, link)
+
+	return nil
+}
+
+// convert loads the input specification and writes the output, returning the document written and the files
+// the specification was read from.
+func (c *CLI) convert() (*domain.OpenAPIDocument, []string, error) {
+	c.log.Infof("Loading OpenAPI specification from: %s", stdioName(c.inputFile, "standard input"))
 
-	doc, err := c.loadOpenAPI(c.inputFile)
+	doc, sources, err := c.loadOpenAPI(c.inputFile)
 	if err != nil {
-		return fmt.Errorf("failed to load OpenAPI specification: %w", err)
+		return nil, nil, fmt.Errorf("failed to load OpenAPI specification: %w", err)
 	}
 
 	c.log.Infof("Loaded API: %s (v%s)", doc.Title, doc.Version)
 
-	converter, err := c.getConverter()
+	if c.title != "" {
+		doc.Title = c.title
+	}
+
+	if c.strict {
+		if err := c.checkSpec(doc, sources, c.inputFile); err != nil {
+			return nil, sources, err
+		}
+	}
+
+	sources = append(sources, metadataSources(c.metadata)...)
+
+	if err := applyMetadata(doc, c.metadata); err != nil {
+		return nil, sources, err
+	}
+
+	if c.harFile != "" {
+		sources = append(sources, c.harFile)
+
+		if err := c.enrichDocument(doc); err != nil {
+			return nil, sources, err
+		}
+	}
+
+	doc, err = prepareDocument(doc, withServer(c.transforms, c.server), c.redaction, c.hideDeprecated, c.selection,
+		c.groupBy, c.tagOrder, c.operationOrder)
 	if err != nil {
-		return err
+		return nil, sources, err
+	}
+
+	if err := c.writeDocument(doc); err != nil {
+		return nil, sources, err
+	}
+
+	return doc, sources, nil
+}
+
+// writeDocument converts a loaded specification to the output file, or directory with --split.
+// Several comma-separated formats are converted concurrently, see writeFormats.
+func (c *CLI) writeDocument(doc *domain.OpenAPIDocument) error {
+	if c.split && c.anchorsFile != "" {
+		return errors.New("--anchors-file maps the anchors of a single document and cannot be combined with --split")
+	}
+
+	if formats := strings.Split(c.format, ","); len(formats) > 1 {
+		if err := c.writeFormats(doc, formats); err != nil {
+			return err
+		}
+	} else {
+		converter, err := c.getConverter(c.format, c.conversionFlags)
+		if err != nil {
+			return err
+		}
+
+		if err := c.outputFormat(doc, converter, c.outputFile); err != nil {
+			return err
+		}
+	}
+
+	if c.anchorsFile == "" || c.check {
+		return nil
+	}
+
+	return c.writeAnchors(c.anchorsFile, converters.OperationAnchors(doc, c.stableAnchors))
+}
+
+// writeFormats converts a specification parsed once to several formats in parallel, each converter writing its own file:
+// the output path with the extension of the format, e.g. docs/api.md and docs/api.html for -o docs/api,
+// or a subdirectory of the output directory named after the format with --split.
+// Formats sharing an extension are told apart by their name, e.g. docs/api-markdown.md and docs/api-slate.md.
+func (c *CLI) writeFormats(doc *domain.OpenAPIDocument, formats []string) error {
+	if isStdio(c.outputFile) {
+		return errors.New("several formats write several files and require an output path")
+	}
+
+	// Fail on an unknown or repeated format before converting anything
+	converterList := make([]domain.Converter, 0, len(formats))
+	listed := make(map[string]struct{}, len(formats))
+	extensions := make(map[string]int, len(formats)) // Number of formats using each extension
+
+	for _, format := range formats {
+		converter, err := c.getConverter(strings.TrimSpace(format), c.conversionFlags)
+		if err != nil {
+			return err
+		}
+
+		if _, ok := listed[converter.Format()]; ok {
+			return fmt.Errorf("format %s is listed more than once", converter.Format())
+		}
+
+		listed[converter.Format()] = struct{}{}
+		extensions[formatExtension(converter.Format())]++
+		converterList = append(converterList, converter)
+	}
+
+	base := strings.TrimSuffix(c.outputFile, filepath.Ext(c.outputFile))
+	errs := make([]error, len(converterList))
+
+	var wg sync.WaitGroup
+	for i, converter := range converterList {
+		format := converter.Format()
+
+		path := filepath.Join(c.outputFile, format)
+		if !c.split {
+			path = base
+			if extensions[formatExtension(format)] > 1 {
+				path += "-" + format
+			}
+
+			path += "." + formatExtension(format)
+		}
+
+		wg.Go(func() {
+			if err := c.outputFormat(doc, converter, path); err != nil {
+				errs[i] = fmt.Errorf("%s: %w", format, err)
+			}
+		})
 	}
 
+	wg.Wait()
+
+	return errors.Join(errs...)
+}
+
+// outputFormat writes the conversion of a specification with one converter to path, or compares it with
+// the files at path with --check.
+func (c *CLI) outputFormat(doc *domain.OpenAPIDocument, converter domain.Converter, path string) error {
+	if c.check {
+		return c.checkFormat(doc, converter, path)
+	}
+
+	return c.writeFormat(doc, converter, path)
+}
+
+// writeFormat converts a specification with one converter to the output file at path, or directory with --split.
+func (c *CLI) writeFormat(doc *domain.OpenAPIDocument, converter domain.Converter, path string) error {
 	c.log.Infof("Converting to %s format...", converter.Format())
 
-	outputFile, err := os.Create(c.outputFile)
+	if c.split {
+		return c.runSplit(doc, converter, path)
+	}
+
+	if _, ok := converter.(*converters.ADFConverter); ok && c.maxDocSize > 0 && !isStdio(path) {
+		return c.writeContinued(doc, converter, path)
+	}
+
+	output, err := c.createOutput(path)
 	if err != nil {
-		return fmt.Errorf("failed to create output file: %w", err)
+		return err
 	}
-	defer outputFile.Close()
+	defer output.Close()
 
-	if err := converter.Convert(doc, outputFile); err != nil {
+	if err := converter.Convert(doc, output); err != nil {
 		return fmt.Errorf("conversion failed: %w", err)
 	}
 
-	c.log.Infof("Successfully created: %s", c.outputFile)
+	// With --check the files are temporary, see checkFormat
+	if !c.check {
+		c.log.Infof("Successfully created: %s", stdioName(path, "standard output"))
+	}
 
 	return nil
 }
 
-func (c *CLI) getConverter() (domain.Converter, error) {
-	format := strings.ToLower(c.format)
+// writeContinued converts a specification to an ADF document and writes it to path, split as writeParts does.
+func (c *CLI) writeContinued(doc *domain.OpenAPIDocument, converter domain.Converter, path string) error {
+	var content bytes.Buffer
 
-	switch format {
-	case "pdf":
-		return converters.NewPDFConverter(), nil
-	case "docx", "word":
-		return converters.NewDocxConverter(), nil
-	case "confluence", "adf":
-		return converters.NewADFConverter(), nil
-	default:
-		return nil, fmt.Errorf("unsupported format: %s (supported: pdf, docx, confluence)", c.format)
+	if err := converter.Convert(doc, &content); err != nil {
+		return fmt.Errorf("conversion failed: %w", err)
 	}
-}
 
-func (c *CLI) loadOpenAPI(path string) (*domain.OpenAPIDocument, error) {
-	loader := openapi3.NewLoader()
-	loader.IsExternalRefsAllowed = true
+	if err := c.writeParts(path, content.Bytes()); err != nil {
+		return err
+	}
 
-	absPath, err := filepath.Abs(path)
-	if err != nil {
-		return nil, fmt.Errorf("failed to resolve path: %w", err)
+	// With --check the files are temporary, see checkFormat
+	if !c.check {
+		c.log.Infof("Successfully created: %s", path)
 	}
 
-	spec, err := loader.LoadFromFile(absPath)
+	return nil
+}
+
+// writeParts writes an ADF document to path, splitting it first when it exceeds --max-doc-size: the first part is
+// written at path and the others next to it, e.g. api-2.json and api-3.json, each linked from the one before.
+// Nothing is written when the document cannot be split.
+func (c *CLI) writeParts(path string, content []byte) error {
+	parts, err := converters.SplitADF(content, c.maxDocSize)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse OpenAPI file: %w", err)
+		return fmt.Errorf("failed to split %s: %w", path, err)
+	}
+
+	ext := filepath.Ext(path)
+	paths := []string{path}
+
+	for i := 2; i <= len(parts); i++ {
+		paths = append(paths, fmt.Sprintf("%s-%d%s", strings.TrimSuffix(path, ext), i, ext))
+	}
+
+	for i, part := range parts {
+		if i+1 < len(parts) {
+			next := filepath.Base(paths[i+1])
+			if part, err = converters.LinkADFContinuation(part, next, next); err != nil {
+				return fmt.Errorf("failed to link %s to its continuation: %w", paths[i], err)
+			}
+		}
+
+		if err := os.WriteFile(paths[i], part, 0o644); err != nil { //nolint:gosec // documentation is not secret
+			return fmt.Errorf("failed to write output file: %w", err)
+		}
+	}
+
+	if len(parts) > 1 {
+		c.log.Infof("Split %s into %d documents of at most %d bytes: %s", path, len(parts), c.maxDocSize,
+			strings.Join(paths[1:], ", "))
 	}
 
-	return c.convertSpec(spec), nil
+	return nil
 }
 
-func (c *CLI) convertSpec(spec *openapi3.T) *domain.OpenAPIDocument {
-	doc := &domain.OpenAPIDocument{
-		Title:       spec.Info.Title,
-		Version:     spec.Info.Version,
-		Description: spec.Info.Description,
-		Components:  make(map[string]domain.Schema),
+// writeAnchors writes a map of operation anchors as JSON.
+func (c *CLI) writeAnchors(path string, anchors map[string]converters.OperationAnchor) error {
+	data, err := json.MarshalIndent(anchors, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode anchors: %w", err)
 	}
 
-	// Convert servers
-	for _, server := range spec.Servers {
-		doc.Servers = append(doc.Servers, domain.Server{
-			URL:         server.URL,
-			Description: server.Description,
-		})
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil { //nolint:gosec // anchors are not secret
+		return fmt.Errorf("failed to write anchors: %w", err)
 	}
 
-	// Convert paths
-	for pathStr, pathItem := range spec.Paths.Map() {
-		path := domain.Path{Path: pathStr}
+	c.log.Infof("Successfully created: %s", path)
+
+	return nil
+}
 
-		path.Operations = c.convertOperations(pathItem)
-		doc.Paths = append(doc.Paths, path)
+func (c *CLI) runSplit(doc *domain.OpenAPIDocument, converter domain.Converter, dir string) error {
+	if isStdio(dir) {
+		return errors.New("--split writes several files and requires an output directory")
+	}
+
+	// Converters laying the parts out themselves split on their own, others through a TagSplitter
+	splitter, ok := converter.(domain.MultiConverter)
+	if !ok {
+		splitter = converters.NewTagSplitter(converter, formatExtension(converter.Format()))
+	}
+
+	files, err := splitter.MultiConvert(doc, dir)
+	if err != nil {
+		return fmt.Errorf("conversion failed: %w", err)
 	}
 
-	// Convert components/schemas
-	if spec.Components != nil && spec.Components.Schemas != nil {
-		for name, schemaRef := range spec.Components.Schemas {
-			doc.Components[name] = c.convertSchema(schemaRef)
+	for _, file := range files {
+		if !c.check {
+			c.log.Infof("Successfully created: %s", file)
+		}
+
+		if err := c.continueOversized(converter, file); err != nil {
+			return err
 		}
 	}
 
-	return doc
+	return nil
 }
 
-func (c *CLI) convertOperations(pathItem *openapi3.PathItem) []domain.Operation {
-	var operations []domain.Operation
+// continueOversized splits an ADF document written at path that exceeds --max-doc-size as writeParts does,
+// removing it when it cannot be split.
+func (c *CLI) continueOversized(converter domain.Converter, path string) error {
+	if _, ok := converter.(*converters.ADFConverter); !ok || c.maxDocSize <= 0 {
+		return nil
+	}
 
-	methods := map[string]*openapi3.Operation{
-		"GET":     pathItem.Get,
-		"POST":    pathItem.Post,
-		"PUT":     pathItem.Put,
-		"DELETE":  pathItem.Delete,
-		"PATCH":   pathItem.Patch,
-		"HEAD":    pathItem.Head,
-		"OPTIONS": pathItem.Options,
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read output file: %w", err)
 	}
 
-	for method, op := range methods {
-		if op == nil {
-			continue
-		}
+	if len(content) <= c.maxDocSize {
+		return nil
+	}
 
-		operation := domain.Operation{
-			Method:      method,
-			Summary:     op.Summary,
-			Description: op.Description,
-			OperationID: op.OperationID,
-			Tags:        op.Tags,
+	if err := c.writeParts(path, content); err != nil {
+		return errors.Join(err, os.Remove(path))
+	}
+
+	return nil
+}
+
+// getConverter returns the converter to a format rendering a specification the way the flags of a command say.
+func (c *CLI) getConverter(format string, flags conversionFlags) (domain.Converter, error) {
+	locale, err := converters.LoadLocale(flags.locale, flags.stringsFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load locale: %w", err)
+	}
+
+	values, err := c.formatValues(format)
+	if err != nil {
+		return nil, err
+	}
+
+	return converters.DefaultRegistry.New(format, converters.Options{
+		Tables:         flags.tables,
+		TemplateDir:    flags.templateDir,
+		Snippets:       flags.snippets,
+		Extensions:     flags.extensions,
+		SchemaDepth:    flags.schemaDepth,
+		SchemaAppendix: flags.schemaAppendix,
+		Directional:    flags.directional,
+		SchemaGraph:    flags.schemaGraph,
+		Sequences:      flags.sequences,
+		MethodColors:   flags.methodColors,
+		Panels:         flags.panels,
+		StableAnchors:  flags.stableAnchors,
+		SchemaRefs:     flags.schemaRefs,
+		GoClient:       flags.goClient,
+		PlainLinks:     flags.plainLinks,
+		CollapseSize:   flags.collapseSize,
+		Locale:         locale,
+		Values:         values,
+	})
+}
+
+// formatValues returns the values of the --option flags set for a format, by option name. Options of unknown
+// formats fail, those of other formats are left to them.
+func (c *CLI) formatValues(format string) (map[string]string, error) {
+	canonical, _ := converters.DefaultRegistry.Canonical(format)
+	values := make(map[string]string)
+
+	for _, option := range c.formatOptions {
+		name, value, ok := strings.Cut(option, "=")
+		optionFormat, name, dotted := strings.Cut(name, ".")
+
+		if !ok || !dotted || name == "" {
+			return nil, fmt.Errorf("invalid option %q, expected format.name=value", option)
 		}
 
-		// Convert parameters
-		for _, param := range op.Parameters {
-			if param.Value == nil {
-				continue
-			}
+		target, known := converters.DefaultRegistry.Canonical(optionFormat)
+		if !known {
+			return nil, fmt.Errorf("invalid option %q: unsupported format %s", option, optionFormat)
+		}
 
-			operation.Parameters = append(operation.Parameters, domain.Parameter{
-				Name:        param.Value.Name,
-				In:          param.Value.In,
-				Description: param.Value.Description,
-				Required:    param.Value.Required,
-				Schema:      c.convertSchema(param.Value.Schema),
-			})
+		if target == canonical {
+			values[name] = value
 		}
+	}
 
-		// Convert responses
-		if op.Responses != nil {
-			for statusCode, response := range op.Responses.Map() {
-				if response.Value == nil {
-					continue
-				}
+	return values, nil
+}
 
-				resp := domain.Response{
-					StatusCode: statusCode,
-				}
+// formatUsage describes the format flag with the registered output formats.
+func formatUsage() string {
+	return "Output format: " + strings.Join(converters.DefaultRegistry.Formats(), ", ")
+}
 
-				if response.Value.Description != nil {
-					resp.Description = *response.Value.Description
-				}
+// formatListUsage describes the format flag of the commands converting to several formats in one run.
+func formatListUsage() string {
+	return formatUsage() + ", or several comma-separated formats converted in parallel, " +
+		"each written to the output path with the extension of its format"
+}
 
-				resp.Content = c.convertContent(response.Value.Content)
-				operation.Responses = append(operation.Responses, resp)
-			}
+// operationOrderUsage describes the sort-operations flag with the supported orders.
+func operationOrderUsage() string {
+	return "Order of the operations of each tag: " + strings.Join(converters.OperationOrders(), ", ") +
+		"; spec-order keeps the document order of paths, their operations in method order"
+}
+
+// groupByUsage describes the group-by flag with the supported groupings.
+func groupByUsage() string {
+	return "Grouping of the operations into sections: " + strings.Join(converters.GroupBys(), ", ") +
+		"; x-group uses the x-group extension of the operations, falling back to their tags"
+}
+
+// schemaRefsUsage describes the schema-refs flag with the supported renderings.
+func schemaRefsUsage() string {
+	return "Rendering of the component schemas request and response bodies refer to: " +
+		strings.Join(converters.SchemaRefModes(), ", ") + "; inline lists the fields of request bodies and responses, reference links " +
+		"schema names to the Schemas appendix, which it renders, both does both (markdown, docusaurus, hugo, mkdocs and html formats)"
+}
+
+// tagOrderUsage describes the tag-order flag with the supported orders.
+func tagOrderUsage() string {
+	return "Order of the tag sections, " + strings.Join(converters.TagOrders(), " or ") +
+		": spec follows the top-level tags of the specification, with undeclared tags last, alpha sorts them by name"
+}
+
+// localeUsage describes the locale flag with the built-in locales.
+func localeUsage() string {
+	return "Language of the section headings and other fixed strings: " + strings.Join(converters.Locales(), ", ") +
+		" (documentation formats)"
+}
+
+// panelsUsage describes the panels flag with the sections that can be set in panels.
+func panelsUsage() string {
+	return "Sections set in info, warning and note panels, empty for none (confluence format): " +
+		strings.Join(converters.PanelSections(), ", ")
+}
+
+// snippetsUsage describes the snippets flag with the supported languages.
+func snippetsUsage() string {
+	return "Languages of the example request of each endpoint, empty for none " +
+		"(markdown, slate, confluence, confluence-storage and notion formats): " + strings.Join(converters.SnippetLanguages(), ", ")
+}
+
+// addConversionFlags adds the flags of a command converting a specification, and the --option flags setting the
+// options of a single format.
+func (c *CLI) addConversionFlags(cmd *cobra.Command, flags *conversionFlags) {
+	cmd.Flags().BoolVar(&flags.tables, "tables", false,
+		"Render parameters and responses as tables (confluence and confluence-storage formats)")
+	cmd.Flags().BoolVar(&flags.hideDeprecated, "hide-deprecated", false, "Omit deprecated operations, parameters and schemas")
+	cmd.Flags().StringVar(&flags.server, "server", "", serverUsage)
+	addSelectionFlags(cmd, &flags.selection)
+	addRedactionFlags(cmd, &flags.redaction)
+	cmd.Flags().StringVar(&flags.groupBy, "group-by", converters.GroupByTag, groupByUsage())
+	cmd.Flags().StringVar(&flags.tagOrder, "tag-order", converters.TagOrderSpec, tagOrderUsage())
+	cmd.Flags().StringVar(&flags.operationOrder, "sort-operations", converters.OperationOrderPath, operationOrderUsage())
+	cmd.Flags().StringVar(&flags.templateDir, "template-dir", "",
+		"Directory of *.tmpl files overriding the heading, tag, operation and schema templates (markdown, slate and html formats)")
+	cmd.Flags().StringSliceVar(&flags.snippets, "snippets", []string{"curl"}, snippetsUsage())
+	cmd.Flags().StringSliceVar(&flags.extensions, "extensions", nil, extensionsUsage)
+	cmd.Flags().IntVar(&flags.schemaDepth, "schema-depth", converters.DefaultSchemaDepth, schemaDepthUsage)
+	cmd.Flags().BoolVar(&flags.schemaAppendix, "schema-appendix", false, schemaAppendixUsage)
+	cmd.Flags().BoolVar(&flags.directional, "directional-fields", false, directionalFieldsUsage)
+	cmd.Flags().BoolVar(&flags.schemaGraph, "schema-graph", false, schemaGraphUsage)
+	cmd.Flags().BoolVar(&flags.sequences, "sequence-diagrams", false, sequenceDiagramsUsage)
+	cmd.Flags().StringSliceVar(&flags.methodColors, "method-colors", nil, methodColorsUsage)
+	cmd.Flags().StringSliceVar(&flags.panels, "panels", converters.PanelSections(), panelsUsage())
+	cmd.Flags().BoolVar(&flags.stableAnchors, "stable-anchors", false, stableAnchorsUsage)
+	cmd.Flags().StringVar(&flags.schemaRefs, "schema-refs", converters.SchemaRefsInline, schemaRefsUsage())
+	cmd.Flags().BoolVar(&flags.goClient, "go-client", false, goClientUsage)
+	cmd.Flags().BoolVar(&flags.plainLinks, "plain-links", false, plainLinksUsage)
+	cmd.Flags().IntVar(&flags.collapseSize, "collapse-examples", 0, collapseExamplesUsage)
+	cmd.Flags().StringArrayVar(&c.formatOptions, "option", nil, formatOptionUsage)
+	cmd.Flags().StringVar(&flags.locale, "locale", converters.DefaultLocale, localeUsage())
+	cmd.Flags().StringVar(&flags.stringsFile, "strings-file", "", stringsFileUsage)
+}
+
+// addSelectionFlags adds the flags choosing the operations to document.
+func addSelectionFlags(cmd *cobra.Command, selection *filter.Selection) {
+	cmd.Flags().StringSliceVar(&selection.IncludeTags, "include-tags", nil, "Only document operations with one of these tags")
+	cmd.Flags().StringSliceVar(&selection.ExcludeTags, "exclude-tags", nil, "Omit operations with any of these tags")
+	cmd.Flags().StringSliceVar(&selection.IncludePaths, "include-paths", nil,
+		"Only document paths matching one of these globs, e.g. /pets/* or /admin/** (* stays within a path segment)")
+	cmd.Flags().StringSliceVar(&selection.Operations, "operations", nil, "Only document operations with one of these operationIds")
+}
+
+// addRedactionFlags adds the flags choosing the audience of the documentation and the internal parts hidden from the public.
+func addRedactionFlags(cmd *cobra.Command, redaction *filter.Redaction) {
+	cmd.Flags().StringVar(&redaction.Audience, "audience", filter.AudienceInternal,
+		"Audience of the documentation: "+strings.Join(filter.Audiences(), ", ")+
+			"; the public one omits the operations and schemas flagged x-internal: true or matching --internal-paths and --internal-schemas, "+
+			"the internal one documents everything")
+	cmd.Flags().StringSliceVar(&redaction.Paths, "internal-paths", nil,
+		"Globs of the internal paths omitted from public documentation, e.g. /admin/**")
+	cmd.Flags().StringSliceVar(&redaction.Schemas, "internal-schemas", nil,
+		"Globs of the names of the internal component schemas omitted from public documentation, e.g. Internal*")
+}
+
+// prepareDocument runs the transforms of the configuration on a specification, removes the parts of it hidden from
+// the audience of the redaction and the deprecated and unselected operations before conversion, then groups its
+// operations the given way and puts the groups and operations in the given orders.
+func prepareDocument(doc *domain.OpenAPIDocument, transforms transform.Chain, redaction filter.Redaction, hideDeprecated bool,
+	selection filter.Selection, groupBy, tagOrder, operationOrder string,
+) (*domain.OpenAPIDocument, error) {
+	if err := transforms.Run(doc); err != nil {
+		return nil, err
+	}
+
+	doc, err := filter.Redact(doc, redaction)
+	if err != nil {
+		return nil, err
+	}
+
+	if hideDeprecated {
+		doc = filter.WithoutDeprecated(doc)
+	}
+
+	if !selection.IsEmpty() {
+		selected, err := filter.Select(doc, selection)
+		if err != nil {
+			return nil, err
 		}
 
-		// Convert request body
-		if op.RequestBody != nil && op.RequestBody.Value != nil {
-			operation.RequestBody = &domain.RequestBody{
-				Description: op.RequestBody.Value.Description,
-				Required:    op.RequestBody.Value.Required,
-				Content:     c.convertContent(op.RequestBody.Value.Content),
-			}
+		if len(selected.Paths) == 0 && len(selected.Webhooks) == 0 {
+			return nil, errors.New("no operation matches the --include-tags, --exclude-tags, --include-paths and --operations filters")
 		}
 
-		operations = append(operations, operation)
+		doc = selected
 	}
 
-	return operations
+	doc, err = converters.GroupOperations(doc, groupBy)
+	if err != nil {
+		return nil, err
+	}
+
+	doc, err = converters.OrderTags(doc, tagOrder)
+	if err != nil {
+		return nil, err
+	}
+
+	return converters.OrderOperations(doc, operationOrder)
 }
 
-func (c *CLI) convertContent(content openapi3.Content) map[string]domain.MediaType {
-	result := make(map[string]domain.MediaType)
+// enrichDocument adds the request and response bodies recorded in the --har capture as examples of the operations.
+func (c *CLI) enrichDocument(doc *domain.OpenAPIDocument) error {
+	har, err := captures.ReadHARFile(c.harFile)
+	if err != nil {
+		return fmt.Errorf("failed to load HAR capture: %w", err)
+	}
+
+	matched := har.Enrich(doc)
+	c.log.Infof("Matched %d of %d HAR entries to operations", matched, len(har.Log.Entries))
 
-	for mediaType, item := range content {
-		result[mediaType] = domain.MediaType{
-			Schema: c.convertSchema(item.Schema),
+	return nil
+}
+
+// loadOpenAPI parses the specification at path and returns it with the locations it was read from.
+// An empty path or "-" reads the specification from standard input.
+func (c *CLI) loadOpenAPI(path string) (*domain.OpenAPIDocument, []string, error) {
+	if isStdio(path) {
+		input := c.rootCmd.InOrStdin()
+		if file, ok := input.(*os.File); ok && isTerminal(file) {
+			return nil, nil, errors.New("no specification given: pass an input file or pipe one on standard input")
 		}
+
+		doc, err := parsers.ParseReader(input)
+
+		return doc, nil, err
 	}
 
-	return result
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to resolve path: %w", err)
+	}
+
+	return parsers.ParseFileWithSources(absPath)
 }
 
-func (c *CLI) convertSchema(ref *openapi3.SchemaRef) domain.Schema {
-	if ref == nil {
-		return domain.Schema{}
+// createOutput creates the output file at path, or writes to standard output when path is empty or "-".
+func (c *CLI) createOutput(path string) (io.WriteCloser, error) {
+	if isStdio(path) {
+		return nopWriteCloser{c.rootCmd.OutOrStdout()}, nil
 	}
 
-	schema := domain.Schema{
-		Ref: ref.Ref,
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create output file: %w", err)
 	}
 
-	if ref.Value != nil {
-		types := ref.Value.Type.Slice()
-		if len(types) > 0 {
-			schema.Type = types[0]
-		}
-		schema.Format = ref.Value.Format
-		schema.Description = ref.Value.Description
+	return file, nil
+}
 
-		// Convert properties
-		if len(ref.Value.Properties) > 0 {
-			schema.Properties = make(map[string]domain.Schema)
+// nopWriteCloser keeps standard output open when the output is closed.
+type nopWriteCloser struct {
+	io.Writer
+}
 
-			for name, prop := range ref.Value.Properties {
-				schema.Properties[name] = c.convertSchema(prop)
-			}
-		}
+func (nopWriteCloser) Close() error {
+	return nil
+}
 
-		// Convert items for arrays
-		if ref.Value.Items != nil {
-			itemSchema := c.convertSchema(ref.Value.Items)
-			schema.Items = &itemSchema
-		}
+// isStdio reports whether path stands for standard input or output.
+func isStdio(path string) bool {
+	return path == "" || path == stdioPath
+}
+
+// stdioName returns path for display, or name when path stands for standard input or output.
+func stdioName(path, name string) string {
+	if isStdio(path) {
+		return name
 	}
 
-	return schema
+	return path
+}
+
+// isTerminal reports whether file is an interactive terminal rather than a pipe or a regular file.
+func isTerminal(file *os.File) bool {
+	info, err := file.Stat()
+
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
 }
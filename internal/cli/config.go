@@ -0,0 +1,129 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"slices"
+
+	"github.com/GabrielNunesIT/openapi-converter/internal/config"
+	"github.com/GabrielNunesIT/openapi-converter/internal/usecases/transform"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// configSection annotates the commands reading the configuration file with the section of it they read,
+// see config.Config.Section. Commands without it ignore the configuration file.
+const configSection = "config-section"
+
+// applyConfig sets the flags of cmd that were not given on the command line from the configuration file, and adds
+// the format options of the configuration before those given with --option.
+func (c *CLI) applyConfig(cmd *cobra.Command, _ []string) error {
+	section, ok := cmd.Annotations[configSection]
+	if !ok {
+		return nil
+	}
+
+	cfg, err := config.Load(c.configFile)
+	if err != nil {
+		return err
+	}
+
+	values, credentials := cfg.Section(section)
+	c.credentials = credentials
+	c.transforms = transformChain(cfg.Transforms)
+
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+
+	slices.Sort(names)
+
+	for _, name := range names {
+		flag := cmd.Flags().Lookup(name)
+		if flag == nil {
+			continue
+		}
+
+		value := values[name]
+
+		if flag.Changed {
+			list, ok := flag.Value.(pflag.SliceValue)
+			if name != "option" || !ok {
+				continue
+			}
+
+			// Format options given on the command line override those of the configuration one by one, the last
+			// value of an option winning
+			value = append(slices.Clone(value), list.GetSlice()...)
+		}
+
+		if err := setFlag(cmd.Flags(), flag, value); err != nil {
+			return fmt.Errorf("invalid %s in configuration: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// setFlag sets a flag to the values of a configuration option, replacing the values of list flags.
+func setFlag(flags *pflag.FlagSet, flag *pflag.Flag, values []string) error {
+	if list, ok := flag.Value.(pflag.SliceValue); ok {
+		if err := list.Replace(values); err != nil {
+			return err //nolint:wrapcheck // wrapped with the option name by the caller
+		}
+
+		flag.Changed = true
+
+		return nil
+	}
+
+	return flags.Set(flag.Name, values[0]) //nolint:wrapcheck // wrapped with the option name by the caller
+}
+
+// configSectionAnnotation returns the annotations of a command reading a section of the configuration file.
+func configSectionAnnotation(section string) map[string]string {
+	return map[string]string{configSection: section}
+}
+
+// credentialEnv reads a credential from the environment variable named by the configuration, or from fallback.
+func credentialEnv(configured, fallback string) string {
+	if configured != "" {
+		return os.Getenv(configured)
+	}
+
+	return os.Getenv(fallback)
+}
+
+// transformChain returns the middleware of the transforms of the configuration, in order.
+func transformChain(transforms []config.Transform) transform.Chain {
+	var chain transform.Chain
+
+	for _, t := range transforms {
+		if t.StripPrefix != nil {
+			chain = append(chain, transform.StripPathPrefix(t.StripPrefix))
+		}
+
+		if t.RenameTags != nil {
+			chain = append(chain, transform.RenameTags(t.RenameTags))
+		}
+
+		if t.Descriptions != nil || t.TagDescriptions != nil {
+			chain = append(chain, transform.InjectDescriptions(transform.Descriptions{
+				Operations: t.Descriptions,
+				Tags:       t.TagDescriptions,
+			}))
+		}
+	}
+
+	return chain
+}
+
+// withServer returns the transforms followed by the selection of a server, when one is given.
+func withServer(transforms transform.Chain, server string) transform.Chain {
+	if server == "" {
+		return transforms
+	}
+
+	return append(slices.Clone(transforms), transform.SelectServer(server))
+}
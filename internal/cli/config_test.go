@@ -0,0 +1,89 @@
+package cli
+
+import (
+	"io"
+	"maps"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/GabrielNunesIT/go-libs/logger"
+)
+
+const optionsConfig = `options:
+  markdown:
+    schema-refs: both
+    stable-anchors: true
+  confluence:
+    tables: true
+`
+
+func TestApplyConfigFormatOptions(t *testing.T) {
+	configFile := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(configFile, []byte(optionsConfig), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		args    []string
+		format  string
+		want    map[string]string
+		wantErr string
+	}{
+		{
+			name:   "configuration",
+			format: "markdown",
+			want:   map[string]string{"schema-refs": "both", "stable-anchors": "true"},
+		},
+		{
+			name:   "overridden one by one",
+			args:   []string{"--option", "markdown.schema-refs=reference", "--option", "markdown.template-dir=tmpl"},
+			format: "md",
+			want:   map[string]string{"schema-refs": "reference", "stable-anchors": "true", "template-dir": "tmpl"},
+		},
+		{
+			name:   "other format",
+			args:   []string{"--option", "markdown.schema-refs=reference"},
+			format: "confluence",
+			want:   map[string]string{"tables": "true"},
+		},
+		{
+			name:    "invalid",
+			args:    []string{"--option", "markdown"},
+			format:  "markdown",
+			wantErr: `invalid option "markdown", expected format.name=value`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := New(logger.NewConsoleLogger(io.Discard))
+
+			if err := c.rootCmd.ParseFlags(append([]string{"--config", configFile}, tt.args...)); err != nil {
+				t.Fatalf("parse flags: %v", err)
+			}
+
+			if err := c.applyConfig(c.rootCmd, nil); err != nil {
+				t.Fatalf("apply configuration: %v", err)
+			}
+
+			got, err := c.formatValues(tt.format)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("got error %v, want %q", err, tt.wantErr)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("format values: %v", err)
+			}
+
+			if !maps.Equal(got, tt.want) {
+				t.Errorf("got values %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
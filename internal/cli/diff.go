@@ -0,0 +1,151 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/GabrielNunesIT/openapi-converter/internal/domain"
+	"github.com/GabrielNunesIT/openapi-converter/internal/usecases/diff"
+	"github.com/spf13/cobra"
+)
+
+// diffJSONFormat is the diff format writing the changelog as JSON for scripts rather than through a converter.
+const diffJSONFormat = "json"
+
+// diffReport is the changelog written by diff --format json, for CI to gate on.
+type diffReport struct {
+	Title              string             `json:"title"`
+	FromVersion        string             `json:"fromVersion"`
+	ToVersion          string             `json:"toVersion"`
+	Breaking           int                `json:"breaking"`
+	RecommendedBump    domain.VersionBump `json:"recommendedBump"`
+	RecommendedVersion string             `json:"recommendedVersion,omitempty"`
+	VersionBump        domain.VersionBump `json:"versionBump,omitempty"` // Bump from FromVersion to ToVersion
+	SufficientBump     bool               `json:"sufficientBump"`        // VersionBump covers RecommendedBump
+	Changes            []diffReportChange `json:"changes"`
+}
+
+type diffReportChange struct {
+	Kind     domain.ChangeKind    `json:"kind"`
+	Subject  domain.ChangeSubject `json:"subject"`
+	Target   string               `json:"target"`
+	Detail   string               `json:"detail"`
+	Breaking bool                 `json:"breaking"`
+}
+
+func (c *CLI) newDiffCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "diff <old-spec> <new-spec>",
+		Short: "Generate a changelog between two versions of an OpenAPI specification",
+		Long: "Compares two versions of an OpenAPI specification and renders the added, removed and changed " +
+			"endpoints and schemas, flagging breaking changes, in any of the supported output formats, " +
+			"or as JSON for scripts.\n" +
+			"Removed endpoints, narrowed enumerations, new required parameters and properties and changed types are " +
+			"breaking. The changelog recommends the semantic version bump the changes call for: major for breaking " +
+			"changes, minor for additions, patch for other changes; with --enforce-semver, a smaller bump from the old " +
+			"version to the new one fails the run so that CI can gate on it.\n" +
+			"Either specification may be \"-\" to read it from standard input.",
+		Args: cobra.ExactArgs(2),
+		RunE: c.runDiff,
+	}
+
+	cmd.Flags().StringVarP(&c.outputFile, "output", "o", "", "Path for the output file (default: standard output)")
+	cmd.Flags().StringVarP(&c.diffFormat, "format", "f", "markdown", "Output format: pdf, docx, confluence, markdown, html, json")
+	cmd.Flags().BoolVar(&c.enforceSemver, "enforce-semver", false,
+		"Fail when the version of the new specification is not bumped at least as much as the changes call for")
+
+	return cmd
+}
+
+func (c *CLI) runDiff(_ *cobra.Command, args []string) error {
+	from, _, err := c.loadOpenAPI(args[0])
+	if err != nil {
+		return fmt.Errorf("failed to load old specification: %w", err)
+	}
+
+	to, _, err := c.loadOpenAPI(args[1])
+	if err != nil {
+		return fmt.Errorf("failed to load new specification: %w", err)
+	}
+
+	c.log.Infof("Comparing %s v%s with v%s", to.Title, from.Version, to.Version)
+
+	write := writeDiffJSON
+	if !strings.EqualFold(c.diffFormat, diffJSONFormat) {
+		converter, err := c.getConverter(c.diffFormat, c.conversionFlags)
+		if err != nil {
+			return err
+		}
+
+		changelogConverter, ok := converter.(domain.ChangelogConverter)
+		if !ok {
+			return fmt.Errorf("format %s does not support changelogs", converter.Format())
+		}
+
+		write = changelogConverter.ConvertChangelog
+	}
+
+	changelog := diff.Compare(from, to)
+
+	output, err := c.createOutput(c.outputFile)
+	if err != nil {
+		return err
+	}
+	defer output.Close()
+
+	if err := write(changelog, output); err != nil {
+		return fmt.Errorf("conversion failed: %w", err)
+	}
+
+	c.log.Infof("Found %d change(s) calling for a %s version bump, successfully created: %s", len(changelog.Changes),
+		changelog.Bump, stdioName(c.outputFile, "standard output"))
+
+	if !c.enforceSemver {
+		return nil
+	}
+
+	if bump, ok := diff.BumpBetween(changelog.FromVersion, changelog.ToVersion); !ok {
+		return fmt.Errorf("cannot check the version bump from %q to %q: not increasing semantic versions",
+			changelog.FromVersion, changelog.ToVersion)
+	} else if !diff.Covers(bump, changelog.Bump) {
+		return fmt.Errorf("version %s is a %s bump of %s, but the changes call for a %s bump to %s",
+			changelog.ToVersion, bump, changelog.FromVersion, changelog.Bump, changelog.RecommendedVersion)
+	}
+
+	return nil
+}
+
+// writeDiffJSON writes a changelog as a JSON document for scripts.
+func writeDiffJSON(changelog *domain.Changelog, output io.Writer) error {
+	bump, ok := diff.BumpBetween(changelog.FromVersion, changelog.ToVersion)
+
+	report := diffReport{
+		Title:              changelog.Title,
+		FromVersion:        changelog.FromVersion,
+		ToVersion:          changelog.ToVersion,
+		RecommendedBump:    changelog.Bump,
+		RecommendedVersion: changelog.RecommendedVersion,
+		VersionBump:        bump,
+		SufficientBump:     ok && diff.Covers(bump, changelog.Bump),
+		Changes:            make([]diffReportChange, 0, len(changelog.Changes)),
+	}
+
+	for _, change := range changelog.Changes {
+		if change.Breaking {
+			report.Breaking++
+		}
+
+		report.Changes = append(report.Changes, diffReportChange(change))
+	}
+
+	encoder := json.NewEncoder(output)
+	encoder.SetIndent("", "  ")
+
+	if err := encoder.Encode(report); err != nil {
+		return fmt.Errorf("failed to write changelog: %w", err)
+	}
+
+	return nil
+}
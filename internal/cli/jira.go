@@ -0,0 +1,266 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/GabrielNunesIT/openapi-converter/internal/adapters/converters"
+	"github.com/GabrielNunesIT/openapi-converter/internal/adapters/publishers"
+	"github.com/GabrielNunesIT/openapi-converter/internal/domain"
+	"github.com/GabrielNunesIT/openapi-converter/internal/usecases/diff"
+	"github.com/spf13/cobra"
+)
+
+// Environment variables holding the Jira connection settings.
+const (
+	envJiraBaseURL  = "JIRA_BASE_URL"
+	envJiraEmail    = "JIRA_EMAIL"
+	envJiraAPIToken = "JIRA_API_TOKEN" //nolint:gosec // environment variable name, not a credential
+)
+
+// jiraFlags holds the flags of the publish jira command.
+type jiraFlags struct {
+	inputFile     string
+	baseURL       string
+	project       string
+	issueType     string
+	labels        []string
+	since         string // Path of the previous version of the specification, whose breaking changes are filed
+	docsURL       string
+	anchorsFile   string
+	stableAnchors bool
+	linkTo        string
+	linkType      string
+	dryRun        bool
+}
+
+// docLinks resolves the documentation of the endpoints the issues are filed for.
+type docLinks struct {
+	page    string            // URL of the documentation page, empty when unknown
+	anchors map[string]string // Links to the endpoints, by "METHOD /path"
+}
+
+func (c *CLI) newPublishJiraCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "jira",
+		Short: "File Jira issues for the deprecated endpoints and breaking changes of an OpenAPI specification",
+		Long: "Files a Jira Cloud issue for every deprecated endpoint of the specification and, with --since, for every " +
+			"endpoint or schema the specification changes in a breaking way since a previous version, so that the " +
+			"teams consuming the API can plan the migration. Issues link to the documentation of their endpoint: the " +
+			"anchor links of --anchors-file, as written by publish --anchors-file, else the anchors of the endpoints " +
+			"on the --docs-url page.\n" +
+			"Issues are filed once: an issue of the project with the same summary is reused. Every issue, new or " +
+			"reused, is linked to the --link-to issue, such as the epic of an API release.\n\n" +
+			"Credentials are read from the " + envJiraEmail + " and " + envJiraAPIToken + " environment variables; " +
+			"the site URL defaults to " + envJiraBaseURL + ".",
+		RunE:        c.runPublishJira,
+		Annotations: configSectionAnnotation("jira"),
+	}
+
+	cmd.Flags().StringVarP(&c.jira.inputFile, "input", "i", "", "Path to the OpenAPI specification file (default: standard input)")
+	cmd.Flags().StringVar(&c.jira.baseURL, "base-url", os.Getenv(envJiraBaseURL), "Jira site URL, e.g. https://example.atlassian.net")
+	cmd.Flags().StringVar(&c.jira.project, "project", "", "Key of the Jira project to file the issues in (required)")
+	cmd.Flags().StringVar(&c.jira.issueType, "issue-type", "Task", "Type of the filed issues")
+	cmd.Flags().StringSliceVar(&c.jira.labels, "labels", []string{"openapi-converter"}, "Labels of the filed issues")
+	cmd.Flags().StringVar(&c.jira.since, "since", "",
+		"Previous version of the specification, whose breaking changes are filed besides the deprecated endpoints")
+	cmd.Flags().StringVar(&c.jira.docsURL, "docs-url", "",
+		"URL of the page documenting the specification, whose operation anchors the issues link to")
+	cmd.Flags().StringVar(&c.jira.anchorsFile, "anchors-file", "",
+		"JSON map of the operation anchor links written by publish --anchors-file, preferred to --docs-url")
+	cmd.Flags().BoolVar(&c.jira.stableAnchors, "stable-anchors", false,
+		"The --docs-url page was published with --stable-anchors, anchoring operations at \"op-\" and their operationId")
+	cmd.Flags().StringVar(&c.jira.linkTo, "link-to", "", "Key of an issue, such as an epic, to link every filed issue to")
+	cmd.Flags().StringVar(&c.jira.linkType, "link-type", "Relates", "Name of the type of the --link-to links")
+	cmd.Flags().BoolVar(&c.jira.dryRun, "dry-run", false, "List the issues that would be filed without filing them")
+
+	_ = cmd.MarkFlagRequired("project")
+
+	return cmd
+}
+
+func (c *CLI) runPublishJira(cmd *cobra.Command, _ []string) error {
+	c.log.Infof("Loading OpenAPI specification from: %s", stdioName(c.jira.inputFile, "standard input"))
+
+	doc, _, err := c.loadOpenAPI(c.jira.inputFile)
+	if err != nil {
+		return fmt.Errorf("failed to load OpenAPI specification: %w", err)
+	}
+
+	c.log.Infof("Loaded API: %s (v%s)", doc.Title, doc.Version)
+
+	links, err := c.docLinks(doc)
+	if err != nil {
+		return err
+	}
+
+	issues := deprecationIssues(doc, links)
+
+	if c.jira.since != "" {
+		previous, _, err := c.loadOpenAPI(c.jira.since)
+		if err != nil {
+			return fmt.Errorf("failed to load previous specification: %w", err)
+		}
+
+		issues = append(issues, breakingIssues(diff.Compare(previous, doc), links)...)
+	}
+
+	if len(issues) == 0 {
+		c.log.Info("No deprecated endpoints or breaking changes to file issues for")
+
+		return nil
+	}
+
+	tracker := publishers.NewJiraTracker(publishers.JiraConfig{
+		BaseURL:    c.jira.baseURL,
+		ProjectKey: c.jira.project,
+		IssueType:  c.jira.issueType,
+		Labels:     c.jira.labels,
+		LinkTo:     c.jira.linkTo,
+		LinkType:   c.jira.linkType,
+		Email:      credentialEnv(c.credentials.EmailEnv, envJiraEmail),
+		APIToken:   credentialEnv(c.credentials.TokenEnv, envJiraAPIToken),
+	})
+
+	created := 0
+
+	for _, issue := range issues {
+		if c.jira.dryRun {
+			c.log.Infof("Would file %q", issue.Summary)
+
+			continue
+		}
+
+		key, isNew, err := tracker.File(cmd.Context(), issue)
+		if err != nil {
+			return fmt.Errorf("failed to file %q: %w", issue.Summary, err)
+		}
+
+		if isNew {
+			created++
+			c.log.Infof("Filed %s %q: %s", key, issue.Summary, tracker.IssueURL(key))
+		} else {
+			c.log.Infof("Already filed as %s %q: %s", key, issue.Summary, tracker.IssueURL(key))
+		}
+	}
+
+	if !c.jira.dryRun {
+		c.log.Infof("Successfully filed %d new issue(s) of %d", created, len(issues))
+	}
+
+	return nil
+}
+
+// docLinks returns the links to the documentation of the endpoints of doc, from the anchors file or docs URL.
+func (c *CLI) docLinks(doc *domain.OpenAPIDocument) (docLinks, error) {
+	links := docLinks{page: c.jira.docsURL, anchors: make(map[string]string)}
+
+	anchors := make(map[string]converters.OperationAnchor)
+
+	if c.jira.anchorsFile != "" {
+		data, err := os.ReadFile(c.jira.anchorsFile)
+		if err != nil {
+			return links, fmt.Errorf("failed to read anchors: %w", err)
+		}
+
+		if err := json.Unmarshal(data, &anchors); err != nil {
+			return links, fmt.Errorf("failed to parse anchors %s: %w", c.jira.anchorsFile, err)
+		}
+	} else if links.page != "" {
+		anchors = converters.OperationAnchors(doc, c.jira.stableAnchors)
+		for key, anchor := range anchors {
+			anchor.URL = links.page + "#" + anchor.Anchor
+			anchors[key] = anchor
+		}
+	}
+
+	for _, anchor := range anchors {
+		if anchor.URL != "" {
+			links.anchors[anchor.Method+" "+anchor.Path] = anchor.URL
+		}
+	}
+
+	return links, nil
+}
+
+// link returns the documentation of an endpoint or schema, the page itself for the schemas, empty when unknown.
+func (l docLinks) link(target string) string {
+	if anchor, ok := l.anchors[target]; ok {
+		return anchor
+	}
+
+	return l.page
+}
+
+// deprecationIssues returns an issue per deprecated operation of the document.
+func deprecationIssues(doc *domain.OpenAPIDocument, links docLinks) []publishers.JiraIssue {
+	var issues []publishers.JiraIssue
+
+	for _, path := range doc.Paths {
+		for _, op := range path.Operations {
+			if !op.Deprecated {
+				continue
+			}
+
+			endpoint := strings.ToUpper(op.Method) + " " + path.Path
+
+			text := fmt.Sprintf("%s is deprecated as of %s %s: its clients should move off it before it is removed.",
+				endpoint, doc.Title, doc.Version)
+			if op.Summary != "" {
+				text = fmt.Sprintf("%s (%s) is deprecated as of %s %s: its clients should move off it before it is removed.",
+					endpoint, op.Summary, doc.Title, doc.Version)
+			}
+
+			issues = append(issues, publishers.JiraIssue{
+				Summary:   fmt.Sprintf("%s: deprecated endpoint %s", doc.Title, endpoint),
+				Text:      []string{text},
+				DocsURL:   links.link(endpoint),
+				DocsLabel: "Documentation of " + endpoint,
+			})
+		}
+	}
+
+	return issues
+}
+
+// breakingIssues returns an issue per endpoint or schema with breaking changes in a changelog, listing them.
+func breakingIssues(changelog *domain.Changelog, links docLinks) []publishers.JiraIssue {
+	var (
+		targets []string
+		changes = make(map[string][]string)
+	)
+
+	for _, change := range changelog.Changes {
+		if !change.Breaking {
+			continue
+		}
+
+		if _, seen := changes[change.Target]; !seen {
+			targets = append(targets, change.Target)
+		}
+
+		detail := change.Detail
+		if detail == "" {
+			detail = fmt.Sprintf("%s %s", change.Subject, change.Kind)
+		}
+
+		changes[change.Target] = append(changes[change.Target], detail)
+	}
+
+	issues := make([]publishers.JiraIssue, 0, len(targets))
+
+	for _, target := range targets {
+		issues = append(issues, publishers.JiraIssue{
+			Summary: fmt.Sprintf("%s %s: breaking changes to %s", changelog.Title, changelog.ToVersion, target),
+			Text: []string{fmt.Sprintf("%s %s changes %s in ways that may break its clients, coming from %s:",
+				changelog.Title, changelog.ToVersion, target, changelog.FromVersion)},
+			Items:     changes[target],
+			DocsURL:   links.link(target),
+			DocsLabel: "Documentation of " + changelog.Title + " " + changelog.ToVersion,
+		})
+	}
+
+	return issues
+}
@@ -0,0 +1,129 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/GabrielNunesIT/openapi-converter/internal/adapters/reporters"
+	"github.com/GabrielNunesIT/openapi-converter/internal/domain"
+	"github.com/GabrielNunesIT/openapi-converter/internal/usecases/lint"
+	"github.com/spf13/cobra"
+)
+
+// defaultLintConfig is the rule configuration read from the working directory when --config is not given.
+const defaultLintConfig = ".openapi-lint.yaml"
+
+// lintFlags holds the flags of the lint command.
+type lintFlags struct {
+	config     string
+	format     string
+	outputFile string
+	listRules  bool
+}
+
+func (c *CLI) newLintCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "lint [spec]",
+		Short: "Check an OpenAPI specification against style rules",
+		Long: "Checks an OpenAPI specification against the built-in style rules, adjusted by a YAML rule configuration, " +
+			"and reports the issues as text, JSON or SARIF. Exits with an error when any issue has error severity, " +
+			"so that CI can gate on specification quality.\n" +
+			"The configuration sets the severity of each rule (error, warning, info or off) and its options:\n\n" +
+			"  rules:\n" +
+			"    operation-tags: off\n" +
+			"    operation-summary:\n" +
+			"      severity: error\n" +
+			"      max-length: 60\n\n" +
+			"The specification is read from standard input when omitted or \"-\".",
+		Args: cobra.MaximumNArgs(1),
+		RunE: c.runLint,
+	}
+
+	cmd.Flags().StringVarP(&c.lint.config, "config", "c", "",
+		"Path to the YAML rule configuration (default: "+defaultLintConfig+" when present)")
+	cmd.Flags().StringVarP(&c.lint.format, "format", "f", "text", "Report format: text, json, sarif")
+	cmd.Flags().StringVarP(&c.lint.outputFile, "output", "o", "", "Path for the report (default: standard output)")
+	cmd.Flags().BoolVar(&c.lint.listRules, "list-rules", false, "List the built-in rules and exit")
+
+	return cmd
+}
+
+func (c *CLI) runLint(cmd *cobra.Command, args []string) error {
+	if c.lint.listRules {
+		for _, rule := range lint.Rules() {
+			fmt.Fprintf(cmd.OutOrStdout(), "%s (%s)\n    %s\n", rule.Name, rule.Severity, rule.Description)
+		}
+
+		return nil
+	}
+
+	reporter, err := reporters.New(c.lint.format)
+	if err != nil {
+		return err
+	}
+
+	config, err := c.loadLintConfig()
+	if err != nil {
+		return err
+	}
+
+	path := stdioPath
+	if len(args) > 0 {
+		path = args[0]
+	}
+
+	doc, sources, err := c.loadOpenAPI(path)
+	if err != nil {
+		return fmt.Errorf("failed to load OpenAPI specification: %w", err)
+	}
+
+	report := lint.Run(doc, config)
+	report.File = stdioName(path, "")
+	locateIssues(report.Issues, sources)
+
+	output, err := c.createOutput(c.lint.outputFile)
+	if err != nil {
+		return err
+	}
+	defer output.Close()
+
+	if err := reporter.Report(report, output); err != nil {
+		return err
+	}
+
+	errorCount := report.Count(domain.SeverityError)
+	c.log.Infof("Found %d error(s), %d warning(s) and %d info(s) in %s", errorCount,
+		report.Count(domain.SeverityWarning), report.Count(domain.SeverityInfo), stdioName(path, "standard input"))
+
+	if errorCount > 0 {
+		return fmt.Errorf("specification has %d lint error(s)", errorCount)
+	}
+
+	return nil
+}
+
+// loadLintConfig reads the rule configuration given by --config, or the default one when present.
+// Without a configuration every rule runs with its defaults.
+func (c *CLI) loadLintConfig() (*lint.Config, error) {
+	path := c.lint.config
+	if path == "" {
+		path = defaultLintConfig
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if c.lint.config == "" && errors.Is(err, os.ErrNotExist) {
+			return nil, nil //nolint:nilnil // no configuration means default rules
+		}
+
+		return nil, fmt.Errorf("failed to read lint configuration: %w", err)
+	}
+
+	config, err := lint.ParseConfig(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid lint configuration %s: %w", path, err)
+	}
+
+	return config, nil
+}
@@ -0,0 +1,116 @@
+package cli
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/GabrielNunesIT/openapi-converter/internal/usecases/merge"
+	"github.com/spf13/cobra"
+)
+
+// mergeFlags holds the flags of the merge command.
+type mergeFlags struct {
+	version     string
+	description string
+	prefixTags  bool
+}
+
+func (c *CLI) newMergeCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "merge <spec> <spec>...",
+		Short: "Merge several specifications into one document",
+		Long: "Merges several OpenAPI specifications, such as those of the services behind a gateway, and converts " +
+			"the result into a single document.\n" +
+			"Each specification is named after its file, or given as name=path, e.g. billing=specs/billing.yaml. " +
+			"Component schemas and security schemes defined differently by several specifications are renamed " +
+			"\"<name>.<component>\" in all but the first, and an operation defined by several specifications is an error.",
+		Args:        cobra.MinimumNArgs(2),
+		RunE:        c.runMerge,
+		Annotations: configSectionAnnotation(""),
+	}
+
+	cmd.Flags().StringVarP(&c.outputFile, "output", "o", "", "Path for the output file, or directory with --split (default: standard output)")
+	cmd.Flags().StringVarP(&c.format, "format", "f", "pdf", formatListUsage())
+	cmd.Flags().StringVar(&c.title, "title", "", "Document title (defaults to the title of the first specification)")
+	cmd.Flags().StringVar(&c.merge.version, "version", "", "Document version (defaults to the version of the first specification)")
+	cmd.Flags().StringVar(&c.merge.description, "description", "", "Document description (defaults to a list of the merged APIs)")
+	addPreambleFlags(cmd, &c.metadata)
+	cmd.Flags().BoolVar(&c.merge.prefixTags, "prefix-tags", false,
+		"Prefix the tags of each specification with its name, tagging its untagged operations with the name alone")
+	c.addConversionFlags(cmd, &c.conversionFlags)
+	cmd.Flags().BoolVar(&c.split, "split", false,
+		"Write one document per tag plus an index into the output directory instead of a single file")
+	cmd.Flags().IntVar(&c.maxDocSize, "max-doc-size", 0, maxDocSizeUsage)
+	cmd.Flags().StringVar(&c.anchorsFile, "anchors-file", "", anchorsFileUsage)
+	cmd.Flags().BoolVar(&c.strict, "strict", false, strictUsage)
+	cmd.Flags().BoolVar(&c.check, "check", false, checkUsage)
+	cmd.Flags().StringVar(&c.harFile, "har", "", harUsage)
+
+	return cmd
+}
+
+func (c *CLI) runMerge(_ *cobra.Command, args []string) error {
+	sources := make([]merge.Source, 0, len(args))
+
+	for _, arg := range args {
+		name, path := mergeSourceName(arg)
+
+		c.log.Infof("Loading OpenAPI specification from: %s", path)
+
+		doc, specSources, err := c.loadOpenAPI(path)
+		if err != nil {
+			return fmt.Errorf("failed to load OpenAPI specification %s: %w", path, err)
+		}
+
+		if c.strict {
+			if err := c.checkSpec(doc, specSources, path); err != nil {
+				return err
+			}
+		}
+
+		sources = append(sources, merge.Source{Name: name, Document: doc})
+	}
+
+	doc, err := merge.Documents(sources, merge.Options{
+		Title:       c.title,
+		Version:     c.merge.version,
+		Description: c.merge.description,
+		PrefixTags:  c.merge.prefixTags,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to merge specifications: %w", err)
+	}
+
+	c.log.Infof("Merged %d specifications into: %s (v%s)", len(sources), doc.Title, doc.Version)
+
+	if err := applyMetadata(doc, c.metadata); err != nil {
+		return err
+	}
+
+	if c.harFile != "" {
+		if err := c.enrichDocument(doc); err != nil {
+			return err
+		}
+	}
+
+	doc, err = prepareDocument(doc, withServer(c.transforms, c.server), c.redaction, c.hideDeprecated, c.selection,
+		c.groupBy, c.tagOrder, c.operationOrder)
+	if err != nil {
+		return err
+	}
+
+	return c.writeDocument(doc)
+}
+
+// mergeSourceName splits a merge argument into the name of the specification and its path.
+// Arguments without a name are named after the file, without its extension.
+func mergeSourceName(arg string) (string, string) {
+	if name, path, ok := strings.Cut(arg, "="); ok && name != "" {
+		return name, path
+	}
+
+	base := filepath.Base(arg)
+
+	return strings.TrimSuffix(base, filepath.Ext(base)), arg
+}
@@ -0,0 +1,79 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/GabrielNunesIT/openapi-converter/internal/domain"
+	"github.com/spf13/cobra"
+)
+
+// metadataFlags holds the flags overriding the information block of a specification, which is often not what
+// its published documents should show.
+type metadataFlags struct {
+	version     string
+	description string
+	preamble    string // Path of a Markdown file set before the generated content
+	epilogue    string // Path of a Markdown file set after the generated content
+}
+
+// addMetadataFlags adds the flags overriding the version and description of a specification, and the preamble
+// and epilogue flags.
+func addMetadataFlags(cmd *cobra.Command, metadata *metadataFlags) {
+	cmd.Flags().StringVar(&metadata.version, "version", "", "Document version (defaults to the API version)")
+	cmd.Flags().StringVar(&metadata.description, "description", "",
+		"Document description, as Markdown (defaults to the API description)")
+	addPreambleFlags(cmd, metadata)
+}
+
+// addPreambleFlags adds the flags of the Markdown files set before and after the generated content.
+func addPreambleFlags(cmd *cobra.Command, metadata *metadataFlags) {
+	cmd.Flags().StringVar(&metadata.preamble, "preamble", "",
+		"Markdown file set after the title of the document, before the generated content (documentation formats)")
+	cmd.Flags().StringVar(&metadata.epilogue, "epilogue", "",
+		"Markdown file set at the end of the document, after the generated content (documentation formats)")
+}
+
+// applyMetadata overrides the information of a specification with the given metadata.
+func applyMetadata(doc *domain.OpenAPIDocument, metadata metadataFlags) error {
+	if metadata.version != "" {
+		doc.Version = metadata.version
+	}
+
+	if metadata.description != "" {
+		doc.Description = metadata.description
+	}
+
+	if metadata.preamble != "" {
+		preamble, err := os.ReadFile(metadata.preamble)
+		if err != nil {
+			return fmt.Errorf("failed to read preamble: %w", err)
+		}
+
+		doc.Preamble = string(preamble)
+	}
+
+	if metadata.epilogue != "" {
+		epilogue, err := os.ReadFile(metadata.epilogue)
+		if err != nil {
+			return fmt.Errorf("failed to read epilogue: %w", err)
+		}
+
+		doc.Epilogue = string(epilogue)
+	}
+
+	return nil
+}
+
+// metadataSources returns the preamble and epilogue files of the metadata, for watch to convert again on their changes.
+func metadataSources(metadata metadataFlags) []string {
+	var sources []string
+
+	for _, path := range []string{metadata.preamble, metadata.epilogue} {
+		if path != "" {
+			sources = append(sources, path)
+		}
+	}
+
+	return sources
+}
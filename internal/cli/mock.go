@@ -0,0 +1,105 @@
+package cli
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/GabrielNunesIT/openapi-converter/internal/adapters/mock"
+	"github.com/spf13/cobra"
+)
+
+// mockFlags holds the flags of the mock command.
+type mockFlags struct {
+	host     string
+	port     int
+	validate bool
+	server   string
+}
+
+func (c *CLI) newMockCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "mock <spec>",
+		Short: "Serve example responses of the specification over HTTP",
+		Long: "Starts a local HTTP server answering the requests to the operations of the specification with their " +
+			"documented responses, so that clients can be developed against the contract before the API exists.\n" +
+			"The response is the first success response of the operation, in the first media type the Accept header " +
+			"allows, with the first example of the specification or else one generated from the schema. " +
+			"A \"Prefer: code=404\" header picks the response of another status and \"Prefer: example=<name>\" " +
+			"a named example.\n" +
+			"Requests missing required parameters or bodies, or with values not matching their schema, are answered " +
+			"with 400 Bad Request listing the mismatches. The specification is read again on every request, " +
+			"so edits apply immediately. Stop the server with Ctrl+C.",
+		Args:        cobra.ExactArgs(1),
+		RunE:        c.runMock,
+		Annotations: configSectionAnnotation(""),
+	}
+
+	cmd.Flags().StringVar(&c.mock.host, "host", "localhost", "Address to listen on, empty for all interfaces")
+	cmd.Flags().IntVarP(&c.mock.port, "port", "p", 9090, "Port to listen on")
+	cmd.Flags().BoolVar(&c.mock.validate, "validate", true,
+		"Answer requests not matching the specification with 400 Bad Request; --validate=false answers them all")
+	cmd.Flags().StringVar(&c.mock.server, "server", "", serverUsage)
+
+	return cmd
+}
+
+func (c *CLI) runMock(cmd *cobra.Command, args []string) error {
+	inputFile, err := filepath.Abs(args[0])
+	if err != nil {
+		return fmt.Errorf("failed to resolve path: %w", err)
+	}
+
+	// Fail early on a specification that does not load rather than on the first request
+	doc, _, err := c.loadOpenAPI(inputFile)
+	if err != nil {
+		return fmt.Errorf("failed to load OpenAPI specification: %w", err)
+	}
+
+	c.log.Infof("Mocking %d path(s) of %s (v%s)", len(doc.Paths), doc.Title, doc.Version)
+
+	if err := withServer(nil, c.mock.server).Run(doc); err != nil {
+		return err
+	}
+
+	// Paths match after any base path, so that clients only swap the host of the server for that of the mock
+	if len(doc.Servers) > 0 {
+		base := ""
+		if location, err := url.Parse(doc.Servers[0].URL); err == nil {
+			base = strings.TrimRight(location.Path, "/")
+		}
+
+		c.log.Infof("Clients of %s reach the mock at http://%s%s", doc.Servers[0].URL,
+			net.JoinHostPort(c.mock.host, strconv.Itoa(c.mock.port)), base)
+	}
+
+	return c.listenAndServe(cmd.Context(), c.mock.host, c.mock.port, c.mockHandler(inputFile), "Mocking "+inputFile)
+}
+
+// mockHandler returns the handler mocking the specification at inputFile, read again on every request with the
+// server chosen by --server, so that its base path is matched.
+func (c *CLI) mockHandler(inputFile string) http.Handler {
+	logger := mock.WithLogger(func(method, path string, status int) {
+		c.log.Infof("%s %s -> %d", method, path, status)
+	})
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		doc, _, err := c.loadOpenAPI(inputFile)
+		if err == nil {
+			err = withServer(nil, c.mock.server).Run(doc)
+		}
+
+		if err != nil {
+			c.log.Errorf("Failed to load OpenAPI specification: %v", err)
+			http.Error(w, fmt.Sprintf("failed to load OpenAPI specification: %v", err), http.StatusInternalServerError)
+
+			return
+		}
+
+		mock.NewServer(doc, mock.WithValidation(c.mock.validate), logger).ServeHTTP(w, r)
+	})
+}
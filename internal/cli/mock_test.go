@@ -0,0 +1,67 @@
+package cli
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/GabrielNunesIT/go-libs/logger"
+)
+
+const mockSpec = `openapi: 3.0.3
+info:
+  title: Pets
+  version: 1.0.0
+servers:
+  - url: https://api.example.com/v1
+    description: production
+paths:
+  /pets:
+    get:
+      responses:
+        '200':
+          description: The pets
+          content:
+            application/json:
+              example: [rex]
+`
+
+func TestMockHandlerServer(t *testing.T) {
+	spec := filepath.Join(t.TempDir(), "pets.yaml")
+	if err := os.WriteFile(spec, []byte(mockSpec), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		server     string
+		target     string
+		wantStatus int
+	}{
+		{name: "listed base path", target: "/v1/pets", wantStatus: http.StatusOK},
+		{name: "unlisted base path", target: "/beta/pets", wantStatus: http.StatusNotFound},
+		{name: "server by name", server: "production", target: "/v1/pets", wantStatus: http.StatusOK},
+		{name: "server by index", server: "0", target: "/pets", wantStatus: http.StatusOK},
+		{name: "server URL", server: "https://staging.example.com/beta", target: "/beta/pets", wantStatus: http.StatusOK},
+		{name: "server URL and listed one", server: "https://staging.example.com/beta", target: "/v1/pets", wantStatus: http.StatusOK},
+		{name: "server out of range", server: "3", target: "/v1/pets", wantStatus: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := New(logger.NewConsoleLogger(io.Discard))
+			c.mock.validate = true
+			c.mock.server = tt.server
+
+			recorder := httptest.NewRecorder()
+			c.mockHandler(spec).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, tt.target, nil))
+
+			if recorder.Code != tt.wantStatus {
+				t.Errorf("got status %d, want %d: %s", recorder.Code, tt.wantStatus, recorder.Body)
+			}
+		})
+	}
+}
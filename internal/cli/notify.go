@@ -0,0 +1,91 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/GabrielNunesIT/openapi-converter/internal/adapters/notifiers"
+	"github.com/GabrielNunesIT/openapi-converter/internal/domain"
+	"github.com/GabrielNunesIT/openapi-converter/internal/usecases/diff"
+	"github.com/GabrielNunesIT/openapi-converter/internal/usecases/stats"
+	"github.com/spf13/cobra"
+)
+
+// envNotifyWebhook is the environment variable holding the default webhook URL of the notifications, which is
+// a credential better kept out of command lines.
+const envNotifyWebhook = "OPENAPI_CONVERTER_NOTIFY_WEBHOOK"
+
+// notifyFlags holds the flags of the chat notification sent after a successful conversion or publication.
+type notifyFlags struct {
+	webhook  string
+	provider string
+	since    string // Path of the previous version of the specification whose changes the notification summarizes
+}
+
+// addNotifyFlags adds the flags of the chat notification.
+func addNotifyFlags(cmd *cobra.Command, notify *notifyFlags) {
+	cmd.Flags().StringVar(&notify.webhook, "notify", "",
+		"Slack or Microsoft Teams incoming webhook URL to post a summary to once done (default: "+envNotifyWebhook+")")
+	cmd.Flags().StringVar(&notify.provider, "notify-provider", "",
+		"Webhook provider, "+notifiers.ProviderSlack+" or "+notifiers.ProviderTeams+" (default: detected from the URL)")
+	cmd.Flags().StringVar(&notify.since, "notify-since", "",
+		"Previous version of the specification, whose changes the notification summarizes")
+}
+
+// notifier returns the notifier of the flags, or nil when no webhook is set.
+func (c *CLI) notifier(notify notifyFlags) (domain.Notifier, error) {
+	webhook := notify.webhook
+	if webhook == "" {
+		webhook = os.Getenv(envNotifyWebhook)
+	}
+
+	if webhook == "" {
+		if notify.since != "" {
+			return nil, fmt.Errorf("--notify-since requires a webhook, set with --notify or %s", envNotifyWebhook)
+		}
+
+		return nil, nil //nolint:nilnil // no notification configured
+	}
+
+	notifier, err := notifiers.NewWebhookNotifier(notifiers.WebhookConfig{URL: webhook, Provider: notify.provider})
+	if err != nil {
+		return nil, fmt.Errorf("failed to set up notification: %w", err)
+	}
+
+	return notifier, nil
+}
+
+// notify sends the summary of a converted or published document, linking to link, through notifier when set.
+// A failed notification is reported as a warning: the documentation itself is already written.
+func (c *CLI) notify(ctx context.Context, notifier domain.Notifier, notify notifyFlags,
+	doc *domain.OpenAPIDocument, title, link string,
+) {
+	if notifier == nil {
+		return
+	}
+
+	notification := domain.Notification{
+		Title:     title,
+		Version:   doc.Version,
+		Endpoints: stats.Compute(doc).Operations,
+		Link:      link,
+	}
+
+	if notify.since != "" {
+		previous, _, err := c.loadOpenAPI(notify.since)
+		if err != nil {
+			c.log.Warningf("Notification sent without changes: failed to load previous specification: %v", err)
+		} else {
+			notification.Changes = diff.Compare(previous, doc)
+		}
+	}
+
+	if err := notifier.Notify(ctx, notification); err != nil {
+		c.log.Warningf("Failed to send notification: %v", err)
+
+		return
+	}
+
+	c.log.Info("Sent notification")
+}
@@ -0,0 +1,121 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/GabrielNunesIT/openapi-converter/internal/adapters/converters"
+	"github.com/GabrielNunesIT/openapi-converter/internal/adapters/publishers"
+	"github.com/spf13/cobra"
+)
+
+// envNotionToken holds the token of the Notion integration used for publishing.
+const envNotionToken = "NOTION_TOKEN" //nolint:gosec // environment variable name, not a credential
+
+// notionFormat is the output format the publish notion command converts to, whose options --option sets.
+const notionFormat = "notion"
+
+// notionFlags holds the flags of the publish notion command.
+type notionFlags struct {
+	conversionFlags
+
+	inputFile string
+	parentID  string
+	title     string
+	metadata  metadataFlags
+	split     bool
+	notify    notifyFlags
+}
+
+func (c *CLI) newPublishNotionCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "notion",
+		Short: "Publish an OpenAPI specification as a Notion page",
+		Long: "Converts an OpenAPI specification to Notion blocks and creates or updates a page under a parent page.\n" +
+			"The page is replaced when a child page with the same title already exists.\n\n" +
+			"The token of an internal integration shared with the parent page is read from the " + envNotionToken +
+			" environment variable.",
+		RunE:        c.runPublishNotion,
+		Annotations: configSectionAnnotation("notion"),
+	}
+
+	cmd.Flags().StringVarP(&c.notion.inputFile, "input", "i", "", "Path to the OpenAPI specification file (default: standard input)")
+	cmd.Flags().StringVar(&c.notion.parentID, "parent", "", "ID of the Notion page to publish under (required)")
+	cmd.Flags().StringVar(&c.notion.title, "title", "", "Page title (defaults to the API title)")
+	addMetadataFlags(cmd, &c.notion.metadata)
+	cmd.Flags().BoolVar(&c.notion.split, "split", false,
+		"Publish one page per tag plus an index page, titled \"<title> - <tag>\"")
+	c.addConversionFlags(cmd, &c.notion.conversionFlags)
+	addNotifyFlags(cmd, &c.notion.notify)
+
+	_ = cmd.MarkFlagRequired("parent")
+
+	return cmd
+}
+
+func (c *CLI) runPublishNotion(cmd *cobra.Command, _ []string) error {
+	c.log.Infof("Loading OpenAPI specification from: %s", stdioName(c.notion.inputFile, "standard input"))
+
+	doc, _, err := c.loadOpenAPI(c.notion.inputFile)
+	if err != nil {
+		return fmt.Errorf("failed to load OpenAPI specification: %w", err)
+	}
+
+	c.log.Infof("Loaded API: %s (v%s)", doc.Title, doc.Version)
+
+	notifier, err := c.notifier(c.notion.notify)
+	if err != nil {
+		return err
+	}
+
+	if err := applyMetadata(doc, c.notion.metadata); err != nil {
+		return err
+	}
+
+	doc, err = prepareDocument(doc, withServer(c.transforms, c.notion.server), c.notion.redaction, c.notion.hideDeprecated,
+		c.notion.selection, c.notion.groupBy, c.notion.tagOrder, c.notion.operationOrder)
+	if err != nil {
+		return err
+	}
+
+	title := c.notion.title
+	if title == "" {
+		title = doc.Title
+	}
+
+	publisher := publishers.NewNotionPublisher(publishers.NotionConfig{
+		ParentID: c.notion.parentID,
+		Token:    credentialEnv(c.credentials.TokenEnv, envNotionToken),
+	})
+
+	converter, err := c.getConverter(notionFormat, c.notion.conversionFlags)
+	if err != nil {
+		return err
+	}
+
+	parts := []converters.DocumentPart{{Document: doc}}
+	if c.notion.split {
+		parts = converters.SplitByTag(doc)
+	}
+
+	var link string // URL of the first page, the index page with --split
+
+	for _, part := range parts {
+		pageTitle := title
+		if part.Name != "" {
+			pageTitle = fmt.Sprintf("%s - %s", title, part.Name)
+		}
+
+		pageURL, _, err := c.publishPage(cmd.Context(), publisher, converter, pageTitle, "Notion", part.Document, 0)
+		if err != nil {
+			return err
+		}
+
+		if link == "" {
+			link = pageURL
+		}
+	}
+
+	c.notify(cmd.Context(), notifier, c.notion.notify, doc, title, link)
+
+	return nil
+}
@@ -0,0 +1,36 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/GabrielNunesIT/openapi-converter/internal/adapters/converters"
+	"github.com/spf13/cobra"
+)
+
+// preRun prepares every command: it loads the converter plugins, then the configuration of the command.
+func (c *CLI) preRun(cmd *cobra.Command, args []string) error {
+	if err := c.loadPlugins(); err != nil {
+		return err
+	}
+
+	return c.applyConfig(cmd, args)
+}
+
+// loadPlugins registers the converter plugins of --plugin-dir as output formats. Plugins failing to load are
+// reported without failing the command, which may not need them.
+func (c *CLI) loadPlugins() error {
+	if c.pluginDir == "" {
+		return nil
+	}
+
+	formats, err := converters.DefaultRegistry.LoadPlugins(c.pluginDir)
+	if formats == nil && err != nil {
+		return fmt.Errorf("failed to load plugins: %w", err)
+	} else if err != nil {
+		c.log.Warningf("Some plugins failed to load: %v", err)
+	}
+
+	c.log.Debugf("Loaded %d plugin(s) from %s: %v", len(formats), c.pluginDir, formats)
+
+	return nil
+}
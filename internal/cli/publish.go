@@ -0,0 +1,407 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/GabrielNunesIT/openapi-converter/internal/adapters/converters"
+	"github.com/GabrielNunesIT/openapi-converter/internal/adapters/publishers"
+	"github.com/GabrielNunesIT/openapi-converter/internal/domain"
+	"github.com/spf13/cobra"
+)
+
+// Environment variables holding the Confluence connection settings.
+const (
+	envConfluenceBaseURL  = "CONFLUENCE_BASE_URL"
+	envConfluenceEmail    = "CONFLUENCE_EMAIL"
+	envConfluenceAPIToken = "CONFLUENCE_API_TOKEN" //nolint:gosec // environment variable name, not a credential
+)
+
+// publishFormat is the output format the publish command converts to, whose options --option sets.
+const publishFormat = "confluence"
+
+// publishFlags holds the flags of the publish command.
+type publishFlags struct {
+	conversionFlags
+
+	inputFile   string
+	baseURL     string
+	spaceKey    string
+	parentID    string
+	title       string
+	metadata    metadataFlags
+	split       bool
+	renderer    string
+	maxDocSize  int
+	anchorsFile string
+	check       bool
+	incremental bool
+	prune       bool
+	notify      notifyFlags
+}
+
+func (c *CLI) newPublishCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "publish",
+		Short: "Publish an OpenAPI specification as a Confluence Cloud page",
+		Long: "Converts an OpenAPI specification to Atlassian Document Format and creates or updates a Confluence Cloud page.\n" +
+			"The page is updated when a page with the same title already exists in the space.\n\n" +
+			"Credentials are read from the " + envConfluenceEmail + " and " + envConfluenceAPIToken + " environment variables; " +
+			"the site URL defaults to " + envConfluenceBaseURL + ".\n" +
+			"Use \"publish notion\" to publish to Notion instead, \"publish changelog\" to publish release notes, " +
+			"and \"publish jira\" to file issues for the deprecated endpoints and breaking changes.",
+		RunE:        c.runPublish,
+		Annotations: configSectionAnnotation("publish"),
+	}
+
+	cmd.Flags().StringVarP(&c.publish.inputFile, "input", "i", "", "Path to the OpenAPI specification file (default: standard input)")
+	cmd.Flags().StringVar(&c.publish.baseURL, "base-url", os.Getenv(envConfluenceBaseURL),
+		"Confluence site URL, e.g. https://example.atlassian.net/wiki")
+	cmd.Flags().StringVar(&c.publish.spaceKey, "space", "", "Key of the Confluence space to publish into (required)")
+	cmd.Flags().StringVar(&c.publish.parentID, "parent", "", "ID of the parent page for newly created pages")
+	cmd.Flags().StringVar(&c.publish.title, "title", "", "Page title (defaults to the API title)")
+	addMetadataFlags(cmd, &c.publish.metadata)
+	c.addConversionFlags(cmd, &c.publish.conversionFlags)
+	cmd.Flags().BoolVar(&c.publish.split, "split", false,
+		"Publish one page per tag, titled \"<title> - <tag>\", under an index page")
+	cmd.Flags().BoolVar(&c.publish.prune, "prune", false,
+		"With --split, delete the tag pages published before under the index page for the tags no longer in the specification")
+	cmd.Flags().StringVar(&c.publish.renderer, "diagram-renderer", "",
+		"Command rendering the Mermaid code blocks as PNG images attached to the pages in their place, taking the "+
+			"options of the Mermaid CLI, e.g. mmdc or \"npx -y @mermaid-js/mermaid-cli\"")
+	cmd.Flags().IntVar(&c.publish.maxDocSize, "max-doc-size", 0,
+		"Split pages larger than this many bytes into continuation pages, titled \"<title> (2)\" and so on, "+
+			"each linked from the page before, 0 for no limit")
+	cmd.Flags().StringVar(&c.publish.anchorsFile, "anchors-file", "",
+		"Write a JSON map of the published operations, by operationId or \"METHOD /path\", with links to their anchors to this file")
+
+	cmd.Flags().BoolVar(&c.publish.check, "check", false,
+		"Compare the conversion with the published pages instead of publishing it, failing with a diff when they differ")
+	cmd.Flags().BoolVar(&c.publish.incremental, "incremental", false,
+		"Store a hash of the content of each page in a page property and skip the pages whose content has not changed, "+
+			"not notifying when none has")
+	addNotifyFlags(cmd, &c.publish.notify)
+
+	_ = cmd.MarkFlagRequired("space")
+
+	cmd.AddCommand(c.newPublishNotionCmd())
+	cmd.AddCommand(c.newPublishWikiCmd())
+	cmd.AddCommand(c.newPublishStorageCmd())
+	cmd.AddCommand(c.newPublishJiraCmd())
+	cmd.AddCommand(c.newPublishChangelogCmd())
+
+	return cmd
+}
+
+func (c *CLI) runPublish(cmd *cobra.Command, _ []string) error {
+	c.log.Infof("Loading OpenAPI specification from: %s", stdioName(c.publish.inputFile, "standard input"))
+
+	doc, _, err := c.loadOpenAPI(c.publish.inputFile)
+	if err != nil {
+		return fmt.Errorf("failed to load OpenAPI specification: %w", err)
+	}
+
+	c.log.Infof("Loaded API: %s (v%s)", doc.Title, doc.Version)
+
+	notifier, err := c.notifier(c.publish.notify)
+	if err != nil {
+		return err
+	}
+
+	if err := applyMetadata(doc, c.publish.metadata); err != nil {
+		return err
+	}
+
+	doc, err = prepareDocument(doc, withServer(c.transforms, c.publish.server), c.publish.redaction, c.publish.hideDeprecated,
+		c.publish.selection, c.publish.groupBy, c.publish.tagOrder, c.publish.operationOrder)
+	if err != nil {
+		return err
+	}
+
+	title := c.publish.title
+	if title == "" {
+		title = doc.Title
+	}
+
+	cfg := publishers.ConfluenceConfig{
+		BaseURL:     c.publish.baseURL,
+		SpaceKey:    c.publish.spaceKey,
+		ParentID:    c.publish.parentID,
+		Email:       credentialEnv(c.credentials.EmailEnv, envConfluenceEmail),
+		APIToken:    credentialEnv(c.credentials.TokenEnv, envConfluenceAPIToken),
+		Incremental: c.publish.incremental,
+	}
+
+	if c.publish.renderer != "" {
+		cfg.RenderDiagram = publishers.NewMermaidCLIRenderer(c.publish.renderer)
+	}
+
+	publisher := publishers.NewConfluencePublisher(cfg)
+
+	converter, err := c.getConverter(publishFormat, c.publish.conversionFlags)
+	if err != nil {
+		return err
+	}
+
+	parts := []converters.DocumentPart{{Document: doc}}
+	if c.publish.split {
+		parts = converters.SplitByTag(doc)
+	}
+
+	anchors := make(map[string]converters.OperationAnchor)
+
+	var (
+		drift    []error
+		link     string // URL of the first page, the index page with --split
+		changed  bool
+		children *publishers.ConfluencePublisher // Publisher of the tag pages under the index page with --split
+	)
+
+	for _, part := range parts {
+		pageTitle := partTitle(title, part)
+		destination := "space " + c.publish.spaceKey
+
+		if c.publish.check {
+			if err := c.checkPage(cmd.Context(), publisher, converter, pageTitle, part.Document, c.publish.maxDocSize); err != nil {
+				drift = append(drift, err)
+			}
+
+			continue
+		}
+
+		target := publisher
+		if children != nil {
+			target = children
+		}
+
+		pageURL, published, err := c.publishPage(cmd.Context(), target, converter, pageTitle, destination, part.Document,
+			c.publish.maxDocSize)
+		if err != nil {
+			return err
+		}
+
+		changed = changed || published
+
+		// The index page, published first, holds the tag pages. The operations of all of them are set before any is
+		// published, so that a new tag sharing operations with another does not take the page of the other
+		if c.publish.split && children == nil {
+			if children, err = publisher.Child(cmd.Context(), pageTitle); err != nil {
+				return fmt.Errorf("failed to find index page %q: %w", pageTitle, err)
+			}
+
+			for _, tag := range parts[1:] {
+				children.Documents(partTitle(title, tag), slices.Sorted(maps.Keys(converters.OperationAnchors(tag.Document, false))))
+			}
+		}
+
+		if link == "" {
+			link = pageURL
+		}
+
+		// With --split, operations keep the link to the first page documenting them
+		for key, anchor := range converters.OperationAnchors(part.Document, c.publish.stableAnchors) {
+			if _, exists := anchors[key]; !exists {
+				anchor.URL = pageURL + "#" + anchor.Anchor
+				anchors[key] = anchor
+			}
+		}
+	}
+
+	if c.publish.check {
+		return errors.Join(drift...)
+	}
+
+	if children != nil {
+		stale, err := children.Prune(cmd.Context(), !c.publish.prune)
+		for _, staleTitle := range stale {
+			if c.publish.prune {
+				c.log.Infof("Deleted page %q of a tag no longer in the specification", staleTitle)
+			} else {
+				c.log.Warningf("Page %q is of a tag no longer in the specification, delete it with --prune", staleTitle)
+			}
+		}
+
+		if err != nil {
+			return fmt.Errorf("failed to prune tag pages: %w", err)
+		}
+
+		changed = changed || c.publish.prune && len(stale) > 0
+	}
+
+	if c.publish.anchorsFile != "" {
+		if err := c.writeAnchors(c.publish.anchorsFile, anchors); err != nil {
+			return err
+		}
+	}
+
+	if !changed {
+		c.log.Infof("No page changed, skipping the notification")
+
+		return nil
+	}
+
+	c.notify(cmd.Context(), notifier, c.publish.notify, doc, title, link)
+
+	return nil
+}
+
+// partTitle returns the title of the page of a part of a document split by tag, "<title> - <tag>" but for the index.
+func partTitle(title string, part converters.DocumentPart) string {
+	if part.Name == "" {
+		return title
+	}
+
+	return fmt.Sprintf("%s - %s", title, part.Name)
+}
+
+// publishPage converts a document and publishes it as a page, returning the page URL and whether any page was
+// published. ADF documents larger than maxSize bytes, when positive, are published as the page followed by
+// continuation pages titled "<title> (2)" and so on, each linked from the page before. Pages a publisher
+// implementing domain.ChangeDetector reports unchanged are skipped.
+func (c *CLI) publishPage(ctx context.Context, publisher domain.Publisher, converter domain.Converter,
+	title, destination string, doc *domain.OpenAPIDocument, maxSize int,
+) (string, bool, error) {
+	var content bytes.Buffer
+
+	if err := converter.Convert(doc, &content); err != nil {
+		return "", false, fmt.Errorf("conversion failed: %w", err)
+	}
+
+	parts, err := converters.SplitADF(content.Bytes(), maxSize)
+	if err != nil {
+		return "", false, fmt.Errorf("failed to split page %q: %w", title, err)
+	}
+
+	detector, detects := publisher.(domain.ChangeDetector)
+
+	// Continuation pages are published last first, so that every page can link to the URL of the next one
+	pageURL, pageTitle := "", ""
+	published := false
+
+	for i := len(parts) - 1; i >= 0; i-- {
+		part := parts[i]
+		if pageURL != "" {
+			if part, err = converters.LinkADFContinuation(part, pageTitle, pageURL); err != nil {
+				return "", false, fmt.Errorf("failed to link to continuation page %q: %w", pageTitle, err)
+			}
+		}
+
+		pageTitle = title
+		if i > 0 {
+			pageTitle = fmt.Sprintf("%s (%d)", title, i+1)
+		}
+
+		if detects {
+			unchanged, existingURL, err := detector.Unchanged(ctx, pageTitle, part)
+			if err != nil {
+				return "", false, fmt.Errorf("failed to compare page %q: %w", pageTitle, err)
+			}
+
+			if unchanged {
+				c.log.Infof("Page %q unchanged, skipped: %s", pageTitle, existingURL)
+
+				pageURL = existingURL
+
+				continue
+			}
+		}
+
+		c.log.Infof("Publishing page %q to %s...", pageTitle, destination)
+
+		if pageURL, err = publisher.Publish(ctx, pageTitle, part); err != nil {
+			return "", false, fmt.Errorf("publishing failed: %w", err)
+		}
+
+		published = true
+
+		c.log.Infof("Successfully published: %s", pageURL)
+	}
+
+	return pageURL, published, nil
+}
+
+// checkPage converts a document as publishPage would and compares it, continuation pages included, with the pages
+// already published, printing the differences of their indented ADF as a unified diff.
+func (c *CLI) checkPage(ctx context.Context, publisher domain.Publisher, converter domain.Converter,
+	title string, doc *domain.OpenAPIDocument, maxSize int,
+) error {
+	fetcher, ok := publisher.(domain.Fetcher)
+	if !ok {
+		return errors.New("--check requires a publisher able to read back its pages")
+	}
+
+	var content bytes.Buffer
+
+	if err := converter.Convert(doc, &content); err != nil {
+		return fmt.Errorf("conversion failed: %w", err)
+	}
+
+	parts, err := converters.SplitADF(content.Bytes(), maxSize)
+	if err != nil {
+		return fmt.Errorf("failed to split page %q: %w", title, err)
+	}
+
+	var report strings.Builder
+
+	drifted := 0
+	pageURL, pageTitle := "", ""
+
+	for i := len(parts) - 1; i >= 0; i-- {
+		part := parts[i]
+		if pageURL != "" {
+			if part, err = converters.LinkADFContinuation(part, pageTitle, pageURL); err != nil {
+				return fmt.Errorf("failed to link to continuation page %q: %w", pageTitle, err)
+			}
+		}
+
+		pageTitle = title
+		if i > 0 {
+			pageTitle = fmt.Sprintf("%s (%d)", title, i+1)
+		}
+
+		c.log.Infof("Checking page %q...", pageTitle)
+
+		var published []byte
+		if published, pageURL, err = fetcher.Fetch(ctx, pageTitle); err != nil {
+			return fmt.Errorf("failed to fetch page %q: %w", pageTitle, err)
+		}
+
+		if published == nil {
+			drifted++
+			fmt.Fprintf(&report, "page %q is not published\n", pageTitle)
+
+			continue
+		}
+
+		want, err := canonicalJSON(part)
+		if err != nil {
+			return fmt.Errorf("failed to compare page %q: %w", pageTitle, err)
+		}
+
+		got, err := canonicalJSON(published)
+		if err != nil {
+			return fmt.Errorf("failed to compare page %q: %w", pageTitle, err)
+		}
+
+		if diff := lineDiff(pageTitle, pageTitle+" (expected)", got, want); diff != "" {
+			drifted++
+			report.WriteString(diff)
+		}
+	}
+
+	if drifted > 0 {
+		fmt.Fprint(c.rootCmd.OutOrStdout(), report.String())
+
+		return fmt.Errorf("page %q is out of date: %d page(s) differ from the conversion", title, drifted)
+	}
+
+	c.log.Infof("Up to date: %s", pageURL)
+
+	return nil
+}
@@ -0,0 +1,250 @@
+package cli
+
+import (
+	"encoding/json"
+	"io"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/GabrielNunesIT/go-libs/logger"
+)
+
+// fakePage is a page of a fakeConfluence site.
+type fakePage struct {
+	id         string
+	title      string
+	parent     string
+	version    int
+	properties map[string]json.RawMessage
+}
+
+// fakeConfluence is a Confluence site serving the parts of the REST API the publish command uses.
+type fakeConfluence struct {
+	t      *testing.T
+	mu     sync.Mutex
+	pages  map[string]*fakePage
+	nextID int
+}
+
+func newFakeConfluence(t *testing.T) *fakeConfluence {
+	t.Helper()
+
+	return &fakeConfluence{t: t, pages: make(map[string]*fakePage)}
+}
+
+func (f *fakeConfluence) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	rest, _ := strings.CutPrefix(r.URL.Path, "/rest/api/content")
+	segments := strings.Split(strings.Trim(rest, "/"), "/")
+
+	var body struct {
+		Title     string          `json:"title"`
+		Key       string          `json:"key"`
+		Value     json.RawMessage `json:"value"`
+		Ancestors []struct {
+			ID string `json:"id"`
+		} `json:"ancestors"`
+	}
+
+	if r.Body != nil {
+		_ = json.NewDecoder(r.Body).Decode(&body)
+	}
+
+	page := f.pages[segments[0]]
+
+	switch {
+	case r.Method == http.MethodGet && rest == "":
+		f.writePages(w, func(page *fakePage) bool { return page.title == r.URL.Query().Get("title") })
+	case r.Method == http.MethodPost && rest == "":
+		f.nextID++
+		page = &fakePage{id: strconv.Itoa(f.nextID), title: body.Title, properties: make(map[string]json.RawMessage)}
+		f.pages[page.id] = page
+
+		fallthrough
+	case r.Method == http.MethodPut && len(segments) == 1 && page != nil:
+		page.title = body.Title
+		page.version++
+
+		if len(body.Ancestors) > 0 {
+			page.parent = body.Ancestors[0].ID
+		}
+
+		writeJSON(w, page.json())
+	case r.Method == http.MethodDelete && len(segments) == 1 && page != nil:
+		delete(f.pages, page.id)
+	case r.Method == http.MethodGet && len(segments) == 3 && segments[1] == "child" && page != nil:
+		f.writePages(w, func(child *fakePage) bool { return child.parent == page.id })
+	case r.Method != http.MethodGet && len(segments) >= 2 && segments[1] == "property" && page != nil:
+		page.properties[body.Key] = body.Value
+	default:
+		f.t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		http.NotFound(w, r)
+	}
+}
+
+// writePages writes the pages matching a predicate as search results, by ID.
+func (f *fakeConfluence) writePages(w http.ResponseWriter, matches func(*fakePage) bool) {
+	results := []map[string]any{}
+
+	for _, id := range slices.Sorted(maps.Keys(f.pages)) {
+		if matches(f.pages[id]) {
+			results = append(results, f.pages[id].json())
+		}
+	}
+
+	writeJSON(w, map[string]any{"results": results})
+}
+
+// json returns the page as the REST API returns it, with its properties expanded.
+func (page *fakePage) json() map[string]any {
+	properties := make(map[string]any)
+	for key, value := range page.properties {
+		properties[key] = map[string]any{"key": key, "value": value, "version": map[string]int{"number": 1}}
+	}
+
+	return map[string]any{
+		"id":       page.id,
+		"type":     "page",
+		"title":    page.title,
+		"version":  map[string]int{"number": page.version},
+		"metadata": map[string]any{"properties": properties},
+	}
+}
+
+func writeJSON(w http.ResponseWriter, value any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(value)
+}
+
+// pageIDs returns the IDs of the pages by title.
+func (f *fakeConfluence) pageIDs() map[string]string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	ids := make(map[string]string)
+	for _, page := range f.pages {
+		ids[page.title] = page.id
+	}
+
+	return ids
+}
+
+// tagSpec returns a specification of the given top-level tags and operations, as operationId: tags.
+func tagSpec(tags []string, operations map[string][]string) string {
+	var spec strings.Builder
+
+	spec.WriteString("openapi: 3.0.3\ninfo:\n  title: Pets\n  version: 1.0.0\ntags:\n")
+
+	for _, tag := range tags {
+		spec.WriteString("  - name: " + tag + "\n")
+	}
+
+	spec.WriteString("paths:\n")
+
+	for _, id := range slices.Sorted(maps.Keys(operations)) {
+		spec.WriteString("  /" + id + ":\n    get:\n      operationId: " + id + "\n      tags: [" +
+			strings.Join(operations[id], ", ") + "]\n      responses:\n        '200':\n          description: OK\n")
+	}
+
+	return spec.String()
+}
+
+func TestPublishSplitPages(t *testing.T) {
+	t.Setenv(envConfluenceEmail, "user@example.com")
+	t.Setenv(envConfluenceAPIToken, "token")
+	t.Setenv(envNotifyWebhook, "")
+
+	site := newFakeConfluence(t)
+	server := httptest.NewServer(site)
+	t.Cleanup(server.Close)
+
+	publish := func(spec string, args ...string) {
+		t.Helper()
+
+		input := filepath.Join(t.TempDir(), "pets.yaml")
+		if err := os.WriteFile(input, []byte(spec), 0o600); err != nil {
+			t.Fatal(err)
+		}
+
+		c := New(logger.NewConsoleLogger(io.Discard))
+		c.rootCmd.SetArgs(append([]string{"publish", "-i", input, "--base-url", server.URL, "--space", "API", "--split"}, args...))
+		c.rootCmd.SetOut(io.Discard)
+		c.rootCmd.SetErr(io.Discard)
+
+		if err := c.Execute(); err != nil {
+			t.Fatalf("publish: %v", err)
+		}
+	}
+
+	publish(tagSpec([]string{"pets", "store", "legacy"}, map[string][]string{
+		"listPets":   {"pets"},
+		"getPet":     {"pets"},
+		"getOrder":   {"store"},
+		"placeOrder": {"store"},
+		"oldPets":    {"legacy"},
+	}))
+
+	first := site.pageIDs()
+
+	// The pets tag is renamed animals, and a new inventory tag published first shares an operation with store
+	renamed := tagSpec([]string{"inventory", "animals", "store"}, map[string][]string{
+		"listPets":   {"animals"},
+		"getPet":     {"animals"},
+		"getOrder":   {"inventory", "store"},
+		"placeOrder": {"store"},
+	})
+
+	tests := []struct {
+		name string
+		args []string
+		want map[string]string // IDs of the pages by title, "new" for a page created
+	}{
+		{
+			name: "rename",
+			want: map[string]string{
+				"Pets":             first["Pets"],
+				"Pets - animals":   first["Pets - pets"],
+				"Pets - store":     first["Pets - store"],
+				"Pets - inventory": "new",
+				"Pets - legacy":    first["Pets - legacy"],
+			},
+		},
+		{
+			name: "prune",
+			args: []string{"--prune"},
+			want: map[string]string{
+				"Pets":             first["Pets"],
+				"Pets - animals":   first["Pets - pets"],
+				"Pets - store":     first["Pets - store"],
+				"Pets - inventory": "new",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			publish(renamed, tt.args...)
+
+			got := site.pageIDs()
+			if !slices.Equal(slices.Sorted(maps.Keys(got)), slices.Sorted(maps.Keys(tt.want))) {
+				t.Fatalf("got pages %q, want %q", slices.Sorted(maps.Keys(got)), slices.Sorted(maps.Keys(tt.want)))
+			}
+
+			for title, id := range tt.want {
+				if id == "new" && slices.Contains(slices.Collect(maps.Values(first)), got[title]) || id != "new" && got[title] != id {
+					t.Errorf("got page %q with ID %s, want %s", title, got[title], id)
+				}
+			}
+		})
+	}
+}
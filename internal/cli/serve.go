@@ -0,0 +1,204 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/GabrielNunesIT/openapi-converter/internal/domain"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// serveReadHeaderTimeout bounds how long a client may take to send the headers of a request.
+	serveReadHeaderTimeout = 10 * time.Second
+
+	// serveShutdownTimeout bounds how long requests in progress may take to finish once the server stops.
+	serveShutdownTimeout = 5 * time.Second
+)
+
+// serveContentTypes maps converter formats to the media type of their output.
+var serveContentTypes = map[string]string{
+	"html":               "text/html; charset=utf-8",
+	"markdown":           "text/markdown; charset=utf-8",
+	"slate":              "text/markdown; charset=utf-8",
+	"confluence":         "application/json",
+	"confluence-storage": "application/xhtml+xml; charset=utf-8",
+	"notion":             "application/json",
+	"postman":            "application/json",
+	"mermaid":            "text/plain; charset=utf-8",
+	"dot":                "text/vnd.graphviz; charset=utf-8",
+	"pdf":                "application/pdf",
+	"docx":               "application/vnd.openxmlformats-officedocument.wordprocessingml.document",
+}
+
+// serveFlags holds the flags of the serve command that the other commands do not have.
+type serveFlags struct {
+	host string
+	port int
+}
+
+func (c *CLI) newServeCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "serve <spec>",
+		Short: "Serve the converted documentation over HTTP",
+		Long: "Starts a local HTTP server serving the specification as HTML at / and in any other format at /<format>, " +
+			"e.g. /markdown, /adf or /pdf. The specification is read again on every request, so edits show up on reload. " +
+			"Stop the server with Ctrl+C.",
+		Args:        cobra.ExactArgs(1),
+		RunE:        c.runServe,
+		Annotations: configSectionAnnotation(""),
+	}
+
+	cmd.Flags().StringVar(&c.serve.host, "host", "localhost", "Address to listen on, empty for all interfaces")
+	cmd.Flags().IntVarP(&c.serve.port, "port", "p", 8080, "Port to listen on")
+	cmd.Flags().StringVar(&c.title, "title", "", titleUsage)
+	addMetadataFlags(cmd, &c.metadata)
+	c.addConversionFlags(cmd, &c.conversionFlags)
+	cmd.Flags().BoolVar(&c.strict, "strict", false, strictUsage)
+	cmd.Flags().StringVar(&c.harFile, "har", "", harUsage)
+
+	return cmd
+}
+
+func (c *CLI) runServe(cmd *cobra.Command, args []string) error {
+	inputFile, err := filepath.Abs(args[0])
+	if err != nil {
+		return fmt.Errorf("failed to resolve path: %w", err)
+	}
+
+	c.inputFile = inputFile
+
+	// Fail early on a specification that does not load rather than on the first request
+	if _, err := c.loadServedDocument(); err != nil {
+		return err
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /{$}", func(w http.ResponseWriter, r *http.Request) {
+		c.serveFormat(w, "html")
+	})
+	mux.HandleFunc("GET /{format}", func(w http.ResponseWriter, r *http.Request) {
+		c.serveFormat(w, r.PathValue("format"))
+	})
+
+	return c.listenAndServe(cmd.Context(), c.serve.host, c.serve.port, mux, "Serving "+c.inputFile)
+}
+
+// listenAndServe serves handler on host and port until interrupted, then waits for the requests in progress.
+// Activity describes what is served in the startup message, e.g. "Serving spec.yaml".
+func (c *CLI) listenAndServe(ctx context.Context, host string, port int, handler http.Handler, activity string) error {
+	server := &http.Server{
+		Addr:              net.JoinHostPort(host, strconv.Itoa(port)),
+		Handler:           handler,
+		ReadHeaderTimeout: serveReadHeaderTimeout,
+	}
+
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	listener, err := net.Listen("tcp", server.Addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", server.Addr, err)
+	}
+
+	c.log.Infof("%s at http://%s/, press Ctrl+C to stop", activity, listener.Addr())
+
+	served := make(chan error, 1)
+	go func() {
+		served <- server.Serve(listener)
+	}()
+
+	select {
+	case err := <-served:
+		return fmt.Errorf("server stopped: %w", err)
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), serveShutdownTimeout)
+	defer cancel()
+
+	if err := server.Shutdown(shutdownCtx); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("failed to stop server: %w", err)
+	}
+
+	c.log.Info("Stopped serving")
+
+	return nil
+}
+
+// serveFormat converts the specification to a format, or one of its aliases, and writes the result.
+// The output is buffered so that a failed conversion is reported as an error rather than a truncated page.
+func (c *CLI) serveFormat(w http.ResponseWriter, format string) {
+	converter, err := c.getConverter(format, c.conversionFlags)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+
+		return
+	}
+
+	doc, err := c.loadServedDocument()
+	if err != nil {
+		c.log.Errorf("%v", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+
+		return
+	}
+
+	var body bytes.Buffer
+	if err := converter.Convert(doc, &body); err != nil {
+		c.log.Errorf("Conversion to %s failed: %v", converter.Format(), err)
+		http.Error(w, fmt.Sprintf("conversion failed: %v", err), http.StatusInternalServerError)
+
+		return
+	}
+
+	contentType, ok := serveContentTypes[converter.Format()]
+	if !ok {
+		contentType = "application/octet-stream"
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Cache-Control", "no-store")
+	_, _ = w.Write(body.Bytes())
+}
+
+// loadServedDocument reads the specification again, so that every request reflects its latest version.
+func (c *CLI) loadServedDocument() (*domain.OpenAPIDocument, error) {
+	doc, sources, err := c.loadOpenAPI(c.inputFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load OpenAPI specification: %w", err)
+	}
+
+	if c.title != "" {
+		doc.Title = c.title
+	}
+
+	if err := applyMetadata(doc, c.metadata); err != nil {
+		return nil, err
+	}
+
+	if c.strict {
+		if err := c.checkSpec(doc, sources, c.inputFile); err != nil {
+			return nil, err
+		}
+	}
+
+	if c.harFile != "" {
+		if err := c.enrichDocument(doc); err != nil {
+			return nil, err
+		}
+	}
+
+	return prepareDocument(doc, withServer(c.transforms, c.server), c.redaction, c.hideDeprecated, c.selection,
+		c.groupBy, c.tagOrder, c.operationOrder)
+}
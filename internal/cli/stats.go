@@ -0,0 +1,154 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/GabrielNunesIT/openapi-converter/internal/usecases/stats"
+	"github.com/spf13/cobra"
+)
+
+// statsFlags holds the flags of the stats command.
+type statsFlags struct {
+	format      string
+	outputFile  string
+	minCoverage float64
+}
+
+func (c *CLI) newStatsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "stats [spec]",
+		Short: "Report the size and documentation coverage of an OpenAPI specification",
+		Long: "Counts the operations of an OpenAPI specification per tag and method, its schemas and the responses per status " +
+			"code, and lists the operations missing a description or examples, as text or JSON, " +
+			"to track documentation coverage over time.\n" +
+			"The coverage score is the percentage of operations with a summary, a description, examples of their " +
+			"request and response bodies and an error response; with --min-coverage, a lower score fails the run " +
+			"so that CI can enforce documentation quality.\n" +
+			"The specification is read from standard input when omitted or \"-\".",
+		Args: cobra.MaximumNArgs(1),
+		RunE: c.runStats,
+	}
+
+	cmd.Flags().StringVarP(&c.stats.format, "format", "f", "text", "Report format: text, json")
+	cmd.Flags().StringVarP(&c.stats.outputFile, "output", "o", "", "Path for the report (default: standard output)")
+	cmd.Flags().Float64Var(&c.stats.minCoverage, "min-coverage", 0,
+		"Coverage score, in percent, below which the command fails (default: never fails)")
+
+	return cmd
+}
+
+func (c *CLI) runStats(_ *cobra.Command, args []string) error {
+	write := writeStatsText
+	switch strings.ToLower(c.stats.format) {
+	case "text":
+	case "json":
+		write = writeStatsJSON
+	default:
+		return fmt.Errorf("unsupported report format: %s (supported: text, json)", c.stats.format)
+	}
+
+	path := stdioPath
+	if len(args) > 0 {
+		path = args[0]
+	}
+
+	doc, _, err := c.loadOpenAPI(path)
+	if err != nil {
+		return fmt.Errorf("failed to load OpenAPI specification: %w", err)
+	}
+
+	report := stats.Compute(doc)
+
+	output, err := c.createOutput(c.stats.outputFile)
+	if err != nil {
+		return err
+	}
+	defer output.Close()
+
+	if err := write(report, output); err != nil {
+		return err
+	}
+
+	c.log.Infof("Found %d operation(s) with a coverage score of %.1f%% in %s", report.Operations,
+		report.Coverage, stdioName(path, "standard input"))
+
+	if report.Coverage < c.stats.minCoverage {
+		return fmt.Errorf("coverage score %.1f%% is below the minimum of %.1f%%", report.Coverage, c.stats.minCoverage)
+	}
+
+	return nil
+}
+
+// writeStatsJSON writes the statistics of a specification as a JSON document for scripts.
+func writeStatsJSON(report *stats.Stats, output io.Writer) error {
+	encoder := json.NewEncoder(output)
+	encoder.SetIndent("", "  ")
+
+	if err := encoder.Encode(report); err != nil {
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+
+	return nil
+}
+
+// writeStatsText writes the statistics of a specification as aligned sections for people.
+func writeStatsText(report *stats.Stats, output io.Writer) error {
+	table := tabwriter.NewWriter(output, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintf(table, "%s %s\n\n", report.Title, report.Version)
+	fmt.Fprintf(table, "Coverage score:\t%.1f%%\n", report.Coverage)
+	fmt.Fprintf(table, "Operations:\t%d\n", report.Operations)
+	fmt.Fprintf(table, "Schemas:\t%d\n", report.Schemas)
+	fmt.Fprintf(table, "Webhooks:\t%d\n", report.Webhooks)
+	fmt.Fprintf(table, "Description coverage:\t%.1f%%\n", report.DescriptionCoverage)
+	fmt.Fprintf(table, "Example coverage:\t%.1f%% (of %d operation(s) with bodies)\n", report.ExampleCoverage, report.WithBodies)
+
+	sections := []struct {
+		title  string
+		counts []stats.Count
+	}{
+		{"Operations by tag", report.ByTag},
+		{"Operations by method", report.ByMethod},
+		{"Responses by status code", report.Responses},
+	}
+
+	for _, section := range sections {
+		fmt.Fprintf(table, "\n%s:\n", section.title)
+
+		for _, count := range section.counts {
+			fmt.Fprintf(table, "  %s\t%d\n", count.Name, count.Count)
+		}
+	}
+
+	lists := []struct {
+		title      string
+		operations []string
+	}{
+		{"Operations without a summary", report.MissingSummaries},
+		{"Operations without a description", report.MissingDescriptions},
+		{"Operations without examples", report.MissingExamples},
+		{"Operations without an error response", report.MissingErrorResponses},
+	}
+
+	for _, list := range lists {
+		if len(list.operations) == 0 {
+			continue
+		}
+
+		fmt.Fprintf(table, "\n%s:\n", list.title)
+
+		for _, operation := range list.operations {
+			fmt.Fprintf(table, "  %s\n", operation)
+		}
+	}
+
+	if err := table.Flush(); err != nil {
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,174 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/GabrielNunesIT/openapi-converter/internal/adapters/publishers"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// envGCSToken holds the OAuth 2.0 access token used to upload to Google Cloud Storage,
+	// e.g. from gcloud auth print-access-token.
+	envGCSToken = "GOOGLE_OAUTH_ACCESS_TOKEN" //nolint:gosec // environment variable name, not a credential
+
+	// The environment variables of the AWS command line interface and SDKs, read for S3.
+	envAWSAccessKeyID     = "AWS_ACCESS_KEY_ID"
+	envAWSSecretAccessKey = "AWS_SECRET_ACCESS_KEY" //nolint:gosec // environment variable name, not a credential
+	envAWSSessionToken    = "AWS_SESSION_TOKEN"     //nolint:gosec // environment variable name, not a credential
+	envAWSRegion          = "AWS_REGION"
+	envAWSDefaultRegion   = "AWS_DEFAULT_REGION"
+	envAWSEndpointURL     = "AWS_ENDPOINT_URL_S3"
+)
+
+// storageFlags holds the flags of the publish storage command.
+type storageFlags struct {
+	cacheControl string
+	endpoint     string
+	region       string
+	dryRun       bool
+}
+
+// storageFile is a file to upload with the name of its object under the key prefix.
+type storageFile struct {
+	path string
+	name string
+}
+
+func (c *CLI) newPublishStorageCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "storage <bucket-url> <path>...",
+		Short: "Upload generated documents to an S3 or Google Cloud Storage bucket",
+		Long: "Uploads generated files, such as a PDF, the ADF JSON of a page or a directory written with --split, " +
+			"to an S3 or Google Cloud Storage bucket under the key prefix of the bucket URL, e.g. s3://docs-bucket/api/v1 " +
+			"or gs://docs-bucket/api. Files keep their name, and the files of directories their path relative to " +
+			"the directory; objects are stored with the media type of their extension and the --cache-control header.\n\n" +
+			"S3 credentials and region are read from the " + envAWSAccessKeyID + ", " + envAWSSecretAccessKey + ", " +
+			envAWSSessionToken + " and " + envAWSRegion + " environment variables, and S3-compatible stores such as " +
+			"MinIO or R2 are reached with --endpoint. Google Cloud Storage takes an OAuth 2.0 access token from the " +
+			envGCSToken + " environment variable, e.g. from gcloud auth print-access-token.",
+		Args:        cobra.MinimumNArgs(2),
+		RunE:        c.runPublishStorage,
+		Annotations: configSectionAnnotation("storage"),
+	}
+
+	region := os.Getenv(envAWSRegion)
+	if region == "" {
+		region = os.Getenv(envAWSDefaultRegion)
+	}
+
+	cmd.Flags().StringVar(&c.storage.cacheControl, "cache-control", "",
+		"Cache-Control header of the uploaded objects, e.g. \"public, max-age=300\"")
+	cmd.Flags().StringVar(&c.storage.endpoint, "endpoint", os.Getenv(envAWSEndpointURL),
+		"URL of an S3-compatible store such as MinIO or R2, addressed with path-style URLs (default: "+envAWSEndpointURL+")")
+	cmd.Flags().StringVar(&c.storage.region, "region", region,
+		"Region of the S3 bucket (default: "+envAWSRegion+", else us-east-1)")
+	cmd.Flags().BoolVar(&c.storage.dryRun, "dry-run", false, "List the objects that would be uploaded without uploading them")
+
+	return cmd
+}
+
+func (c *CLI) runPublishStorage(cmd *cobra.Command, args []string) error {
+	publisher, err := publishers.NewStoragePublisher(publishers.StorageConfig{
+		URL:             args[0],
+		CacheControl:    c.storage.cacheControl,
+		Endpoint:        c.storage.endpoint,
+		Region:          c.storage.region,
+		AccessKeyID:     os.Getenv(envAWSAccessKeyID),
+		SecretAccessKey: os.Getenv(envAWSSecretAccessKey),
+		SessionToken:    os.Getenv(envAWSSessionToken),
+		Token:           credentialEnv(c.credentials.TokenEnv, envGCSToken),
+	})
+	if err != nil {
+		return fmt.Errorf("publishing failed: %w", err)
+	}
+
+	files, err := storageFiles(args[1:])
+	if err != nil {
+		return err
+	}
+
+	keys := make(map[string]string, len(files))
+
+	for _, file := range files {
+		key := publisher.Key(file.name)
+		if other, taken := keys[key]; taken {
+			return fmt.Errorf("%s and %s would both be uploaded as %s", other, file.path, key)
+		}
+
+		keys[key] = file.path
+	}
+
+	for _, file := range files {
+		if c.storage.dryRun {
+			c.log.Infof("Would upload %s as %s (%s)", file.path, publisher.Key(file.name), publishers.StorageContentType(file.name))
+
+			continue
+		}
+
+		content, err := os.ReadFile(file.path)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", file.path, err)
+		}
+
+		objectURL, err := publisher.Publish(cmd.Context(), file.name, content)
+		if err != nil {
+			return fmt.Errorf("publishing failed: %w", err)
+		}
+
+		c.log.Infof("Uploaded %s: %s", file.path, objectURL)
+	}
+
+	if !c.storage.dryRun {
+		c.log.Infof("Successfully uploaded %d file(s)", len(files))
+	}
+
+	return nil
+}
+
+// storageFiles lists the files to upload from the paths given: files by their name, and the files of directories
+// by their path relative to the directory.
+func storageFiles(paths []string) ([]storageFile, error) {
+	var files []storageFile
+
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+
+		if !info.IsDir() {
+			files = append(files, storageFile{path: path, name: filepath.Base(path)})
+
+			continue
+		}
+
+		err = filepath.WalkDir(path, func(file string, entry fs.DirEntry, err error) error {
+			if err != nil || entry.IsDir() {
+				return err
+			}
+
+			rel, err := filepath.Rel(path, file)
+			if err != nil {
+				return fmt.Errorf("failed to resolve %s: %w", file, err)
+			}
+
+			files = append(files, storageFile{path: file, name: filepath.ToSlash(rel)})
+
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list %s: %w", path, err)
+		}
+	}
+
+	if len(files) == 0 {
+		return nil, errors.New("no files to upload")
+	}
+
+	return files, nil
+}
@@ -0,0 +1,90 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/GabrielNunesIT/openapi-converter/internal/adapters/parsers"
+	"github.com/GabrielNunesIT/openapi-converter/internal/adapters/reporters"
+	"github.com/GabrielNunesIT/openapi-converter/internal/domain"
+	"github.com/GabrielNunesIT/openapi-converter/internal/usecases/validate"
+	"github.com/spf13/cobra"
+)
+
+func (c *CLI) newValidateCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "validate [spec]",
+		Short: "Check an OpenAPI specification for structural problems",
+		Long: "Checks an OpenAPI specification for duplicate paths, missing or duplicate operationIds, unresolved references " +
+			"and responses without descriptions, printing each problem as file:line: severity: message.\n" +
+			"Exits with an error when any error is found. The specification is read from standard input when omitted or \"-\".",
+		Args: cobra.MaximumNArgs(1),
+		RunE: c.runValidate,
+	}
+}
+
+func (c *CLI) runValidate(cmd *cobra.Command, args []string) error {
+	path := stdioPath
+	if len(args) > 0 {
+		path = args[0]
+	}
+
+	doc, sources, err := c.loadOpenAPI(path)
+	if err != nil {
+		return fmt.Errorf("failed to load OpenAPI specification: %w", err)
+	}
+
+	report := &domain.Report{File: stdioName(path, ""), Issues: validate.Document(doc)}
+	locateIssues(report.Issues, sources)
+
+	if err := reporters.NewTextReporter().Report(report, cmd.OutOrStdout()); err != nil {
+		return err
+	}
+
+	errorCount := report.Count(domain.SeverityError)
+	c.log.Infof("Found %d error(s) and %d warning(s) in %s", errorCount, report.Count(domain.SeverityWarning),
+		stdioName(path, "standard input"))
+
+	if errorCount > 0 {
+		return fmt.Errorf("specification has %d validation error(s)", errorCount)
+	}
+
+	return nil
+}
+
+// checkSpec logs the problems of a specification before conversion and fails when any is an error.
+func (c *CLI) checkSpec(doc *domain.OpenAPIDocument, sources []string, path string) error {
+	report := &domain.Report{Issues: validate.Document(doc)}
+	locateIssues(report.Issues, sources)
+
+	for _, issue := range report.Issues {
+		if issue.Severity == domain.SeverityError {
+			c.log.Errorf("%s", reporters.FormatIssue(stdioName(path, "<stdin>"), issue))
+		} else {
+			c.log.Warningf("%s", reporters.FormatIssue(stdioName(path, "<stdin>"), issue))
+		}
+	}
+
+	if errorCount := report.Count(domain.SeverityError); errorCount > 0 {
+		return fmt.Errorf("specification has %d validation error(s), fix them or run without --strict", errorCount)
+	}
+
+	return nil
+}
+
+// locateIssues sets the line of each issue in the file the specification was read from, if any.
+func locateIssues(issues []domain.Issue, sources []string) {
+	if len(sources) == 0 {
+		return
+	}
+
+	data, err := os.ReadFile(sources[0])
+	if err != nil {
+		return
+	}
+
+	sourceMap := parsers.NewSourceMap(data)
+	for i := range issues {
+		issues[i].Line = sourceMap.Line(issues[i].Pointer)
+	}
+}
@@ -0,0 +1,198 @@
+package cli
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/spf13/cobra"
+)
+
+// watchDebounce groups the bursts of events editors emit for a single save into one regeneration.
+const watchDebounce = 200 * time.Millisecond
+
+func (c *CLI) newWatchCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "watch <spec>",
+		Short: "Regenerate the output whenever the specification changes",
+		Long: "Converts an OpenAPI specification and keeps regenerating the output whenever the specification " +
+			"or any local file it references through $ref changes. Stop watching with Ctrl+C.",
+		Args:        cobra.ExactArgs(1),
+		RunE:        c.runWatch,
+		Annotations: configSectionAnnotation(""),
+	}
+
+	cmd.Flags().StringVarP(&c.outputFile, "output", "o", "", "Path for the output file, or directory with --split (required)")
+	cmd.Flags().StringVarP(&c.format, "format", "f", "pdf", formatListUsage())
+	cmd.Flags().StringVar(&c.title, "title", "", titleUsage)
+	addMetadataFlags(cmd, &c.metadata)
+	c.addConversionFlags(cmd, &c.conversionFlags)
+	cmd.Flags().BoolVar(&c.split, "split", false,
+		"Write one document per tag plus an index into the output directory instead of a single file")
+	cmd.Flags().IntVar(&c.maxDocSize, "max-doc-size", 0, maxDocSizeUsage)
+	cmd.Flags().StringVar(&c.anchorsFile, "anchors-file", "", anchorsFileUsage)
+	cmd.Flags().BoolVar(&c.strict, "strict", false, strictUsage)
+	cmd.Flags().StringVar(&c.harFile, "har", "", harUsage)
+
+	_ = cmd.MarkFlagRequired("output")
+
+	return cmd
+}
+
+func (c *CLI) runWatch(cmd *cobra.Command, args []string) error {
+	inputFile, err := filepath.Abs(args[0])
+	if err != nil {
+		return fmt.Errorf("failed to resolve path: %w", err)
+	}
+
+	c.inputFile = inputFile
+
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("failed to start file watcher: %w", err)
+	}
+	defer watcher.Close()
+
+	files := newWatchedFiles(watcher)
+
+	regenerate := func() {
+		_, sources, err := c.convert()
+		if err != nil {
+			c.log.Errorf("Regeneration failed: %v", err)
+		}
+
+		// A specification that fails to load keeps the previous set of watched files
+		if len(sources) == 0 {
+			if files.empty() {
+				sources = []string{c.inputFile}
+			} else {
+				return
+			}
+		}
+
+		if err := files.update(sources); err != nil {
+			c.log.Errorf("Failed to watch files: %v", err)
+		}
+
+		c.log.Infof("Watching %d file(s) for changes, press Ctrl+C to stop", files.count())
+	}
+
+	regenerate()
+
+	pending := time.NewTimer(watchDebounce)
+	pending.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			c.log.Info("Stopped watching")
+
+			return nil
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+
+			if files.contains(event.Name) && event.Op.Has(fsnotify.Write|fsnotify.Create|fsnotify.Rename|fsnotify.Remove) {
+				pending.Reset(watchDebounce)
+			}
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+
+			c.log.Errorf("File watcher error: %v", err)
+		case <-pending.C:
+			c.log.Info("Change detected, regenerating...")
+			regenerate()
+		}
+	}
+}
+
+// watchedFiles tracks the local files of a specification. Their parent directories are watched
+// instead of the files themselves so that editors replacing files on save are still noticed.
+type watchedFiles struct {
+	watcher *fsnotify.Watcher
+	files   map[string]struct{}
+	dirs    map[string]struct{}
+}
+
+func newWatchedFiles(watcher *fsnotify.Watcher) *watchedFiles {
+	return &watchedFiles{
+		watcher: watcher,
+		files:   make(map[string]struct{}),
+		dirs:    make(map[string]struct{}),
+	}
+}
+
+// update replaces the watched files with the local files among sources, skipping remote references.
+func (w *watchedFiles) update(sources []string) error {
+	files := make(map[string]struct{})
+	dirs := make(map[string]struct{})
+
+	for _, source := range sources {
+		path, ok := localPath(source)
+		if !ok {
+			continue
+		}
+
+		files[path] = struct{}{}
+		dirs[filepath.Dir(path)] = struct{}{}
+	}
+
+	for dir := range w.dirs {
+		if _, keep := dirs[dir]; !keep {
+			_ = w.watcher.Remove(dir)
+		}
+	}
+
+	w.files = files
+	w.dirs = make(map[string]struct{})
+
+	for dir := range dirs {
+		if err := w.watcher.Add(dir); err != nil {
+			return fmt.Errorf("failed to watch %s: %w", dir, err)
+		}
+
+		w.dirs[dir] = struct{}{}
+	}
+
+	return nil
+}
+
+func (w *watchedFiles) contains(path string) bool {
+	_, ok := w.files[filepath.Clean(path)]
+
+	return ok
+}
+
+func (w *watchedFiles) empty() bool {
+	return len(w.files) == 0
+}
+
+func (w *watchedFiles) count() int {
+	return len(w.files)
+}
+
+// localPath returns the file system path of a source location, or false for remote locations.
+func localPath(source string) (string, bool) {
+	location, err := url.Parse(source)
+	if err != nil || len(location.Scheme) == 1 {
+		// Windows drive letters parse as a scheme
+		return filepath.Clean(source), true
+	}
+
+	if location.Scheme != "" && location.Scheme != "file" {
+		return "", false
+	}
+
+	return filepath.Clean(filepath.FromSlash(location.Path)), true
+}
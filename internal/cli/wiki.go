@@ -0,0 +1,171 @@
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+
+	"github.com/GabrielNunesIT/openapi-converter/internal/adapters/converters"
+	"github.com/GabrielNunesIT/openapi-converter/internal/adapters/publishers"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// envWikiURL holds the git URL of the wiki published to.
+	envWikiURL = "WIKI_URL"
+
+	// envWikiToken holds the personal access token used to push to the wiki.
+	envWikiToken = "WIKI_TOKEN" //nolint:gosec // environment variable name, not a credential
+
+	// wikiFormat is the output format the publish wiki command converts to, whose options --option sets.
+	wikiFormat = "markdown"
+)
+
+// wikiFlags holds the flags of the publish wiki command.
+type wikiFlags struct {
+	conversionFlags
+
+	inputFile string
+	url       string
+	provider  string
+	title     string
+	message   string
+	sidebar   bool
+	metadata  metadataFlags
+	split     bool
+	notify    notifyFlags
+}
+
+func (c *CLI) newPublishWikiCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "wiki",
+		Short: "Publish an OpenAPI specification as Markdown pages of a GitHub or GitLab wiki",
+		Long: "Converts an OpenAPI specification to Markdown and pushes it to the git repository of a GitHub or GitLab " +
+			"wiki, e.g. https://github.com/owner/repo.wiki.git, in a single commit. Pages are named after their title " +
+			"and replaced when they already exist; with --split, every tag gets its own page besides an index page, " +
+			"and the sidebar of the wiki is replaced with links to the pages unless --sidebar=false.\n\n" +
+			"A personal access token allowed to push to the wiki is read from the " + envWikiToken + " environment " +
+			"variable; without one, git uses its own credentials.",
+		RunE:        c.runPublishWiki,
+		Annotations: configSectionAnnotation("wiki"),
+	}
+
+	cmd.Flags().StringVarP(&c.wiki.inputFile, "input", "i", "", "Path to the OpenAPI specification file (default: standard input)")
+	cmd.Flags().StringVar(&c.wiki.url, "url", os.Getenv(envWikiURL),
+		"Git URL of the wiki, e.g. https://github.com/owner/repo.wiki.git (required, default: "+envWikiURL+")")
+	cmd.Flags().StringVar(&c.wiki.provider, "provider", "",
+		"Wiki provider, "+publishers.WikiGitHub+" or "+publishers.WikiGitLab+" (default: detected from the URL)")
+	cmd.Flags().StringVar(&c.wiki.title, "title", "", "Page title (defaults to the API title)")
+	cmd.Flags().StringVar(&c.wiki.message, "message", "", "Commit message (defaults to \"Update <title> to version <version>\")")
+	cmd.Flags().BoolVar(&c.wiki.sidebar, "sidebar", true, "Replace the sidebar of the wiki with links to the published pages")
+	addMetadataFlags(cmd, &c.wiki.metadata)
+	cmd.Flags().BoolVar(&c.wiki.split, "split", false,
+		"Publish one page per tag plus an index page, titled \"<title> - <tag>\"")
+	c.addConversionFlags(cmd, &c.wiki.conversionFlags)
+	addNotifyFlags(cmd, &c.wiki.notify)
+
+	return cmd
+}
+
+func (c *CLI) runPublishWiki(cmd *cobra.Command, _ []string) error {
+	if c.wiki.url == "" {
+		return fmt.Errorf("required flag \"url\" not set, nor the %s environment variable", envWikiURL)
+	}
+
+	c.log.Infof("Loading OpenAPI specification from: %s", stdioName(c.wiki.inputFile, "standard input"))
+
+	doc, _, err := c.loadOpenAPI(c.wiki.inputFile)
+	if err != nil {
+		return fmt.Errorf("failed to load OpenAPI specification: %w", err)
+	}
+
+	c.log.Infof("Loaded API: %s (v%s)", doc.Title, doc.Version)
+
+	notifier, err := c.notifier(c.wiki.notify)
+	if err != nil {
+		return err
+	}
+
+	if err := applyMetadata(doc, c.wiki.metadata); err != nil {
+		return err
+	}
+
+	doc, err = prepareDocument(doc, withServer(c.transforms, c.wiki.server), c.wiki.redaction, c.wiki.hideDeprecated,
+		c.wiki.selection, c.wiki.groupBy, c.wiki.tagOrder, c.wiki.operationOrder)
+	if err != nil {
+		return err
+	}
+
+	title := c.wiki.title
+	if title == "" {
+		title = doc.Title
+	}
+
+	converter, err := c.getConverter(wikiFormat, c.wiki.conversionFlags)
+	if err != nil {
+		return err
+	}
+
+	publisher := publishers.NewWikiPublisher(publishers.WikiConfig{
+		URL:      c.wiki.url,
+		Provider: c.wiki.provider,
+		Token:    credentialEnv(c.credentials.TokenEnv, envWikiToken),
+		Sidebar:  c.wiki.sidebar,
+	})
+	defer publisher.Close()
+
+	parts := []converters.DocumentPart{{Document: doc}}
+	if c.wiki.split {
+		parts = converters.SplitByTag(doc)
+	}
+
+	var link string // URL of the first page, the index page with --split
+
+	for _, part := range parts {
+		pageTitle := title
+		if part.Name != "" {
+			pageTitle = fmt.Sprintf("%s - %s", title, part.Name)
+		}
+
+		var content bytes.Buffer
+
+		if err := converter.Convert(part.Document, &content); err != nil {
+			return fmt.Errorf("conversion failed: %w", err)
+		}
+
+		pageURL, err := publisher.Publish(cmd.Context(), pageTitle, content.Bytes())
+		if err != nil {
+			return fmt.Errorf("publishing failed: %w", err)
+		}
+
+		c.log.Infof("Prepared page %q: %s", pageTitle, pageURL)
+
+		if link == "" {
+			link = pageURL
+		}
+	}
+
+	message := c.wiki.message
+	if message == "" {
+		message = fmt.Sprintf("Update %s to version %s", title, doc.Version)
+	}
+
+	c.log.Infof("Pushing %d page(s) to %s...", len(parts), c.wiki.url)
+
+	pushed, err := publisher.Push(cmd.Context(), message)
+	if err != nil {
+		return fmt.Errorf("publishing failed: %w", err)
+	}
+
+	if !pushed {
+		c.log.Info("The wiki is already up to date")
+
+		return nil
+	}
+
+	c.log.Info("Successfully published")
+
+	c.notify(cmd.Context(), notifier, c.wiki.notify, doc, title, link)
+
+	return nil
+}
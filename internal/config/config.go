@@ -2,33 +2,369 @@
 package config
 
 import (
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
 	configloader "github.com/GabrielNunesIT/go-libs/config-loader"
 )
 
+// DefaultFile is the configuration read from the working directory when no path is given.
+const DefaultFile = ".openapi-converter.yaml"
+
 // Config holds the application configuration.
-// This is a placeholder for future configuration options.
+// The settings at the top level apply to every command converting a specification; the publish
+// sections add the settings of the publish commands, overriding the top level ones.
+// The transforms apply to the specification of every command converting one, before conversion.
 type Config struct {
-	// Add configuration fields here as needed
-	// Example:
-	// OutputDir string `koanf:"output_dir"`
-	// LogLevel  string `koanf:"log_level"`
+	Settings `koanf:",squash"`
+
+	Transforms []Transform `koanf:"transforms"`
+	Publish    Publish     `koanf:"publish"`
+}
+
+// Transform is a built-in transform of the specification. Transforms run in the order listed, each entry
+// usually setting a single field:
+//
+//	transforms:
+//	  - strip-prefix: [/api/v1]
+//	  - rename-tags: {pets: Pets}
+//	  - descriptions: {listPets: Lists the pets of the store.}
+type Transform struct {
+	RenameTags      map[string]string `koanf:"rename-tags"`      // Tag names by old name
+	StripPrefix     []string          `koanf:"strip-prefix"`     // Path prefixes removed from the paths
+	Descriptions    map[string]string `koanf:"descriptions"`     // Operation descriptions by operationId or "METHOD /path"
+	TagDescriptions map[string]string `koanf:"tag-descriptions"` // Tag descriptions by tag name
+}
+
+// Settings holds the options shared by the commands, named after their command-line flags.
+// Unset options are nil or empty, so that they leave the default of the flag in place.
+type Settings struct {
+	Format          string   `koanf:"format"`
+	Output          string   `koanf:"output"`
+	Out             string   `koanf:"out"` // Output directory of the batch command
+	Title           string   `koanf:"title"`
+	Version         string   `koanf:"version"`
+	Description     string   `koanf:"description"`
+	Preamble        string   `koanf:"preamble"`
+	Epilogue        string   `koanf:"epilogue"`
+	Tables          *bool    `koanf:"tables"`
+	Split           *bool    `koanf:"split"`
+	HideDeprecated  *bool    `koanf:"hide-deprecated"`
+	Audience        string   `koanf:"audience"`
+	InternalPaths   []string `koanf:"internal-paths"`
+	InternalSchemas []string `koanf:"internal-schemas"`
+	IncludeTags     []string `koanf:"include-tags"`
+	ExcludeTags     []string `koanf:"exclude-tags"`
+	IncludePaths    []string `koanf:"include-paths"`
+	Operations      []string `koanf:"operations"`
+	GroupBy         string   `koanf:"group-by"`
+	TagOrder        string   `koanf:"tag-order"`
+	SortOperations  string   `koanf:"sort-operations"`
+	TemplateDir     string   `koanf:"template-dir"`
+	Snippets        []string `koanf:"snippets"`
+	Extensions      []string `koanf:"extensions"`
+	SchemaDepth     *int     `koanf:"schema-depth"`
+	SchemaAppendix  *bool    `koanf:"schema-appendix"`
+	Directional     *bool    `koanf:"directional-fields"`
+	SchemaGraph     *bool    `koanf:"schema-graph"`
+	Sequences       *bool    `koanf:"sequence-diagrams"`
+	MethodColors    []string `koanf:"method-colors"`
+	Panels          []string `koanf:"panels"`
+	StableAnchors   *bool    `koanf:"stable-anchors"`
+	SchemaRefs      string   `koanf:"schema-refs"`
+	GoClient        *bool    `koanf:"go-client"`
+	PlainLinks      *bool    `koanf:"plain-links"`
+	Incremental     *bool    `koanf:"incremental"`
+	Prune           *bool    `koanf:"prune"`
+	MaxDocSize      *int     `koanf:"max-doc-size"`
+	CollapseSize    *int     `koanf:"collapse-examples"`
+	Locale          string   `koanf:"locale"`
+	StringsFile     string   `koanf:"strings-file"`
+	AnchorsFile     string   `koanf:"anchors-file"`
+	Strict          *bool    `koanf:"strict"`
+	HAR             string   `koanf:"har"`
+	Server          string   `koanf:"server"`
+
+	// Options holds the options of single output formats by format then name, e.g. {confluence: {tables: true}}
+	Options map[string]map[string]any `koanf:"options"`
+}
+
+// Publish holds the settings of the publish command, to Confluence, and of its notion, wiki, storage and jira subcommands.
+type Publish struct {
+	Settings    `koanf:",squash"`
+	Credentials `koanf:",squash"`
+
+	BaseURL         string `koanf:"base-url"`
+	Space           string `koanf:"space"`
+	Parent          string `koanf:"parent"`
+	DiagramRenderer string `koanf:"diagram-renderer"`
+
+	Notion  Notion  `koanf:"notion"`
+	Wiki    Wiki    `koanf:"wiki"`
+	Storage Storage `koanf:"storage"`
+	Jira    Jira    `koanf:"jira"`
+}
+
+// Notion holds the settings of the publish notion command.
+type Notion struct {
+	Settings    `koanf:",squash"`
+	Credentials `koanf:",squash"`
+
+	Parent string `koanf:"parent"`
+}
+
+// Wiki holds the settings of the publish wiki command.
+type Wiki struct {
+	Settings    `koanf:",squash"`
+	Credentials `koanf:",squash"`
+
+	URL      string `koanf:"url"`
+	Provider string `koanf:"provider"`
 }
 
-// Load returns the application configuration using go-libs config-loader.
-func Load() (*Config, error) {
-	defaults := Config{}
+// Storage holds the settings of the publish storage command.
+type Storage struct {
+	Credentials `koanf:",squash"`
+
+	CacheControl string `koanf:"cache-control"`
+	Endpoint     string `koanf:"endpoint"`
+	Region       string `koanf:"region"`
+}
+
+// Jira holds the settings of the publish jira command.
+type Jira struct {
+	Credentials `koanf:",squash"`
+
+	BaseURL     string   `koanf:"base-url"`
+	Project     string   `koanf:"project"`
+	IssueType   string   `koanf:"issue-type"`
+	Labels      []string `koanf:"labels"`
+	DocsURL     string   `koanf:"docs-url"`
+	AnchorsFile string   `koanf:"anchors-file"`
+	LinkTo      string   `koanf:"link-to"`
+	LinkType    string   `koanf:"link-type"`
+}
+
+// Credentials names the environment variables holding the credentials of a publishing target,
+// so that the configuration can be committed without the secrets themselves.
+type Credentials struct {
+	EmailEnv string `koanf:"email-env"`
+	TokenEnv string `koanf:"token-env"`
+}
+
+// Load reads the configuration file at path.
+// An empty path reads DefaultFile, and returns an empty configuration when that file does not exist.
+func Load(path string) (*Config, error) {
+	if path == "" {
+		if _, err := os.Stat(DefaultFile); errors.Is(err, os.ErrNotExist) {
+			return &Config{}, nil
+		}
+
+		path = DefaultFile
+	}
 
 	loader := configloader.NewConfigLoader(
-		configloader.WithDefaults(defaults),
-		// Future: Add file, env, flags support
-		// configloader.WithFile[Config]("config.yaml"),
-		// configloader.WithEnv[Config]("OPENAPI_"),
+		configloader.WithFile[Config](path),
 	)
 
 	cfg, err := loader.Load()
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("failed to load configuration %s: %w", path, err)
 	}
 
 	return &cfg, nil
 }
+
+// Flags returns the set options as flag values, by flag name.
+// Lists are returned with one element per value, everything else as a single element.
+func (s Settings) Flags() map[string][]string {
+	flags := make(map[string][]string)
+
+	setString := func(name, value string) {
+		if value != "" {
+			flags[name] = []string{value}
+		}
+	}
+
+	setBool := func(name string, value *bool) {
+		if value != nil {
+			flags[name] = []string{strconv.FormatBool(*value)}
+		}
+	}
+
+	setList := func(name string, values []string) {
+		if values != nil {
+			flags[name] = values
+		}
+	}
+
+	setString("format", s.Format)
+	setString("output", s.Output)
+	setString("out", s.Out)
+	setString("title", s.Title)
+	setString("version", s.Version)
+	setString("description", s.Description)
+	setString("preamble", s.Preamble)
+	setString("epilogue", s.Epilogue)
+	setBool("tables", s.Tables)
+	setBool("split", s.Split)
+	setBool("hide-deprecated", s.HideDeprecated)
+	setString("audience", s.Audience)
+	setList("internal-paths", s.InternalPaths)
+	setList("internal-schemas", s.InternalSchemas)
+	setList("include-tags", s.IncludeTags)
+	setList("exclude-tags", s.ExcludeTags)
+	setList("include-paths", s.IncludePaths)
+	setList("operations", s.Operations)
+	setString("group-by", s.GroupBy)
+	setString("schema-refs", s.SchemaRefs)
+	setString("tag-order", s.TagOrder)
+	setString("sort-operations", s.SortOperations)
+	setString("template-dir", s.TemplateDir)
+	setList("snippets", s.Snippets)
+	setList("extensions", s.Extensions)
+	setBool("schema-appendix", s.SchemaAppendix)
+	setBool("directional-fields", s.Directional)
+	setBool("schema-graph", s.SchemaGraph)
+	setBool("sequence-diagrams", s.Sequences)
+	setList("method-colors", s.MethodColors)
+	setList("panels", s.Panels)
+	setBool("stable-anchors", s.StableAnchors)
+	setBool("go-client", s.GoClient)
+	setBool("plain-links", s.PlainLinks)
+	setBool("incremental", s.Incremental)
+	setBool("prune", s.Prune)
+	setString("locale", s.Locale)
+	setString("strings-file", s.StringsFile)
+	setString("anchors-file", s.AnchorsFile)
+	setBool("strict", s.Strict)
+	setString("har", s.HAR)
+	setString("server", s.Server)
+
+	if s.SchemaDepth != nil {
+		flags["schema-depth"] = []string{strconv.Itoa(*s.SchemaDepth)}
+	}
+
+	if s.MaxDocSize != nil {
+		flags["max-doc-size"] = []string{strconv.Itoa(*s.MaxDocSize)}
+	}
+
+	if s.CollapseSize != nil {
+		flags["collapse-examples"] = []string{strconv.Itoa(*s.CollapseSize)}
+	}
+
+	if s.Options != nil {
+		flags["option"] = formatOptions(s.Options)
+	}
+
+	return flags
+}
+
+// formatOptions returns the options of single output formats as option flag values, format.name=value, with
+// list values comma-separated.
+func formatOptions(options map[string]map[string]any) []string {
+	values := []string{}
+
+	for _, format := range slices.Sorted(maps.Keys(options)) {
+		for _, name := range slices.Sorted(maps.Keys(options[format])) {
+			value := fmt.Sprint(options[format][name])
+
+			if list, ok := options[format][name].([]any); ok {
+				items := make([]string, len(list))
+				for i, item := range list {
+					items[i] = fmt.Sprint(item)
+				}
+
+				value = strings.Join(items, ",")
+			}
+
+			values = append(values, format+"."+name+"="+value)
+		}
+	}
+
+	return values
+}
+
+// Section returns the flag values and credentials of a command section: "" for the converting commands,
+// "publish", "notion", "wiki", "storage" or "jira". The publish settings override the top level ones, and the notion and
+// wiki ones both; storage and jira only have their own.
+func (c *Config) Section(name string) (map[string][]string, Credentials) {
+	flags := c.Settings.Flags()
+
+	switch name {
+	case "publish":
+		merge(flags, c.Publish.Flags())
+		merge(flags, map[string][]string{
+			"base-url": nonEmpty(c.Publish.BaseURL),
+			"space":    nonEmpty(c.Publish.Space),
+			"parent":   nonEmpty(c.Publish.Parent),
+
+			"diagram-renderer": nonEmpty(c.Publish.DiagramRenderer),
+		})
+
+		return flags, c.Publish.Credentials
+	case "notion":
+		merge(flags, c.Publish.Flags())
+		merge(flags, c.Publish.Notion.Flags())
+		merge(flags, map[string][]string{"parent": nonEmpty(c.Publish.Notion.Parent)})
+
+		return flags, c.Publish.Notion.Credentials
+	case "wiki":
+		merge(flags, c.Publish.Flags())
+		merge(flags, c.Publish.Wiki.Flags())
+		merge(flags, map[string][]string{
+			"url":      nonEmpty(c.Publish.Wiki.URL),
+			"provider": nonEmpty(c.Publish.Wiki.Provider),
+		})
+
+		return flags, c.Publish.Wiki.Credentials
+	case "storage":
+		flags = make(map[string][]string)
+		merge(flags, map[string][]string{
+			"cache-control": nonEmpty(c.Publish.Storage.CacheControl),
+			"endpoint":      nonEmpty(c.Publish.Storage.Endpoint),
+			"region":        nonEmpty(c.Publish.Storage.Region),
+		})
+
+		return flags, c.Publish.Storage.Credentials
+	case "jira":
+		flags = make(map[string][]string)
+		merge(flags, map[string][]string{
+			"base-url":     nonEmpty(c.Publish.Jira.BaseURL),
+			"project":      nonEmpty(c.Publish.Jira.Project),
+			"issue-type":   nonEmpty(c.Publish.Jira.IssueType),
+			"labels":       c.Publish.Jira.Labels,
+			"docs-url":     nonEmpty(c.Publish.Jira.DocsURL),
+			"anchors-file": nonEmpty(c.Publish.Jira.AnchorsFile),
+			"link-to":      nonEmpty(c.Publish.Jira.LinkTo),
+			"link-type":    nonEmpty(c.Publish.Jira.LinkType),
+		})
+
+		return flags, c.Publish.Jira.Credentials
+	default:
+		return flags, Credentials{}
+	}
+}
+
+// merge copies the set values of from into to.
+func merge(to, from map[string][]string) {
+	for name, values := range from {
+		if values != nil {
+			to[name] = values
+		}
+	}
+}
+
+// nonEmpty returns value as a flag value, or nil when it is empty.
+func nonEmpty(value string) []string {
+	if value == "" {
+		return nil
+	}
+
+	return []string{value}
+}
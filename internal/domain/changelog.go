@@ -0,0 +1,55 @@
+package domain
+
+// ChangeKind classifies a change between two specification versions.
+type ChangeKind string
+
+// Change kinds.
+const (
+	ChangeAdded    ChangeKind = "added"
+	ChangeRemoved  ChangeKind = "removed"
+	ChangeModified ChangeKind = "changed"
+)
+
+// ChangeSubject identifies the part of the specification a change applies to.
+type ChangeSubject string
+
+// Change subjects.
+const (
+	SubjectEndpoint    ChangeSubject = "endpoint"
+	SubjectParameter   ChangeSubject = "parameter"
+	SubjectRequestBody ChangeSubject = "requestBody"
+	SubjectResponse    ChangeSubject = "response"
+	SubjectSecurity    ChangeSubject = "security"
+	SubjectSchema      ChangeSubject = "schema"
+	SubjectProperty    ChangeSubject = "property"
+)
+
+// Changelog describes the differences between two versions of an API specification.
+type Changelog struct {
+	Title              string
+	FromVersion        string
+	ToVersion          string
+	Changes            []Change
+	Bump               VersionBump // Smallest semantic version bump the changes call for
+	RecommendedVersion string      // FromVersion bumped by Bump, empty when it is not a semantic version
+}
+
+// VersionBump is the part of a semantic version a set of changes calls for raising.
+type VersionBump string
+
+// Version bumps, from the smallest.
+const (
+	BumpNone  VersionBump = "none"  // No change
+	BumpPatch VersionBump = "patch" // Compatible changes adding nothing
+	BumpMinor VersionBump = "minor" // Compatible additions
+	BumpMajor VersionBump = "major" // Breaking changes
+)
+
+// Change represents a single difference between two specification versions.
+type Change struct {
+	Kind     ChangeKind
+	Subject  ChangeSubject
+	Target   string // Endpoint ("GET /pets") or component schema name the change belongs to
+	Detail   string // Human-readable description of the change
+	Breaking bool   // Whether existing clients may stop working
+}
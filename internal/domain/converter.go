@@ -10,3 +10,16 @@ type Converter interface {
 	// Format returns the output format name (e.g., "pdf", "docx").
 	Format() string
 }
+
+// MultiConverter defines the interface for converters that split a document into several outputs.
+type MultiConverter interface {
+	// MultiConvert writes an index document plus one document per group of endpoints into outputDir
+	// and returns the paths of the written files, index first.
+	MultiConvert(doc *OpenAPIDocument, outputDir string) ([]string, error)
+}
+
+// ChangelogConverter defines the interface for converters able to render a changelog between two specifications.
+type ChangelogConverter interface {
+	// ConvertChangelog transforms a changelog to the target format.
+	ConvertChangelog(changelog *Changelog, output io.Writer) error
+}
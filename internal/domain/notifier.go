@@ -0,0 +1,18 @@
+package domain
+
+import "context"
+
+// Notification summarizes a successful conversion or publication for the people following an API.
+type Notification struct {
+	Title     string
+	Version   string
+	Endpoints int        // Number of operations documented
+	Changes   *Changelog // Changes since a previous version of the specification, nil when not compared
+	Link      string     // URL of the published page, or path of the written document; empty when there is none
+}
+
+// Notifier defines the interface for sending notifications to a chat or other messaging system.
+type Notifier interface {
+	// Notify sends a notification.
+	Notify(ctx context.Context, notification Notification) error
+}
@@ -3,12 +3,35 @@ package domain
 
 // OpenAPIDocument represents a parsed OpenAPI specification.
 type OpenAPIDocument struct {
-	Title       string
-	Version     string
-	Description string
-	Servers     []Server
-	Paths       []Path
-	Components  map[string]Schema // Schema components (key is schema name)
+	Title           string
+	Version         string
+	Description     string
+	ExternalDocs    *ExternalDocs // Documentation of the whole API outside the specification
+	Contact         *Contact
+	License         *License
+	TermsOfService  string // URL of the terms of service of the API
+	Servers         []Server
+	Tags            []Tag // Tags declared at the top level, in declaration order
+	Paths           []Path
+	Webhooks        []Webhook                 // Incoming requests the API may send to consumers (OpenAPI 3.1)
+	Components      map[string]Schema         // Schema components (key is schema name)
+	SecuritySchemes map[string]SecurityScheme // Authentication mechanisms (key is scheme name)
+	Extensions      map[string]any            // Vendor extensions of the info object (key is the x- field name)
+	Preamble        string                    // Markdown set before the generated content of documentation formats
+	Epilogue        string                    // Markdown set after the generated content of documentation formats
+}
+
+// Contact tells who to reach about the API.
+type Contact struct {
+	Name  string
+	Email string
+	URL   string
+}
+
+// License is the license the API is offered under.
+type License struct {
+	Name string
+	URL  string
 }
 
 // Server represents an API server.
@@ -17,22 +40,53 @@ type Server struct {
 	Description string
 }
 
+// Tag describes a group of operations, named in their Tags.
+type Tag struct {
+	Name         string
+	Description  string
+	ExternalDocs *ExternalDocs
+}
+
+// ExternalDocs links to documentation outside the specification.
+type ExternalDocs struct {
+	URL         string
+	Description string
+}
+
 // Path represents an API endpoint path.
 type Path struct {
 	Path       string
 	Operations []Operation
 }
 
+// Webhook represents a named set of requests the API may initiate towards its consumers.
+type Webhook struct {
+	Name       string
+	Operations []Operation
+}
+
 // Operation represents an HTTP operation on a path.
 type Operation struct {
-	Method      string
-	Summary     string
-	Description string
-	OperationID string
-	Tags        []string
-	Parameters  []Parameter
-	RequestBody *RequestBody
-	Responses   []Response
+	Method       string
+	Summary      string
+	Description  string
+	OperationID  string
+	Deprecated   bool
+	ExternalDocs *ExternalDocs
+	Tags         []string
+	Parameters   []Parameter
+	RequestBody  *RequestBody
+	Responses    []Response
+	Security     []SecurityRequirement // Alternative requirements, any one grants access; empty when no auth is needed
+	Callbacks    []Callback            // Requests the API sends back to the consumer, by callback name
+	Extensions   map[string]any        // Vendor extensions (key is the x- field name)
+}
+
+// Callback represents a request the API sends to a URL taken from the triggering request.
+type Callback struct {
+	Name       string // Key of the callback, such as "onStatusChange"
+	Expression string // Runtime expression of the URL, such as "{$request.body#/callbackUrl}"
+	Operations []Operation
 }
 
 // Parameter represents a request parameter.
@@ -41,6 +95,7 @@ type Parameter struct {
 	In          string // query, path, header, cookie
 	Description string
 	Required    bool
+	Deprecated  bool
 	Schema      Schema
 }
 
@@ -53,7 +108,16 @@ type RequestBody struct {
 
 // MediaType represents the content type and schema.
 type MediaType struct {
-	Schema Schema
+	Schema   Schema
+	Example  any                // Single inline example payload
+	Examples map[string]Example // Named examples (key is example name)
+}
+
+// Example represents a named example payload.
+type Example struct {
+	Summary     string
+	Description string
+	Value       any
 }
 
 // Response represents an API response.
@@ -61,14 +125,91 @@ type Response struct {
 	StatusCode  string
 	Description string
 	Content     map[string]MediaType
+	Headers     []Header // Headers sent with the response, by name
+	Links       []Link   // Operations that can follow the response, by link name
+}
+
+// Header represents a header sent with a response.
+type Header struct {
+	Name        string
+	Description string
+	Required    bool
+	Deprecated  bool
+	Schema      Schema
+}
+
+// Link describes an operation that can follow a response, called with values taken from it.
+type Link struct {
+	Name         string
+	Description  string
+	OperationID  string         // Target operation, when given by operationId
+	OperationRef string         // Reference to the target operation otherwise, such as "#/paths/~1pets~1{petId}/get"
+	Parameters   map[string]any // Target parameter name to runtime expression, such as "$response.body#/id", or value
+	RequestBody  any            // Runtime expression or value sent as the target request body
 }
 
 // Schema represents a JSON schema for request/response bodies.
 type Schema struct {
-	Type        string
-	Format      string
-	Description string
-	Properties  map[string]Schema
-	Items       *Schema
-	Ref         string
+	Type             string // Multiple non-null types (OpenAPI 3.1) are joined with " | "
+	Format           string
+	Description      string
+	Nullable         bool
+	ReadOnly         bool // Sent in responses only
+	WriteOnly        bool // Sent in requests only
+	Deprecated       bool
+	Const            any
+	Examples         []any
+	Enum             []any // Allowed values
+	Default          any
+	Minimum          *float64
+	Maximum          *float64
+	ExclusiveMinimum bool // Minimum itself is not allowed
+	ExclusiveMaximum bool // Maximum itself is not allowed
+	MinLength        uint64
+	MaxLength        *uint64
+	Pattern          string
+	Properties       map[string]Schema
+	Required         []string // Names of the properties that must be present
+	Items            *Schema
+	MinItems         uint64
+	MaxItems         *uint64
+	UniqueItems      bool     // Items must all differ
+	AllOf            []Schema // Schemas that must all apply; their properties are combined
+	AnyOf            []Schema // Schemas of which at least one applies
+	OneOf            []Schema // Schemas of which exactly one applies
+	Discriminator    *Discriminator
+	Ref              string
+	ExternalDocs     *ExternalDocs
+	Extensions       map[string]any // Vendor extensions (key is the x- field name)
 }
+
+// Discriminator names the property that selects the variant of a polymorphic schema.
+type Discriminator struct {
+	PropertyName string
+	Mapping      map[string]string // Property value to schema reference
+}
+
+// SecurityScheme represents an authentication mechanism supported by the API.
+type SecurityScheme struct {
+	Type             string // apiKey, http, oauth2, openIdConnect, mutualTLS
+	Description      string
+	Name             string // Name of the header, query or cookie parameter (apiKey)
+	In               string // header, query, cookie (apiKey)
+	Scheme           string // HTTP authorization scheme such as basic or bearer (http)
+	BearerFormat     string
+	OpenIDConnectURL string
+	Flows            []OAuthFlow
+}
+
+// OAuthFlow represents a single OAuth 2.0 flow of a security scheme.
+type OAuthFlow struct {
+	Type             string // implicit, password, clientCredentials, authorizationCode
+	AuthorizationURL string
+	TokenURL         string
+	RefreshURL       string
+	Scopes           map[string]string // Scope descriptions (key is scope name)
+}
+
+// SecurityRequirement lists the schemes that must all be satisfied together (key is scheme name, value is required scopes).
+// An empty requirement means anonymous access is allowed.
+type SecurityRequirement map[string][]string
@@ -0,0 +1,8 @@
+package domain
+
+// Parser defines the interface for specification parsers.
+type Parser interface {
+	// Parse maps a raw specification into the domain model.
+	// The path is used to resolve relative references and may be empty.
+	Parse(data []byte, path string) (*OpenAPIDocument, error)
+}
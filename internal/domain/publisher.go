@@ -0,0 +1,22 @@
+package domain
+
+import "context"
+
+// Publisher defines the interface for publishing generated documents to an external system.
+type Publisher interface {
+	// Publish uploads a rendered document under the given title and returns the URL of the published page.
+	Publish(ctx context.Context, title string, content []byte) (string, error)
+}
+
+// ChangeDetector is implemented by publishers that can tell whether a page already holds a document, so that
+// unchanged pages are not published again.
+type ChangeDetector interface {
+	// Unchanged reports whether the page with the given title was last published with the same content, and its URL.
+	Unchanged(ctx context.Context, title string, content []byte) (bool, string, error)
+}
+
+// Fetcher is implemented by publishers that can read back the documents they published, to detect drift.
+type Fetcher interface {
+	// Fetch returns the content of the page with the given title and its URL, or nil content when there is no such page.
+	Fetch(ctx context.Context, title string) ([]byte, string, error)
+}
@@ -0,0 +1,12 @@
+package domain
+
+import "io"
+
+// Reporter defines the interface for writers of issue reports.
+type Reporter interface {
+	// Report writes the issues of a report to output.
+	Report(report *Report, output io.Writer) error
+
+	// Format returns the report format name (e.g., "text", "sarif").
+	Format() string
+}
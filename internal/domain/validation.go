@@ -0,0 +1,52 @@
+package domain
+
+import "strings"
+
+// Severity ranks a problem found in a specification.
+type Severity string
+
+// Severities.
+const (
+	SeverityError   Severity = "error"   // The specification is invalid
+	SeverityWarning Severity = "warning" // The specification is valid but the documentation suffers
+	SeverityInfo    Severity = "info"    // A suggestion that does not need to be acted upon
+)
+
+// Issue represents a problem found in a specification.
+type Issue struct {
+	Rule     string // Lint rule that raised the issue, empty for validation issues
+	Severity Severity
+	Pointer  []string // Keys leading to the offending node, such as "paths", "/pets", "get"
+	Message  string
+	Line     int // Line of the node in the specification, 0 when unknown
+}
+
+// Location returns the JSON pointer of the offending node, such as "#/paths/~1pets/get".
+func (i Issue) Location() string {
+	escaped := make([]string, 0, len(i.Pointer))
+	for _, key := range i.Pointer {
+		escaped = append(escaped, strings.ReplaceAll(strings.ReplaceAll(key, "~", "~0"), "/", "~1"))
+	}
+
+	return "#/" + strings.Join(escaped, "/")
+}
+
+// Report gathers the issues found in a specification.
+type Report struct {
+	File   string            // Specification the issues were found in, empty for standard input
+	Rules  map[string]string // Descriptions of the rules that were run (key is rule name)
+	Issues []Issue
+}
+
+// Count returns the number of issues of a severity.
+func (r *Report) Count(severity Severity) int {
+	count := 0
+
+	for _, issue := range r.Issues {
+		if issue.Severity == severity {
+			count++
+		}
+	}
+
+	return count
+}
@@ -0,0 +1,455 @@
+// Package diff compares two versions of an API specification and produces a changelog.
+package diff
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/GabrielNunesIT/openapi-converter/internal/domain"
+)
+
+const anonymousAccess = "anonymous"
+
+// Compare returns the changes needed to go from one version of a document to the next.
+func Compare(from, to *domain.OpenAPIDocument) *domain.Changelog {
+	c := &comparer{}
+
+	c.compareEndpoints(from, to)
+	c.compareSchemas(from.Components, to.Components)
+
+	sort.SliceStable(c.changes, func(i, j int) bool {
+		if c.changes[i].Target != c.changes[j].Target {
+			return c.changes[i].Target < c.changes[j].Target
+		}
+
+		return c.changes[i].Detail < c.changes[j].Detail
+	})
+
+	title := to.Title
+	if title == "" {
+		title = from.Title
+	}
+
+	bump := Recommend(c.changes)
+
+	return &domain.Changelog{
+		Title:              title,
+		FromVersion:        from.Version,
+		ToVersion:          to.Version,
+		Changes:            c.changes,
+		Bump:               bump,
+		RecommendedVersion: Bumped(from.Version, bump),
+	}
+}
+
+type comparer struct {
+	changes []domain.Change
+}
+
+func (c *comparer) add(kind domain.ChangeKind, subject domain.ChangeSubject, target, detail string, breaking bool) {
+	c.changes = append(c.changes, domain.Change{
+		Kind:     kind,
+		Subject:  subject,
+		Target:   target,
+		Detail:   detail,
+		Breaking: breaking,
+	})
+}
+
+// indexOperations maps every operation of a document to its "METHOD /path" key.
+func indexOperations(doc *domain.OpenAPIDocument) map[string]domain.Operation {
+	operations := make(map[string]domain.Operation)
+
+	for _, path := range doc.Paths {
+		for _, op := range path.Operations {
+			operations[fmt.Sprintf("%s %s", strings.ToUpper(op.Method), path.Path)] = op
+		}
+	}
+
+	return operations
+}
+
+func (c *comparer) compareEndpoints(from, to *domain.OpenAPIDocument) {
+	oldOps := indexOperations(from)
+	newOps := indexOperations(to)
+
+	for _, key := range slices.Sorted(maps.Keys(newOps)) {
+		oldOp, exists := oldOps[key]
+		if !exists {
+			c.add(domain.ChangeAdded, domain.SubjectEndpoint, key, newOps[key].Summary, false)
+
+			continue
+		}
+
+		c.compareOperation(key, oldOp, newOps[key])
+	}
+
+	for _, key := range slices.Sorted(maps.Keys(oldOps)) {
+		if _, exists := newOps[key]; !exists {
+			c.add(domain.ChangeRemoved, domain.SubjectEndpoint, key, oldOps[key].Summary, true)
+		}
+	}
+}
+
+func (c *comparer) compareOperation(target string, oldOp, newOp domain.Operation) {
+	c.compareParameters(target, oldOp.Parameters, newOp.Parameters)
+	c.compareRequestBody(target, oldOp.RequestBody, newOp.RequestBody)
+	c.compareResponses(target, oldOp.Responses, newOp.Responses)
+
+	oldSecurity := securityAlternatives(oldOp.Security)
+	newSecurity := securityAlternatives(newOp.Security)
+
+	oldLabel, newLabel := strings.Join(oldSecurity, " or "), strings.Join(newSecurity, " or ")
+	if oldLabel != newLabel {
+		// Clients keep working only if every way they could authenticate before is still accepted
+		accepted := oldSecurity
+		if len(accepted) == 0 {
+			accepted = []string{anonymousAccess}
+		}
+
+		breaking := len(newSecurity) > 0 && !containsAll(newSecurity, accepted)
+		c.add(domain.ChangeModified, domain.SubjectSecurity, target,
+			fmt.Sprintf("authentication changed from %s to %s", orNone(oldLabel), orNone(newLabel)), breaking)
+	}
+}
+
+func (c *comparer) compareParameters(target string, oldParams, newParams []domain.Parameter) {
+	key := func(param domain.Parameter) string {
+		return fmt.Sprintf("%s parameter %q", param.In, param.Name)
+	}
+
+	oldIndex := make(map[string]domain.Parameter, len(oldParams))
+	for _, param := range oldParams {
+		oldIndex[key(param)] = param
+	}
+
+	newIndex := make(map[string]domain.Parameter, len(newParams))
+	for _, param := range newParams {
+		newIndex[key(param)] = param
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(newIndex)) {
+		newParam := newIndex[name]
+
+		oldParam, exists := oldIndex[name]
+		if !exists {
+			detail := fmt.Sprintf("added %s", name)
+			if newParam.Required {
+				detail = fmt.Sprintf("added required %s", name)
+			}
+
+			c.add(domain.ChangeAdded, domain.SubjectParameter, target, detail, newParam.Required)
+
+			continue
+		}
+
+		if !oldParam.Required && newParam.Required {
+			c.add(domain.ChangeModified, domain.SubjectParameter, target, fmt.Sprintf("%s is now required", name), true)
+		} else if oldParam.Required && !newParam.Required {
+			c.add(domain.ChangeModified, domain.SubjectParameter, target, fmt.Sprintf("%s is now optional", name), false)
+		}
+
+		if oldType, newType := schemaType(oldParam.Schema), schemaType(newParam.Schema); oldType != newType {
+			c.add(domain.ChangeModified, domain.SubjectParameter, target,
+				fmt.Sprintf("%s type changed from %s to %s", name, oldType, newType), true)
+		} else {
+			c.compareEnum(domain.SubjectParameter, target, name, oldParam.Schema.Enum, newParam.Schema.Enum)
+		}
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(oldIndex)) {
+		if _, exists := newIndex[name]; !exists {
+			c.add(domain.ChangeRemoved, domain.SubjectParameter, target, fmt.Sprintf("removed %s", name), false)
+		}
+	}
+}
+
+func (c *comparer) compareRequestBody(target string, oldBody, newBody *domain.RequestBody) {
+	switch {
+	case oldBody == nil && newBody == nil:
+		return
+	case oldBody == nil:
+		c.add(domain.ChangeAdded, domain.SubjectRequestBody, target, "added request body", newBody.Required)
+
+		return
+	case newBody == nil:
+		c.add(domain.ChangeRemoved, domain.SubjectRequestBody, target, "removed request body", false)
+
+		return
+	}
+
+	if !oldBody.Required && newBody.Required {
+		c.add(domain.ChangeModified, domain.SubjectRequestBody, target, "request body is now required", true)
+	}
+
+	c.compareContent(target, domain.SubjectRequestBody, "request body", oldBody.Content, newBody.Content, true)
+}
+
+func (c *comparer) compareResponses(target string, oldResponses, newResponses []domain.Response) {
+	oldIndex := make(map[string]domain.Response, len(oldResponses))
+	for _, resp := range oldResponses {
+		oldIndex[resp.StatusCode] = resp
+	}
+
+	newIndex := make(map[string]domain.Response, len(newResponses))
+	for _, resp := range newResponses {
+		newIndex[resp.StatusCode] = resp
+	}
+
+	for _, status := range slices.Sorted(maps.Keys(newIndex)) {
+		oldResp, exists := oldIndex[status]
+		if !exists {
+			c.add(domain.ChangeAdded, domain.SubjectResponse, target, fmt.Sprintf("added response %s", status), false)
+
+			continue
+		}
+
+		label := fmt.Sprintf("response %s", status)
+		c.compareContent(target, domain.SubjectResponse, label, oldResp.Content, newIndex[status].Content, false)
+	}
+
+	for _, status := range slices.Sorted(maps.Keys(oldIndex)) {
+		if _, exists := newIndex[status]; !exists {
+			c.add(domain.ChangeRemoved, domain.SubjectResponse, target, fmt.Sprintf("removed response %s", status), true)
+		}
+	}
+}
+
+// compareContent compares the media types of a request body or response.
+// Removing a request content type breaks clients sending it; for responses, changing the schema type breaks readers.
+func (c *comparer) compareContent(target string, subject domain.ChangeSubject, label string,
+	oldContent, newContent map[string]domain.MediaType, request bool,
+) {
+	for _, contentType := range slices.Sorted(maps.Keys(newContent)) {
+		oldMedia, exists := oldContent[contentType]
+		if !exists {
+			c.add(domain.ChangeAdded, subject, target, fmt.Sprintf("%s now supports %s", label, contentType), false)
+
+			continue
+		}
+
+		if oldType, newType := schemaType(oldMedia.Schema), schemaType(newContent[contentType].Schema); oldType != newType {
+			c.add(domain.ChangeModified, subject, target,
+				fmt.Sprintf("%s (%s) schema changed from %s to %s", label, contentType, orNone(oldType), orNone(newType)), true)
+		}
+	}
+
+	for _, contentType := range slices.Sorted(maps.Keys(oldContent)) {
+		if _, exists := newContent[contentType]; !exists {
+			c.add(domain.ChangeRemoved, subject, target, fmt.Sprintf("%s no longer uses %s", label, contentType), request)
+		}
+	}
+}
+
+func (c *comparer) compareSchemas(oldSchemas, newSchemas map[string]domain.Schema) {
+	for _, name := range slices.Sorted(maps.Keys(newSchemas)) {
+		oldSchema, exists := oldSchemas[name]
+		if !exists {
+			c.add(domain.ChangeAdded, domain.SubjectSchema, name, "added schema", false)
+
+			continue
+		}
+
+		c.compareSchema(name, "", oldSchema, newSchemas[name])
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(oldSchemas)) {
+		if _, exists := newSchemas[name]; !exists {
+			c.add(domain.ChangeRemoved, domain.SubjectSchema, name, "removed schema", true)
+		}
+	}
+}
+
+// compareSchema compares two versions of a schema, descending into inline object properties.
+func (c *comparer) compareSchema(target, path string, oldSchema, newSchema domain.Schema) {
+	if oldType, newType := schemaType(oldSchema), schemaType(newSchema); oldType != newType {
+		subject := domain.SubjectSchema
+		detail := fmt.Sprintf("type changed from %s to %s", orNone(oldType), orNone(newType))
+
+		if path != "" {
+			subject = domain.SubjectProperty
+			detail = fmt.Sprintf("property %q %s", path, detail)
+		}
+
+		c.add(domain.ChangeModified, subject, target, detail, true)
+
+		return
+	}
+
+	if !oldSchema.Nullable && newSchema.Nullable && path != "" {
+		c.add(domain.ChangeModified, domain.SubjectProperty, target, fmt.Sprintf("property %q is now nullable", path), true)
+	}
+
+	subject, label := domain.SubjectSchema, "schema"
+	if path != "" {
+		subject, label = domain.SubjectProperty, fmt.Sprintf("property %q", path)
+	}
+
+	c.compareEnum(subject, target, label, oldSchema.Enum, newSchema.Enum)
+
+	for _, name := range slices.Sorted(maps.Keys(newSchema.Properties)) {
+		propPath := joinPath(path, name)
+		required := slices.Contains(newSchema.Required, name)
+
+		oldProp, exists := oldSchema.Properties[name]
+		if !exists {
+			detail := fmt.Sprintf("added property %q", propPath)
+			if required {
+				detail = fmt.Sprintf("added required property %q", propPath)
+			}
+
+			c.add(domain.ChangeAdded, domain.SubjectProperty, target, detail, required)
+
+			continue
+		}
+
+		// Clients sending the schema must now send the property
+		if wasRequired := slices.Contains(oldSchema.Required, name); !wasRequired && required {
+			c.add(domain.ChangeModified, domain.SubjectProperty, target, fmt.Sprintf("property %q is now required", propPath), true)
+		} else if wasRequired && !required {
+			c.add(domain.ChangeModified, domain.SubjectProperty, target, fmt.Sprintf("property %q is now optional", propPath), false)
+		}
+
+		c.compareSchema(target, propPath, oldProp, newSchema.Properties[name])
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(oldSchema.Properties)) {
+		if _, exists := newSchema.Properties[name]; !exists {
+			c.add(domain.ChangeRemoved, domain.SubjectProperty, target,
+				fmt.Sprintf("removed property %q", joinPath(path, name)), true)
+		}
+	}
+
+	if oldSchema.Items != nil && newSchema.Items != nil {
+		c.compareSchema(target, joinPath(path, "[]"), *oldSchema.Items, *newSchema.Items)
+	}
+}
+
+// compareEnum compares the allowed values of a parameter or schema. Removing values, or restricting a free value
+// to an enumeration, breaks clients sending them; adding values does not.
+func (c *comparer) compareEnum(subject domain.ChangeSubject, target, label string, oldEnum, newEnum []any) {
+	if len(newEnum) == 0 {
+		if len(oldEnum) > 0 {
+			c.add(domain.ChangeModified, subject, target, fmt.Sprintf("%s no longer restricts its values", label), false)
+		}
+
+		return
+	}
+
+	if len(oldEnum) == 0 {
+		c.add(domain.ChangeModified, subject, target,
+			fmt.Sprintf("%s is now restricted to %s", label, enumList(newEnum)), true)
+
+		return
+	}
+
+	if removed := enumDifference(oldEnum, newEnum); len(removed) > 0 {
+		c.add(domain.ChangeModified, subject, target, fmt.Sprintf("%s no longer allows %s", label, enumList(removed)), true)
+	}
+
+	if added := enumDifference(newEnum, oldEnum); len(added) > 0 {
+		c.add(domain.ChangeModified, subject, target, fmt.Sprintf("%s now allows %s", label, enumList(added)), false)
+	}
+}
+
+// enumDifference returns the values of a not in b, in order.
+func enumDifference(a, b []any) []any {
+	values := make(map[string]struct{}, len(b))
+	for _, value := range b {
+		values[fmt.Sprint(value)] = struct{}{}
+	}
+
+	var difference []any
+
+	for _, value := range a {
+		if _, exists := values[fmt.Sprint(value)]; !exists {
+			difference = append(difference, value)
+		}
+	}
+
+	return difference
+}
+
+// enumList renders enumeration values for a change detail, e.g. "pending", "sold".
+func enumList(values []any) string {
+	quoted := make([]string, 0, len(values))
+	for _, value := range values {
+		quoted = append(quoted, fmt.Sprintf("%q", fmt.Sprint(value)))
+	}
+
+	return strings.Join(quoted, ", ")
+}
+
+// schemaType returns a comparable type label for a schema, using the component name for references.
+func schemaType(schema domain.Schema) string {
+	if schema.Ref != "" {
+		return schema.Ref[strings.LastIndex(schema.Ref, "/")+1:]
+	}
+
+	if schema.Items != nil {
+		return fmt.Sprintf("array of %s", schemaType(*schema.Items))
+	}
+
+	if schema.Format != "" {
+		return fmt.Sprintf("%s (%s)", schema.Type, schema.Format)
+	}
+
+	return schema.Type
+}
+
+// securityAlternatives renders each alternative security requirement of an operation as a sorted label.
+func securityAlternatives(requirements []domain.SecurityRequirement) []string {
+	alternatives := make([]string, 0, len(requirements))
+
+	for _, requirement := range requirements {
+		names := slices.Sorted(maps.Keys(requirement))
+		if len(names) == 0 {
+			names = []string{anonymousAccess}
+		}
+
+		alternatives = append(alternatives, strings.Join(names, " + "))
+	}
+
+	sort.Strings(alternatives)
+
+	return alternatives
+}
+
+func containsAll(set, values []string) bool {
+	for _, value := range values {
+		found := false
+
+		for _, item := range set {
+			if item == value {
+				found = true
+
+				break
+			}
+		}
+
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+
+	return path + "." + name
+}
+
+func orNone(value string) string {
+	if value == "" {
+		return "none"
+	}
+
+	return value
+}
@@ -0,0 +1,214 @@
+package diff_test
+
+import (
+	"testing"
+
+	"github.com/GabrielNunesIT/openapi-converter/internal/domain"
+	"github.com/GabrielNunesIT/openapi-converter/internal/usecases/diff"
+)
+
+// petsDoc returns a document of version 1.2.3 with a single operation, changed by edit when not nil.
+func petsDoc(edit func(op *domain.Operation, components map[string]domain.Schema)) *domain.OpenAPIDocument {
+	op := domain.Operation{
+		Method: "get",
+		Parameters: []domain.Parameter{
+			{Name: "status", In: "query", Schema: domain.Schema{Type: "string", Enum: []any{"available", "sold"}}},
+			{Name: "limit", In: "query", Required: true, Schema: domain.Schema{Type: "integer"}},
+		},
+		Responses: []domain.Response{
+			{StatusCode: "200", Description: "OK", Content: map[string]domain.MediaType{
+				"application/json": {Schema: domain.Schema{Ref: "#/components/schemas/Pet"}},
+			}},
+			{StatusCode: "404", Description: "Not found"},
+		},
+	}
+
+	components := map[string]domain.Schema{
+		"Pet": {Type: "object", Required: []string{"name"}, Properties: map[string]domain.Schema{
+			"name":   {Type: "string"},
+			"weight": {Type: "number"},
+		}},
+	}
+
+	if edit != nil {
+		edit(&op, components)
+	}
+
+	return &domain.OpenAPIDocument{
+		Title:      "Pets",
+		Version:    "1.2.3",
+		Paths:      []domain.Path{{Path: "/pets", Operations: []domain.Operation{op}}},
+		Components: components,
+	}
+}
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		name         string
+		edit         func(op *domain.Operation, components map[string]domain.Schema)
+		wantBump     domain.VersionBump
+		wantVersion  string
+		wantBreaking []string // Details of the breaking changes, in order
+	}{
+		{
+			name:        "no change",
+			wantBump:    domain.BumpNone,
+			wantVersion: "1.2.3",
+		},
+		{
+			name: "required parameter added",
+			edit: func(op *domain.Operation, _ map[string]domain.Schema) {
+				op.Parameters = append(op.Parameters, domain.Parameter{Name: "owner", In: "query", Required: true})
+			},
+			wantBump:     domain.BumpMajor,
+			wantVersion:  "2.0.0",
+			wantBreaking: []string{`added required query parameter "owner"`},
+		},
+		{
+			name: "optional parameter added",
+			edit: func(op *domain.Operation, _ map[string]domain.Schema) {
+				op.Parameters = append(op.Parameters, domain.Parameter{Name: "owner", In: "query"})
+			},
+			wantBump:    domain.BumpMinor,
+			wantVersion: "1.3.0",
+		},
+		{
+			name: "parameter made optional",
+			edit: func(op *domain.Operation, _ map[string]domain.Schema) {
+				op.Parameters[1].Required = false
+			},
+			wantBump:    domain.BumpPatch,
+			wantVersion: "1.2.4",
+		},
+		{
+			name: "enum value removed",
+			edit: func(op *domain.Operation, _ map[string]domain.Schema) {
+				op.Parameters[0].Schema.Enum = []any{"available"}
+			},
+			wantBump:     domain.BumpMajor,
+			wantVersion:  "2.0.0",
+			wantBreaking: []string{`query parameter "status" no longer allows "sold"`},
+		},
+		{
+			name: "enum value added",
+			edit: func(op *domain.Operation, _ map[string]domain.Schema) {
+				op.Parameters[0].Schema.Enum = []any{"available", "pending", "sold"}
+			},
+			wantBump:    domain.BumpPatch,
+			wantVersion: "1.2.4",
+		},
+		{
+			name: "response code removed",
+			edit: func(op *domain.Operation, _ map[string]domain.Schema) {
+				op.Responses = op.Responses[:1]
+			},
+			wantBump:     domain.BumpMajor,
+			wantVersion:  "2.0.0",
+			wantBreaking: []string{"removed response 404"},
+		},
+		{
+			name: "response code added",
+			edit: func(op *domain.Operation, _ map[string]domain.Schema) {
+				op.Responses = append(op.Responses, domain.Response{StatusCode: "429", Description: "Too many requests"})
+			},
+			wantBump:    domain.BumpMinor,
+			wantVersion: "1.3.0",
+		},
+		{
+			name: "type narrowed",
+			edit: func(_ *domain.Operation, components map[string]domain.Schema) {
+				components["Pet"].Properties["weight"] = domain.Schema{Type: "integer"}
+			},
+			wantBump:     domain.BumpMajor,
+			wantVersion:  "2.0.0",
+			wantBreaking: []string{`property "weight" type changed from number to integer`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			changelog := diff.Compare(petsDoc(nil), petsDoc(tt.edit))
+
+			if changelog.Bump != tt.wantBump {
+				t.Errorf("got bump %s, want %s (changes: %+v)", changelog.Bump, tt.wantBump, changelog.Changes)
+			}
+
+			if changelog.RecommendedVersion != tt.wantVersion {
+				t.Errorf("got recommended version %s, want %s", changelog.RecommendedVersion, tt.wantVersion)
+			}
+
+			var breaking []string
+
+			for _, change := range changelog.Changes {
+				if change.Breaking {
+					breaking = append(breaking, change.Detail)
+				}
+			}
+
+			if len(breaking) != len(tt.wantBreaking) {
+				t.Fatalf("got breaking changes %q, want %q", breaking, tt.wantBreaking)
+			}
+
+			for i := range breaking {
+				if breaking[i] != tt.wantBreaking[i] {
+					t.Errorf("got breaking changes %q, want %q", breaking, tt.wantBreaking)
+				}
+			}
+
+			if tt.wantBump == domain.BumpNone && len(changelog.Changes) > 0 {
+				t.Errorf("got changes %+v, want none", changelog.Changes)
+			}
+		})
+	}
+}
+
+func TestBumped(t *testing.T) {
+	tests := []struct {
+		version string
+		bump    domain.VersionBump
+		want    string
+	}{
+		{"1.2.3", domain.BumpNone, "1.2.3"},
+		{"1.2.3", domain.BumpPatch, "1.2.4"},
+		{"1.2.3", domain.BumpMinor, "1.3.0"},
+		{"v1.2.3-beta.1", domain.BumpMajor, "v2.0.0"},
+		{"1.2", domain.BumpPatch, ""},
+		{"latest", domain.BumpMajor, ""},
+	}
+
+	for _, tt := range tests {
+		if got := diff.Bumped(tt.version, tt.bump); got != tt.want {
+			t.Errorf("Bumped(%q, %s) = %q, want %q", tt.version, tt.bump, got, tt.want)
+		}
+	}
+}
+
+func TestBumpBetween(t *testing.T) {
+	tests := []struct {
+		from, to string
+		want     domain.VersionBump
+		wantOK   bool
+	}{
+		{"1.2.3", "1.2.3", domain.BumpNone, true},
+		{"1.2.3", "1.2.4", domain.BumpPatch, true},
+		{"1.2.3", "1.3.0", domain.BumpMinor, true},
+		{"v1.2.3", "v2.0.0", domain.BumpMajor, true},
+		{"1.2.3", "1.1.9", domain.BumpMinor, false},
+		{"1.2.3", "next", "", false},
+	}
+
+	for _, tt := range tests {
+		got, ok := diff.BumpBetween(tt.from, tt.to)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("BumpBetween(%q, %q) = %s, %t, want %s, %t", tt.from, tt.to, got, ok, tt.want, tt.wantOK)
+		}
+
+		if ok && !diff.Covers(got, got) {
+			t.Errorf("Covers(%s, %s) = false", got, got)
+		}
+	}
+
+	if diff.Covers(domain.BumpMinor, domain.BumpMajor) || !diff.Covers(domain.BumpMajor, domain.BumpPatch) {
+		t.Error("Covers does not order bumps from none to major")
+	}
+}
@@ -0,0 +1,110 @@
+package diff
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/GabrielNunesIT/openapi-converter/internal/domain"
+)
+
+// bumps lists the version bumps from the smallest, to compare them.
+var bumps = []domain.VersionBump{ //nolint:gochecknoglobals // read-only lookup table
+	domain.BumpNone, domain.BumpPatch, domain.BumpMinor, domain.BumpMajor,
+}
+
+// Recommend returns the semantic version bump changes call for: major for breaking changes, minor for
+// compatible additions, patch for other compatible changes and none without changes.
+func Recommend(changes []domain.Change) domain.VersionBump {
+	bump := domain.BumpNone
+
+	for _, change := range changes {
+		switch {
+		case change.Breaking:
+			return domain.BumpMajor
+		case change.Kind == domain.ChangeAdded:
+			bump = domain.BumpMinor
+		case bump == domain.BumpNone:
+			bump = domain.BumpPatch
+		}
+	}
+
+	return bump
+}
+
+// Covers reports whether a version bump is at least as large as another.
+func Covers(bump, required domain.VersionBump) bool {
+	return slices.Index(bumps, bump) >= slices.Index(bumps, required)
+}
+
+// Bumped returns a semantic version, such as 1.2.3 or v1.2.3, raised by a bump, dropping any pre-release or
+// build suffix, or "" when version is not a semantic version.
+func Bumped(version string, bump domain.VersionBump) string {
+	prefix, parts, ok := parseVersion(version)
+	if !ok {
+		return ""
+	}
+
+	switch bump {
+	case domain.BumpMajor:
+		parts = [3]int{parts[0] + 1, 0, 0}
+	case domain.BumpMinor:
+		parts = [3]int{parts[0], parts[1] + 1, 0}
+	case domain.BumpPatch:
+		parts[2]++
+	case domain.BumpNone:
+	}
+
+	return fmt.Sprintf("%s%d.%d.%d", prefix, parts[0], parts[1], parts[2])
+}
+
+// BumpBetween returns the bump going from one semantic version to another, and false when either is not a
+// semantic version or the second is not greater.
+func BumpBetween(from, to string) (domain.VersionBump, bool) {
+	_, old, okOld := parseVersion(from)
+	_, current, okNew := parseVersion(to)
+
+	switch {
+	case !okOld || !okNew:
+		return "", false
+	case current[0] != old[0]:
+		return domain.BumpMajor, current[0] > old[0]
+	case current[1] != old[1]:
+		return domain.BumpMinor, current[1] > old[1]
+	case current[2] != old[2]:
+		return domain.BumpPatch, current[2] > old[2]
+	default:
+		return domain.BumpNone, true
+	}
+}
+
+// parseVersion splits a version such as v1.2.3-beta into its "v" prefix and numbers.
+func parseVersion(version string) (string, [3]int, bool) {
+	var parts [3]int
+
+	prefix := ""
+	if strings.HasPrefix(version, "v") {
+		prefix, version = "v", version[1:]
+	}
+
+	if end := strings.IndexAny(version, "-+"); end >= 0 {
+		version = version[:end]
+	}
+
+	fields := strings.Split(version, ".")
+	if len(fields) != len(parts) {
+		return "", parts, false
+	}
+
+	for i, field := range fields {
+		number, err := strconv.Atoi(field)
+		if err != nil || number < 0 {
+			return "", parts, false
+		}
+
+		parts[i] = number
+	}
+
+	return prefix, parts, true
+}
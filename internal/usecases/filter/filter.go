@@ -0,0 +1,189 @@
+// Package filter narrows an API specification down to the parts that should be documented.
+package filter
+
+import (
+	"github.com/GabrielNunesIT/openapi-converter/internal/domain"
+)
+
+// WithoutDeprecated returns a copy of the document without deprecated operations, parameters,
+// component schemas and schema properties. Paths and webhooks left without operations are dropped,
+// and so are the component schemas only the removed parts referenced.
+func WithoutDeprecated(doc *domain.OpenAPIDocument) *domain.OpenAPIDocument {
+	filtered := *doc
+	filtered.Paths = withoutDeprecatedPaths(doc.Paths)
+	filtered.Webhooks = withoutDeprecatedWebhooks(doc.Webhooks)
+
+	filtered.Components = make(map[string]domain.Schema, len(doc.Components))
+	for name, schema := range doc.Components {
+		if !schema.Deprecated {
+			filtered.Components[name] = withoutDeprecatedProperties(schema)
+		}
+	}
+
+	used, stillUsed := usedComponents(doc), usedComponents(&filtered)
+	for name := range filtered.Components {
+		_, wasUsed := used[name]
+		if _, isUsed := stillUsed[name]; wasUsed && !isUsed {
+			delete(filtered.Components, name)
+		}
+	}
+
+	return &filtered
+}
+
+func withoutDeprecatedPaths(paths []domain.Path) []domain.Path {
+	if paths == nil {
+		return nil
+	}
+
+	filtered := make([]domain.Path, 0, len(paths))
+
+	for _, path := range paths {
+		if path.Operations = withoutDeprecatedOperations(path.Operations); len(path.Operations) > 0 {
+			filtered = append(filtered, path)
+		}
+	}
+
+	return filtered
+}
+
+func withoutDeprecatedWebhooks(webhooks []domain.Webhook) []domain.Webhook {
+	if webhooks == nil {
+		return nil
+	}
+
+	filtered := make([]domain.Webhook, 0, len(webhooks))
+
+	for _, webhook := range webhooks {
+		if webhook.Operations = withoutDeprecatedOperations(webhook.Operations); len(webhook.Operations) > 0 {
+			filtered = append(filtered, webhook)
+		}
+	}
+
+	return filtered
+}
+
+func withoutDeprecatedOperations(operations []domain.Operation) []domain.Operation {
+	filtered := make([]domain.Operation, 0, len(operations))
+
+	for _, op := range operations {
+		if !op.Deprecated {
+			filtered = append(filtered, withoutDeprecatedOperationParts(op))
+		}
+	}
+
+	return filtered
+}
+
+func withoutDeprecatedOperationParts(op domain.Operation) domain.Operation {
+	params := make([]domain.Parameter, 0, len(op.Parameters))
+
+	for _, param := range op.Parameters {
+		if !param.Deprecated {
+			param.Schema = withoutDeprecatedProperties(param.Schema)
+			params = append(params, param)
+		}
+	}
+
+	op.Parameters = params
+
+	if op.RequestBody != nil {
+		body := *op.RequestBody
+		body.Content = withoutDeprecatedContent(body.Content)
+		op.RequestBody = &body
+	}
+
+	responses := make([]domain.Response, 0, len(op.Responses))
+
+	for _, resp := range op.Responses {
+		resp.Content = withoutDeprecatedContent(resp.Content)
+		resp.Headers = withoutDeprecatedHeaders(resp.Headers)
+		responses = append(responses, resp)
+	}
+
+	op.Responses = responses
+
+	callbacks := make([]domain.Callback, 0, len(op.Callbacks))
+
+	for _, callback := range op.Callbacks {
+		callback.Operations = withoutDeprecatedOperations(callback.Operations)
+		if len(callback.Operations) > 0 {
+			callbacks = append(callbacks, callback)
+		}
+	}
+
+	op.Callbacks = callbacks
+
+	return op
+}
+
+func withoutDeprecatedHeaders(headers []domain.Header) []domain.Header {
+	if headers == nil {
+		return nil
+	}
+
+	filtered := make([]domain.Header, 0, len(headers))
+
+	for _, header := range headers {
+		if !header.Deprecated {
+			header.Schema = withoutDeprecatedProperties(header.Schema)
+			filtered = append(filtered, header)
+		}
+	}
+
+	return filtered
+}
+
+func withoutDeprecatedContent(content map[string]domain.MediaType) map[string]domain.MediaType {
+	if content == nil {
+		return nil
+	}
+
+	filtered := make(map[string]domain.MediaType, len(content))
+
+	for contentType, media := range content {
+		media.Schema = withoutDeprecatedProperties(media.Schema)
+		filtered[contentType] = media
+	}
+
+	return filtered
+}
+
+// withoutDeprecatedProperties removes deprecated properties from a schema and the schemas nested in it.
+func withoutDeprecatedProperties(schema domain.Schema) domain.Schema {
+	if schema.Properties != nil {
+		properties := make(map[string]domain.Schema, len(schema.Properties))
+
+		for name, property := range schema.Properties {
+			if !property.Deprecated {
+				properties[name] = withoutDeprecatedProperties(property)
+			}
+		}
+
+		schema.Properties = properties
+	}
+
+	if schema.Items != nil {
+		items := withoutDeprecatedProperties(*schema.Items)
+		schema.Items = &items
+	}
+
+	schema.AllOf = withoutDeprecatedSchemas(schema.AllOf)
+	schema.AnyOf = withoutDeprecatedSchemas(schema.AnyOf)
+	schema.OneOf = withoutDeprecatedSchemas(schema.OneOf)
+
+	return schema
+}
+
+func withoutDeprecatedSchemas(schemas []domain.Schema) []domain.Schema {
+	if schemas == nil {
+		return nil
+	}
+
+	filtered := make([]domain.Schema, 0, len(schemas))
+	for _, schema := range schemas {
+		filtered = append(filtered, withoutDeprecatedProperties(schema))
+	}
+
+	return filtered
+}
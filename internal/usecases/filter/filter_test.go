@@ -0,0 +1,200 @@
+package filter_test
+
+import (
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/GabrielNunesIT/openapi-converter/internal/domain"
+	"github.com/GabrielNunesIT/openapi-converter/internal/usecases/filter"
+)
+
+func ref(name string) domain.Schema {
+	return domain.Schema{Ref: "#/components/schemas/" + name}
+}
+
+// operation returns an operation answering with the schemas, one response each.
+func operation(method, id string, tags []string, schemas ...domain.Schema) domain.Operation {
+	op := domain.Operation{Method: method, OperationID: id, Tags: tags}
+
+	for i, schema := range schemas {
+		op.Responses = append(op.Responses, domain.Response{
+			StatusCode: strings.Repeat("2", i+1),
+			Content:    map[string]domain.MediaType{"application/json": {Schema: schema}},
+		})
+	}
+
+	return op
+}
+
+// storeDoc returns a document whose operations reference component schemas, some of them shared with others,
+// some through other components.
+func storeDoc() *domain.OpenAPIDocument {
+	internal := map[string]any{"x-internal": true}
+
+	deletePet := operation("delete", "deletePet", []string{"admin"}, ref("Shared"), ref("AdminReport"))
+	deletePet.Extensions = internal
+
+	legacy := operation("get", "legacy", []string{"pets"}, ref("Legacy"))
+	legacy.Deprecated = true
+
+	return &domain.OpenAPIDocument{
+		Paths: []domain.Path{
+			{Path: "/pets", Operations: []domain.Operation{
+				operation("get", "listPets", []string{"pets"}, ref("Pet"), ref("Shared")),
+				deletePet,
+			}},
+			{Path: "/orders/{id}", Operations: []domain.Operation{operation("get", "getOrder", []string{"store"}, ref("Order"))}},
+			{Path: "/legacy", Operations: []domain.Operation{legacy}},
+			{Path: "/internal/stats", Operations: []domain.Operation{operation("get", "stats", nil, ref("Stats"))}},
+		},
+		Components: map[string]domain.Schema{
+			"Pet": {Type: "object", Properties: map[string]domain.Schema{
+				"owner":  ref("Owner"),
+				"secret": ref("Secret"),
+			}},
+			"Owner":       {Type: "object"},
+			"Secret":      {Type: "object", Extensions: internal},
+			"Shared":      {Type: "object"},
+			"AdminReport": {Type: "object"},
+			"Order":       {Type: "object", Items: &domain.Schema{Ref: "#/components/schemas/Item"}},
+			"Item":        {Type: "object"},
+			"Legacy":      {Type: "object"},
+			"Stats":       {Type: "object"},
+			"Unused":      {Type: "object"},
+			"Old":         {Type: "object", Deprecated: true},
+		},
+	}
+}
+
+func TestFilters(t *testing.T) {
+	tests := []struct {
+		name           string
+		apply          func(*domain.OpenAPIDocument) (*domain.OpenAPIDocument, error)
+		wantOperations []string
+		wantComponents []string
+		wantErr        string
+	}{
+		{
+			name: "include tags",
+			apply: func(doc *domain.OpenAPIDocument) (*domain.OpenAPIDocument, error) {
+				return filter.Select(doc, filter.Selection{IncludeTags: []string{"PETS"}})
+			},
+			wantOperations: []string{"listPets", "legacy"},
+			wantComponents: []string{"Legacy", "Owner", "Pet", "Secret", "Shared"},
+		},
+		{
+			name: "exclude tags",
+			apply: func(doc *domain.OpenAPIDocument) (*domain.OpenAPIDocument, error) {
+				return filter.Select(doc, filter.Selection{ExcludeTags: []string{"pets", "store"}})
+			},
+			wantOperations: []string{"deletePet", "stats"},
+			wantComponents: []string{"AdminReport", "Shared", "Stats"},
+		},
+		{
+			name: "include paths",
+			apply: func(doc *domain.OpenAPIDocument) (*domain.OpenAPIDocument, error) {
+				return filter.Select(doc, filter.Selection{IncludePaths: []string{"/orders/*", "/internal/**"}})
+			},
+			wantOperations: []string{"getOrder", "stats"},
+			wantComponents: []string{"Item", "Order", "Stats"},
+		},
+		{
+			name: "operationIds",
+			apply: func(doc *domain.OpenAPIDocument) (*domain.OpenAPIDocument, error) {
+				return filter.Select(doc, filter.Selection{Operations: []string{"deletePet"}})
+			},
+			wantOperations: []string{"deletePet"},
+			wantComponents: []string{"AdminReport", "Shared"},
+		},
+		{
+			name: "without deprecated",
+			apply: func(doc *domain.OpenAPIDocument) (*domain.OpenAPIDocument, error) {
+				return filter.WithoutDeprecated(doc), nil
+			},
+			wantOperations: []string{"listPets", "deletePet", "getOrder", "stats"},
+			wantComponents: []string{"AdminReport", "Item", "Order", "Owner", "Pet", "Secret", "Shared", "Stats", "Unused"},
+		},
+		{
+			name: "internal audience",
+			apply: func(doc *domain.OpenAPIDocument) (*domain.OpenAPIDocument, error) {
+				return filter.Redact(doc, filter.Redaction{Paths: []string{"/internal/**"}})
+			},
+			wantOperations: []string{"listPets", "deletePet", "getOrder", "legacy", "stats"},
+			wantComponents: []string{
+				"AdminReport", "Item", "Legacy", "Old", "Order", "Owner", "Pet", "Secret", "Shared", "Stats", "Unused",
+			},
+		},
+		{
+			name: "public audience",
+			apply: func(doc *domain.OpenAPIDocument) (*domain.OpenAPIDocument, error) {
+				return filter.Redact(doc, filter.Redaction{Audience: filter.AudiencePublic})
+			},
+			wantOperations: []string{"listPets", "getOrder", "legacy", "stats"},
+			wantComponents: []string{"Item", "Legacy", "Old", "Order", "Owner", "Pet", "Shared", "Stats", "Unused"},
+		},
+		{
+			name: "public audience with patterns",
+			apply: func(doc *domain.OpenAPIDocument) (*domain.OpenAPIDocument, error) {
+				return filter.Redact(doc, filter.Redaction{
+					Audience: filter.AudiencePublic,
+					Paths:    []string{"/internal/**"},
+					Schemas:  []string{"Ite?", "Un*"},
+				})
+			},
+			wantOperations: []string{"listPets", "getOrder", "legacy"},
+			wantComponents: []string{"Legacy", "Old", "Order", "Owner", "Pet", "Shared"},
+		},
+		{
+			name: "unknown audience",
+			apply: func(doc *domain.OpenAPIDocument) (*domain.OpenAPIDocument, error) {
+				return filter.Redact(doc, filter.Redaction{Audience: "partners"})
+			},
+			wantErr: "unsupported audience: partners",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := storeDoc()
+
+			filtered, err := tt.apply(doc)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("got error %v, want %q", err, tt.wantErr)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("filter: %v", err)
+			}
+
+			var operations []string
+
+			for _, path := range filtered.Paths {
+				if len(path.Operations) == 0 {
+					t.Errorf("got path %s without operations", path.Path)
+				}
+
+				for _, op := range path.Operations {
+					operations = append(operations, op.OperationID)
+				}
+			}
+
+			if !slices.Equal(operations, tt.wantOperations) {
+				t.Errorf("got operations %q, want %q", operations, tt.wantOperations)
+			}
+
+			if got := slices.Sorted(maps.Keys(filtered.Components)); !slices.Equal(got, tt.wantComponents) {
+				t.Errorf("got components %q, want %q", got, tt.wantComponents)
+			}
+
+			if len(doc.Paths) != 4 || len(doc.Components) != 11 {
+				t.Error("the document given was modified")
+			}
+		})
+	}
+}
@@ -0,0 +1,141 @@
+package filter
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/GabrielNunesIT/openapi-converter/internal/domain"
+)
+
+// Audiences of the documentation, chosen with Redaction.Audience.
+const (
+	AudiencePublic   = "public"   // Internal operations and schemas are removed
+	AudienceInternal = "internal" // Everything is documented
+)
+
+// internalExtension flags an operation or component schema as internal when true.
+const internalExtension = "x-internal"
+
+// Redaction removes the internal operations and schemas of a specification from its public documentation,
+// so that one specification produces both the public and the internal documentation.
+type Redaction struct {
+	Audience string   // public or internal, empty for internal
+	Paths    []string // Globs of internal paths, as for Selection.IncludePaths
+	Schemas  []string // Globs of the names of internal component schemas, where * matches any characters
+}
+
+// Audiences returns the supported audiences.
+func Audiences() []string {
+	return []string{AudiencePublic, AudienceInternal}
+}
+
+// Redact returns a copy of the document for the audience of the redaction. For the public, operations flagged
+// x-internal or on internal paths are removed, paths, webhooks and callbacks left without operations are dropped,
+// and so are the component schemas flagged x-internal or with internal names, along with those only the removed
+// operations referenced. References of the remaining parts to internal schemas are kept, showing their name only.
+func Redact(doc *domain.OpenAPIDocument, redaction Redaction) (*domain.OpenAPIDocument, error) {
+	switch redaction.Audience {
+	case AudienceInternal, "":
+		return doc, nil
+	case AudiencePublic:
+	default:
+		return nil, fmt.Errorf("unsupported audience: %s (supported: %s)", redaction.Audience, strings.Join(Audiences(), ", "))
+	}
+
+	paths, err := compileGlobs(redaction.Paths)
+	if err != nil {
+		return nil, err
+	}
+
+	schemas, err := compileGlobs(redaction.Schemas)
+	if err != nil {
+		return nil, err
+	}
+
+	redacted := *doc
+	redacted.Paths = nil
+	redacted.Webhooks = nil
+
+	for _, path := range doc.Paths {
+		if matchesAny(paths, path.Path) {
+			continue
+		}
+
+		if path.Operations = publicOperations(path.Operations); len(path.Operations) > 0 {
+			redacted.Paths = append(redacted.Paths, path)
+		}
+	}
+
+	for _, webhook := range doc.Webhooks {
+		if webhook.Operations = publicOperations(webhook.Operations); len(webhook.Operations) > 0 {
+			redacted.Webhooks = append(redacted.Webhooks, webhook)
+		}
+	}
+
+	used, stillUsed := usedComponents(doc), usedComponents(&redacted)
+	redacted.Components = make(map[string]domain.Schema, len(doc.Components))
+
+	for name, schema := range doc.Components {
+		if isInternal(schema.Extensions) || matchesAny(schemas, name) {
+			continue
+		}
+
+		_, wasUsed := used[name]
+		if _, isUsed := stillUsed[name]; wasUsed && !isUsed {
+			continue
+		}
+
+		redacted.Components[name] = schema
+	}
+
+	return &redacted, nil
+}
+
+func publicOperations(operations []domain.Operation) []domain.Operation {
+	public := make([]domain.Operation, 0, len(operations))
+
+	for _, op := range operations {
+		if isInternal(op.Extensions) {
+			continue
+		}
+
+		callbacks := make([]domain.Callback, 0, len(op.Callbacks))
+
+		for _, callback := range op.Callbacks {
+			if callback.Operations = publicOperations(callback.Operations); len(callback.Operations) > 0 {
+				callbacks = append(callbacks, callback)
+			}
+		}
+
+		if op.Callbacks != nil {
+			op.Callbacks = callbacks
+		}
+
+		public = append(public, op)
+	}
+
+	return public
+}
+
+// isInternal reports whether vendor extensions flag their owner as internal.
+func isInternal(extensions map[string]any) bool {
+	internal, _ := extensions[internalExtension].(bool)
+
+	return internal
+}
+
+func compileGlobs(patterns []string) ([]*regexp.Regexp, error) {
+	globs := make([]*regexp.Regexp, 0, len(patterns))
+
+	for _, pattern := range patterns {
+		glob, err := compileGlob(pattern)
+		if err != nil {
+			return nil, err
+		}
+
+		globs = append(globs, glob)
+	}
+
+	return globs, nil
+}
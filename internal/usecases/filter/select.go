@@ -0,0 +1,245 @@
+package filter
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/GabrielNunesIT/openapi-converter/internal/domain"
+)
+
+// Selection picks the operations to document, such as the public endpoints only. Empty criteria select everything.
+type Selection struct {
+	IncludeTags  []string // Keep operations with at least one of these tags
+	ExcludeTags  []string // Drop operations with any of these tags
+	IncludePaths []string // Keep paths matching one of these globs, where * stays within a segment and ** spans segments
+	Operations   []string // Keep operations with one of these operationIds
+}
+
+// IsEmpty reports whether the selection keeps every operation.
+func (s Selection) IsEmpty() bool {
+	return len(s.IncludeTags) == 0 && len(s.ExcludeTags) == 0 && len(s.IncludePaths) == 0 && len(s.Operations) == 0
+}
+
+// Select returns a copy of the document with only the selected operations. Paths and webhooks left without
+// operations are dropped, as are component schemas no longer referenced by the remaining operations.
+// Path globs apply to paths only; webhooks are selected by tag and operationId.
+func Select(doc *domain.OpenAPIDocument, selection Selection) (*domain.OpenAPIDocument, error) {
+	globs, err := compileGlobs(selection.IncludePaths)
+	if err != nil {
+		return nil, err
+	}
+
+	filtered := *doc
+	filtered.Paths = nil
+	filtered.Webhooks = nil
+
+	for _, path := range doc.Paths {
+		if len(globs) > 0 && !matchesAny(globs, path.Path) {
+			continue
+		}
+
+		if path.Operations = selectOperations(path.Operations, selection); len(path.Operations) > 0 {
+			filtered.Paths = append(filtered.Paths, path)
+		}
+	}
+
+	for _, webhook := range doc.Webhooks {
+		if webhook.Operations = selectOperations(webhook.Operations, selection); len(webhook.Operations) > 0 {
+			filtered.Webhooks = append(filtered.Webhooks, webhook)
+		}
+	}
+
+	filtered.Components = usedComponents(&filtered)
+
+	return &filtered, nil
+}
+
+func selectOperations(operations []domain.Operation, selection Selection) []domain.Operation {
+	selected := make([]domain.Operation, 0, len(operations))
+
+	for _, op := range operations {
+		if len(selection.IncludeTags) > 0 && !containsAny(selection.IncludeTags, op.Tags) {
+			continue
+		}
+
+		if containsAny(selection.ExcludeTags, op.Tags) {
+			continue
+		}
+
+		if len(selection.Operations) > 0 && !containsAny(selection.Operations, []string{op.OperationID}) {
+			continue
+		}
+
+		selected = append(selected, op)
+	}
+
+	return selected
+}
+
+// containsAny reports whether any of values is in set, ignoring case.
+func containsAny(set, values []string) bool {
+	for _, value := range values {
+		for _, candidate := range set {
+			if strings.EqualFold(candidate, value) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func matchesAny(globs []*regexp.Regexp, path string) bool {
+	for _, glob := range globs {
+		if glob.MatchString(path) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// compileGlob turns a path glob into a regular expression: ** matches any characters,
+// * any characters but "/" and ? a single character other than "/".
+func compileGlob(pattern string) (*regexp.Regexp, error) {
+	var expr strings.Builder
+
+	expr.WriteString("^")
+
+	for i := 0; i < len(pattern); i++ {
+		switch {
+		case strings.HasPrefix(pattern[i:], "**"):
+			expr.WriteString(".*")
+			i++
+		case pattern[i] == '*':
+			expr.WriteString("[^/]*")
+		case pattern[i] == '?':
+			expr.WriteString("[^/]")
+		default:
+			expr.WriteString(regexp.QuoteMeta(pattern[i : i+1]))
+		}
+	}
+
+	expr.WriteString("$")
+
+	glob, err := regexp.Compile(expr.String())
+	if err != nil {
+		return nil, fmt.Errorf("invalid path pattern %s: %w", pattern, err)
+	}
+
+	return glob, nil
+}
+
+// usedComponents returns the component schemas referenced by the operations of a document,
+// directly or through other components.
+func usedComponents(doc *domain.OpenAPIDocument) map[string]domain.Schema {
+	used := make(map[string]domain.Schema)
+	pending := []string{}
+
+	visit := func(schema domain.Schema) {
+		for _, name := range schemaRefs(schema) {
+			if _, seen := used[name]; seen {
+				continue
+			}
+
+			if component, exists := doc.Components[name]; exists {
+				used[name] = component
+				pending = append(pending, name)
+			}
+		}
+	}
+
+	operations := []domain.Operation{}
+	for _, path := range doc.Paths {
+		operations = append(operations, path.Operations...)
+	}
+
+	for _, webhook := range doc.Webhooks {
+		operations = append(operations, webhook.Operations...)
+	}
+
+	// Callback requests are documented with the operation that triggers them
+	for i := 0; i < len(operations); i++ {
+		for _, callback := range operations[i].Callbacks {
+			operations = append(operations, callback.Operations...)
+		}
+	}
+
+	for _, op := range operations {
+		for _, param := range op.Parameters {
+			visit(param.Schema)
+		}
+
+		if op.RequestBody != nil {
+			for _, media := range op.RequestBody.Content {
+				visit(media.Schema)
+			}
+		}
+
+		for _, resp := range op.Responses {
+			for _, media := range resp.Content {
+				visit(media.Schema)
+			}
+
+			for _, header := range resp.Headers {
+				visit(header.Schema)
+			}
+		}
+	}
+
+	for len(pending) > 0 {
+		name := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+
+		visit(used[name])
+	}
+
+	return used
+}
+
+// schemaRefs returns the names of the components a schema refers to, including through its members.
+func schemaRefs(schema domain.Schema) []string {
+	return appendSchemaRefs(nil, schema, make(map[string]struct{}))
+}
+
+// appendSchemaRefs walks each referenced schema once, so circular references end at the name they lead back to.
+func appendSchemaRefs(refs []string, schema domain.Schema, walked map[string]struct{}) []string {
+	if schema.Ref != "" {
+		name := refName(schema.Ref)
+		refs = append(refs, name)
+
+		if _, circular := walked[name]; circular {
+			return refs
+		}
+
+		walked[name] = struct{}{}
+	}
+
+	for _, prop := range schema.Properties {
+		refs = appendSchemaRefs(refs, prop, walked)
+	}
+
+	if schema.Items != nil {
+		refs = appendSchemaRefs(refs, *schema.Items, walked)
+	}
+
+	for _, composed := range [][]domain.Schema{schema.AllOf, schema.AnyOf, schema.OneOf} {
+		for _, member := range composed {
+			refs = appendSchemaRefs(refs, member, walked)
+		}
+	}
+
+	if schema.Discriminator != nil {
+		for _, ref := range schema.Discriminator.Mapping {
+			refs = append(refs, refName(ref))
+		}
+	}
+
+	return refs
+}
+
+// refName returns the component name at the end of a reference such as "#/components/schemas/Pet".
+func refName(ref string) string {
+	return ref[strings.LastIndex(ref, "/")+1:]
+}
@@ -0,0 +1,146 @@
+// Package lint checks a parsed API specification against configurable style rules, in the spirit of Spectral,
+// so that specification quality can gate CI with the tool that generates the documentation.
+package lint
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/GabrielNunesIT/openapi-converter/internal/domain"
+	"gopkg.in/yaml.v3"
+)
+
+// SeverityOff disables a rule in a configuration.
+const SeverityOff = "off"
+
+// Rule checks a document against one convention.
+type Rule struct {
+	Name        string
+	Description string
+	Severity    domain.Severity // Severity of the issues unless the configuration overrides it
+	check       func(doc *domain.OpenAPIDocument, opts options) []domain.Issue
+}
+
+// Config selects and adjusts the rules to run. Rules missing from it run with their defaults.
+//
+//	rules:
+//	  operation-tags: off
+//	  path-casing: error
+//	  operation-summary:
+//	    severity: warning
+//	    max-length: 60
+type Config struct {
+	Rules map[string]RuleConfig `yaml:"rules"`
+}
+
+// RuleConfig overrides the severity of a rule, or turns it off, and sets its options.
+type RuleConfig struct {
+	Severity string
+	Options  map[string]any
+}
+
+// UnmarshalYAML accepts either a severity or a mapping of a severity and rule options.
+func (c *RuleConfig) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		c.Severity = node.Value
+
+		return nil
+	}
+
+	if err := node.Decode(&c.Options); err != nil {
+		return fmt.Errorf("rule settings must be a severity or a mapping: %w", err)
+	}
+
+	if severity, ok := c.Options["severity"].(string); ok {
+		c.Severity = severity
+		delete(c.Options, "severity")
+	}
+
+	return nil
+}
+
+// ParseConfig reads a YAML rule configuration and checks that it names known rules and severities.
+func ParseConfig(data []byte) (*Config, error) {
+	config := &Config{}
+	if err := yaml.Unmarshal(data, config); err != nil {
+		return nil, fmt.Errorf("failed to parse lint configuration: %w", err)
+	}
+
+	known := make(map[string]struct{})
+	for _, rule := range Rules() {
+		known[rule.Name] = struct{}{}
+	}
+
+	errs := []error{}
+
+	for _, name := range slices.Sorted(maps.Keys(config.Rules)) {
+		if _, exists := known[name]; !exists {
+			errs = append(errs, fmt.Errorf("unknown lint rule: %s", name))
+		}
+
+		switch domain.Severity(config.Rules[name].Severity) {
+		case "", SeverityOff, domain.SeverityError, domain.SeverityWarning, domain.SeverityInfo:
+		default:
+			errs = append(errs, fmt.Errorf("rule %s: unknown severity %s (expected error, warning, info or off)",
+				name, config.Rules[name].Severity))
+		}
+	}
+
+	return config, errors.Join(errs...)
+}
+
+// Run checks a document with the built-in rules adjusted by config, which may be nil.
+// Issues are grouped by rule in alphabetical order.
+func Run(doc *domain.OpenAPIDocument, config *Config) *domain.Report {
+	report := &domain.Report{Rules: make(map[string]string)}
+
+	for _, rule := range Rules() {
+		settings := RuleConfig{}
+		if config != nil {
+			settings = config.Rules[rule.Name]
+		}
+
+		if settings.Severity == SeverityOff {
+			continue
+		}
+
+		severity := rule.Severity
+		if settings.Severity != "" {
+			severity = domain.Severity(settings.Severity)
+		}
+
+		report.Rules[rule.Name] = rule.Description
+
+		for _, issue := range rule.check(doc, settings.Options) {
+			issue.Rule = rule.Name
+			issue.Severity = severity
+			report.Issues = append(report.Issues, issue)
+		}
+	}
+
+	return report
+}
+
+// options are the settings of a rule from the configuration.
+type options map[string]any
+
+// int returns an integer option, or fallback when it is missing or not a number.
+func (o options) int(name string, fallback int) int {
+	if value, ok := o[name].(int); ok {
+		return value
+	}
+
+	return fallback
+}
+
+// string returns a string option, or fallback when it is missing.
+func (o options) string(name, fallback string) string {
+	if value, ok := o[name].(string); ok && value != "" {
+		return strings.ToLower(value)
+	}
+
+	return fallback
+}
@@ -0,0 +1,167 @@
+package lint
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/GabrielNunesIT/openapi-converter/internal/domain"
+)
+
+// defaultSummaryLength is the longest summary accepted by the operation-summary rule.
+const defaultSummaryLength = 80
+
+// segmentCases match the literal path segments of each naming convention of the path-casing rule.
+var segmentCases = map[string]*regexp.Regexp{ //nolint:gochecknoglobals // read-only lookup table
+	"kebab": regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`),
+	"snake": regexp.MustCompile(`^[a-z0-9]+(_[a-z0-9]+)*$`),
+	"camel": regexp.MustCompile(`^[a-z][a-zA-Z0-9]*$`),
+}
+
+// Rules returns the built-in rules in alphabetical order.
+func Rules() []Rule {
+	return []Rule{
+		{
+			Name:        "operation-4xx-response",
+			Description: "Operations document at least one 4xx response so that clients know how requests can fail.",
+			Severity:    domain.SeverityWarning,
+			check:       checkClientErrors,
+		},
+		{
+			Name:        "operation-summary",
+			Description: "Operations have a summary of at most max-length characters (default 80).",
+			Severity:    domain.SeverityWarning,
+			check:       checkSummaries,
+		},
+		{
+			Name:        "operation-tags",
+			Description: "Operations have at least one tag, which groups them in the generated documentation.",
+			Severity:    domain.SeverityWarning,
+			check:       checkTags,
+		},
+		{
+			Name:        "path-casing",
+			Description: "Literal path segments follow one naming convention, set by case: kebab (default), snake or camel.",
+			Severity:    domain.SeverityWarning,
+			check:       checkPathCasing,
+		},
+	}
+}
+
+// operationRef is an operation of a path with its location.
+type operationRef struct {
+	pointer []string
+	label   string
+	op      domain.Operation
+}
+
+// pathOperations returns the operations of the paths of a document in document order.
+func pathOperations(doc *domain.OpenAPIDocument) []operationRef {
+	refs := []operationRef{}
+
+	for _, path := range doc.Paths {
+		for _, op := range path.Operations {
+			refs = append(refs, operationRef{
+				pointer: []string{"paths", path.Path, strings.ToLower(op.Method)},
+				label:   strings.ToUpper(op.Method) + " " + path.Path,
+				op:      op,
+			})
+		}
+	}
+
+	return refs
+}
+
+func checkClientErrors(doc *domain.OpenAPIDocument, _ options) []domain.Issue {
+	issues := []domain.Issue{}
+
+	for _, ref := range pathOperations(doc) {
+		documented := false
+
+		for _, resp := range ref.op.Responses {
+			if strings.HasPrefix(resp.StatusCode, "4") {
+				documented = true
+
+				break
+			}
+		}
+
+		if !documented {
+			issues = append(issues, domain.Issue{
+				Pointer: append(ref.pointer, "responses"),
+				Message: ref.label + " documents no 4xx response",
+			})
+		}
+	}
+
+	return issues
+}
+
+func checkSummaries(doc *domain.OpenAPIDocument, opts options) []domain.Issue {
+	maxLength := opts.int("max-length", defaultSummaryLength)
+	issues := []domain.Issue{}
+
+	for _, ref := range pathOperations(doc) {
+		summary := strings.TrimSpace(ref.op.Summary)
+
+		switch length := len([]rune(summary)); {
+		case length == 0:
+			issues = append(issues, domain.Issue{
+				Pointer: ref.pointer,
+				Message: ref.label + " has no summary",
+			})
+		case length > maxLength:
+			issues = append(issues, domain.Issue{
+				Pointer: append(ref.pointer, "summary"),
+				Message: fmt.Sprintf("summary of %s is %d characters long, more than %d", ref.label, length, maxLength),
+			})
+		}
+	}
+
+	return issues
+}
+
+func checkTags(doc *domain.OpenAPIDocument, _ options) []domain.Issue {
+	issues := []domain.Issue{}
+
+	for _, ref := range pathOperations(doc) {
+		if len(ref.op.Tags) == 0 {
+			issues = append(issues, domain.Issue{
+				Pointer: ref.pointer,
+				Message: ref.label + " has no tags",
+			})
+		}
+	}
+
+	return issues
+}
+
+func checkPathCasing(doc *domain.OpenAPIDocument, opts options) []domain.Issue {
+	style := opts.string("case", "kebab")
+
+	pattern, known := segmentCases[style]
+	if !known {
+		return []domain.Issue{{
+			Message: fmt.Sprintf("unknown path case %q, expected kebab, snake or camel", style),
+		}}
+	}
+
+	issues := []domain.Issue{}
+
+	for _, path := range doc.Paths {
+		for _, segment := range strings.Split(path.Path, "/") {
+			if segment == "" || strings.HasPrefix(segment, "{") || pattern.MatchString(segment) {
+				continue
+			}
+
+			issues = append(issues, domain.Issue{
+				Pointer: []string{"paths", path.Path},
+				Message: fmt.Sprintf("segment %q of path %s is not %s-case", segment, path.Path, style),
+			})
+
+			break
+		}
+	}
+
+	return issues
+}
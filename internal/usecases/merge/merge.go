@@ -0,0 +1,411 @@
+// Package merge combines several API specifications, such as those of the services behind a gateway, into one.
+package merge
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/GabrielNunesIT/openapi-converter/internal/domain"
+)
+
+// schemaRefPrefix starts the references to component schemas.
+const schemaRefPrefix = "#/components/schemas/"
+
+// Source is a specification to merge, with the name telling it apart from the others.
+type Source struct {
+	Name     string // Service name prefixing its renamed components and, with PrefixTags, its tags
+	Document *domain.OpenAPIDocument
+}
+
+// Options adjusts the merged document.
+type Options struct {
+	Title       string // Title of the merged document, defaults to the title of the first source
+	Version     string // Version of the merged document, defaults to the version of the first source
+	Description string // Description of the merged document, defaults to a list of the merged APIs
+	PrefixTags  bool   // Prefix the tags of each source with its name, tagging untagged operations with the name alone
+}
+
+// Documents merges the sources into one document.
+// Component schemas and security schemes defined differently by several sources keep their name in the first
+// source defining them and are renamed "<source>.<name>" in the others, along with every reference to them.
+// The contact, license and terms of service are those of the first source.
+// An operation or webhook defined by several sources is an error.
+func Documents(sources []Source, opts Options) (*domain.OpenAPIDocument, error) {
+	if len(sources) == 0 {
+		return nil, errors.New("no specification to merge")
+	}
+
+	merged := &domain.OpenAPIDocument{
+		Title:           opts.Title,
+		Version:         opts.Version,
+		Description:     opts.Description,
+		Contact:         sources[0].Document.Contact,
+		License:         sources[0].Document.License,
+		TermsOfService:  sources[0].Document.TermsOfService,
+		Components:      make(map[string]domain.Schema),
+		SecuritySchemes: make(map[string]domain.SecurityScheme),
+	}
+
+	if merged.Title == "" {
+		merged.Title = sources[0].Document.Title
+	}
+
+	if merged.Version == "" {
+		merged.Version = sources[0].Document.Version
+	}
+
+	if merged.Description == "" {
+		merged.Description = describeSources(sources)
+	}
+
+	owners := make(map[string]string) // Source of each operation and webhook, to report collisions
+	paths := make(map[string]int)     // Index of each path in merged.Paths
+
+	for _, source := range sources {
+		doc := renameCollisions(merged, source)
+
+		maps.Copy(merged.Components, doc.Components)
+		maps.Copy(merged.SecuritySchemes, doc.SecuritySchemes)
+		merged.Servers = appendServers(merged.Servers, doc.Servers)
+		merged.Tags = appendTags(merged.Tags, doc.Tags, source.Name, opts.PrefixTags)
+
+		for name, value := range doc.Extensions {
+			if _, ok := merged.Extensions[name]; !ok {
+				if merged.Extensions == nil {
+					merged.Extensions = make(map[string]any)
+				}
+
+				merged.Extensions[name] = value
+			}
+		}
+
+		for _, path := range doc.Paths {
+			operations := prefixTags(path.Operations, source.Name, opts.PrefixTags)
+			if err := claimOperations(owners, source.Name, path.Path, operations); err != nil {
+				return nil, err
+			}
+
+			if i, ok := paths[path.Path]; ok {
+				merged.Paths[i].Operations = append(merged.Paths[i].Operations, operations...)
+
+				continue
+			}
+
+			paths[path.Path] = len(merged.Paths)
+			merged.Paths = append(merged.Paths, domain.Path{Path: path.Path, Operations: operations})
+		}
+
+		for _, webhook := range doc.Webhooks {
+			webhook.Operations = prefixTags(webhook.Operations, source.Name, opts.PrefixTags)
+			if err := claimOperations(owners, source.Name, "webhook "+webhook.Name, webhook.Operations); err != nil {
+				return nil, err
+			}
+
+			merged.Webhooks = append(merged.Webhooks, webhook)
+		}
+	}
+
+	return merged, nil
+}
+
+// describeSources lists the merged APIs, for the description of the merged document.
+func describeSources(sources []Source) string {
+	lines := make([]string, 0, len(sources))
+
+	for _, source := range sources {
+		line := fmt.Sprintf("- %s: %s (v%s)", source.Name, source.Document.Title, source.Document.Version)
+		lines = append(lines, line)
+	}
+
+	return "Combines the following APIs:\n\n" + strings.Join(lines, "\n")
+}
+
+// claimOperations records source as the owner of the operations of a path or webhook,
+// failing when another source already defines one of them.
+func claimOperations(owners map[string]string, source, location string, operations []domain.Operation) error {
+	for _, op := range operations {
+		key := strings.ToUpper(op.Method) + " " + location
+		if owner, ok := owners[key]; ok {
+			return fmt.Errorf("%s is defined by both %s and %s", key, owner, source)
+		}
+
+		owners[key] = source
+	}
+
+	return nil
+}
+
+// prefixTags returns the operations with the tags prefixed by the source name, when enabled.
+func prefixTags(operations []domain.Operation, name string, enabled bool) []domain.Operation {
+	if !enabled {
+		return operations
+	}
+
+	prefixed := make([]domain.Operation, 0, len(operations))
+
+	for _, op := range operations {
+		if len(op.Tags) == 0 {
+			op.Tags = []string{name}
+		} else {
+			tags := make([]string, 0, len(op.Tags))
+			for _, tag := range op.Tags {
+				tags = append(tags, name+" / "+tag)
+			}
+
+			op.Tags = tags
+		}
+
+		prefixed = append(prefixed, op)
+	}
+
+	return prefixed
+}
+
+// appendTags adds the tag declarations of a source, prefixed like its operation tags when enabled,
+// keeping the first declaration of a tag declared by several sources.
+func appendTags(tags, added []domain.Tag, name string, prefix bool) []domain.Tag {
+	for _, tag := range added {
+		if prefix {
+			tag.Name = name + " / " + tag.Name
+		}
+
+		if !slices.ContainsFunc(tags, func(declared domain.Tag) bool { return declared.Name == tag.Name }) {
+			tags = append(tags, tag)
+		}
+	}
+
+	return tags
+}
+
+// appendServers adds the servers whose URL is not listed yet.
+func appendServers(servers, added []domain.Server) []domain.Server {
+	for _, server := range added {
+		if !slices.ContainsFunc(servers, func(listed domain.Server) bool { return listed.URL == server.URL }) {
+			servers = append(servers, server)
+		}
+	}
+
+	return servers
+}
+
+// renameCollisions returns the document of a source with the components and security schemes defined
+// differently in the merged document renamed, and the references to them updated.
+func renameCollisions(merged *domain.OpenAPIDocument, source Source) *domain.OpenAPIDocument {
+	schemaNames := make(map[string]string)
+
+	// A schema equal to the merged one but referencing a renamed schema differs once its references are renamed,
+	// so renaming repeats until no other schema needs it
+	for renamed := true; renamed; {
+		renamed = false
+		r := renamer{schemas: schemaNames}
+
+		for name, schema := range source.Document.Components {
+			if _, done := schemaNames[name]; done {
+				continue
+			}
+
+			if existing, ok := merged.Components[name]; ok && !reflect.DeepEqual(existing, r.schema(schema)) {
+				schemaNames[name] = source.Name + "." + name
+				renamed = true
+			}
+		}
+	}
+
+	schemeNames := make(map[string]string)
+
+	for name, scheme := range source.Document.SecuritySchemes {
+		if existing, ok := merged.SecuritySchemes[name]; ok && !reflect.DeepEqual(existing, scheme) {
+			schemeNames[name] = source.Name + "." + name
+		}
+	}
+
+	if len(schemaNames) == 0 && len(schemeNames) == 0 {
+		return source.Document
+	}
+
+	r := renamer{schemas: schemaNames, schemes: schemeNames}
+
+	return r.document(source.Document)
+}
+
+// renamer rewrites a document with some component schemas and security schemes renamed.
+type renamer struct {
+	schemas map[string]string // Old to new component schema names
+	schemes map[string]string // Old to new security scheme names
+}
+
+func (r renamer) document(doc *domain.OpenAPIDocument) *domain.OpenAPIDocument {
+	renamed := *doc
+
+	renamed.Components = make(map[string]domain.Schema, len(doc.Components))
+	for name, schema := range doc.Components {
+		renamed.Components[newName(r.schemas, name)] = r.schema(schema)
+	}
+
+	renamed.SecuritySchemes = make(map[string]domain.SecurityScheme, len(doc.SecuritySchemes))
+	for name, scheme := range doc.SecuritySchemes {
+		renamed.SecuritySchemes[newName(r.schemes, name)] = scheme
+	}
+
+	renamed.Paths = make([]domain.Path, 0, len(doc.Paths))
+	for _, path := range doc.Paths {
+		path.Operations = r.operations(path.Operations)
+		renamed.Paths = append(renamed.Paths, path)
+	}
+
+	renamed.Webhooks = make([]domain.Webhook, 0, len(doc.Webhooks))
+	for _, webhook := range doc.Webhooks {
+		webhook.Operations = r.operations(webhook.Operations)
+		renamed.Webhooks = append(renamed.Webhooks, webhook)
+	}
+
+	return &renamed
+}
+
+func (r renamer) operations(operations []domain.Operation) []domain.Operation {
+	renamed := make([]domain.Operation, 0, len(operations))
+
+	for _, op := range operations {
+		params := make([]domain.Parameter, 0, len(op.Parameters))
+		for _, param := range op.Parameters {
+			param.Schema = r.schema(param.Schema)
+			params = append(params, param)
+		}
+
+		op.Parameters = params
+
+		if op.RequestBody != nil {
+			body := *op.RequestBody
+			body.Content = r.content(body.Content)
+			op.RequestBody = &body
+		}
+
+		responses := make([]domain.Response, 0, len(op.Responses))
+		for _, resp := range op.Responses {
+			resp.Content = r.content(resp.Content)
+
+			headers := make([]domain.Header, 0, len(resp.Headers))
+			for _, header := range resp.Headers {
+				header.Schema = r.schema(header.Schema)
+				headers = append(headers, header)
+			}
+
+			resp.Headers = headers
+			responses = append(responses, resp)
+		}
+
+		op.Responses = responses
+
+		security := make([]domain.SecurityRequirement, 0, len(op.Security))
+		for _, requirement := range op.Security {
+			renamedRequirement := make(domain.SecurityRequirement, len(requirement))
+			for name, scopes := range requirement {
+				renamedRequirement[newName(r.schemes, name)] = scopes
+			}
+
+			security = append(security, renamedRequirement)
+		}
+
+		op.Security = security
+
+		callbacks := make([]domain.Callback, 0, len(op.Callbacks))
+		for _, callback := range op.Callbacks {
+			callback.Operations = r.operations(callback.Operations)
+			callbacks = append(callbacks, callback)
+		}
+
+		op.Callbacks = callbacks
+		renamed = append(renamed, op)
+	}
+
+	return renamed
+}
+
+func (r renamer) content(content map[string]domain.MediaType) map[string]domain.MediaType {
+	if content == nil {
+		return nil
+	}
+
+	renamed := make(map[string]domain.MediaType, len(content))
+
+	for contentType, media := range content {
+		media.Schema = r.schema(media.Schema)
+		renamed[contentType] = media
+	}
+
+	return renamed
+}
+
+// schema renames the references of a schema and of the schemas nested in it.
+func (r renamer) schema(schema domain.Schema) domain.Schema {
+	schema.Ref = r.ref(schema.Ref)
+
+	if schema.Properties != nil {
+		properties := make(map[string]domain.Schema, len(schema.Properties))
+		for name, property := range schema.Properties {
+			properties[name] = r.schema(property)
+		}
+
+		schema.Properties = properties
+	}
+
+	if schema.Items != nil {
+		items := r.schema(*schema.Items)
+		schema.Items = &items
+	}
+
+	schema.AllOf = r.schemaList(schema.AllOf)
+	schema.AnyOf = r.schemaList(schema.AnyOf)
+	schema.OneOf = r.schemaList(schema.OneOf)
+
+	if schema.Discriminator != nil {
+		discriminator := *schema.Discriminator
+
+		if schema.Discriminator.Mapping != nil {
+			discriminator.Mapping = make(map[string]string, len(schema.Discriminator.Mapping))
+			for value, ref := range schema.Discriminator.Mapping {
+				discriminator.Mapping[value] = r.ref(ref)
+			}
+		}
+
+		schema.Discriminator = &discriminator
+	}
+
+	return schema
+}
+
+func (r renamer) schemaList(schemas []domain.Schema) []domain.Schema {
+	if schemas == nil {
+		return nil
+	}
+
+	renamed := make([]domain.Schema, 0, len(schemas))
+	for _, schema := range schemas {
+		renamed = append(renamed, r.schema(schema))
+	}
+
+	return renamed
+}
+
+// ref renames a reference to a component schema, leaving other references alone.
+func (r renamer) ref(ref string) string {
+	name, ok := strings.CutPrefix(ref, schemaRefPrefix)
+	if !ok {
+		return ref
+	}
+
+	return schemaRefPrefix + newName(r.schemas, name)
+}
+
+// newName returns the new name of a component, or name itself when it is not renamed.
+func newName(names map[string]string, name string) string {
+	if renamedTo, ok := names[name]; ok {
+		return renamedTo
+	}
+
+	return name
+}
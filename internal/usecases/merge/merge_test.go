@@ -0,0 +1,178 @@
+package merge_test
+
+import (
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/GabrielNunesIT/openapi-converter/internal/domain"
+	"github.com/GabrielNunesIT/openapi-converter/internal/usecases/merge"
+)
+
+// service returns a document with one GET operation on path answering with a reference to the schema ref.
+func service(path, ref string, components map[string]domain.Schema) *domain.OpenAPIDocument {
+	return &domain.OpenAPIDocument{
+		Title:   "Service",
+		Version: "1.0.0",
+		Paths: []domain.Path{{Path: path, Operations: []domain.Operation{{
+			Method: "get",
+			Responses: []domain.Response{{StatusCode: "200", Content: map[string]domain.MediaType{
+				"application/json": {Schema: domain.Schema{Ref: "#/components/schemas/" + ref}},
+			}}},
+		}}}},
+		Components: components,
+	}
+}
+
+// responseRef returns the reference of the response of the GET operation on path.
+func responseRef(doc *domain.OpenAPIDocument, path string) string {
+	for _, p := range doc.Paths {
+		if p.Path == path {
+			return p.Operations[0].Responses[0].Content["application/json"].Schema.Ref
+		}
+	}
+
+	return ""
+}
+
+func TestDocumentsComponents(t *testing.T) {
+	pet := domain.Schema{Type: "object", Properties: map[string]domain.Schema{"name": {Type: "string"}}}
+	otherPet := domain.Schema{Type: "object", Properties: map[string]domain.Schema{"id": {Type: "integer"}}}
+	owned := domain.Schema{Type: "object", Properties: map[string]domain.Schema{
+		"pet": {Ref: "#/components/schemas/Pet"},
+	}}
+
+	tests := []struct {
+		name           string
+		first, second  map[string]domain.Schema
+		secondRef      string
+		wantComponents []string
+		wantRef        string // Reference of the response of the second source after merging
+	}{
+		{
+			name:           "different schemas of the same name",
+			first:          map[string]domain.Schema{"Pet": pet},
+			second:         map[string]domain.Schema{"Pet": otherPet},
+			secondRef:      "Pet",
+			wantComponents: []string{"Pet", "store.Pet"},
+			wantRef:        "#/components/schemas/store.Pet",
+		},
+		{
+			name:           "identical schemas",
+			first:          map[string]domain.Schema{"Pet": pet},
+			second:         map[string]domain.Schema{"Pet": pet},
+			secondRef:      "Pet",
+			wantComponents: []string{"Pet"},
+			wantRef:        "#/components/schemas/Pet",
+		},
+		{
+			name:           "identical schemas referencing renamed ones",
+			first:          map[string]domain.Schema{"Pet": pet, "Owner": owned},
+			second:         map[string]domain.Schema{"Pet": otherPet, "Owner": owned},
+			secondRef:      "Owner",
+			wantComponents: []string{"Owner", "Pet", "store.Owner", "store.Pet"},
+			wantRef:        "#/components/schemas/store.Owner",
+		},
+		{
+			name:           "distinct names",
+			first:          map[string]domain.Schema{"Pet": pet},
+			second:         map[string]domain.Schema{"Order": otherPet},
+			secondRef:      "Order",
+			wantComponents: []string{"Order", "Pet"},
+			wantRef:        "#/components/schemas/Order",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			merged, err := merge.Documents([]merge.Source{
+				{Name: "pets", Document: service("/pets", "Pet", tt.first)},
+				{Name: "store", Document: service("/store", tt.secondRef, tt.second)},
+			}, merge.Options{})
+			if err != nil {
+				t.Fatalf("merge: %v", err)
+			}
+
+			if got := slices.Sorted(maps.Keys(merged.Components)); !slices.Equal(got, tt.wantComponents) {
+				t.Errorf("got components %q, want %q", got, tt.wantComponents)
+			}
+
+			if !reflect.DeepEqual(merged.Components["Pet"], pet) {
+				t.Errorf("got Pet %+v, want that of the first source", merged.Components["Pet"])
+			}
+
+			if got := responseRef(merged, "/pets"); got != "#/components/schemas/Pet" {
+				t.Errorf("got reference %s in the first source, want it unchanged", got)
+			}
+
+			if got := responseRef(merged, "/store"); got != tt.wantRef {
+				t.Errorf("got reference %s in the second source, want %s", got, tt.wantRef)
+			}
+
+			if renamed, ok := merged.Components["store.Owner"]; ok {
+				if got := renamed.Properties["pet"].Ref; got != "#/components/schemas/store.Pet" {
+					t.Errorf("got store.Owner referencing %s, want store.Pet", got)
+				}
+			}
+		})
+	}
+}
+
+func TestDocumentsPaths(t *testing.T) {
+	post := service("/pets", "Pet", nil)
+	post.Paths[0].Operations[0].Method = "post"
+
+	tests := []struct {
+		name      string
+		second    *domain.OpenAPIDocument
+		wantErr   string
+		wantPaths int
+	}{
+		{
+			name:    "same operation",
+			second:  service("/pets", "Pet", nil),
+			wantErr: "GET /pets is defined by both pets and store",
+		},
+		{
+			name:      "same path, other method",
+			second:    post,
+			wantPaths: 1,
+		},
+		{
+			name:      "other path",
+			second:    service("/orders", "Order", nil),
+			wantPaths: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			merged, err := merge.Documents([]merge.Source{
+				{Name: "pets", Document: service("/pets", "Pet", nil)},
+				{Name: "store", Document: tt.second},
+			}, merge.Options{})
+
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("got error %v, want %q", err, tt.wantErr)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("merge: %v", err)
+			}
+
+			if len(merged.Paths) != tt.wantPaths {
+				t.Errorf("got %d paths, want %d", len(merged.Paths), tt.wantPaths)
+			}
+
+			if len(merged.Paths[0].Operations) != 3-tt.wantPaths {
+				t.Errorf("got %d operations on %s, want %d", len(merged.Paths[0].Operations), merged.Paths[0].Path, 3-tt.wantPaths)
+			}
+		})
+	}
+}
@@ -0,0 +1,180 @@
+// Package stats summarizes a parsed API specification: its size and how much of it is documented,
+// so that documentation coverage can be tracked over time.
+package stats
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/GabrielNunesIT/openapi-converter/internal/domain"
+)
+
+const (
+	untagged = "Default" // Group of the operations without tags, as the converters name it
+	percent  = 100
+)
+
+// Stats summarizes a specification.
+type Stats struct {
+	Title      string `json:"title"`
+	Version    string `json:"version"`
+	Operations int    `json:"operations"`
+	Schemas    int    `json:"schemas"`
+	Webhooks   int    `json:"webhooks"`
+
+	ByTag     []Count `json:"byTag"`     // Operations per tag, an operation counting once for each of its tags
+	ByMethod  []Count `json:"byMethod"`  // Operations per HTTP method
+	Responses []Count `json:"responses"` // Responses per status code, across all operations
+
+	MissingSummaries      []string `json:"missingSummaries"`      // Operations without a summary, as "METHOD /path"
+	MissingDescriptions   []string `json:"missingDescriptions"`   // Operations without a description
+	MissingExamples       []string `json:"missingExamples"`       // Operations with bodies but no example of any of them
+	MissingErrorResponses []string `json:"missingErrorResponses"` // Operations without a 4xx, 5xx or default response
+	WithBodies            int      `json:"withBodies"`            // Operations with a request or response body
+
+	DescriptionCoverage float64 `json:"descriptionCoverage"` // Percentage of operations with a description
+	ExampleCoverage     float64 `json:"exampleCoverage"`     // Percentage of operations with bodies that have an example
+	Coverage            float64 `json:"coverage"`            // Percentage of operations missing none of the above
+}
+
+// Count is the number of occurrences of a name, such as a tag or a status code.
+type Count struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+// Compute returns the statistics of a document. Counts are sorted by name, operations in document order.
+// An operation is covered when it has a summary, a description, an example of its bodies if it has any,
+// and an error response.
+func Compute(doc *domain.OpenAPIDocument) *Stats {
+	stats := &Stats{
+		Title:                 doc.Title,
+		Version:               doc.Version,
+		Schemas:               len(doc.Components),
+		Webhooks:              len(doc.Webhooks),
+		MissingSummaries:      []string{},
+		MissingDescriptions:   []string{},
+		MissingExamples:       []string{},
+		MissingErrorResponses: []string{},
+	}
+
+	tags, methods, responses := map[string]int{}, map[string]int{}, map[string]int{}
+	covered := 0
+
+	for _, path := range doc.Paths {
+		for _, op := range path.Operations {
+			name := strings.ToUpper(op.Method) + " " + path.Path
+			stats.Operations++
+			methods[strings.ToUpper(op.Method)]++
+
+			if len(op.Tags) == 0 {
+				tags[untagged]++
+			}
+
+			for _, tag := range op.Tags {
+				tags[tag]++
+			}
+
+			for _, resp := range op.Responses {
+				responses[resp.StatusCode]++
+			}
+
+			complete := true
+			missing := func(list *[]string) {
+				*list = append(*list, name)
+				complete = false
+			}
+
+			if strings.TrimSpace(op.Summary) == "" {
+				missing(&stats.MissingSummaries)
+			}
+
+			if strings.TrimSpace(op.Description) == "" {
+				missing(&stats.MissingDescriptions)
+			}
+
+			if hasBodies, hasExamples := bodyExamples(op); hasBodies {
+				stats.WithBodies++
+
+				if !hasExamples {
+					missing(&stats.MissingExamples)
+				}
+			}
+
+			if !hasErrorResponse(op) {
+				missing(&stats.MissingErrorResponses)
+			}
+
+			if complete {
+				covered++
+			}
+		}
+	}
+
+	stats.ByTag = sortedCounts(tags)
+	stats.ByMethod = sortedCounts(methods)
+	stats.Responses = sortedCounts(responses)
+	stats.DescriptionCoverage = coverage(stats.Operations-len(stats.MissingDescriptions), stats.Operations)
+	stats.ExampleCoverage = coverage(stats.WithBodies-len(stats.MissingExamples), stats.WithBodies)
+	stats.Coverage = coverage(covered, stats.Operations)
+
+	return stats
+}
+
+// bodyExamples reports whether an operation has request or response bodies, and whether any of them has an example,
+// given with the media type or its schema.
+func bodyExamples(op domain.Operation) (hasBodies, hasExamples bool) {
+	contents := []map[string]domain.MediaType{}
+	if op.RequestBody != nil {
+		contents = append(contents, op.RequestBody.Content)
+	}
+
+	for _, resp := range op.Responses {
+		contents = append(contents, resp.Content)
+	}
+
+	for _, content := range contents {
+		for _, media := range content {
+			hasBodies = true
+
+			if media.Example != nil || len(media.Examples) > 0 || len(media.Schema.Examples) > 0 {
+				return true, true
+			}
+		}
+	}
+
+	return hasBodies, false
+}
+
+// hasErrorResponse reports whether an operation documents a client or server error response, or a default one.
+func hasErrorResponse(op domain.Operation) bool {
+	for _, resp := range op.Responses {
+		code := strings.ToUpper(resp.StatusCode)
+		if code == "DEFAULT" || strings.HasPrefix(code, "4") || strings.HasPrefix(code, "5") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// sortedCounts returns the counts of a map sorted by name.
+func sortedCounts(counts map[string]int) []Count {
+	result := make([]Count, 0, len(counts))
+	for name, count := range counts {
+		result = append(result, Count{Name: name, Count: count})
+	}
+
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+
+	return result
+}
+
+// coverage returns part as a percentage of total, 100 when total is zero.
+func coverage(part, total int) float64 {
+	if total == 0 {
+		return percent
+	}
+
+	return float64(part) * percent / float64(total)
+}
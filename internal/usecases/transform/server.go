@@ -0,0 +1,79 @@
+package transform
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/GabrielNunesIT/openapi-converter/internal/domain"
+)
+
+// SelectServer moves the server chosen by selector first among the servers of the document, as the examples,
+// collections and mock use the first server. The selector is the position of a server, counted from 0, its
+// description, compared case-insensitively, or its URL. A URL not listed by the document is added as the first
+// server, so that the examples can target an environment the specification leaves out.
+func SelectServer(selector string) Middleware {
+	return func(doc *domain.OpenAPIDocument) error {
+		i, err := findServer(doc.Servers, selector)
+		if err != nil {
+			return err
+		}
+
+		if i < 0 {
+			doc.Servers = slices.Insert(doc.Servers, 0, domain.Server{URL: selector})
+
+			return nil
+		}
+
+		server := doc.Servers[i]
+		doc.Servers = slices.Insert(slices.Delete(slices.Clone(doc.Servers), i, i+1), 0, server)
+
+		return nil
+	}
+}
+
+// findServer returns the position of the server chosen by selector, or -1 for a URL the servers do not list.
+func findServer(servers []domain.Server, selector string) (int, error) {
+	if index, err := strconv.Atoi(selector); err == nil {
+		if index < 0 || index >= len(servers) {
+			return 0, fmt.Errorf("server %d out of range: the specification lists %d server(s)", index, len(servers))
+		}
+
+		return index, nil
+	}
+
+	for i, server := range servers {
+		if strings.EqualFold(server.Description, selector) {
+			return i, nil
+		}
+	}
+
+	if !isServerURL(selector) {
+		names := make([]string, 0, len(servers))
+		for _, server := range servers {
+			if server.Description != "" {
+				names = append(names, strconv.Quote(server.Description))
+			}
+		}
+
+		if len(names) == 0 {
+			return 0, fmt.Errorf("unknown server %q, expected an index or a URL", selector)
+		}
+
+		return 0, fmt.Errorf("unknown server %q, expected an index, a URL or one of: %s", selector, strings.Join(names, ", "))
+	}
+
+	for i, server := range servers {
+		if strings.TrimRight(server.URL, "/") == strings.TrimRight(selector, "/") {
+			return i, nil
+		}
+	}
+
+	return -1, nil
+}
+
+// isServerURL reports whether a selector is a server URL: absolute, or a path relative to the specification.
+func isServerURL(selector string) bool {
+	return strings.HasPrefix(selector, "https://") || strings.HasPrefix(selector, "http://") || strings.HasPrefix(selector, "/")
+}
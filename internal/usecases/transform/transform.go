@@ -0,0 +1,170 @@
+// Package transform mutates a parsed API specification before conversion, through a chain of middleware,
+// so that documents can be adjusted without writing a converter.
+package transform
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/GabrielNunesIT/openapi-converter/internal/domain"
+)
+
+// Middleware mutates a specification in place between parsing and conversion.
+type Middleware func(doc *domain.OpenAPIDocument) error
+
+// Chain is a list of middleware run in order.
+type Chain []Middleware
+
+// Run runs every middleware of the chain on the document, stopping at the first error.
+func (c Chain) Run(doc *domain.OpenAPIDocument) error {
+	for i, middleware := range c {
+		if err := middleware(doc); err != nil {
+			return fmt.Errorf("transform %d failed: %w", i+1, err)
+		}
+	}
+
+	return nil
+}
+
+// RenameTags renames tags, given as old name to new name, in the top-level declarations and the operations.
+// Tags renamed to an already declared tag are merged into it, keeping its declaration.
+func RenameTags(names map[string]string) Middleware {
+	return func(doc *domain.OpenAPIDocument) error {
+		declared := make(map[string]struct{}, len(doc.Tags))
+		tags := make([]domain.Tag, 0, len(doc.Tags))
+
+		for _, tag := range doc.Tags {
+			if name, ok := names[tag.Name]; ok {
+				tag.Name = name
+			}
+
+			if _, exists := declared[tag.Name]; exists {
+				continue
+			}
+
+			declared[tag.Name] = struct{}{}
+			tags = append(tags, tag)
+		}
+
+		if doc.Tags != nil {
+			doc.Tags = tags
+		}
+
+		forEachOperation(doc, func(_ string, op *domain.Operation) {
+			renamed := make([]string, 0, len(op.Tags))
+			seen := make(map[string]struct{}, len(op.Tags))
+
+			for _, tag := range op.Tags {
+				if name, ok := names[tag]; ok {
+					tag = name
+				}
+
+				if _, exists := seen[tag]; !exists {
+					seen[tag] = struct{}{}
+					renamed = append(renamed, tag)
+				}
+			}
+
+			if op.Tags != nil {
+				op.Tags = renamed
+			}
+		})
+
+		return nil
+	}
+}
+
+// StripPathPrefix removes the first matching prefix, such as /api/v1, from every path, so that documents show
+// the paths relative to a server URL. Paths equal to a prefix become "/". Stripping two paths to the same one fails.
+func StripPathPrefix(prefixes []string) Middleware {
+	return func(doc *domain.OpenAPIDocument) error {
+		stripped := make(map[string]string, len(doc.Paths))
+
+		for i, path := range doc.Paths {
+			for _, prefix := range prefixes {
+				prefix = strings.TrimSuffix(prefix, "/")
+				if prefix == "" || (path.Path != prefix && !strings.HasPrefix(path.Path, prefix+"/")) {
+					continue
+				}
+
+				doc.Paths[i].Path = "/" + strings.TrimPrefix(strings.TrimPrefix(path.Path, prefix), "/")
+
+				break
+			}
+
+			if original, exists := stripped[doc.Paths[i].Path]; exists {
+				return fmt.Errorf("paths %s and %s are both stripped to %s", original, path.Path, doc.Paths[i].Path)
+			}
+
+			stripped[doc.Paths[i].Path] = path.Path
+		}
+
+		return nil
+	}
+}
+
+// Descriptions are descriptions injected into a specification, replacing those it has.
+type Descriptions struct {
+	Operations map[string]string // By operationId or "METHOD /path", e.g. "GET /pets"
+	Tags       map[string]string // By tag name; undeclared tags are declared
+}
+
+// InjectDescriptions sets the descriptions of operations and tags, for specifications generated from code
+// with terse or missing ones.
+func InjectDescriptions(descriptions Descriptions) Middleware {
+	return func(doc *domain.OpenAPIDocument) error {
+		forEachOperation(doc, func(path string, op *domain.Operation) {
+			if description, ok := descriptions.Operations[op.OperationID]; ok && op.OperationID != "" {
+				op.Description = description
+			} else if description, ok := descriptions.Operations[strings.ToUpper(op.Method)+" "+path]; ok {
+				op.Description = description
+			}
+		})
+
+		found := make(map[string]struct{}, len(doc.Tags))
+
+		for i, tag := range doc.Tags {
+			if description, ok := descriptions.Tags[tag.Name]; ok {
+				doc.Tags[i].Description = description
+				found[tag.Name] = struct{}{}
+			}
+		}
+
+		// Declare the tags only operations use, in the order of the operations
+		forEachOperation(doc, func(_ string, op *domain.Operation) {
+			for _, tag := range op.Tags {
+				description, ok := descriptions.Tags[tag]
+				if _, exists := found[tag]; ok && !exists {
+					found[tag] = struct{}{}
+					doc.Tags = append(doc.Tags, domain.Tag{Name: tag, Description: description})
+				}
+			}
+		})
+
+		return nil
+	}
+}
+
+// forEachOperation calls fn with every operation of the paths and webhooks, and their callbacks, along with
+// its path or webhook name.
+func forEachOperation(doc *domain.OpenAPIDocument, fn func(path string, op *domain.Operation)) {
+	var visit func(path string, operations []domain.Operation)
+
+	visit = func(path string, operations []domain.Operation) {
+		for i := range operations {
+			fn(path, &operations[i])
+
+			for _, callback := range operations[i].Callbacks {
+				visit(callback.Expression, callback.Operations)
+			}
+		}
+	}
+
+	for _, path := range doc.Paths {
+		visit(path.Path, path.Operations)
+	}
+
+	for _, webhook := range doc.Webhooks {
+		visit(webhook.Name, webhook.Operations)
+	}
+}
@@ -0,0 +1,171 @@
+// Package validate checks a parsed API specification for structural problems that break or degrade
+// the generated documentation.
+package validate
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/GabrielNunesIT/openapi-converter/internal/domain"
+)
+
+// pathParam matches the {param} segments of a path template.
+var pathParam = regexp.MustCompile(`\{[^}]*\}`)
+
+// localRefPrefixes are the reference prefixes of component schemas in OpenAPI 3.x and Swagger 2.0 documents.
+var localRefPrefixes = []string{"#/components/schemas/", "#/definitions/"} //nolint:gochecknoglobals // read-only
+
+// Document returns the problems found in a document: duplicate paths, missing or duplicate operationIds,
+// operations without responses, responses without descriptions and references to missing components.
+// Issues are reported in document order, then components in alphabetical order.
+func Document(doc *domain.OpenAPIDocument) []domain.Issue {
+	v := &validator{
+		components:   doc.Components,
+		operationIDs: make(map[string]string),
+	}
+
+	templates := make(map[string]string)
+
+	for _, path := range doc.Paths {
+		pointer := []string{"paths", path.Path}
+
+		template := pathParam.ReplaceAllString(path.Path, "{}")
+		if first, duplicate := templates[template]; duplicate {
+			v.add(domain.SeverityError, pointer,
+				"path %s matches the same requests as %s; merge them or rename the literal segments", path.Path, first)
+		} else {
+			templates[template] = path.Path
+		}
+
+		for _, op := range path.Operations {
+			v.checkOperation(extend(pointer, strings.ToLower(op.Method)), strings.ToUpper(op.Method)+" "+path.Path, op)
+		}
+	}
+
+	for _, webhook := range doc.Webhooks {
+		for _, op := range webhook.Operations {
+			pointer := []string{"webhooks", webhook.Name, strings.ToLower(op.Method)}
+			v.checkOperation(pointer, fmt.Sprintf("%s %s webhook", strings.ToUpper(op.Method), webhook.Name), op)
+		}
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(doc.Components)) {
+		v.checkRefs([]string{"components", "schemas", name}, doc.Components[name], true)
+	}
+
+	return v.issues
+}
+
+type validator struct {
+	components   map[string]domain.Schema
+	operationIDs map[string]string // operationId to the first operation using it
+	issues       []domain.Issue
+}
+
+func (v *validator) add(severity domain.Severity, pointer []string, format string, args ...any) {
+	v.issues = append(v.issues, domain.Issue{
+		Severity: severity,
+		Pointer:  pointer,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+func (v *validator) checkOperation(pointer []string, label string, op domain.Operation) {
+	switch first, duplicate := v.operationIDs[op.OperationID]; {
+	case op.OperationID == "":
+		v.add(domain.SeverityWarning, pointer,
+			"%s has no operationId; add one to give generated clients and links a stable name", label)
+	case duplicate:
+		v.add(domain.SeverityError, extend(pointer, "operationId"),
+			"%s reuses operationId %q of %s; operationIds must be unique", label, op.OperationID, first)
+	default:
+		v.operationIDs[op.OperationID] = label
+	}
+
+	for _, param := range op.Parameters {
+		v.checkRefs(extend(pointer, "parameters"), param.Schema, false)
+	}
+
+	if op.RequestBody != nil {
+		for _, contentType := range slices.Sorted(maps.Keys(op.RequestBody.Content)) {
+			v.checkRefs(extend(pointer, "requestBody", "content", contentType), op.RequestBody.Content[contentType].Schema, false)
+		}
+	}
+
+	if len(op.Responses) == 0 {
+		v.add(domain.SeverityError, pointer, "%s has no responses; document at least its success response", label)
+	}
+
+	// Responses are parsed from a map, in no particular order
+	byStatus := slices.SortedFunc(slices.Values(op.Responses), func(a, b domain.Response) int {
+		return cmp.Compare(a.StatusCode, b.StatusCode)
+	})
+
+	for _, resp := range byStatus {
+		responsePointer := extend(pointer, "responses", resp.StatusCode)
+
+		if strings.TrimSpace(resp.Description) == "" {
+			v.add(domain.SeverityError, responsePointer, "response %s of %s has no description", resp.StatusCode, label)
+		}
+
+		for _, contentType := range slices.Sorted(maps.Keys(resp.Content)) {
+			v.checkRefs(extend(responsePointer, "content", contentType), resp.Content[contentType].Schema, false)
+		}
+	}
+}
+
+// checkRefs reports the references of a schema that do not resolve to a component. The members of
+// referenced components are checked with the component itself; root is set when schema is a component.
+func (v *validator) checkRefs(pointer []string, schema domain.Schema, root bool) {
+	if schema.Ref != "" {
+		v.checkRef(pointer, schema.Ref)
+
+		if !root {
+			return
+		}
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(schema.Properties)) {
+		v.checkRefs(pointer, schema.Properties[name], false)
+	}
+
+	if schema.Items != nil {
+		v.checkRefs(pointer, *schema.Items, false)
+	}
+
+	for _, composed := range [][]domain.Schema{schema.AllOf, schema.AnyOf, schema.OneOf} {
+		for _, member := range composed {
+			v.checkRefs(pointer, member, false)
+		}
+	}
+
+	if schema.Discriminator != nil {
+		for _, value := range slices.Sorted(maps.Keys(schema.Discriminator.Mapping)) {
+			v.checkRef(pointer, schema.Discriminator.Mapping[value])
+		}
+	}
+}
+
+func (v *validator) checkRef(pointer []string, ref string) {
+	for _, prefix := range localRefPrefixes {
+		name, local := strings.CutPrefix(ref, prefix)
+		if !local {
+			continue
+		}
+
+		if _, exists := v.components[name]; !exists {
+			v.add(domain.SeverityError, pointer, "reference %s does not resolve to a component schema", ref)
+		}
+
+		return
+	}
+}
+
+// extend returns a copy of pointer followed by keys.
+func extend(pointer []string, keys ...string) []string {
+	return append(append(make([]string, 0, len(pointer)+len(keys)), pointer...), keys...)
+}
@@ -0,0 +1,107 @@
+package validate_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/GabrielNunesIT/openapi-converter/internal/domain"
+	"github.com/GabrielNunesIT/openapi-converter/internal/usecases/validate"
+)
+
+func TestDocumentReportsIssuesInOrder(t *testing.T) {
+	missing := domain.Schema{Ref: "#/components/schemas/Missing"}
+	content := map[string]domain.MediaType{"application/json": {Schema: missing}}
+
+	doc := &domain.OpenAPIDocument{
+		Paths: []domain.Path{
+			{Path: "/pets", Operations: []domain.Operation{{
+				Method:      "get",
+				OperationID: "listPets",
+				// In the order of the map the parser reads them from, not by status code
+				Responses: []domain.Response{
+					{StatusCode: "500", Content: content},
+					{StatusCode: "200", Description: "OK", Content: content},
+					{StatusCode: "404"},
+					{StatusCode: "default", Content: content},
+				},
+			}}},
+			{Path: "/owners", Operations: []domain.Operation{{Method: "post"}}},
+		},
+		Components: map[string]domain.Schema{
+			"Pet":   {Properties: map[string]domain.Schema{"owner": missing}},
+			"Owner": {Items: &missing},
+		},
+	}
+
+	want := []string{
+		"#/paths/~1pets/get/responses/200/content/application~1json",
+		"#/paths/~1pets/get/responses/404",
+		"#/paths/~1pets/get/responses/500",
+		"#/paths/~1pets/get/responses/500/content/application~1json",
+		"#/paths/~1pets/get/responses/default",
+		"#/paths/~1pets/get/responses/default/content/application~1json",
+		"#/paths/~1owners/post",
+		"#/paths/~1owners/post",
+		"#/components/schemas/Owner",
+		"#/components/schemas/Pet",
+	}
+
+	for run := range 10 {
+		var got []string
+		for _, issue := range validate.Document(doc) {
+			got = append(got, issue.Location())
+		}
+
+		if !slices.Equal(got, want) {
+			t.Fatalf("run %d: got issues at\n%q\nwant\n%q", run+1, got, want)
+		}
+	}
+}
+
+func TestDocumentDuplicates(t *testing.T) {
+	ok := []domain.Response{{StatusCode: "200", Description: "OK"}}
+
+	tests := []struct {
+		name  string
+		paths []domain.Path
+		want  []string
+	}{
+		{
+			name: "path templates",
+			paths: []domain.Path{
+				{Path: "/pets/{id}", Operations: []domain.Operation{{Method: "get", OperationID: "a", Responses: ok}}},
+				{Path: "/pets/{petId}", Operations: []domain.Operation{{Method: "put", OperationID: "b", Responses: ok}}},
+			},
+			want: []string{"#/paths/~1pets~1{petId}"},
+		},
+		{
+			name: "operationIds",
+			paths: []domain.Path{
+				{Path: "/pets", Operations: []domain.Operation{
+					{Method: "get", OperationID: "pets", Responses: ok},
+					{Method: "post", OperationID: "pets", Responses: ok},
+				}},
+			},
+			want: []string{"#/paths/~1pets/post/operationId"},
+		},
+		{
+			name: "none",
+			paths: []domain.Path{
+				{Path: "/pets", Operations: []domain.Operation{{Method: "get", OperationID: "pets", Responses: ok}}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, issue := range validate.Document(&domain.OpenAPIDocument{Paths: tt.paths}) {
+				got = append(got, issue.Location())
+			}
+
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("got issues at %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
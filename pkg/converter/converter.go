@@ -0,0 +1,166 @@
+// Package converter exposes the conversion pipeline to other Go programs: parse an OpenAPI 3.x or
+// Swagger 2.0 specification, then render it with any registered output format.
+//
+//	doc, err := converter.ParseFile("openapi.yaml")
+//	...
+//	md, err := converter.Get("markdown")
+//	...
+//	err = md.Convert(doc, os.Stdout)
+package converter
+
+import (
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/GabrielNunesIT/openapi-converter/internal/adapters/converters"
+	"github.com/GabrielNunesIT/openapi-converter/internal/adapters/parsers"
+	"github.com/GabrielNunesIT/openapi-converter/internal/domain"
+	"github.com/GabrielNunesIT/openapi-converter/internal/usecases/transform"
+)
+
+type (
+	// Document is a parsed API specification.
+	Document = domain.OpenAPIDocument
+	// Converter renders a Document into an output format.
+	Converter = domain.Converter
+	// Options configures converters created through a Registry.
+	Options = converters.Options
+	// Factory creates a converter with the given options.
+	Factory = converters.Factory
+	// FormatOption is an option of a single output format, a field of its options struct set by name in Options.Values.
+	FormatOption = converters.FormatOption
+	// OptionKind is the type of the value of a format option.
+	OptionKind = converters.OptionKind
+	// Registry maps output format names to converter factories.
+	Registry = converters.Registry
+	// Middleware mutates a Document in place between parsing and conversion.
+	Middleware = transform.Middleware
+	// Chain is a list of middleware run in order.
+	Chain = transform.Chain
+	// Descriptions are operation and tag descriptions injected by InjectDescriptions.
+	Descriptions = transform.Descriptions
+)
+
+// middleware holds the chain Convert runs between parsing and conversion, see Use.
+var middleware struct { //nolint:gochecknoglobals // middleware registers like converters do
+	sync.RWMutex
+	chain Chain
+}
+
+// Use appends middleware to the chain Convert runs on every document between parsing and conversion.
+func Use(m ...Middleware) {
+	middleware.Lock()
+	defer middleware.Unlock()
+
+	middleware.chain = append(middleware.chain, m...)
+}
+
+// RenameTags returns middleware renaming tags, given as old name to new name, merging tags renamed alike.
+func RenameTags(names map[string]string) Middleware {
+	return transform.RenameTags(names)
+}
+
+// StripPathPrefix returns middleware removing the first matching prefix, such as /api/v1, from every path.
+func StripPathPrefix(prefixes []string) Middleware {
+	return transform.StripPathPrefix(prefixes)
+}
+
+// InjectDescriptions returns middleware setting the descriptions of operations and tags.
+func InjectDescriptions(descriptions Descriptions) Middleware {
+	return transform.InjectDescriptions(descriptions)
+}
+
+// NewRegistry creates an empty registry, for programs that want their own set of formats.
+func NewRegistry() *Registry {
+	return converters.NewRegistry()
+}
+
+// Default returns the registry holding the built-in converters.
+func Default() *Registry {
+	return converters.DefaultRegistry
+}
+
+// Register adds a converter to the default registry under a format name and optional aliases.
+// It panics if a name is already taken.
+func Register(format string, factory Factory, aliases ...string) {
+	converters.Register(format, factory, aliases...)
+}
+
+// Kinds of the values of format options.
+const (
+	BoolOption   = converters.BoolOption
+	IntOption    = converters.IntOption
+	StringOption = converters.StringOption
+	ListOption   = converters.ListOption
+)
+
+// RegisterFormat adds a converter configured by the options struct O to the default registry under a format name
+// and optional aliases. New fills the struct with defaults, sets its fields tagged option, e.g.
+// `option:"page-size"`, from Options.Values and builds the converter from it with build.
+func RegisterFormat[O any](format string, defaults func(Options) O, build func(O) Converter, aliases ...string) {
+	converters.RegisterFormat(format, defaults, build, aliases...)
+}
+
+// Get returns a converter of the default registry for the format, or one of its aliases.
+func Get(format string) (Converter, error) {
+	return converters.DefaultRegistry.Get(format)
+}
+
+// New returns a converter of the default registry for the format, configured with opts.
+func New(format string, opts Options) (Converter, error) {
+	return converters.DefaultRegistry.New(format, opts)
+}
+
+// Formats returns the format names of the default registry in alphabetical order.
+func Formats() []string {
+	return converters.DefaultRegistry.Formats()
+}
+
+// ParseFile reads the JSON or YAML specification at path, resolving references relative to it.
+func ParseFile(path string) (*Document, error) {
+	doc, err := parsers.ParseFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse specification: %w", err)
+	}
+
+	return doc, nil
+}
+
+// Parse reads a JSON or YAML specification from r, resolving references against the working directory.
+func Parse(r io.Reader) (*Document, error) {
+	doc, err := parsers.ParseReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse specification: %w", err)
+	}
+
+	return doc, nil
+}
+
+// Convert parses the specification read from input, runs the middleware registered with Use on it and writes it
+// to output in the given format.
+func Convert(input io.Reader, format string, output io.Writer) error {
+	conv, err := Get(format)
+	if err != nil {
+		return err
+	}
+
+	doc, err := Parse(input)
+	if err != nil {
+		return err
+	}
+
+	middleware.RLock()
+	chain := middleware.chain
+	middleware.RUnlock()
+
+	if err := chain.Run(doc); err != nil {
+		return fmt.Errorf("failed to transform specification: %w", err)
+	}
+
+	if err := conv.Convert(doc, output); err != nil {
+		return fmt.Errorf("conversion failed: %w", err)
+	}
+
+	return nil
+}